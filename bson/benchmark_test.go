@@ -15,6 +15,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -447,3 +448,50 @@ func BenchmarkCodeMarshal(b *testing.B) {
 		b.SetBytes(int64(len(codeJSON)))
 	})
 }
+
+func BenchmarkUnmarshalMixedArray(b *testing.B) {
+	arr := make(A, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		switch i % 5 {
+		case 0:
+			arr = append(arr, int32(i))
+		case 1:
+			arr = append(arr, int64(i))
+		case 2:
+			arr = append(arr, float64(i))
+		case 3:
+			arr = append(arr, strconv.Itoa(i))
+		case 4:
+			arr = append(arr, i%2 == 0)
+		}
+	}
+	data, err := Marshal(D{{"arr", arr}})
+	if err != nil {
+		b.Fatalf("error marshalling BSON: %s", err)
+	}
+
+	b.Run("[]interface{}", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var v struct {
+				Arr []interface{}
+			}
+			if err := Unmarshal(data, &v); err != nil {
+				b.Fatalf("error unmarshalling BSON: %s", err)
+			}
+		}
+	})
+	b.Run("bson.A", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var v struct {
+				Arr A
+			}
+			if err := Unmarshal(data, &v); err != nil {
+				b.Fatalf("error unmarshalling BSON: %s", err)
+			}
+		}
+	})
+}
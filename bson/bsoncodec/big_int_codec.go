@@ -0,0 +1,170 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BigIntRepresentation is the BSON type that the codec registered with RegisterBigIntCodec encodes big.Int values as.
+type BigIntRepresentation int
+
+const (
+	// BigIntString encodes big.Int values as BSON strings holding their base 10 representation.
+	BigIntString BigIntRepresentation = iota
+
+	// BigIntDecimal128 encodes big.Int values as BSON decimal128 values if they can be represented exactly, and as
+	// BSON strings otherwise. A decimal128 holds integers with up to 34 significant digits.
+	BigIntDecimal128
+)
+
+var (
+	tBigInt    = reflect.TypeOf(big.Int{})
+	tBigIntPtr = reflect.TypeOf((*big.Int)(nil))
+)
+
+// bigIntCodec is the Codec used for big.Int and *big.Int values when registered with RegisterBigIntCodec.
+type bigIntCodec struct {
+	repr BigIntRepresentation
+}
+
+var _ ValueCodec = bigIntCodec{}
+
+// RegisterBigIntCodec registers a codec on r that encodes big.Int and *big.Int values as the BSON type given by repr.
+// A nil *big.Int is encoded as BSON null.
+//
+// Values are decoded from BSON strings holding a base 10 integer, decimal128 values with an integral value, and BSON
+// int32 and int64 values, regardless of repr. Decoding a decimal128 value that is NaN, infinite, or not an integer
+// returns an error. BSON null is decoded as a nil *big.Int.
+//
+// The default registry encodes big.Int like any other struct, which loses its value, so the codec must be registered
+// explicitly:
+//
+//	reg := bson.NewRegistry()
+//	bsoncodec.RegisterBigIntCodec(reg, bsoncodec.BigIntDecimal128)
+func RegisterBigIntCodec(r *Registry, repr BigIntRepresentation) {
+	codec := bigIntCodec{repr: repr}
+	r.RegisterTypeEncoder(tBigInt, codec)
+	r.RegisterTypeEncoder(tBigIntPtr, codec)
+	r.RegisterTypeDecoder(tBigInt, codec)
+	r.RegisterTypeDecoder(tBigIntPtr, codec)
+}
+
+// EncodeValue is the ValueEncoder for big.Int and *big.Int values.
+func (bic bigIntCodec) EncodeValue(_ EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	var bi *big.Int
+	switch {
+	case !val.IsValid():
+		return ValueEncoderError{Name: "BigIntEncodeValue", Types: []reflect.Type{tBigInt, tBigIntPtr}, Received: val}
+	case val.Type() == tBigIntPtr:
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		bi = val.Interface().(*big.Int)
+	case val.Type() == tBigInt:
+		if val.CanAddr() {
+			bi = val.Addr().Interface().(*big.Int)
+		} else {
+			bi = new(big.Int)
+			reflect.ValueOf(bi).Elem().Set(val)
+		}
+	default:
+		return ValueEncoderError{Name: "BigIntEncodeValue", Types: []reflect.Type{tBigInt, tBigIntPtr}, Received: val}
+	}
+
+	if bic.repr == BigIntDecimal128 {
+		if d, ok := primitive.ParseDecimal128FromBigInt(bi, 0); ok {
+			return vw.WriteDecimal128(d)
+		}
+	}
+	return vw.WriteString(bi.String())
+}
+
+// DecodeValue is the ValueDecoder for big.Int and *big.Int values.
+func (bigIntCodec) DecodeValue(_ DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != tBigInt && val.Type() != tBigIntPtr) {
+		return ValueDecoderError{Name: "BigIntDecodeValue", Types: []reflect.Type{tBigInt, tBigIntPtr}, Received: val}
+	}
+
+	bi := new(big.Int)
+	switch vrType := vr.Type(); vrType {
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		if _, ok := bi.SetString(s, 10); !ok {
+			return fmt.Errorf("cannot decode string %q into a big.Int: not a base 10 integer", s)
+		}
+	case bsontype.Decimal128:
+		d, err := vr.ReadDecimal128()
+		if err != nil {
+			return err
+		}
+		if bi, err = decimal128ToBigInt(d); err != nil {
+			return err
+		}
+	case bsontype.Int32:
+		i32, err := vr.ReadInt32()
+		if err != nil {
+			return err
+		}
+		bi.SetInt64(int64(i32))
+	case bsontype.Int64:
+		i64, err := vr.ReadInt64()
+		if err != nil {
+			return err
+		}
+		bi.SetInt64(i64)
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a big.Int", vrType)
+	}
+
+	if val.Type() == tBigIntPtr {
+		val.Set(reflect.ValueOf(bi))
+	} else {
+		val.Set(reflect.ValueOf(bi).Elem())
+	}
+	return nil
+}
+
+// decimal128ToBigInt returns the integer value of d, or an error if d is NaN, infinite, or has a fractional part.
+func decimal128ToBigInt(d primitive.Decimal128) (*big.Int, error) {
+	bi, exp, err := d.BigInt()
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode decimal128 %v into a big.Int: %w", d, err)
+	}
+
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+	if exp >= 0 {
+		return bi.Mul(bi, pow), nil
+	}
+	q, r := new(big.Int).QuoRem(bi, pow, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, fmt.Errorf("cannot decode decimal128 %v into a big.Int: value is not an integer", d)
+	}
+	return q, nil
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
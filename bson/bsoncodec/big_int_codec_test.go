@@ -0,0 +1,154 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestBigIntCodec(t *testing.T) {
+	newRegistry := func(repr BigIntRepresentation) *Registry {
+		reg := buildDefaultRegistry()
+		RegisterBigIntCodec(reg, repr)
+		return reg
+	}
+	encode := func(t *testing.T, reg *Registry, val interface{}) bsoncore.Document {
+		t.Helper()
+
+		enc, err := reg.LookupEncoder(reflect.TypeOf(val))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(val))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		return buf.Bytes()
+	}
+	decode := func(t *testing.T, reg *Registry, doc bsoncore.Document, val interface{}) error {
+		t.Helper()
+
+		rv := reflect.ValueOf(val).Elem()
+		dec, err := reg.LookupDecoder(rv.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		return dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), rv)
+	}
+	parse := func(s string) *big.Int {
+		bi, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("invalid big.Int %q", s)
+		}
+		return bi
+	}
+
+	type pointer struct {
+		N *big.Int `bson:"n"`
+	}
+	type value struct {
+		N big.Int `bson:"n"`
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		// 2^64 does not fit in an int64, and the 40 digit value does not fit in a decimal128.
+		testCases := []struct {
+			name     string
+			n        string
+			repr     BigIntRepresentation
+			wantType bsontype.Type
+		}{
+			{"string", "18446744073709551616", BigIntString, bsontype.String},
+			{"negative string", "-18446744073709551616", BigIntString, bsontype.String},
+			{"decimal128", "18446744073709551616", BigIntDecimal128, bsontype.Decimal128},
+			{"negative decimal128", "-18446744073709551616", BigIntDecimal128, bsontype.Decimal128},
+			{"decimal128 trailing zeros", "1" + string(bytes.Repeat([]byte("0"), 50)), BigIntDecimal128,
+				bsontype.Decimal128},
+			{"decimal128 out of range", "1234567890123456789012345678901234567890", BigIntDecimal128,
+				bsontype.String},
+			{"zero", "0", BigIntDecimal128, bsontype.Decimal128},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				reg := newRegistry(tc.repr)
+				want := parse(tc.n)
+
+				doc := encode(t, reg, pointer{N: want})
+				gotType := doc.Lookup("n").Type
+				assert.Equal(t, tc.wantType, gotType, "expected BSON type %v, got %v", tc.wantType, gotType)
+
+				var gotPtr pointer
+				err := decode(t, reg, doc, &gotPtr)
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				assert.True(t, want.Cmp(gotPtr.N) == 0, "expected %v, got %v", want, gotPtr.N)
+
+				doc = encode(t, reg, value{N: *want})
+				var gotVal value
+				err = decode(t, reg, doc, &gotVal)
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				assert.True(t, want.Cmp(&gotVal.N) == 0, "expected %v, got %v", want, &gotVal.N)
+			})
+		}
+	})
+	t.Run("nil pointer", func(t *testing.T) {
+		reg := newRegistry(BigIntString)
+		doc := encode(t, reg, pointer{})
+		assert.Equal(t, `{"n": null}`, doc.String(), "expected null, got %v", doc)
+
+		got := pointer{N: big.NewInt(1)}
+		err := decode(t, reg, doc, &got)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Nil(t, got.N, "expected nil *big.Int, got %v", got.N)
+	})
+	t.Run("decode", func(t *testing.T) {
+		reg := newRegistry(BigIntString)
+		decimalDoc := func(s string) bsoncore.Document {
+			d, err := primitive.ParseDecimal128(s)
+			assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+			return bsoncore.NewDocumentBuilder().AppendDecimal128("n", d).Build()
+		}
+
+		testCases := []struct {
+			name    string
+			doc     bsoncore.Document
+			want    string
+			wantErr bool
+		}{
+			{"int32", bsoncore.NewDocumentBuilder().AppendInt32("n", -42).Build(), "-42", false},
+			{"int64", bsoncore.NewDocumentBuilder().AppendInt64("n", 1<<62).Build(), "4611686018427387904", false},
+			{"decimal128 with exponent", decimalDoc("1.5E+3"), "1500", false},
+			{"integral decimal128 with negative exponent", decimalDoc("25.00"), "25", false},
+			{"fractional decimal128", decimalDoc("2.5"), "", true},
+			{"NaN decimal128", decimalDoc("NaN"), "", true},
+			{"infinite decimal128", decimalDoc("-Infinity"), "", true},
+			{"invalid string", bsoncore.NewDocumentBuilder().AppendString("n", "12abc").Build(), "", true},
+			{"double", bsoncore.NewDocumentBuilder().AppendDouble("n", 1).Build(), "", true},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				var got pointer
+				err := decode(t, reg, tc.doc, &got)
+				if tc.wantErr {
+					assert.NotNil(t, err, "expected an error, got %v", got.N)
+					return
+				}
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				assert.Equal(t, tc.want, got.N.String(), "expected %v, got %v", tc.want, got.N)
+			})
+		}
+	})
+}
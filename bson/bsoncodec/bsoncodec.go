@@ -134,6 +134,10 @@ type EncodeContext struct {
 	nilByteSliceAsEmpty     bool
 	omitZeroStruct          bool
 	useJSONStructTags       bool
+	sortMapKeys             bool
+
+	// canonicalExtJSON is set for struct fields with the "canonical" struct tag option.
+	canonicalExtJSON bool
 }
 
 // ErrorOnInlineDuplicates causes the Encoder to return an error if there is a duplicate field in
@@ -195,6 +199,15 @@ func (ec *EncodeContext) UseJSONStructTags() {
 	ec.useJSONStructTags = true
 }
 
+// SortMapKeys causes the Encoder to write the elements of Go maps in lexicographic order of their
+// BSON document field names instead of the random map iteration order.
+//
+// Use [go.mongodb.org/mongo-driver/bson.Encoder.SortMapKeys] to configure this behavior when
+// marshaling.
+func (ec *EncodeContext) SortMapKeys() {
+	ec.sortMapKeys = true
+}
+
 // DecodeContext is the contextual information required for a Codec to decode a
 // value.
 type DecodeContext struct {
@@ -227,6 +240,13 @@ type DecodeContext struct {
 	useLocalTimeZone  bool
 	zeroMaps          bool
 	zeroStructs       bool
+
+	// numberNormalization specifies the Go type that BSON int32, int64, and double values are unmarshaled into when
+	// decoding into an empty interface.
+	numberNormalization numberNormalization
+
+	// canonicalExtJSON is set for struct fields with the "canonical" struct tag option.
+	canonicalExtJSON bool
 }
 
 // BinaryAsSlice causes the Decoder to unmarshal BSON binary field values that are the "Generic" or
@@ -237,6 +257,26 @@ func (dc *DecodeContext) BinaryAsSlice() {
 	dc.binaryAsSlice = true
 }
 
+// NumbersAsFloat64 causes the Decoder to unmarshal BSON int32, int64, and double values into a Go
+// float64 when decoding into an empty interface. Integer values with a magnitude greater than 2^53
+// lose precision.
+//
+// Use [go.mongodb.org/mongo-driver/bson.Decoder.NumbersAsFloat64] to configure this behavior when
+// unmarshaling.
+func (dc *DecodeContext) NumbersAsFloat64() {
+	dc.numberNormalization = numbersAsFloat64
+}
+
+// IntegralNumbersAsInt64 causes the Decoder to unmarshal BSON int32 and int64 values, and BSON
+// double values that have no fractional part and fit in an int64, into a Go int64 when decoding
+// into an empty interface. Other BSON double values are unmarshaled into a Go float64.
+//
+// Use [go.mongodb.org/mongo-driver/bson.Decoder.IntegralNumbersAsInt64] to configure this behavior
+// when unmarshaling.
+func (dc *DecodeContext) IntegralNumbersAsInt64() {
+	dc.numberNormalization = integralNumbersAsInt64
+}
+
 // UseJSONStructTags causes the Decoder to fall back to using the "json" struct tag if a "bson"
 // struct tag is not specified.
 //
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"math"
 	"reflect"
 
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
@@ -127,9 +128,10 @@ func (eic EmptyInterfaceCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReade
 		return emptyValue, ValueDecoderError{Name: "EmptyInterfaceDecodeValue", Types: []reflect.Type{tEmpty}, Received: reflect.Zero(t)}
 	}
 
-	rtype, err := eic.getEmptyInterfaceDecodeType(dc, vr.Type())
+	valueType := vr.Type()
+	rtype, err := eic.getEmptyInterfaceDecodeType(dc, valueType)
 	if err != nil {
-		switch vr.Type() {
+		switch valueType {
 		case bsontype.Null:
 			return reflect.Zero(t), vr.ReadNull()
 		default:
@@ -154,6 +156,11 @@ func (eic EmptyInterfaceCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReade
 		}
 	}
 
+	switch valueType {
+	case bsontype.Int32, bsontype.Int64, bsontype.Double:
+		elem = normalizeNumber(dc.numberNormalization, elem)
+	}
+
 	return elem, nil
 }
 
@@ -171,3 +178,44 @@ func (eic EmptyInterfaceCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueRead
 	val.Set(elem)
 	return nil
 }
+
+// numberNormalization specifies the Go type that BSON numeric values are unmarshaled into when decoding into an empty
+// interface.
+type numberNormalization uint8
+
+const (
+	// numbersUnchanged decodes BSON numeric values into the type registered in the type map (int32, int64, or float64
+	// by default).
+	numbersUnchanged numberNormalization = iota
+
+	// numbersAsFloat64 decodes BSON int32, int64, and double values into a float64.
+	numbersAsFloat64
+
+	// integralNumbersAsInt64 decodes BSON int32 and int64 values, and BSON double values that have no fractional part
+	// and fit in an int64, into an int64.
+	integralNumbersAsInt64
+)
+
+// normalizeNumber converts the decoded numeric value v into the Go type selected by nn. Values that are not integers or
+// floats, such as those produced by custom type map entries, are returned unchanged.
+func normalizeNumber(nn numberNormalization, v reflect.Value) reflect.Value {
+	if nn == numbersUnchanged {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if nn == numbersAsFloat64 {
+			return reflect.ValueOf(float64(v.Int()))
+		}
+		return reflect.ValueOf(v.Int())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		// float64(math.MaxInt64) rounds up to 2^63, which does not fit in an int64.
+		if nn == integralNumbersAsInt64 && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return reflect.ValueOf(int64(f))
+		}
+		return reflect.ValueOf(f)
+	}
+	return v
+}
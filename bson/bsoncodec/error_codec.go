@@ -0,0 +1,174 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrorFieldsProvider is implemented by errors that store structured fields alongside their message when they are
+// encoded by the codec registered with RegisterErrorCodec.
+type ErrorFieldsProvider interface {
+	BSONErrorFields() primitive.D
+}
+
+// DecodedError is the error that documents written by the codec registered with RegisterErrorCodec are decoded into.
+// It preserves the message and fields of the encoded error and of each error in its Unwrap chain, but not their
+// original types, so errors.Is and errors.As cannot match the original errors.
+type DecodedError struct {
+	// Message is the message returned by the Error method of the encoded error.
+	Message string
+
+	// Fields contains the fields returned by BSONErrorFields if the encoded error implemented ErrorFieldsProvider.
+	Fields primitive.D
+
+	wrapped *DecodedError
+}
+
+// Error implements the error interface.
+func (e *DecodedError) Error() string {
+	return e.Message
+}
+
+// BSONErrorFields implements ErrorFieldsProvider so that a DecodedError is encoded with the same fields it was decoded
+// with.
+func (e *DecodedError) BSONErrorFields() primitive.D {
+	return e.Fields
+}
+
+// Unwrap returns the decoded form of the error that the encoded error wrapped, or nil if it did not wrap an error.
+func (e *DecodedError) Unwrap() error {
+	if e.wrapped == nil {
+		return nil
+	}
+	return e.wrapped
+}
+
+var (
+	tError        = reflect.TypeOf((*error)(nil)).Elem()
+	tDecodedError = reflect.TypeOf(DecodedError{})
+	tErrorDoc     = reflect.TypeOf(errorDocument{})
+)
+
+// errorDocument is the BSON form of an error. Wrapped contains the errors in the Unwrap chain of the error in order,
+// each without a Wrapped field.
+type errorDocument struct {
+	Message string          `bson:"message"`
+	Fields  primitive.D     `bson:"fields,omitempty"`
+	Wrapped []errorDocument `bson:"wrapped,omitempty"`
+}
+
+// errorCodec is the Codec used for error values when registered with RegisterErrorCodec.
+type errorCodec struct{}
+
+var _ ValueCodec = errorCodec{}
+
+// RegisterErrorCodec registers a codec on r that encodes values implementing error as a document of the form
+//
+//	{"message": <Error()>, "fields": <BSONErrorFields()>, "wrapped": [{"message": ..., "fields": ...}, ...]}
+//
+// The "fields" element is only present for errors that implement ErrorFieldsProvider, and "wrapped" contains the
+// errors returned by successively calling errors.Unwrap, outermost first. A nil error is encoded as BSON null. Types
+// that implement Marshaler or ValueMarshaler in addition to error keep using those interfaces.
+//
+// Documents are decoded into values of type error or DecodedError. A value of type error is set to a *DecodedError
+// whose Unwrap chain mirrors the "wrapped" array, and BSON null is decoded as a nil error.
+//
+// The default registry encodes errors like any other value, so the codec must be registered explicitly:
+//
+//	reg := bson.NewRegistry()
+//	bsoncodec.RegisterErrorCodec(reg)
+func RegisterErrorCodec(r *Registry) {
+	r.RegisterInterfaceEncoder(tError, errorCodec{})
+	r.RegisterTypeDecoder(tError, errorCodec{})
+	r.RegisterTypeDecoder(tDecodedError, errorCodec{})
+}
+
+// newErrorDocument returns the errorDocument for a single error, ignoring the errors it wraps.
+func newErrorDocument(err error) errorDocument {
+	doc := errorDocument{Message: err.Error()}
+	if fp, ok := err.(ErrorFieldsProvider); ok {
+		doc.Fields = fp.BSONErrorFields()
+	}
+	return doc
+}
+
+// EncodeValue is the ValueEncoder for error values.
+func (errorCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	switch {
+	case !val.IsValid():
+		return ValueEncoderError{Name: "ErrorEncodeValue", Types: []reflect.Type{tError}, Received: val}
+	case val.Type().Implements(tError):
+		if (val.Kind() == reflect.Interface && val.IsNil()) || isImplementationNil(val, tError) {
+			return vw.WriteNull()
+		}
+	case reflect.PtrTo(val.Type()).Implements(tError) && val.CanAddr():
+		val = val.Addr()
+	default:
+		return ValueEncoderError{Name: "ErrorEncodeValue", Types: []reflect.Type{tError}, Received: val}
+	}
+
+	err := val.Interface().(error)
+	doc := newErrorDocument(err)
+	for wrapped := errors.Unwrap(err); wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		doc.Wrapped = append(doc.Wrapped, newErrorDocument(wrapped))
+	}
+
+	enc, lookupErr := ec.LookupEncoder(tErrorDoc)
+	if lookupErr != nil {
+		return lookupErr
+	}
+	return enc.EncodeValue(ec, vw, reflect.ValueOf(doc))
+}
+
+// DecodeValue is the ValueDecoder for error and DecodedError values.
+func (errorCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != tError && val.Type() != tDecodedError) {
+		return ValueDecoderError{Name: "ErrorDecodeValue", Types: []reflect.Type{tError, tDecodedError}, Received: val}
+	}
+
+	switch vrType := vr.Type(); vrType {
+	case bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into an error", vrType)
+	}
+
+	dec, err := dc.LookupDecoder(tErrorDoc)
+	if err != nil {
+		return err
+	}
+	var doc errorDocument
+	if err := dec.DecodeValue(dc, vr, reflect.ValueOf(&doc).Elem()); err != nil {
+		return err
+	}
+
+	decoded := &DecodedError{Message: doc.Message, Fields: doc.Fields}
+	last := decoded
+	for _, wrapped := range doc.Wrapped {
+		last.wrapped = &DecodedError{Message: wrapped.Message, Fields: wrapped.Fields}
+		last = last.wrapped
+	}
+
+	if val.Type() == tError {
+		val.Set(reflect.ValueOf(decoded))
+	} else {
+		val.Set(reflect.ValueOf(decoded).Elem())
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+type fieldsError struct {
+	code int32
+}
+
+func (e fieldsError) Error() string { return fmt.Sprintf("failed with code %d", e.code) }
+
+func (e fieldsError) BSONErrorFields() primitive.D { return primitive.D{{"code", e.code}} }
+
+func TestErrorCodec(t *testing.T) {
+	reg := buildDefaultRegistry()
+	RegisterErrorCodec(reg)
+
+	encode := func(t *testing.T, val interface{}) bsoncore.Document {
+		t.Helper()
+
+		enc, err := reg.LookupEncoder(reflect.TypeOf(val))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(val))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		return buf.Bytes()
+	}
+	decode := func(t *testing.T, doc bsoncore.Document, val interface{}) {
+		t.Helper()
+
+		rv := reflect.ValueOf(val).Elem()
+		dec, err := reg.LookupDecoder(rv.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		err = dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), rv)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+	}
+
+	type outcome struct {
+		Err error `bson:"err"`
+	}
+
+	sentinel := errors.New("not found")
+	wrapped := fmt.Errorf("loading user: %w", fmt.Errorf("query failed: %w", fieldsError{code: 11000}))
+
+	testCases := []struct {
+		name        string
+		err         error
+		want        string
+		wantChain   []string
+		wantFields  []primitive.D
+		wantNullErr bool
+	}{
+		{
+			name:       "sentinel error",
+			err:        sentinel,
+			want:       `{"err": {"message": "not found"}}`,
+			wantChain:  []string{"not found"},
+			wantFields: []primitive.D{nil},
+		},
+		{
+			name: "wrapped errors",
+			err:  wrapped,
+			want: `{"err": {"message": "loading user: query failed: failed with code 11000","wrapped": [` +
+				`{"message": "query failed: failed with code 11000"},` +
+				`{"message": "failed with code 11000","fields": {"code": {"$numberInt":"11000"}}}]}}`,
+			wantChain: []string{
+				"loading user: query failed: failed with code 11000",
+				"query failed: failed with code 11000",
+				"failed with code 11000",
+			},
+			wantFields: []primitive.D{nil, nil, {{"code", int32(11000)}}},
+		},
+		{
+			name:        "nil error",
+			err:         nil,
+			want:        `{"err": null}`,
+			wantNullErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			doc := encode(t, outcome{Err: tc.err})
+			assert.Equal(t, tc.want, doc.String(), "expected document %v, got %v", tc.want, doc)
+
+			var got outcome
+			decode(t, doc, &got)
+			if tc.wantNullErr {
+				assert.Nil(t, got.Err, "expected nil error, got %v", got.Err)
+				return
+			}
+
+			var chain []string
+			var fields []primitive.D
+			for err := got.Err; err != nil; err = errors.Unwrap(err) {
+				de, ok := err.(*DecodedError)
+				assert.True(t, ok, "expected *DecodedError, got %T", err)
+				chain = append(chain, de.Error())
+				fields = append(fields, de.Fields)
+			}
+			assert.Equal(t, tc.wantChain, chain, "expected error chain %v, got %v", tc.wantChain, chain)
+			assert.Equal(t, tc.wantFields, fields, "expected fields %v, got %v", tc.wantFields, fields)
+
+			// Encoding the decoded error produces the same document.
+			reencoded := encode(t, got)
+			assert.Equal(t, doc, reencoded, "expected re-encoded document %v, got %v", doc, reencoded)
+		})
+	}
+
+	t.Run("concrete error type", func(t *testing.T) {
+		type result struct {
+			Err fieldsError `bson:"err"`
+		}
+		doc := encode(t, result{Err: fieldsError{code: 2}})
+		want := `{"err": {"message": "failed with code 2","fields": {"code": {"$numberInt":"2"}}}}`
+		assert.Equal(t, want, doc.String(), "expected document %v, got %v", want, doc)
+	})
+
+	t.Run("decode into DecodedError", func(t *testing.T) {
+		type result struct {
+			Err  DecodedError  `bson:"err"`
+			Last *DecodedError `bson:"last"`
+		}
+		errDoc := bsoncore.NewDocumentBuilder().AppendString("message", "outer").
+			AppendArray("wrapped", bsoncore.NewArrayBuilder().
+				AppendDocument(bsoncore.NewDocumentBuilder().AppendString("message", "inner").Build()).
+				Build()).
+			Build()
+		doc := bsoncore.NewDocumentBuilder().
+			AppendDocument("err", errDoc).
+			AppendDocument("last", errDoc).
+			Build()
+
+		var got result
+		decode(t, doc, &got)
+		assert.Equal(t, "outer", got.Err.Error(), "expected message %q, got %q", "outer", got.Err.Error())
+		assert.NotNil(t, got.Last, "expected Last to be set")
+		inner := errors.Unwrap(got.Last)
+		assert.NotNil(t, inner, "expected a wrapped error")
+		assert.Equal(t, "inner", inner.Error(), "expected message %q, got %q", "inner", inner.Error())
+		assert.Nil(t, errors.Unwrap(inner), "expected end of error chain, got %v", errors.Unwrap(inner))
+	})
+
+	t.Run("decode non-document", func(t *testing.T) {
+		doc := bsoncore.NewDocumentBuilder().AppendString("err", "failed").Build()
+		rv := reflect.ValueOf(&outcome{}).Elem()
+		dec, err := reg.LookupDecoder(rv.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		err = dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), rv)
+		assert.NotNil(t, err, "expected DecodeValue error, got nil")
+	})
+}
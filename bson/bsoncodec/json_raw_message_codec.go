@@ -0,0 +1,102 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var tJSONRawMessage = reflect.TypeOf(json.RawMessage(nil))
+
+// jsonRawMessageCodec is the Codec used for json.RawMessage values when registered with RegisterJSONRawMessageCodec.
+type jsonRawMessageCodec struct{}
+
+var _ ValueCodec = jsonRawMessageCodec{}
+
+// RegisterJSONRawMessageCodec registers a codec on r that stores json.RawMessage values as the BSON value described by
+// the JSON instead of as BSON binary. When encoding, the JSON is parsed as Extended JSON, so it may contain any JSON
+// value and use Extended JSON type wrappers such as {"$date": ...}. When decoding, BSON values are converted to relaxed
+// Extended JSON, or to canonical Extended JSON if the struct field has the "canonical" struct tag option, so every
+// encoded value decodes back to equivalent JSON. BSON null and undefined are decoded as a nil json.RawMessage.
+//
+// The default registry encodes json.RawMessage like any other byte slice, so the codec must be registered explicitly:
+//
+//	reg := bson.NewRegistry()
+//	bsoncodec.RegisterJSONRawMessageCodec(reg)
+func RegisterJSONRawMessageCodec(r *Registry) {
+	r.RegisterTypeEncoder(tJSONRawMessage, jsonRawMessageCodec{})
+	r.RegisterTypeDecoder(tJSONRawMessage, jsonRawMessageCodec{})
+}
+
+// EncodeValue is the ValueEncoder for json.RawMessage.
+func (jsonRawMessageCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tJSONRawMessage {
+		return ValueEncoderError{Name: "JSONRawMessageEncodeValue", Types: []reflect.Type{tJSONRawMessage}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	vr, err := bsonrw.NewExtJSONValueReader(bytes.NewReader(val.Bytes()), ec.canonicalExtJSON)
+	if err != nil {
+		return err
+	}
+	return bsonrw.Copier{}.CopyValue(vw, vr)
+}
+
+// DecodeValue is the ValueDecoder for json.RawMessage.
+func (jsonRawMessageCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tJSONRawMessage {
+		return ValueDecoderError{Name: "JSONRawMessageDecodeValue", Types: []reflect.Type{tJSONRawMessage}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.Zero(tJSONRawMessage))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(tJSONRawMessage))
+		return vr.ReadUndefined()
+	}
+
+	// The Extended JSON writer can only write documents at the top level, so the value is written as the only element
+	// of a wrapper document and extracted from it afterwards.
+	buf := new(bytes.Buffer)
+	vw, err := bsonrw.NewExtJSONValueWriter(buf, dc.canonicalExtJSON, false)
+	if err != nil {
+		return err
+	}
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	evw, err := dw.WriteDocumentElement("v")
+	if err != nil {
+		return err
+	}
+	if err = (bsonrw.Copier{}).CopyValue(evw, vr); err != nil {
+		return err
+	}
+	if err = dw.WriteDocumentEnd(); err != nil {
+		return err
+	}
+
+	var wrapper struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err = json.Unmarshal(buf.Bytes(), &wrapper); err != nil {
+		return fmt.Errorf("cannot decode %v into a json.RawMessage: %w", vr.Type(), err)
+	}
+	val.Set(reflect.ValueOf(wrapper.V))
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestJSONRawMessageCodec(t *testing.T) {
+	reg := buildDefaultRegistry()
+	RegisterJSONRawMessageCodec(reg)
+
+	encode := func(t *testing.T, val interface{}) bsoncore.Document {
+		t.Helper()
+
+		enc, err := reg.LookupEncoder(reflect.TypeOf(val))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(val))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		return buf.Bytes()
+	}
+	decode := func(t *testing.T, doc bsoncore.Document, val interface{}) error {
+		t.Helper()
+
+		rv := reflect.ValueOf(val).Elem()
+		dec, err := reg.LookupDecoder(rv.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		return dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), rv)
+	}
+
+	type relaxed struct {
+		Payload json.RawMessage `bson:"payload"`
+	}
+	type canonical struct {
+		Payload json.RawMessage `bson:"payload,canonical"`
+	}
+
+	date := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	sub := bsoncore.NewDocumentBuilder().
+		AppendInt32("a", 1).
+		AppendDouble("b", 2.5).
+		AppendDateTime("c", date.UnixMilli()).
+		AppendArray("d", bsoncore.NewArrayBuilder().AppendString("x").Build()).
+		Build()
+	doc := bsoncore.NewDocumentBuilder().AppendDocument("payload", sub).Build()
+
+	t.Run("decode relaxed", func(t *testing.T) {
+		var got relaxed
+		err := decode(t, doc, &got)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+
+		want := `{"a":1,"b":2.5,"c":{"$date":"2020-01-02T03:04:05Z"},"d":["x"]}`
+		assert.Equal(t, want, string(got.Payload), "expected JSON %s, got %s", want, got.Payload)
+	})
+	t.Run("decode canonical", func(t *testing.T) {
+		var got canonical
+		err := decode(t, doc, &got)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+
+		want := `{"a":{"$numberInt":"1"},"b":{"$numberDouble":"2.5"},` +
+			`"c":{"$date":{"$numberLong":"1577934245000"}},"d":["x"]}`
+		assert.Equal(t, want, string(got.Payload), "expected JSON %s, got %s", want, got.Payload)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var rel relaxed
+		err := decode(t, doc, &rel)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		got := encode(t, rel)
+		assert.Equal(t, doc, got, "expected relaxed round trip to produce %v, got %v", doc, got)
+
+		var can canonical
+		err = decode(t, doc, &can)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		got = encode(t, can)
+		assert.Equal(t, doc, got, "expected canonical round trip to produce %v, got %v", doc, got)
+	})
+	t.Run("encode JSON values", func(t *testing.T) {
+		got := encode(t, relaxed{Payload: json.RawMessage(`{"oid":{"$oid":"5f0c8b8e1c9d440000a1b2c3"},"n":[1,2]}`)})
+
+		oid, err := primitive.ObjectIDFromHex("5f0c8b8e1c9d440000a1b2c3")
+		assert.Nil(t, err, "ObjectIDFromHex error: %v", err)
+		arr := bsoncore.NewArrayBuilder().AppendInt32(1).AppendInt32(2).Build()
+		want := bsoncore.NewDocumentBuilder().
+			AppendDocument("payload", bsoncore.NewDocumentBuilder().
+				AppendObjectID("oid", oid).
+				AppendArray("n", arr).
+				Build()).
+			Build()
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+
+		got = encode(t, relaxed{Payload: json.RawMessage(`"foo"`)})
+		want = bsoncore.NewDocumentBuilder().AppendString("payload", "foo").Build()
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+	})
+	t.Run("nil", func(t *testing.T) {
+		got := encode(t, relaxed{})
+		want := bsoncore.NewDocumentBuilder().AppendNull("payload").Build()
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+
+		out := relaxed{Payload: json.RawMessage(`{}`)}
+		err := decode(t, got, &out)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Nil(t, out.Payload, "expected nil payload, got %s", out.Payload)
+	})
+	t.Run("round trip non-document values", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			json string
+		}{
+			{"array", `[1,2]`},
+			{"string", `"foo"`},
+			{"int32", `1`},
+			{"date", `{"$date":"2020-01-02T03:04:05Z"}`},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				encoded := encode(t, relaxed{Payload: json.RawMessage(tc.json)})
+
+				var got relaxed
+				err := decode(t, encoded, &got)
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				assert.Equal(t, tc.json, string(got.Payload), "expected JSON %s, got %s", tc.json, got.Payload)
+				assert.Equal(t, encoded, encode(t, got), "expected the value to round trip")
+			})
+		}
+	})
+	t.Run("encode invalid JSON error", func(t *testing.T) {
+		enc, err := reg.LookupEncoder(tJSONRawMessage)
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		vw, err := bsonrw.NewBSONValueWriter(new(bytes.Buffer))
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		dw, err := vw.WriteDocument()
+		assert.Nil(t, err, "WriteDocument error: %v", err)
+		evw, err := dw.WriteDocumentElement("payload")
+		assert.Nil(t, err, "WriteDocumentElement error: %v", err)
+
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, evw, reflect.ValueOf(json.RawMessage(`{"a":`)))
+		assert.NotNil(t, err, "expected EncodeValue error, got nil")
+	})
+}
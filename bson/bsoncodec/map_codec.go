@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
@@ -133,10 +134,27 @@ func (mc *MapCodec) mapEncodeValue(ec EncodeContext, dw bsonrw.DocumentWriter, v
 	}
 
 	keys := val.MapKeys()
-	for _, key := range keys {
-		keyStr, err := mc.encodeKey(key, ec.stringifyMapKeysWithFmt)
-		if err != nil {
-			return err
+	var keyStrs []string
+	if ec.sortMapKeys {
+		keyStrs = make([]string, len(keys))
+		for i, key := range keys {
+			keyStrs[i], err = mc.encodeKey(key, ec.stringifyMapKeysWithFmt)
+			if err != nil {
+				return err
+			}
+		}
+		sort.Sort(mapKeysByName{keys: keys, names: keyStrs})
+	}
+
+	for i, key := range keys {
+		var keyStr string
+		if keyStrs != nil {
+			keyStr = keyStrs[i]
+		} else {
+			keyStr, err = mc.encodeKey(key, ec.stringifyMapKeysWithFmt)
+			if err != nil {
+				return err
+			}
 		}
 
 		if collisionFn != nil && collisionFn(keyStr) {
@@ -170,6 +188,19 @@ func (mc *MapCodec) mapEncodeValue(ec EncodeContext, dw bsonrw.DocumentWriter, v
 	return dw.WriteDocumentEnd()
 }
 
+// mapKeysByName sorts map keys by their encoded BSON document field names.
+type mapKeysByName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (m mapKeysByName) Len() int           { return len(m.keys) }
+func (m mapKeysByName) Less(i, j int) bool { return m.names[i] < m.names[j] }
+func (m mapKeysByName) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.names[i], m.names[j] = m.names[j], m.names[i]
+}
+
 // DecodeValue is the ValueDecoder for map[string/decimal]* types.
 func (mc *MapCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
 	if val.Kind() != reflect.Map || (!val.CanSet() && val.IsNil()) {
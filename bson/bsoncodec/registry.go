@@ -224,6 +224,12 @@ func (rb *RegistryBuilder) Build() *Registry {
 		kindEncoders:      rb.registry.kindEncoders.Clone(),
 		kindDecoders:      rb.registry.kindDecoders.Clone(),
 	}
+	for t, enc := range rb.registry.registeredTypeEncoders {
+		r.RegisterTypeEncoder(t, enc)
+	}
+	for t, dec := range rb.registry.registeredTypeDecoders {
+		r.RegisterTypeDecoder(t, dec)
+	}
 	rb.registry.typeMap.Range(func(k, v interface{}) bool {
 		if k != nil && v != nil {
 			r.typeMap.Store(k, v)
@@ -243,6 +249,11 @@ type Registry struct {
 	kindEncoders      *kindEncoderCache
 	kindDecoders      *kindDecoderCache
 	typeMap           sync.Map // map[bsontype.Type]reflect.Type
+
+	// registeredTypeEncoders and registeredTypeDecoders hold the codecs registered for exact types. typeEncoders and
+	// typeDecoders also cache the results of lookups, so they cannot be used to tell which codecs were registered.
+	registeredTypeEncoders map[reflect.Type]ValueEncoder
+	registeredTypeDecoders map[reflect.Type]ValueDecoder
 }
 
 // NewRegistry creates a new empty Registry.
@@ -255,6 +266,31 @@ func NewRegistry() *Registry {
 	}
 }
 
+// Clone returns a new Registry with all of the encoders, decoders, and type map entries registered on r. Codecs
+// registered on the returned Registry do not affect r and codecs registered on r after Clone returns do not affect the
+// returned Registry, so Clone can be used to derive a variant of a shared Registry such as bson.DefaultRegistry. The
+// encoders and decoders themselves are shared, not copied.
+//
+// Clone should not be called concurrently with any method that registers a codec on r.
+func (r *Registry) Clone() *Registry {
+	c := NewRegistry()
+	c.interfaceEncoders = append([]interfaceValueEncoder(nil), r.interfaceEncoders...)
+	c.interfaceDecoders = append([]interfaceValueDecoder(nil), r.interfaceDecoders...)
+	for t, enc := range r.registeredTypeEncoders {
+		c.RegisterTypeEncoder(t, enc)
+	}
+	for t, dec := range r.registeredTypeDecoders {
+		c.RegisterTypeDecoder(t, dec)
+	}
+	c.kindEncoders = r.kindEncoders.Clone()
+	c.kindDecoders = r.kindDecoders.Clone()
+	r.typeMap.Range(func(k, v interface{}) bool {
+		c.typeMap.Store(k, v)
+		return true
+	})
+	return c
+}
+
 // RegisterTypeEncoder registers the provided ValueEncoder for the provided type.
 //
 // The type will be used as provided, so an encoder can be registered for a type and a different
@@ -266,6 +302,10 @@ func NewRegistry() *Registry {
 //
 // RegisterTypeEncoder should not be called concurrently with any other Registry method.
 func (r *Registry) RegisterTypeEncoder(valueType reflect.Type, enc ValueEncoder) {
+	if r.registeredTypeEncoders == nil {
+		r.registeredTypeEncoders = make(map[reflect.Type]ValueEncoder)
+	}
+	r.registeredTypeEncoders[valueType] = enc
 	r.typeEncoders.Store(valueType, enc)
 }
 
@@ -280,6 +320,10 @@ func (r *Registry) RegisterTypeEncoder(valueType reflect.Type, enc ValueEncoder)
 //
 // RegisterTypeDecoder should not be called concurrently with any other Registry method.
 func (r *Registry) RegisterTypeDecoder(valueType reflect.Type, dec ValueDecoder) {
+	if r.registeredTypeDecoders == nil {
+		r.registeredTypeDecoders = make(map[reflect.Type]ValueDecoder)
+	}
+	r.registeredTypeDecoders[valueType] = dec
 	r.typeDecoders.Store(valueType, dec)
 }
 
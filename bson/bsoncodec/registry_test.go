@@ -889,6 +889,81 @@ func TestRegistry(t *testing.T) {
 			t.Errorf("unexpected error: got %#v, want %#v", got, want)
 		}
 	})
+	t.Run("Clone", func(t *testing.T) {
+		t.Parallel()
+
+		fc1, fc2, fc3, fc4 := &fakeCodec{num: 1}, &fakeCodec{num: 2}, &fakeCodec{num: 3}, &fakeCodec{num: 4}
+		ft1, ft2, ft4 := reflect.TypeOf(fakeType1{}), reflect.TypeOf(fakeType2{}), reflect.TypeOf(fakeType4{})
+		var t1f *testInterface1
+		iface := reflect.TypeOf(t1f).Elem()
+
+		reg := NewRegistry()
+		reg.RegisterTypeEncoder(ft1, fc1)
+		reg.RegisterTypeDecoder(ft1, fc1)
+		reg.RegisterKindEncoder(reflect.Struct, fc2)
+		reg.RegisterKindDecoder(reflect.Struct, fc2)
+		reg.RegisterInterfaceEncoder(iface, fc1)
+		reg.RegisterTypeMapEntry(bsontype.String, reflect.TypeOf(""))
+
+		// Look up ft2 so that the kind encoder is cached for it in reg.
+		enc, err := reg.LookupEncoder(ft2)
+		noerr(t, err)
+		if enc != fc2 {
+			t.Errorf("unexpected encoder: got %#v, want %#v", enc, fc2)
+		}
+
+		clone := reg.Clone()
+		clone.RegisterTypeEncoder(ft1, fc3)
+		clone.RegisterKindEncoder(reflect.Struct, fc4)
+		clone.RegisterKindDecoder(reflect.Struct, fc4)
+		clone.RegisterInterfaceEncoder(iface, fc3)
+		clone.RegisterTypeMapEntry(bsontype.String, reflect.TypeOf([]byte(nil)))
+
+		testCases := []struct {
+			name    string
+			reg     *Registry
+			typ     reflect.Type
+			wantEnc ValueEncoder
+			wantDec ValueDecoder
+		}{
+			{"original registered type", reg, ft1, fc1, fc1},
+			{"original cached type", reg, ft2, fc2, fc2},
+			{"original kind", reg, ft4, fc2, fc2},
+			{"clone registered type", clone, ft1, fc3, fc1},
+			{"clone kind for type cached in original", clone, ft2, fc4, fc4},
+			{"clone kind", clone, ft4, fc4, fc4},
+		}
+		for _, tc := range testCases {
+			enc, err := tc.reg.LookupEncoder(tc.typ)
+			noerr(t, err)
+			if enc != tc.wantEnc {
+				t.Errorf("%s: unexpected encoder: got %#v, want %#v", tc.name, enc, tc.wantEnc)
+			}
+			dec, err := tc.reg.LookupDecoder(tc.typ)
+			noerr(t, err)
+			if dec != tc.wantDec {
+				t.Errorf("%s: unexpected decoder: got %#v, want %#v", tc.name, dec, tc.wantDec)
+			}
+		}
+
+		if got := reg.interfaceEncoders[0].ve; got != fc1 {
+			t.Errorf("unexpected interface encoder in original: got %#v, want %#v", got, fc1)
+		}
+		if got := clone.interfaceEncoders[0].ve; got != fc3 {
+			t.Errorf("unexpected interface encoder in clone: got %#v, want %#v", got, fc3)
+		}
+
+		rt, err := reg.LookupTypeMapEntry(bsontype.String)
+		noerr(t, err)
+		if rt != reflect.TypeOf("") {
+			t.Errorf("unexpected type map entry in original: got %v, want %v", rt, reflect.TypeOf(""))
+		}
+		rt, err = clone.LookupTypeMapEntry(bsontype.String)
+		noerr(t, err)
+		if rt != reflect.TypeOf([]byte(nil)) {
+			t.Errorf("unexpected type map entry in clone: got %v, want %v", rt, reflect.TypeOf([]byte(nil)))
+		}
+	})
 }
 
 // get is only for testing as it does return if the value was found
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
@@ -194,6 +195,8 @@ func (sc *SliceCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val r
 	case tE:
 		dc.Ancestor = val.Type()
 		elemsFunc = defaultValueDecoders.decodeD
+	case tEmpty:
+		return decodeEmptyInterfaceSlice(dc, vr, val)
 	default:
 		elemsFunc = defaultValueDecoders.decodeDefault
 	}
@@ -212,3 +215,47 @@ func (sc *SliceCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val r
 
 	return nil
 }
+
+// decodeEmptyInterfaceSlice decodes a BSON array into a slice with an interface{} element type. Decoded elements are
+// appended directly to the destination slice rather than being collected as reflect.Values and added with
+// reflect.Append, which avoids the per-element reflection overhead of the generic slice decoding path.
+func decodeEmptyInterfaceSlice(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	ar, err := vr.ReadArray()
+	if err != nil {
+		return err
+	}
+
+	decoder, err := dc.LookupDecoder(tEmpty)
+	if err != nil {
+		return err
+	}
+	tEmptyTypeDecoder, _ := decoder.(typeDecoder)
+
+	// Use the backing array of the provided value if it's non nil. Otherwise, allocate a new slice.
+	var elems []interface{}
+	if !val.IsNil() {
+		elems = val.Slice(0, 0).Convert(tEmptySlice).Interface().([]interface{})
+	} else {
+		elems = make([]interface{}, 0)
+	}
+
+	for idx := 0; ; idx++ {
+		elemVr, err := ar.ReadValue()
+		if errors.Is(err, bsonrw.ErrEOA) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Pass false for convert because we don't need to call reflect.Value.Convert for tEmpty.
+		elem, err := decodeTypeOrValueWithInfo(decoder, tEmptyTypeDecoder, dc, elemVr, tEmpty, false)
+		if err != nil {
+			return newDecodeError(strconv.Itoa(idx), err)
+		}
+		elems = append(elems, elem.Interface())
+	}
+
+	val.Set(reflect.ValueOf(elems).Convert(val.Type()))
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestSliceCodec(t *testing.T) {
+	t.Run("DecodeValue/empty interface elements", func(t *testing.T) {
+		oid := primitive.NewObjectID()
+		dec128 := primitive.NewDecimal128(1, 2)
+
+		arr := bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.Double, Data: bsoncore.AppendDouble(nil, 3.14)},
+			bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "foo")},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "a", 1))},
+			bsoncore.Value{Type: bsontype.Array, Data: bsoncore.BuildArray(nil, bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 2)})},
+			bsoncore.Value{Type: bsontype.Binary, Data: bsoncore.AppendBinary(nil, 0x00, []byte{0x01})},
+			bsoncore.Value{Type: bsontype.Undefined},
+			bsoncore.Value{Type: bsontype.ObjectID, Data: bsoncore.AppendObjectID(nil, oid)},
+			bsoncore.Value{Type: bsontype.Boolean, Data: bsoncore.AppendBoolean(nil, true)},
+			bsoncore.Value{Type: bsontype.DateTime, Data: bsoncore.AppendDateTime(nil, 1234)},
+			bsoncore.Value{Type: bsontype.Null},
+			bsoncore.Value{Type: bsontype.Regex, Data: bsoncore.AppendRegex(nil, "^a", "i")},
+			bsoncore.Value{Type: bsontype.JavaScript, Data: bsoncore.AppendJavaScript(nil, "js")},
+			bsoncore.Value{Type: bsontype.Symbol, Data: bsoncore.AppendSymbol(nil, "sym")},
+			bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 32)},
+			bsoncore.Value{Type: bsontype.Timestamp, Data: bsoncore.AppendTimestamp(nil, 1, 2)},
+			bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, 64)},
+			bsoncore.Value{Type: bsontype.Decimal128, Data: bsoncore.AppendDecimal128(nil, dec128)},
+			bsoncore.Value{Type: bsontype.MinKey},
+			bsoncore.Value{Type: bsontype.MaxKey},
+		)
+		want := []interface{}{
+			3.14,
+			"foo",
+			primitive.D{{"a", int32(1)}},
+			primitive.A{int32(2)},
+			primitive.Binary{Subtype: 0x00, Data: []byte{0x01}},
+			primitive.Undefined{},
+			oid,
+			true,
+			primitive.DateTime(1234),
+			nil,
+			primitive.Regex{Pattern: "^a", Options: "i"},
+			primitive.JavaScript("js"),
+			primitive.Symbol("sym"),
+			int32(32),
+			primitive.Timestamp{T: 1, I: 2},
+			int64(64),
+			dec128,
+			primitive.MinKey{},
+			primitive.MaxKey{},
+		}
+
+		testCases := []struct {
+			name string
+			val  interface{}
+		}{
+			{"nil []interface{}", []interface{}(nil)},
+			{"non-nil []interface{}", make([]interface{}, 5, 30)},
+			{"primitive.A", primitive.A(nil)},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				dc := DecodeContext{Registry: buildDefaultRegistry()}
+				vr := bsonrw.NewBSONValueReader(bsontype.Array, arr)
+
+				val := reflect.New(reflect.TypeOf(tc.val)).Elem()
+				val.Set(reflect.ValueOf(tc.val))
+				err := defaultSliceCodec.DecodeValue(dc, vr, val)
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+
+				got := val.Convert(tEmptySlice).Interface().([]interface{})
+				assert.Equal(t, want, got, "expected %v, got %v", want, got)
+				if origCap := reflect.ValueOf(tc.val).Cap(); origCap >= len(want) {
+					assert.Equal(t, origCap, cap(got), "expected the existing backing array to be reused")
+				}
+			})
+		}
+	})
+	t.Run("DecodeValue/empty interface element error", func(t *testing.T) {
+		dc := DecodeContext{Registry: NewRegistryBuilder().Build()}
+		arr := bsoncore.BuildArray(nil, bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)})
+		vr := bsonrw.NewBSONValueReader(bsontype.Array, arr)
+
+		var got []interface{}
+		err := defaultSliceCodec.DecodeValue(dc, vr, reflect.ValueOf(&got).Elem())
+		assert.NotNil(t, err, "expected DecodeValue error when no interface{} decoder is registered")
+	})
+}
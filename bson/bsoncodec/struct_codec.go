@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 // DecodeError represents an error that occurs when unmarshalling BSON bytes into a native Go type.
@@ -233,6 +235,8 @@ func (sc *StructCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 			nilByteSliceAsEmpty:     ec.nilByteSliceAsEmpty,
 			omitZeroStruct:          ec.omitZeroStruct,
 			useJSONStructTags:       ec.useJSONStructTags,
+			sortMapKeys:             ec.sortMapKeys,
+			canonicalExtJSON:        desc.canonical,
 		}
 		err = encoder.EncodeValue(ectx, vw2, rv)
 		if err != nil {
@@ -249,10 +253,41 @@ func (sc *StructCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 
 		return defaultMapCodec.mapEncodeValue(ec, dw, rv, collisionFn)
 	}
+	if sd.extraDoc >= 0 {
+		if err := sd.encodeExtraDoc(dw, val.Field(sd.extraDoc)); err != nil {
+			return err
+		}
+	}
 
 	return dw.WriteDocumentEnd()
 }
 
+// encodeExtraDoc writes the elements of the raw document in the extra field rv to dw. Elements whose key is the name
+// of another struct field are skipped, like the colliding keys of an inline map.
+func (sd *structDescription) encodeExtraDoc(dw bsonrw.DocumentWriter, rv reflect.Value) error {
+	if rv.Len() == 0 {
+		return nil
+	}
+	elems, err := bsoncore.Document(rv.Bytes()).Elements()
+	if err != nil {
+		return fmt.Errorf("invalid extra document in field %s: %w", sd.extraName, err)
+	}
+	for _, elem := range elems {
+		if _, exists := sd.fm[elem.Key()]; exists {
+			continue
+		}
+		vw, err := dw.WriteDocumentElement(elem.Key())
+		if err != nil {
+			return err
+		}
+		val := elem.Value()
+		if err := (bsonrw.Copier{}).CopyValueFromBytes(vw, val.Type, val.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func newDecodeError(key string, original error) error {
 	var de *DecodeError
 	if !errors.As(original, &de) {
@@ -321,6 +356,16 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 		return err
 	}
 
+	// Track which fields with default values are present in the document so the remaining ones can be set to their
+	// defaults after the document is read.
+	var decoded map[string]bool
+	if len(sd.defaults) > 0 {
+		decoded = make(map[string]bool, len(sd.defaults))
+	}
+	// The elements that do not match a struct field are collected in extra if the struct has an extra raw document
+	// field.
+	var extra []byte
+
 	for {
 		name, vr, err := dr.ReadElement()
 		if errors.Is(err, bsonrw.ErrEOD) {
@@ -337,8 +382,20 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 			// names
 			fd, exists = sd.fm[strings.ToLower(name)]
 		}
+		if exists && decoded != nil {
+			decoded[fd.name] = true
+		}
 
 		if !exists {
+			if sd.extraDoc >= 0 {
+				t, data, err := (bsonrw.Copier{}).CopyValueToBytes(vr)
+				if err != nil {
+					return err
+				}
+				extra = bsoncore.AppendHeader(extra, t, name)
+				extra = append(extra, data...)
+				continue
+			}
 			if sd.inlineMap < 0 {
 				// The encoding/json package requires a flag to return on error for non-existent fields.
 				// This functionality seems appropriate for the struct codec.
@@ -391,6 +448,8 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 			useLocalTimeZone:    dc.useLocalTimeZone,
 			zeroMaps:            dc.zeroMaps,
 			zeroStructs:         dc.zeroStructs,
+			numberNormalization: dc.numberNormalization,
+			canonicalExtJSON:    fd.canonical,
 		}
 
 		if fd.decoder == nil {
@@ -403,6 +462,45 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 		}
 	}
 
+	if sd.extraDoc >= 0 {
+		// Replace any existing value so that a reused struct does not keep the extra elements of another document.
+		field := val.Field(sd.extraDoc)
+		if extra == nil {
+			field.Set(reflect.Zero(field.Type()))
+		} else {
+			doc := bsoncore.BuildDocument(nil, extra)
+			field.Set(reflect.ValueOf(doc).Convert(field.Type()))
+		}
+	}
+
+	for _, fd := range sd.defaults {
+		if decoded[fd.name] {
+			continue
+		}
+
+		var field reflect.Value
+		if fd.inline == nil {
+			field = val.Field(fd.idx)
+		} else {
+			field, err = getInlineField(val, fd.inline)
+			if err != nil {
+				return err
+			}
+		}
+		if !field.CanSet() {
+			innerErr := fmt.Errorf("field %v is not settable", field)
+			return newDecodeError(fd.name, innerErr)
+		}
+		if field.Kind() == reflect.Ptr {
+			// Copy the default so decoded values do not share the pointer stored in the struct description.
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(fd.defaultValue.Elem())
+			field.Set(ptr)
+			continue
+		}
+		field.Set(fd.defaultValue)
+	}
+
 	return nil
 }
 
@@ -440,8 +538,13 @@ func isEmpty(v reflect.Value, omitZeroStruct bool) bool {
 type structDescription struct {
 	fm        map[string]fieldDescription
 	fl        []fieldDescription
+	defaults  []fieldDescription // fields with a "bsondefault" struct tag
 	inlineMap int
 	inline    bool
+	// extraDoc is the index of the field with the "extra" struct tag if it is a raw document, and -1 otherwise. An
+	// extra field that is a map is stored in inlineMap. extraName is the name of the extra field of either kind.
+	extraDoc  int
+	extraName string
 }
 
 type fieldDescription struct {
@@ -451,9 +554,13 @@ type fieldDescription struct {
 	omitEmpty bool
 	minSize   bool
 	truncate  bool
+	canonical bool
 	inline    []int
 	encoder   ValueEncoder
 	decoder   ValueDecoder
+	// defaultValue is set when decoding to fields that are missing from the document. It is the zero Value if the
+	// field has no "bsondefault" struct tag.
+	defaultValue reflect.Value
 }
 
 type byIndex []fieldDescription
@@ -519,6 +626,7 @@ func (sc *StructCodec) describeStructSlow(
 		fm:        make(map[string]fieldDescription, numFields),
 		fl:        make([]fieldDescription, 0, numFields),
 		inlineMap: -1,
+		extraDoc:  -1,
 	}
 
 	var fields []fieldDescription
@@ -564,12 +672,36 @@ func (sc *StructCodec) describeStructSlow(
 		description.omitEmpty = stags.OmitEmpty
 		description.minSize = stags.MinSize
 		description.truncate = stags.Truncate
+		description.canonical = stags.Canonical
+		if def, ok := sf.Tag.Lookup("bsondefault"); ok {
+			description.defaultValue, err = parseDefaultValue(sfType, def)
+			if err != nil {
+				return nil, fmt.Errorf("(struct %s) invalid bsondefault for field %s: %w", t.String(), sf.Name, err)
+			}
+		}
 
+		if stags.Extra {
+			if sd.inlineMap >= 0 || sd.extraDoc >= 0 {
+				return nil, fmt.Errorf("(struct %s) multiple extra fields or inline maps", t.String())
+			}
+			switch {
+			case sfType.Kind() == reflect.Map && sfType.Key() == tString:
+				sd.inlineMap = description.idx
+				sd.extraName = sf.Name
+			case sfType.Kind() == reflect.Slice && sfType.Elem().Kind() == reflect.Uint8:
+				sd.extraDoc = description.idx
+				sd.extraName = sf.Name
+			default:
+				return nil, fmt.Errorf("(struct %s) extra field %s must be a map with string keys or a raw document",
+					t.String(), sf.Name)
+			}
+			continue
+		}
 		if stags.Inline {
 			sd.inline = true
 			switch sfType.Kind() {
 			case reflect.Map:
-				if sd.inlineMap >= 0 {
+				if sd.inlineMap >= 0 || sd.extraDoc >= 0 {
 					return nil, errors.New("(struct " + t.String() + ") multiple inline maps")
 				}
 				if sfType.Key() != tString {
@@ -587,6 +719,11 @@ func (sc *StructCodec) describeStructSlow(
 				if err != nil {
 					return nil, err
 				}
+				if inlinesf.extraName != "" {
+					// The extra elements are only collected for the outer struct, so the field would never be set.
+					return nil, fmt.Errorf("(struct %s) extra field %s of inline struct %s is not supported, declare it "+
+						"in the outer struct instead", t.String(), inlinesf.extraName, sfType.String())
+				}
 				for _, fd := range inlinesf.fl {
 					if fd.inline == nil {
 						fd.inline = []int{i, fd.idx}
@@ -644,9 +781,62 @@ func (sc *StructCodec) describeStructSlow(
 
 	sort.Sort(byIndex(sd.fl))
 
+	for _, fd := range sd.fl {
+		if fd.defaultValue.IsValid() {
+			sd.defaults = append(sd.defaults, fd)
+		}
+	}
+
 	return sd, nil
 }
 
+// parseDefaultValue parses the value of a "bsondefault" struct tag as a value of type t, which must be a string,
+// boolean, integer, or floating point type, or a pointer to one of those types.
+func parseDefaultValue(t reflect.Type, s string) (reflect.Value, error) {
+	if t.Kind() == reflect.Ptr {
+		elem, err := parseDefaultValue(t.Elem(), s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	val := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("default values are not supported for type %s", t)
+	}
+	return val, nil
+}
+
 // dominantField looks through the fields, all of which are known to
 // have the same name, to find the single field that dominates the
 // others using Go's inlining rules. If there are multiple top-level
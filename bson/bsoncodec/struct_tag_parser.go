@@ -51,9 +51,19 @@ func (stpf StructTagParserFunc) ParseStructTags(sf reflect.StructField) (StructT
 //	           or keys to be processed as if they were part of the outer struct. For maps,
 //	           keys must not conflict with the bson keys of other struct fields.
 //
+//	Extra      Capture the elements of a decoded document that do not match any other struct
+//	           field, and encode them again after the other fields. The field must be a map with
+//	           string keys, such as bson.M, which behaves like an inline map, or a document of raw
+//	           bytes, such as bson.Raw. Any byte slice type is treated as a raw BSON document, so a
+//	           plain []byte extra field holds BSON rather than binary data. A struct can have only
+//	           one extra field or inline map, and the field cannot be in an inline struct.
+//
 //	Skip       This struct field should be skipped. This is usually denoted by parsing a "-"
 //	           for the name.
 //
+//	Canonical  Use canonical rather than relaxed Extended JSON when converting the field to or
+//	           from JSON, e.g. for json.RawMessage fields (see RegisterJSONRawMessageCodec).
+//
 // Deprecated: Defining custom BSON struct tag parsers will not be supported in Go Driver 2.0.
 type StructTags struct {
 	Name      string
@@ -61,7 +71,9 @@ type StructTags struct {
 	MinSize   bool
 	Truncate  bool
 	Inline    bool
+	Extra     bool
 	Skip      bool
+	Canonical bool
 }
 
 // DefaultStructTagParser is the StructTagParser used by the StructCodec by default.
@@ -120,6 +132,12 @@ func parseTags(key string, tag string) (StructTags, error) {
 			st.Truncate = true
 		case "inline":
 			st.Inline = true
+		case "extra":
+			// Unlike the other flags, "extra" is only a flag after the key so that existing fields named "extra"
+			// keep their behavior.
+			st.Extra = idx > 0
+		case "canonical":
+			st.Canonical = true
 		}
 	}
 
@@ -68,6 +68,24 @@ func TestStructTagParsers(t *testing.T) {
 			StructTags{Name: "foo", OmitEmpty: true, MinSize: true, Truncate: true, Inline: true},
 			DefaultStructTagParser,
 		},
+		{
+			"default bson tag canonical",
+			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`bson:"bar,canonical"`)},
+			StructTags{Name: "bar", Canonical: true},
+			DefaultStructTagParser,
+		},
+		{
+			"default bson tag extra",
+			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`bson:",extra"`)},
+			StructTags{Name: "foo", Extra: true},
+			DefaultStructTagParser,
+		},
+		{
+			"default bson tag named extra",
+			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`bson:"extra"`)},
+			StructTags{Name: "extra"},
+			DefaultStructTagParser,
+		},
 		{
 			"default ignore xml",
 			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`xml:"bar"`)},
@@ -24,6 +24,7 @@ var tString = reflect.TypeOf("")
 var tTime = reflect.TypeOf(time.Time{})
 
 var tEmpty = reflect.TypeOf((*interface{})(nil)).Elem()
+var tEmptySlice = reflect.TypeOf([]interface{}(nil))
 var tByteSlice = reflect.TypeOf([]byte(nil))
 var tByte = reflect.TypeOf(byte(0x00))
 var tURL = reflect.TypeOf(url.URL{})
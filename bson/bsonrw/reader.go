@@ -63,3 +63,10 @@ type ValueReader interface {
 type BytesReader interface {
 	ReadValueBytes(dst []byte) (bsontype.Type, []byte, error)
 }
+
+// NestingDepthLimiter is implemented by ValueReaders that limit how deeply the documents and arrays
+// they read can be nested. The ValueReaders returned by NewBSONDocumentReader and NewBSONValueReader
+// implement NestingDepthLimiter.
+type NestingDepthLimiter interface {
+	SetMaxNestingDepth(depth int)
+}
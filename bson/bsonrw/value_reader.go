@@ -77,6 +77,10 @@ var ErrEOA = errors.New("end of array")
 // ErrEOD is the error returned when the end of a BSON document has been reached.
 var ErrEOD = errors.New("end of document")
 
+// ErrMaxNestingDepth is wrapped by the error returned when a BSON ValueReader reads a document or array that is nested
+// more deeply than its maximum nesting depth.
+var ErrMaxNestingDepth = errors.New("maximum nesting depth exceeded")
+
 type vrState struct {
 	mode  mode
 	vType bsontype.Type
@@ -90,6 +94,11 @@ type valueReader struct {
 
 	stack []vrState
 	frame int64
+
+	// depth is the number of documents and arrays that contain the current position. maxDepth is the maximum depth
+	// allowed, or 0 if the depth is not limited.
+	depth    int
+	maxDepth int
 }
 
 // NewBSONDocumentReader returns a ValueReader using b for the underlying BSON
@@ -136,6 +145,25 @@ func (vr *valueReader) reset(b []byte) {
 	vr.d = b
 	vr.offset = 0
 	vr.frame = 0
+	vr.depth = 0
+	vr.maxDepth = 0
+}
+
+// SetMaxNestingDepth sets the maximum nesting depth of the documents and arrays read by vr. A
+// document that contains no embedded documents or arrays has a depth of 1. If depth is not
+// positive, the nesting depth is not limited, which is the default.
+func (vr *valueReader) SetMaxNestingDepth(depth int) {
+	vr.maxDepth = depth
+}
+
+// pushDepth records that vr has entered a document or array and returns an error if that exceeds
+// the maximum nesting depth.
+func (vr *valueReader) pushDepth() error {
+	vr.depth++
+	if vr.maxDepth > 0 && vr.depth > vr.maxDepth {
+		return fmt.Errorf("%w: document is nested more than %d levels deep", ErrMaxNestingDepth, vr.maxDepth)
+	}
+	return nil
 }
 
 func (vr *valueReader) advanceFrame() {
@@ -158,6 +186,9 @@ func (vr *valueReader) advanceFrame() {
 }
 
 func (vr *valueReader) pushDocument() error {
+	if err := vr.pushDepth(); err != nil {
+		return err
+	}
 	vr.advanceFrame()
 
 	vr.stack[vr.frame].mode = mDocument
@@ -172,6 +203,9 @@ func (vr *valueReader) pushDocument() error {
 }
 
 func (vr *valueReader) pushArray() error {
+	if err := vr.pushDepth(); err != nil {
+		return err
+	}
 	vr.advanceFrame()
 
 	vr.stack[vr.frame].mode = mArray
@@ -200,6 +234,9 @@ func (vr *valueReader) pushValue(t bsontype.Type) {
 }
 
 func (vr *valueReader) pushCodeWithScope() (int64, error) {
+	if err := vr.pushDepth(); err != nil {
+		return 0, err
+	}
 	vr.advanceFrame()
 
 	vr.stack[vr.frame].mode = mCodeWithScope
@@ -218,6 +255,7 @@ func (vr *valueReader) pop() {
 	case mElement, mValue:
 		vr.frame--
 	case mDocument, mArray, mCodeWithScope:
+		vr.depth--
 		vr.frame -= 2 // we pop twice to jump over the vrElement: vrDocument -> vrElement -> vrDocument/TopLevel/etc...
 	}
 }
@@ -430,6 +468,7 @@ func (vr *valueReader) ReadDocument() (DocumentReader, error) {
 			return nil, fmt.Errorf("invalid document length")
 		}
 		vr.stack[vr.frame].end = int64(size) + vr.offset - 4
+		vr.depth = 1
 		return vr, nil
 	case mElement, mValue:
 		if vr.stack[vr.frame].vType != bsontype.EmbeddedDocument {
@@ -0,0 +1,135 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var tD = reflect.TypeOf(D{})
+
+// RegisterComputedFields registers an encoder on reg that appends the fields returned by computed to the document of
+// every value of the struct type t. computed is called with the value being encoded, which has type t, and its
+// elements are encoded with reg and written after the fields of the struct. This can be used to store derived values,
+// such as a normalized copy of a field for indexing, without adding them to the struct.
+//
+// Computed fields are only written. When a document is decoded into a value of type t, they are ignored like any other
+// field that does not match a struct field, unless t has an inline map field, which receives them. Encoding returns an
+// error if a computed field has the same name as a field of the struct.
+//
+// The encoder that reg uses for t when RegisterComputedFields is called is used to encode the struct fields, so any
+// custom encoder for t must be registered first. Pointers to t are encoded with the computed fields as well.
+//
+// For example, to store a lowercase copy of a user's email address:
+//
+//	reg := bson.NewRegistry()
+//	bson.RegisterComputedFields(reg, reflect.TypeOf(User{}), func(v interface{}) bson.D {
+//		return bson.D{{"emailLower", strings.ToLower(v.(User).Email)}}
+//	})
+//
+// RegisterComputedFields panics if reg, t, or computed is nil, if t is not a struct type, or if reg has no encoder
+// for t.
+func RegisterComputedFields(reg *bsoncodec.Registry, t reflect.Type, computed func(interface{}) D) {
+	if reg == nil || t == nil || computed == nil {
+		panic(errors.New("arguments to RegisterComputedFields must not be nil"))
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("RegisterComputedFields expects a type with kind reflect.Struct, got type %s with kind %s",
+			t, t.Kind()))
+	}
+	base, err := reg.LookupEncoder(t)
+	if err != nil {
+		panic(fmt.Errorf("RegisterComputedFields: %w", err))
+	}
+
+	cfe := &computedFieldsEncoder{
+		t:        t,
+		base:     base,
+		computed: computed,
+	}
+	reg.RegisterTypeEncoder(t, bsoncodec.ValueEncoderFunc(cfe.EncodeValue))
+}
+
+// computedFieldsEncoder is the encoder registered by RegisterComputedFields.
+type computedFieldsEncoder struct {
+	t        reflect.Type
+	base     bsoncodec.ValueEncoder
+	computed func(interface{}) D
+}
+
+// EncodeValue encodes a struct with the base encoder and appends the computed fields to the resulting document.
+func (cfe *computedFieldsEncoder) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != cfe.t {
+		return bsoncodec.ValueEncoderError{Name: "ComputedFieldsEncodeValue", Types: []reflect.Type{cfe.t}, Received: val}
+	}
+
+	doc, err := encodeToDocument(ec, cfe.base, val)
+	if err != nil {
+		return err
+	}
+	fields := cfe.computed(val.Interface())
+	if len(fields) == 0 {
+		return bsonrw.NewCopier().CopyDocumentFromBytes(vw, doc)
+	}
+
+	dEncoder, err := ec.LookupEncoder(tD)
+	if err != nil {
+		return err
+	}
+	extra, err := encodeToDocument(ec, dEncoder, reflect.ValueOf(fields))
+	if err != nil {
+		return fmt.Errorf("cannot encode computed fields of %v: %w", cfe.t, err)
+	}
+
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+	extraElems, err := extra.Elements()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]struct{}, len(elems))
+	idx, merged := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		keys[elem.Key()] = struct{}{}
+		merged = append(merged, elem...)
+	}
+	for _, elem := range extraElems {
+		if _, ok := keys[elem.Key()]; ok {
+			return fmt.Errorf("computed field %q conflicts with a field of %v", elem.Key(), cfe.t)
+		}
+		merged = append(merged, elem...)
+	}
+	merged, err = bsoncore.AppendDocumentEnd(merged, idx)
+	if err != nil {
+		return err
+	}
+	return bsonrw.NewCopier().CopyDocumentFromBytes(vw, merged)
+}
+
+// encodeToDocument encodes val with enc and returns the resulting document. It returns an error if val is not
+// encoded as a document.
+func encodeToDocument(ec bsoncodec.EncodeContext, enc bsoncodec.ValueEncoder, val reflect.Value) (bsoncore.Document, error) {
+	buf := new(bytes.Buffer)
+	vw, err := bsonrw.NewBSONValueWriter(buf)
+	if err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeValue(ec, vw, val); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+type testComputedUser struct {
+	Name  string `bson:"name"`
+	Email string `bson:"email"`
+}
+
+type testComputedAccount struct {
+	Owner  testComputedUser    `bson:"owner"`
+	Admins []*testComputedUser `bson:"admins"`
+}
+
+func TestRegisterComputedFields(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func(computed func(interface{}) D) *bsoncodec.Registry {
+		reg := NewRegistry()
+		RegisterComputedFields(reg, reflect.TypeOf(testComputedUser{}), computed)
+		return reg
+	}
+	lowerEmail := func(v interface{}) D {
+		return D{{"emailLower", strings.ToLower(v.(testComputedUser).Email)}}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(lowerEmail)
+		user := testComputedUser{Name: "Ada", Email: "Ada@Example.com"}
+		data := marshalWithRegistry(t, reg, user)
+
+		wantData, err := Marshal(D{{"name", "Ada"}, {"email", "Ada@Example.com"}, {"emailLower", "ada@example.com"}})
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, Raw(wantData).String(), Raw(data).String(), "expected the computed field to be stored")
+
+		var got testComputedUser
+		err = unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, user, got, "expected the computed field to be ignored on decode")
+	})
+	t.Run("nested and pointer values", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(lowerEmail)
+		account := testComputedAccount{
+			Owner:  testComputedUser{Name: "Ada", Email: "A@X"},
+			Admins: []*testComputedUser{{Name: "Bob", Email: "B@X"}, nil},
+		}
+		data := marshalWithRegistry(t, reg, &account)
+
+		owner := Raw(data).Lookup("owner", "emailLower").StringValue()
+		assert.Equal(t, "a@x", owner, "expected owner emailLower %q, got %q", "a@x", owner)
+		admin := Raw(data).Lookup("admins", "0", "emailLower").StringValue()
+		assert.Equal(t, "b@x", admin, "expected admin emailLower %q, got %q", "b@x", admin)
+
+		var got testComputedAccount
+		err := unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, account, got, "expected %v, got %v", account, got)
+	})
+	t.Run("no computed fields", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(func(interface{}) D { return nil })
+		data := marshalWithRegistry(t, reg, testComputedUser{Name: "Ada"})
+
+		wantData, err := Marshal(testComputedUser{Name: "Ada"})
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, wantData, data, "expected only the struct fields")
+	})
+	t.Run("conflicting field", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newRegistry(func(interface{}) D { return D{{"email", "x"}} })
+		vw, err := bsonrw.NewBSONValueWriter(new(bytes.Buffer))
+		require.NoError(t, err, "NewBSONValueWriter error")
+		enc, err := NewEncoder(vw)
+		require.NoError(t, err, "NewEncoder error")
+		enc.SetRegistry(reg)
+
+		err = enc.Encode(testComputedUser{Email: "y"})
+		assert.NotNil(t, err, "expected an error for a computed field named like a struct field, got nil")
+		assert.True(t, strings.Contains(err.Error(), `computed field "email"`), "unexpected error: %v", err)
+	})
+	t.Run("invalid registration panics", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name     string
+			typ      reflect.Type
+			computed func(interface{}) D
+		}{
+			{"not a struct", reflect.TypeOf(""), lowerEmail},
+			{"nil type", nil, lowerEmail},
+			{"nil function", reflect.TypeOf(testComputedUser{}), nil},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				defer func() {
+					assert.NotNil(t, recover(), "expected RegisterComputedFields to panic")
+				}()
+				RegisterComputedFields(NewRegistry(), tc.typ, tc.computed)
+			})
+		}
+	})
+}
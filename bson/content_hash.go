@@ -0,0 +1,131 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ContentHash returns the SHA-256 hash of a canonical form of the document produced by marshaling v with the default
+// registry. Documents that are semantically equal have the same hash, even if they were built from maps with different
+// iteration orders or use different numeric types for the same value:
+//
+//   - The elements of documents, including embedded documents, are hashed in ascending order of their keys, so the
+//     order of the fields does not matter. The order of array elements does matter.
+//   - BSON int32, int64, and double values that hold the same integer, such as int32(1), int64(1), and 1.0, are hashed
+//     as the same value. Doubles with a fractional part are hashed as doubles, and 0.0 and -0.0 are hashed as 0.
+//   - Values of all other types, including decimal128, are hashed as their BSON type and bytes.
+//
+// The hash is intended for deduplication and change detection. It is not a stable wire format and may differ between
+// major versions of the driver.
+func ContentHash(v interface{}) ([]byte, error) {
+	doc, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	if err := hashDocument(h, doc); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// hashDocument writes the canonical form of doc to h.
+func hashDocument(h hash.Hash, doc bsoncore.Document) error {
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(elems, func(i, j int) bool {
+		return elems[i].Key() < elems[j].Key()
+	})
+
+	writeHashHeader(h, bsontype.EmbeddedDocument, len(elems))
+	for _, elem := range elems {
+		key := elem.Key()
+		writeHashLength(h, len(key))
+		h.Write([]byte(key))
+		if err := hashValue(h, elem.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashValue writes the canonical form of val to h.
+func hashValue(h hash.Hash, val bsoncore.Value) error {
+	switch val.Type {
+	case bsontype.EmbeddedDocument:
+		return hashDocument(h, val.Document())
+	case bsontype.Array:
+		values, err := val.Array().Values()
+		if err != nil {
+			return err
+		}
+		writeHashHeader(h, bsontype.Array, len(values))
+		for _, v := range values {
+			if err := hashValue(h, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case bsontype.Int32:
+		writeHashInt(h, int64(val.Int32()))
+		return nil
+	case bsontype.Int64:
+		writeHashInt(h, val.Int64())
+		return nil
+	case bsontype.Double:
+		f := val.Double()
+		// Doubles in [-2^63, 2^63) that hold an integer are hashed like int64 values.
+		if f == math.Trunc(f) && f >= -(1<<63) && f < 1<<63 {
+			writeHashInt(h, int64(f))
+			return nil
+		}
+		bits := math.Float64bits(f)
+		if math.IsNaN(f) {
+			bits = math.Float64bits(math.NaN())
+		}
+		h.Write([]byte{byte(bsontype.Double)})
+		writeHashUint64(h, bits)
+		return nil
+	default:
+		writeHashHeader(h, val.Type, len(val.Data))
+		h.Write(val.Data)
+		return nil
+	}
+}
+
+// writeHashInt writes an integer value to h.
+func writeHashInt(h hash.Hash, i int64) {
+	h.Write([]byte{byte(bsontype.Int64)})
+	writeHashUint64(h, uint64(i))
+}
+
+// writeHashHeader writes a type and a length or element count to h, so that adjacent values cannot be confused.
+func writeHashHeader(h hash.Hash, t bsontype.Type, n int) {
+	h.Write([]byte{byte(t)})
+	writeHashLength(h, n)
+}
+
+func writeHashLength(h hash.Hash, n int) {
+	writeHashUint64(h, uint64(n))
+}
+
+func writeHashUint64(h hash.Hash, u uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], u)
+	h.Write(buf[:])
+}
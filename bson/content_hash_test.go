@@ -0,0 +1,120 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestContentHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		a, b  interface{}
+		equal bool
+	}{
+		{
+			name:  "reordered maps",
+			a:     M{"a": "x", "b": int32(1), "c": M{"d": true, "e": A{1, 2}}},
+			b:     M{"c": M{"e": A{1, 2}, "d": true}, "b": int32(1), "a": "x"},
+			equal: true,
+		},
+		{
+			name:  "reordered D",
+			a:     D{{"a", 1}, {"b", D{{"c", 2}, {"d", 3}}}},
+			b:     D{{"b", D{{"d", 3}, {"c", 2}}}, {"a", 1}},
+			equal: true,
+		},
+		{
+			name:  "int32 and int64",
+			a:     D{{"n", int32(42)}, {"arr", A{int32(-1)}}},
+			b:     D{{"n", int64(42)}, {"arr", A{int64(-1)}}},
+			equal: true,
+		},
+		{
+			name:  "integral double",
+			a:     D{{"n", int32(3)}, {"z", 0.0}},
+			b:     D{{"n", 3.0}, {"z", math.Copysign(0, -1)}},
+			equal: true,
+		},
+		{
+			name:  "struct and map",
+			a:     struct{ A, B int32 }{1, 2},
+			b:     M{"b": int64(2), "a": int64(1)},
+			equal: true,
+		},
+		{
+			name: "fractional double",
+			a:    D{{"n", 3.5}},
+			b:    D{{"n", int32(3)}},
+		},
+		{
+			name: "reordered array",
+			a:    D{{"arr", A{1, 2}}},
+			b:    D{{"arr", A{2, 1}}},
+		},
+		{
+			name: "number and string",
+			a:    D{{"n", 1}},
+			b:    D{{"n", "1"}},
+		},
+		{
+			name: "moved field",
+			a:    D{{"a", D{{"b", 1}}}},
+			b:    D{{"a", D{}}, {"b", 1}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := ContentHash(tc.a)
+			require.NoError(t, err, "ContentHash error")
+			b, err := ContentHash(tc.b)
+			require.NoError(t, err, "ContentHash error")
+
+			assert.Len(t, a, 32, "expected a SHA-256 hash")
+			assert.Equal(t, tc.equal, bytes.Equal(a, b), "expected hashes of %v and %v to be equal: %v", tc.a, tc.b,
+				tc.equal)
+		})
+	}
+}
+
+func TestContentHashStable(t *testing.T) {
+	t.Parallel()
+
+	// Build many maps so that at least some of them iterate in different orders.
+	var first []byte
+	for i := 0; i < 20; i++ {
+		m := M{}
+		for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			m[k] = M{"x": k, "y": int64(1)}
+		}
+		got, err := ContentHash(m)
+		require.NoError(t, err, "ContentHash error")
+		if first == nil {
+			first = got
+			continue
+		}
+		assert.Equal(t, first, got, "expected the same hash for every map")
+	}
+}
+
+func TestContentHashError(t *testing.T) {
+	t.Parallel()
+
+	_, err := ContentHash(42)
+	assert.NotNil(t, err, "expected an error for a value that is not a document")
+}
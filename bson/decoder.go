@@ -44,6 +44,11 @@ type Decoder struct {
 	useLocalTimeZone  bool
 	zeroMaps          bool
 	zeroStructs       bool
+
+	numbersAsFloat64       bool
+	integralNumbersAsInt64 bool
+
+	maxNestingDepth int
 }
 
 // NewDecoder returns a new decoder that uses the DefaultRegistry to read from vr.
@@ -81,6 +86,12 @@ func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*
 //
 // See [Unmarshal] for details about BSON unmarshaling behavior.
 func (d *Decoder) Decode(val interface{}) error {
+	if d.maxNestingDepth > 0 {
+		if limiter, ok := d.vr.(bsonrw.NestingDepthLimiter); ok {
+			limiter.SetMaxNestingDepth(d.maxNestingDepth)
+		}
+	}
+
 	if unmarshaler, ok := val.(Unmarshaler); ok {
 		// TODO(skriptble): Reuse a []byte here and use the AppendDocumentBytes method.
 		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(d.vr)
@@ -130,6 +141,12 @@ func (d *Decoder) Decode(val interface{}) error {
 	if d.zeroStructs {
 		d.dc.ZeroStructs()
 	}
+	if d.numbersAsFloat64 {
+		d.dc.NumbersAsFloat64()
+	}
+	if d.integralNumbersAsInt64 {
+		d.dc.IntegralNumbersAsInt64()
+	}
 
 	return decoder.DecodeValue(d.dc, d.vr, rval)
 }
@@ -183,6 +200,33 @@ func (d *Decoder) BinaryAsSlice() {
 	d.binaryAsSlice = true
 }
 
+// NumbersAsFloat64 causes the Decoder to unmarshal BSON int32, int64, and double values into a Go
+// float64 when decoding into an empty interface, including the values of primitive.M, primitive.D,
+// and map[string]interface{} documents. Data that stores the same logical number with different
+// BSON types (e.g. documents imported from JSON) then decodes to a single Go type, so decoded values
+// can be compared directly. Integer values with a magnitude greater than 2^53 lose precision.
+//
+// Values decoded into struct fields or other variables with a concrete Go type are not affected.
+// BSON decimal128 values are not converted. NumbersAsFloat64 overrides a previous call to
+// IntegralNumbersAsInt64.
+func (d *Decoder) NumbersAsFloat64() {
+	d.numbersAsFloat64 = true
+	d.integralNumbersAsInt64 = false
+}
+
+// IntegralNumbersAsInt64 causes the Decoder to unmarshal BSON int32 and int64 values, and BSON
+// double values that have no fractional part and fit in an int64, into a Go int64 when decoding
+// into an empty interface, including the values of primitive.M, primitive.D, and
+// map[string]interface{} documents. Other BSON double values are unmarshaled into a Go float64.
+//
+// Values decoded into struct fields or other variables with a concrete Go type are not affected.
+// BSON decimal128 values are not converted. IntegralNumbersAsInt64 overrides a previous call to
+// NumbersAsFloat64.
+func (d *Decoder) IntegralNumbersAsInt64() {
+	d.integralNumbersAsInt64 = true
+	d.numbersAsFloat64 = false
+}
+
 // UseJSONStructTags causes the Decoder to fall back to using the "json" struct tag if a "bson"
 // struct tag is not specified.
 func (d *Decoder) UseJSONStructTags() {
@@ -206,3 +250,16 @@ func (d *Decoder) ZeroMaps() {
 func (d *Decoder) ZeroStructs() {
 	d.zeroStructs = true
 }
+
+// SetMaxNestingDepth causes the Decoder to return an error wrapping bsonrw.ErrMaxNestingDepth when
+// it reads a document or array that is nested more than depth levels deep. A document that contains
+// no embedded documents or arrays has a depth of 1. By default, the nesting depth is not limited.
+// Decoding deeply nested documents uses a lot of stack space, so services that decode untrusted
+// BSON can set a limit to reject such documents early.
+//
+// The limit only applies to ValueReaders that implement bsonrw.NestingDepthLimiter, such as the ones
+// returned by bsonrw.NewBSONDocumentReader. Documents decoded into a bson.Raw or another type that
+// keeps the BSON bytes as-is are not checked, because their contents are not decoded.
+func (d *Decoder) SetMaxNestingDepth(depth int) {
+	d.maxNestingDepth = depth
+}
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -328,6 +329,12 @@ func TestDecoderConfiguration(t *testing.T) {
 		MyInt    int
 	}
 
+	type normalizeNumbersTest struct {
+		MyInt32  int32
+		MyDouble float64
+		MyAny    interface{}
+	}
+
 	testCases := []struct {
 		description string
 		configure   func(*Decoder)
@@ -415,6 +422,117 @@ func TestDecoderConfiguration(t *testing.T) {
 				{Key: "myDocument", Value: M{"myString": "test value"}},
 			},
 		},
+		// Test that NumbersAsFloat64 causes the Decoder to unmarshal BSON int32, int64, and double
+		// values into Go float64 values when there is no type information, including in nested
+		// documents and arrays.
+		{
+			description: "NumbersAsFloat64",
+			configure: func(dec *Decoder) {
+				dec.NumbersAsFloat64()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendInt32("myInt32", 1).
+				AppendInt64("myInt64", 2).
+				AppendDouble("myDouble", 3).
+				AppendDouble("myFraction", 3.5).
+				AppendDocument("myDocument", bsoncore.NewDocumentBuilder().
+					AppendInt32("myNested", 4).
+					Build()).
+				AppendArray("myArray", bsoncore.NewArrayBuilder().
+					AppendInt32(5).
+					AppendInt64(6).
+					Build()).
+				Build(),
+			decodeInto: func() interface{} { return M{} },
+			want: M{
+				"myInt32":    float64(1),
+				"myInt64":    float64(2),
+				"myDouble":   float64(3),
+				"myFraction": float64(3.5),
+				"myDocument": M{"myNested": float64(4)},
+				"myArray":    A{float64(5), float64(6)},
+			},
+		},
+		// Test that IntegralNumbersAsInt64 causes the Decoder to unmarshal BSON int32 and int64
+		// values, and BSON doubles without a fractional part, into Go int64 values when there is no
+		// type information.
+		{
+			description: "IntegralNumbersAsInt64",
+			configure: func(dec *Decoder) {
+				dec.IntegralNumbersAsInt64()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendInt32("myInt32", 1).
+				AppendInt64("myInt64", 2).
+				AppendDouble("myDouble", 3).
+				AppendDouble("myFraction", 3.5).
+				AppendDocument("myDocument", bsoncore.NewDocumentBuilder().
+					AppendInt32("myNested", 4).
+					Build()).
+				AppendArray("myArray", bsoncore.NewArrayBuilder().
+					AppendInt32(5).
+					AppendInt64(6).
+					Build()).
+				Build(),
+			decodeInto: func() interface{} { return &D{} },
+			want: &D{
+				{Key: "myInt32", Value: int64(1)},
+				{Key: "myInt64", Value: int64(2)},
+				{Key: "myDouble", Value: int64(3)},
+				{Key: "myFraction", Value: float64(3.5)},
+				{Key: "myDocument", Value: D{{Key: "myNested", Value: int64(4)}}},
+				{Key: "myArray", Value: A{int64(5), int64(6)}},
+			},
+		},
+		// Test that IntegralNumbersAsInt64 does not convert BSON doubles that are out of the int64
+		// range or are not finite.
+		{
+			description: "IntegralNumbersAsInt64 out of range",
+			configure: func(dec *Decoder) {
+				dec.IntegralNumbersAsInt64()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendDouble("myLarge", math.Pow(2, 63)).
+				AppendDouble("myInf", math.Inf(1)).
+				Build(),
+			decodeInto: func() interface{} { return M{} },
+			want: M{
+				"myLarge": math.Pow(2, 63),
+				"myInf":   math.Inf(1),
+			},
+		},
+		// Test that the last number normalization method called on the Decoder wins.
+		{
+			description: "NumbersAsFloat64 overrides IntegralNumbersAsInt64",
+			configure: func(dec *Decoder) {
+				dec.IntegralNumbersAsInt64()
+				dec.NumbersAsFloat64()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendInt32("myInt32", 1).
+				Build(),
+			decodeInto: func() interface{} { return M{} },
+			want:       M{"myInt32": float64(1)},
+		},
+		// Test that number normalization does not affect struct fields with a concrete Go type but
+		// does apply to struct fields typed as interface{}.
+		{
+			description: "NumbersAsFloat64 struct fields",
+			configure: func(dec *Decoder) {
+				dec.NumbersAsFloat64()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendInt32("myInt32", 1).
+				AppendDouble("myDouble", 2).
+				AppendInt64("myAny", 3).
+				Build(),
+			decodeInto: func() interface{} { return &normalizeNumbersTest{} },
+			want: &normalizeNumbersTest{
+				MyInt32:  1,
+				MyDouble: 2,
+				MyAny:    float64(3),
+			},
+		},
 		// Test that UseJSONStructTags causes the Decoder to fall back to "json" struct tags if
 		// "bson" struct tags are not available.
 		{
@@ -545,3 +663,92 @@ func TestDecoderConfiguration(t *testing.T) {
 		assert.Equal(t, want, got, "expected and actual decode results do not match")
 	})
 }
+
+func TestDecoderMaxNestingDepth(t *testing.T) {
+	t.Parallel()
+
+	// nested returns a document with the given nesting depth. If array is true, documents are nested
+	// in arrays, so each additional document adds two levels and depth must be odd.
+	nested := func(depth int, array bool) []byte {
+		doc := bsoncore.NewDocumentBuilder().AppendInt32("x", 1).Build()
+		for d := 1; d < depth; {
+			if array {
+				doc = bsoncore.NewDocumentBuilder().
+					AppendArray("a", bsoncore.NewArrayBuilder().AppendDocument(doc).Build()).
+					Build()
+				d += 2
+			} else {
+				doc = bsoncore.NewDocumentBuilder().AppendDocument("d", doc).Build()
+				d++
+			}
+		}
+		return doc
+	}
+
+	testCases := []struct {
+		name     string
+		input    []byte
+		maxDepth int
+		wantErr  bool
+	}{
+		{"unlimited by default", nested(1000, false), 0, false},
+		{"custom limit", nested(3, false), 3, false},
+		{"exceeds custom limit", nested(4, false), 3, true},
+		{"arrays", nested(5, true), 5, false},
+		{"arrays exceed custom limit", nested(7, true), 5, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(tc.input))
+			require.NoError(t, err, "NewDecoder error")
+			dec.SetMaxNestingDepth(tc.maxDepth)
+
+			var got D
+			err = dec.Decode(&got)
+			if !tc.wantErr {
+				assert.NoError(t, err, "Decode error")
+				return
+			}
+			assert.True(t, errors.Is(err, bsonrw.ErrMaxNestingDepth),
+				"expected error to wrap %v, got %v", bsonrw.ErrMaxNestingDepth, err)
+		})
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+
+		type node struct {
+			X int   `bson:"x"`
+			D *node `bson:"d"`
+		}
+
+		dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(nested(4, false)))
+		require.NoError(t, err, "NewDecoder error")
+		dec.SetMaxNestingDepth(3)
+
+		var got node
+		err = dec.Decode(&got)
+		assert.True(t, errors.Is(err, bsonrw.ErrMaxNestingDepth),
+			"expected error to wrap %v, got %v", bsonrw.ErrMaxNestingDepth, err)
+	})
+	t.Run("multiple documents", func(t *testing.T) {
+		t.Parallel()
+
+		input := nested(3, false)
+		dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(input))
+		require.NoError(t, err, "NewDecoder error")
+		dec.SetMaxNestingDepth(3)
+
+		for i := 0; i < 2; i++ {
+			var got D
+			err = dec.Decode(&got)
+			require.NoError(t, err, "Decode error")
+
+			err = dec.Reset(bsonrw.NewBSONDocumentReader(input))
+			require.NoError(t, err, "Reset error")
+		}
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// RegisterDiscriminator registers codecs on reg that encode and decode values of the interface type iface as
+// documents with a discriminator field named fieldName. The types map associates each discriminator value with the
+// concrete type it stands for. Every type in types must implement iface and must encode to a BSON document.
+//
+// When a value of type iface is encoded, such as a struct field of type iface, its document is written with the
+// discriminator value of its concrete type as the first field. Encoding a value whose concrete type is not in types
+// returns an error. When a document is decoded into a value of type iface, the discriminator field is read and the
+// document is decoded into a new value of the matching concrete type. Decoding a document whose discriminator field is
+// missing, is not a string, or has a value that is not in types returns an error. BSON null is encoded from and
+// decoded into a nil interface.
+//
+// The codecs are only used when the static type of the value is iface. Marshaling a concrete type directly, such as
+// by passing it to Marshal, does not write the discriminator field.
+//
+// For example, given an interface Shape implemented by the types Circle and Square:
+//
+//	reg := bson.NewRegistry()
+//	bson.RegisterDiscriminator(
+//		reg,
+//		reflect.TypeOf((*Shape)(nil)).Elem(),
+//		"_type",
+//		map[string]reflect.Type{
+//			"circle": reflect.TypeOf(Circle{}),
+//			"square": reflect.TypeOf(Square{}),
+//		},
+//	)
+//
+// RegisterDiscriminator panics if reg or iface is nil, if iface is not an interface, if fieldName is empty, or if a
+// type in types is nil, does not implement iface, or is registered for more than one discriminator value.
+func RegisterDiscriminator(reg *bsoncodec.Registry, iface reflect.Type, fieldName string, types map[string]reflect.Type) {
+	if reg == nil || iface == nil {
+		panic(errors.New("arguments to RegisterDiscriminator must not be nil"))
+	}
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Errorf("RegisterDiscriminator expects a type with kind reflect.Interface, got type %s with kind %s",
+			iface, iface.Kind()))
+	}
+	if fieldName == "" {
+		panic(errors.New("RegisterDiscriminator expects a non-empty field name"))
+	}
+
+	dc := &discriminatorCodec{
+		iface:     iface,
+		fieldName: fieldName,
+		types:     make(map[string]reflect.Type, len(types)),
+		names:     make(map[reflect.Type]string, len(types)),
+	}
+	for name, t := range types {
+		if t == nil || !t.Implements(iface) {
+			panic(fmt.Errorf("type %v for discriminator value %q does not implement %v", t, name, iface))
+		}
+		if other, ok := dc.names[t]; ok {
+			panic(fmt.Errorf("type %v is registered for discriminator values %q and %q", t, other, name))
+		}
+		dc.types[name] = t
+		dc.names[t] = name
+	}
+
+	reg.RegisterTypeEncoder(iface, bsoncodec.ValueEncoderFunc(dc.EncodeValue))
+	reg.RegisterTypeDecoder(iface, bsoncodec.ValueDecoderFunc(dc.DecodeValue))
+}
+
+// discriminatorCodec is the codec registered by RegisterDiscriminator.
+type discriminatorCodec struct {
+	iface     reflect.Type
+	fieldName string
+	types     map[string]reflect.Type
+	names     map[reflect.Type]string
+}
+
+// EncodeValue encodes the concrete value of an interface as a document with the discriminator field first.
+func (dc *discriminatorCodec) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != dc.iface {
+		return bsoncodec.ValueEncoderError{Name: "DiscriminatorEncodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	concrete := val.Elem()
+	name, ok := dc.names[concrete.Type()]
+	if !ok {
+		return fmt.Errorf("no discriminator value registered for type %v", concrete.Type())
+	}
+
+	encoder, err := ec.LookupEncoder(concrete.Type())
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	evw, err := bsonrw.NewBSONValueWriter(buf)
+	if err != nil {
+		return err
+	}
+	if err := encoder.EncodeValue(ec, evw, concrete); err != nil {
+		return fmt.Errorf("cannot encode %v as a document: %w", concrete.Type(), err)
+	}
+	elems, err := bsoncore.Document(buf.Bytes()).Elements()
+	if err != nil {
+		return err
+	}
+
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	nvw, err := dw.WriteDocumentElement(dc.fieldName)
+	if err != nil {
+		return err
+	}
+	if err := nvw.WriteString(name); err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if elem.Key() == dc.fieldName {
+			continue
+		}
+		evw, err := dw.WriteDocumentElement(elem.Key())
+		if err != nil {
+			return err
+		}
+		value := elem.Value()
+		if err := bsonrw.NewCopier().CopyValueFromBytes(evw, value.Type, value.Data); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// DecodeValue decodes a document into a new value of the concrete type selected by its discriminator field.
+func (dc *discriminatorCodec) DecodeValue(dctx bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != dc.iface {
+		return bsoncodec.ValueDecoderError{Name: "DiscriminatorDecodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(dc.iface))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(dc.iface))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a %v", vr.Type(), dc.iface)
+	}
+
+	doc, err := bsonrw.NewCopier().CopyDocumentToBytes(vr)
+	if err != nil {
+		return err
+	}
+	nameVal, err := bsoncore.Document(doc).LookupErr(dc.fieldName)
+	if err != nil {
+		return fmt.Errorf("cannot decode document into a %v: missing discriminator field %q", dc.iface, dc.fieldName)
+	}
+	name, ok := nameVal.StringValueOK()
+	if !ok {
+		return fmt.Errorf("cannot decode document into a %v: discriminator field %q must be a string, got %v",
+			dc.iface, dc.fieldName, nameVal.Type)
+	}
+	t, ok := dc.types[name]
+	if !ok {
+		return fmt.Errorf("cannot decode document into a %v: unknown discriminator value %q", dc.iface, name)
+	}
+
+	decoder, err := dctx.LookupDecoder(t)
+	if err != nil {
+		return err
+	}
+	concrete := reflect.New(t).Elem()
+	if err := decoder.DecodeValue(dctx, bsonrw.NewBSONDocumentReader(doc), concrete); err != nil {
+		return err
+	}
+	val.Set(concrete)
+	return nil
+}
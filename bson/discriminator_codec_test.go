@@ -0,0 +1,184 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+type testShape interface {
+	area() float64
+}
+
+type testCircle struct {
+	Radius float64 `bson:"radius"`
+}
+
+func (c testCircle) area() float64 { return 3 * c.Radius * c.Radius }
+
+type testSquare struct {
+	Side float64 `bson:"side"`
+}
+
+func (s *testSquare) area() float64 { return s.Side * s.Side }
+
+type testDrawing struct {
+	Name   string      `bson:"name"`
+	Shape  testShape   `bson:"shape"`
+	Shapes []testShape `bson:"shapes"`
+}
+
+func newDiscriminatorTestRegistry() *bsoncodec.Registry {
+	reg := NewRegistry()
+	RegisterDiscriminator(
+		reg,
+		reflect.TypeOf((*testShape)(nil)).Elem(),
+		"_type",
+		map[string]reflect.Type{
+			"circle": reflect.TypeOf(testCircle{}),
+			"square": reflect.TypeOf(&testSquare{}),
+		},
+	)
+	return reg
+}
+
+func TestDiscriminatorCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newDiscriminatorTestRegistry()
+		want := testDrawing{
+			Name:   "drawing",
+			Shape:  testCircle{Radius: 2},
+			Shapes: []testShape{&testSquare{Side: 3}, testCircle{Radius: 1}},
+		}
+		data := marshalWithRegistry(t, reg, want)
+
+		wantDoc := D{
+			{"name", "drawing"},
+			{"shape", D{{"_type", "circle"}, {"radius", 2.0}}},
+			{"shapes", A{
+				D{{"_type", "square"}, {"side", 3.0}},
+				D{{"_type", "circle"}, {"radius", 1.0}},
+			}},
+		}
+		wantData, err := Marshal(wantDoc)
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, Raw(wantData).String(), Raw(data).String(), "expected and actual documents do not match")
+
+		var got testDrawing
+		err = unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "unmarshal error")
+		assert.Equal(t, want, got, "expected and actual values do not match")
+	})
+	t.Run("discriminator overrides field", func(t *testing.T) {
+		t.Parallel()
+
+		type typedCircle struct {
+			Type   string  `bson:"_type"`
+			Radius float64 `bson:"radius"`
+		}
+		reg := newDiscriminatorTestRegistry()
+		data := marshalWithRegistry(t, reg, testDrawing{Shape: testCircle{Radius: 1}})
+		var got struct {
+			Shape typedCircle `bson:"shape"`
+		}
+		err := unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "unmarshal error")
+		assert.Equal(t, typedCircle{Type: "circle", Radius: 1}, got.Shape, "expected and actual values do not match")
+	})
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newDiscriminatorTestRegistry()
+		data := marshalWithRegistry(t, reg, testDrawing{Name: "empty"})
+		assert.Equal(t, TypeNull, Raw(data).Lookup("shape").Type, "expected shape to be null")
+
+		got := testDrawing{Shape: testCircle{Radius: 1}}
+		err := unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "unmarshal error")
+		assert.Nil(t, got.Shape, "expected nil shape, got %v", got.Shape)
+	})
+	t.Run("unknown discriminator", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Marshal(D{{"shape", D{{"_type", "triangle"}, {"base", 1}}}})
+		require.NoError(t, err, "Marshal error")
+
+		var got testDrawing
+		err = unmarshalWithRegistry(newDiscriminatorTestRegistry(), data, &got)
+		require.Error(t, err, "expected unmarshal error")
+		assert.True(t, strings.Contains(err.Error(), `unknown discriminator value "triangle"`),
+			"expected unknown discriminator error, got %v", err)
+	})
+	t.Run("missing discriminator", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Marshal(D{{"shape", D{{"radius", 1.0}}}})
+		require.NoError(t, err, "Marshal error")
+
+		var got testDrawing
+		err = unmarshalWithRegistry(newDiscriminatorTestRegistry(), data, &got)
+		require.Error(t, err, "expected unmarshal error")
+		assert.True(t, strings.Contains(err.Error(), `missing discriminator field "_type"`),
+			"expected missing discriminator error, got %v", err)
+	})
+	t.Run("unregistered type", func(t *testing.T) {
+		t.Parallel()
+
+		reg := NewRegistry()
+		RegisterDiscriminator(reg, reflect.TypeOf((*testShape)(nil)).Elem(), "_type",
+			map[string]reflect.Type{"circle": reflect.TypeOf(testCircle{})})
+
+		vw, err := bsonrw.NewBSONValueWriter(new(bytes.Buffer))
+		require.NoError(t, err, "NewBSONValueWriter error")
+		enc, err := NewEncoder(vw)
+		require.NoError(t, err, "NewEncoder error")
+		enc.SetRegistry(reg)
+		err = enc.Encode(testDrawing{Shape: &testSquare{Side: 1}})
+		require.Error(t, err, "expected marshal error")
+		assert.True(t, strings.Contains(err.Error(), "no discriminator value registered"),
+			"expected unregistered type error, got %v", err)
+	})
+	t.Run("invalid registration panics", func(t *testing.T) {
+		t.Parallel()
+
+		iface := reflect.TypeOf((*testShape)(nil)).Elem()
+		testCases := []struct {
+			name      string
+			iface     reflect.Type
+			fieldName string
+			types     map[string]reflect.Type
+		}{
+			{"not an interface", reflect.TypeOf(testCircle{}), "_type", nil},
+			{"empty field name", iface, "", nil},
+			{"type does not implement interface", iface, "_type",
+				map[string]reflect.Type{"square": reflect.TypeOf(testSquare{})}},
+			{"duplicate type", iface, "_type",
+				map[string]reflect.Type{"a": reflect.TypeOf(testCircle{}), "b": reflect.TypeOf(testCircle{})}},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				defer func() {
+					assert.NotNil(t, recover(), "expected RegisterDiscriminator to panic")
+				}()
+				RegisterDiscriminator(NewRegistry(), tc.iface, tc.fieldName, tc.types)
+			})
+		}
+	})
+}
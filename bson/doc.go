@@ -134,6 +134,23 @@
 //     error will be returned. This tag can be used with fields that are pointers to structs. If an inlined pointer field
 //     is nil, it will not be marshaled. For fields that are not maps or structs, this tag is ignored.
 //
+//  5. canonical: If the canonical struct tag is specified on a field of type json.RawMessage and the codec registered by
+//     [bsoncodec.RegisterJSONRawMessageCodec] is used, embedded documents unmarshaled into that field are converted to
+//     canonical rather than relaxed Extended JSON. For other types, this tag is ignored.
+//
+// A field can also have a bsondefault struct tag, which gives the value the field is set to when it is unmarshaled from
+// a document that does not contain it. The default is parsed according to the type of the field, which must be a
+// string, boolean, integer, or floating point type, or a pointer to one of those types. A field that is present in the
+// document with a null value is unmarshaled as usual rather than set to the default. For example:
+//
+//	type Account struct {
+//		Status  string `bson:"status" bsondefault:"active"`
+//		Retries int    `bson:"retries" bsondefault:"3"`
+//	}
+//
+// A field of type [Nullable] records whether it was absent from the document, set to null, or set to a value when it
+// is unmarshaled, which is useful for partial updates.
+//
 // # Marshaling and Unmarshaling
 //
 // Manually marshaling and unmarshaling can be done with the Marshal and Unmarshal family of functions.
@@ -38,6 +38,7 @@ type Encoder struct {
 	nilByteSliceAsEmpty     bool
 	omitZeroStruct          bool
 	useJSONStructTags       bool
+	sortMapKeys             bool
 }
 
 // NewEncoder returns a new encoder that uses the DefaultRegistry to write to vw.
@@ -115,6 +116,9 @@ func (e *Encoder) Encode(val interface{}) error {
 	if e.useJSONStructTags {
 		e.ec.UseJSONStructTags()
 	}
+	if e.sortMapKeys {
+		e.ec.SortMapKeys()
+	}
 
 	return encoder.EncodeValue(e.ec, e.vw, reflect.ValueOf(val))
 }
@@ -197,3 +201,15 @@ func (e *Encoder) OmitZeroStruct() {
 func (e *Encoder) UseJSONStructTags() {
 	e.useJSONStructTags = true
 }
+
+// SortMapKeys causes the Encoder to marshal the elements of Go maps (including primitive.M and
+// inline maps) in lexicographic order of their BSON document field names instead of the random
+// order in which Go iterates over maps. Marshaling the same map multiple times then produces the
+// same bytes, which is useful when the marshaled BSON is hashed or compared byte-for-byte.
+//
+// Sorting changes only the byte representation and not the meaning of the document, since BSON
+// documents with the same fields and values are equivalent for queries regardless of field order.
+// Struct fields are always marshaled in declaration order and are not affected by this setting.
+func (e *Encoder) SortMapKeys() {
+	e.sortMapKeys = true
+}
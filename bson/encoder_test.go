@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -274,6 +275,31 @@ func TestEncoderConfiguration(t *testing.T) {
 				AppendString("jsonFieldName", "test value").
 				Build(),
 		},
+		// Test that SortMapKeys encodes map elements in lexicographic order of their field names,
+		// including maps nested in structs and inline maps.
+		{
+			description: "SortMapKeys",
+			configure: func(enc *Encoder) {
+				enc.SortMapKeys()
+			},
+			input: struct {
+				Nested map[int]string
+				Inline map[string]int32 `bson:",inline"`
+			}{
+				Nested: map[int]string{10: "ten", 2: "two", 1: "one"},
+				Inline: map[string]int32{"z": 26, "b": 2, "m": 13},
+			},
+			want: bsoncore.NewDocumentBuilder().
+				AppendDocument("nested", bsoncore.NewDocumentBuilder().
+					AppendString("1", "one").
+					AppendString("10", "ten").
+					AppendString("2", "two").
+					Build()).
+				AppendInt32("b", 2).
+				AppendInt32("m", 13).
+				AppendInt32("z", 26).
+				Build(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -313,3 +339,29 @@ func TestEncoderConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoderSortMapKeys(t *testing.T) {
+	input := M{}
+	for i := 0; i < 50; i++ {
+		input[fmt.Sprintf("key%d", i)] = M{"a": i, "b": i, "c": i}
+	}
+
+	encode := func() []byte {
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		require.NoError(t, err, "NewBSONValueWriter error")
+		enc, err := NewEncoder(vw)
+		require.NoError(t, err, "NewEncoder error")
+		enc.SortMapKeys()
+
+		err = enc.Encode(input)
+		require.NoError(t, err, "Encode error")
+		return buf.Bytes()
+	}
+
+	want := encode()
+	for i := 0; i < 10; i++ {
+		got := encode()
+		assert.Equal(t, want, got, "expected identical bytes when encoding the same map")
+	}
+}
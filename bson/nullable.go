@@ -0,0 +1,77 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Nullable is a struct field type that distinguishes a field that is absent from a document, a field that is set to
+// BSON null, and a field that has a value. This is useful for partial updates, where an absent field should be left
+// unchanged but a null field should be cleared.
+//
+// When a document is unmarshaled into a zero struct with a Nullable field, the field is left as the zero Nullable
+// (Present is false) if the document does not contain it, Present and Null are set to true if it is BSON null or
+// undefined, and Present is set to true and Value is set to the decoded value otherwise. Value is decoded with the
+// default registry.
+//
+// When a struct is marshaled, a Nullable with Null set or Present unset is marshaled as BSON null, and a Nullable with
+// Present set is marshaled as Value. Use the "omitempty" struct tag to omit the field when Present is not set:
+//
+//	type UserPatch struct {
+//		Name     bson.Nullable[string] `bson:"name,omitempty"`
+//		Nickname bson.Nullable[string] `bson:"nickname,omitempty"`
+//	}
+type Nullable[T any] struct {
+	// Value is the value of the field. It is the zero value of T if Present is false or Null is true.
+	Value T
+
+	// Present is true if the field is in the document.
+	Present bool
+
+	// Null is true if the field is in the document and is BSON null.
+	Null bool
+}
+
+// NullableOf returns a Nullable that is present with the value v.
+func NullableOf[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Present: true}
+}
+
+// NullableNull returns a Nullable that is present and null.
+func NullableNull[T any]() Nullable[T] {
+	return Nullable[T]{Present: true, Null: true}
+}
+
+// IsZero returns true if the field is absent, so that fields with the "omitempty" struct tag are omitted when they
+// are absent. It implements bsoncodec.Zeroer.
+func (n Nullable[T]) IsZero() bool {
+	return !n.Present
+}
+
+// MarshalBSONValue implements the ValueMarshaler interface.
+func (n Nullable[T]) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !n.Present || n.Null {
+		return bsontype.Null, nil, nil
+	}
+	return MarshalValue(n.Value)
+}
+
+// UnmarshalBSONValue implements the ValueUnmarshaler interface.
+func (n *Nullable[T]) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var zero T
+	*n = Nullable[T]{Present: true}
+	if t == bsontype.Null || t == bsontype.Undefined {
+		n.Null = true
+		return nil
+	}
+	if err := (RawValue{Type: t, Value: data}).Unmarshal(&n.Value); err != nil {
+		n.Value = zero
+		return err
+	}
+	return nil
+}
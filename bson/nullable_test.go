@@ -0,0 +1,107 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestNullable(t *testing.T) {
+	t.Parallel()
+
+	type patch struct {
+		Name  Nullable[string] `bson:"name,omitempty"`
+		Count Nullable[int32]  `bson:"count,omitempty"`
+	}
+
+	testCases := []struct {
+		name string
+		doc  string
+		want patch
+	}{
+		{
+			name: "absent",
+			doc:  `{}`,
+			want: patch{},
+		},
+		{
+			name: "null",
+			doc:  `{"name": null,"count": null}`,
+			want: patch{Name: NullableNull[string](), Count: NullableNull[int32]()},
+		},
+		{
+			name: "present",
+			doc:  `{"name": "alice","count": {"$numberInt":"0"}}`,
+			want: patch{Name: NullableOf("alice"), Count: NullableOf(int32(0))},
+		},
+		{
+			name: "mixed",
+			doc:  `{"name": null,"count": {"$numberInt":"3"}}`,
+			want: patch{Name: NullableNull[string](), Count: NullableOf(int32(3))},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var raw Raw
+			err := UnmarshalExtJSON([]byte(tc.doc), true, &raw)
+			require.NoError(t, err, "UnmarshalExtJSON error")
+
+			var got patch
+			err = Unmarshal(raw, &got)
+			require.NoError(t, err, "Unmarshal error")
+			assert.Equal(t, tc.want, got, "expected %+v, got %+v", tc.want, got)
+
+			b, err := Marshal(got)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, tc.doc, Raw(b).String(), "expected document %v, got %v", tc.doc, Raw(b))
+		})
+	}
+
+	t.Run("absent without omitempty is null", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := Marshal(struct {
+			Name Nullable[string] `bson:"name"`
+		}{})
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, `{"name": null}`, Raw(b).String(), "unexpected document %v", Raw(b))
+	})
+
+	t.Run("embedded document value", func(t *testing.T) {
+		t.Parallel()
+
+		type address struct {
+			City string `bson:"city"`
+		}
+		var got struct {
+			Address Nullable[address] `bson:"address"`
+		}
+		b, err := Marshal(D{{"address", D{{"city", "Paris"}}}})
+		require.NoError(t, err, "Marshal error")
+		err = Unmarshal(b, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, NullableOf(address{City: "Paris"}), got.Address, "unexpected value %+v", got.Address)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		var got patch
+		b, err := Marshal(D{{"count", "three"}})
+		require.NoError(t, err, "Marshal error")
+		err = Unmarshal(b, &got)
+		assert.NotNil(t, err, "expected Unmarshal error, got nil")
+	})
+}
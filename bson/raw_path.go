@@ -0,0 +1,256 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// emptyDocument is a BSON document with no elements.
+var emptyDocument = bsoncore.Document{5, 0, 0, 0, 0}
+
+// SetPath returns a copy of raw with the value at the dotted path set to value, which is marshaled with the default
+// registry. Path segments that traverse an array must be array indexes, e.g. "items.0.price". Missing embedded
+// documents along the path are created, and an index equal to the length of an array appends to it. The order of all
+// other elements is preserved. If a key appears more than once in a document, only the first occurrence is used.
+//
+// An error is returned if raw is not a valid document, if path is empty or contains an empty segment, if a segment
+// traverses a value that is neither a document nor an array, or if an array index is invalid or greater than the
+// length of the array.
+func SetPath(raw Raw, path string, value interface{}) (Raw, error) {
+	keys, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := raw.Validate(); err != nil {
+		return nil, err
+	}
+	t, data, err := MarshalValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := setDocumentPath(bsoncore.Document(raw), keys, bsoncore.Value{Type: t, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("cannot set path %q: %w", path, err)
+	}
+	return Raw(doc), nil
+}
+
+// RemovePath returns a copy of raw with the element at the dotted path removed. Path segments that traverse an array
+// must be array indexes, e.g. "items.0.price". Removing an array element shifts the elements after it down by one
+// index. The order of all other elements is preserved. If the path does not exist, an unmodified copy of raw is
+// returned.
+//
+// An error is returned if raw is not a valid document, if path is empty or contains an empty segment, or if a
+// segment that traverses an array is not a valid array index.
+func RemovePath(raw Raw, path string) (Raw, error) {
+	keys, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := raw.Validate(); err != nil {
+		return nil, err
+	}
+
+	doc, err := removeDocumentPath(bsoncore.Document(raw), keys)
+	if err != nil {
+		return nil, fmt.Errorf("cannot remove path %q: %w", path, err)
+	}
+	return Raw(doc), nil
+}
+
+func splitPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, errors.New("path must not be empty")
+	}
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("path %q must not contain empty segments", path)
+		}
+	}
+	return keys, nil
+}
+
+// parseArrayIndex parses key as an index into an array.
+func parseArrayIndex(key string) (int, error) {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("%q is not a valid array index", key)
+	}
+	return i, nil
+}
+
+func setDocumentPath(doc bsoncore.Document, keys []string, val bsoncore.Value) (bsoncore.Document, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	found := false
+	for _, elem := range elems {
+		if found || elem.Key() != keys[0] {
+			dst = append(dst, elem...)
+			continue
+		}
+		found = true
+
+		v, err := setValuePath(elem.Value(), keys[1:], val)
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, keys[0], v)
+	}
+	if !found {
+		v, err := newValuePath(keys[1:], val)
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, keys[0], v)
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+func setArrayPath(arr bsoncore.Array, keys []string, val bsoncore.Value) (bsoncore.Array, error) {
+	i, err := parseArrayIndex(keys[0])
+	if err != nil {
+		return nil, err
+	}
+	vals, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case i < len(vals):
+		vals[i], err = setValuePath(vals[i], keys[1:], val)
+	case i == len(vals):
+		var v bsoncore.Value
+		v, err = newValuePath(keys[1:], val)
+		vals = append(vals, v)
+	default:
+		err = fmt.Errorf("array index %d is greater than the array length %d", i, len(vals))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendArrayValues(vals)
+}
+
+// setValuePath sets the value at the path given by keys relative to v. If keys is empty, val replaces v.
+func setValuePath(v bsoncore.Value, keys []string, val bsoncore.Value) (bsoncore.Value, error) {
+	if len(keys) == 0 {
+		return val, nil
+	}
+
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		doc, err := setDocumentPath(v.Document(), keys, val)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		return bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc}, nil
+	case bsontype.Array:
+		arr, err := setArrayPath(v.Array(), keys, val)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		return bsoncore.Value{Type: bsontype.Array, Data: arr}, nil
+	default:
+		return bsoncore.Value{}, fmt.Errorf("cannot traverse into %q because the parent is a BSON %s", keys[0], v.Type)
+	}
+}
+
+// newValuePath returns the value for a path that does not exist yet, creating embedded documents for keys.
+func newValuePath(keys []string, val bsoncore.Value) (bsoncore.Value, error) {
+	return setValuePath(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: emptyDocument}, keys, val)
+}
+
+func removeDocumentPath(doc bsoncore.Document, keys []string) (bsoncore.Document, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	found := false
+	for _, elem := range elems {
+		if found || elem.Key() != keys[0] {
+			dst = append(dst, elem...)
+			continue
+		}
+		found = true
+
+		if len(keys) == 1 {
+			continue
+		}
+		v, err := removeValuePath(elem.Value(), keys[1:])
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, keys[0], v)
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+func removeArrayPath(arr bsoncore.Array, keys []string) (bsoncore.Array, error) {
+	i, err := parseArrayIndex(keys[0])
+	if err != nil {
+		return nil, err
+	}
+	vals, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+	if i >= len(vals) {
+		return arr, nil
+	}
+
+	if len(keys) == 1 {
+		vals = append(vals[:i], vals[i+1:]...)
+	} else if vals[i], err = removeValuePath(vals[i], keys[1:]); err != nil {
+		return nil, err
+	}
+	return appendArrayValues(vals)
+}
+
+// removeValuePath removes the element at the path given by keys relative to v. Values that are neither documents
+// nor arrays cannot contain the path and are returned unmodified.
+func removeValuePath(v bsoncore.Value, keys []string) (bsoncore.Value, error) {
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		doc, err := removeDocumentPath(v.Document(), keys)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		return bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc}, nil
+	case bsontype.Array:
+		arr, err := removeArrayPath(v.Array(), keys)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		return bsoncore.Value{Type: bsontype.Array, Data: arr}, nil
+	default:
+		return v, nil
+	}
+}
+
+func appendArrayValues(vals []bsoncore.Value) (bsoncore.Array, error) {
+	idx, dst := bsoncore.AppendArrayStart(nil)
+	for i, v := range vals {
+		dst = bsoncore.AppendValueElement(dst, strconv.Itoa(i), v)
+	}
+	return bsoncore.AppendArrayEnd(dst, idx)
+}
@@ -0,0 +1,278 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestSetPath(t *testing.T) {
+	t.Parallel()
+
+	doc := D{
+		{"_id", 1},
+		{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+		{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+		{"tags", A{"x", "y"}},
+		{"total", 30},
+	}
+
+	testCases := []struct {
+		name    string
+		path    string
+		value   interface{}
+		want    D
+		wantErr bool
+	}{
+		{
+			name:  "top-level field",
+			path:  "total",
+			value: 31,
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"tags", A{"x", "y"}},
+				{"total", 31},
+			},
+		},
+		{
+			name:  "nested field",
+			path:  "user.ssn",
+			value: "***",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "***"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"tags", A{"x", "y"}},
+				{"total", 30},
+			},
+		},
+		{
+			name:  "new nested field",
+			path:  "user.address.city",
+			value: "NYC",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}, {"address", D{{"city", "NYC"}}}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"tags", A{"x", "y"}},
+				{"total", 30},
+			},
+		},
+		{
+			name:  "field in array element",
+			path:  "items.1.price",
+			value: 25,
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 25}}}},
+				{"tags", A{"x", "y"}},
+				{"total", 30},
+			},
+		},
+		{
+			name:  "array element",
+			path:  "tags.0",
+			value: D{{"k", "v"}},
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"tags", A{D{{"k", "v"}}, "y"}},
+				{"total", 30},
+			},
+		},
+		{
+			name:  "append to array",
+			path:  "tags.2",
+			value: "z",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"tags", A{"x", "y", "z"}},
+				{"total", 30},
+			},
+		},
+		{
+			name:    "empty path",
+			path:    "",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			name:    "empty path segment",
+			path:    "user..name",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			name:    "traverse scalar",
+			path:    "total.value",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric array index",
+			path:    "tags.first",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			name:    "array index out of range",
+			path:    "tags.3",
+			value:   1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := Marshal(doc)
+			require.NoError(t, err, "Marshal error")
+
+			got, err := SetPath(raw, tc.path, tc.value)
+			if tc.wantErr {
+				assert.Error(t, err, "expected SetPath error")
+				return
+			}
+			require.NoError(t, err, "SetPath error")
+
+			want, err := Marshal(tc.want)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, Raw(want), got, "expected document %v, got %v", Raw(want), got)
+
+			orig, err := Marshal(doc)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, orig, []byte(raw), "expected input document to be unmodified")
+		})
+	}
+}
+
+func TestRemovePath(t *testing.T) {
+	t.Parallel()
+
+	doc := D{
+		{"_id", 1},
+		{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+		{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+		{"total", 30},
+	}
+
+	testCases := []struct {
+		name    string
+		path    string
+		want    D
+		wantErr bool
+	}{
+		{
+			name: "top-level field",
+			path: "_id",
+			want: D{
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"total", 30},
+			},
+		},
+		{
+			name: "nested field",
+			path: "user.ssn",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}}},
+				{"items", A{D{{"sku", "a"}, {"price", 10}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"total", 30},
+			},
+		},
+		{
+			name: "field in array element",
+			path: "items.0.price",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "a"}}, D{{"sku", "b"}, {"price", 20}}}},
+				{"total", 30},
+			},
+		},
+		{
+			name: "array element",
+			path: "items.0",
+			want: D{
+				{"_id", 1},
+				{"user", D{{"name", "alice"}, {"ssn", "123-45-6789"}}},
+				{"items", A{D{{"sku", "b"}, {"price", 20}}}},
+				{"total", 30},
+			},
+		},
+		{
+			name: "missing field",
+			path: "user.address.city",
+			want: doc,
+		},
+		{
+			name: "array index out of range",
+			path: "items.5",
+			want: doc,
+		},
+		{
+			name: "traverse scalar",
+			path: "total.value",
+			want: doc,
+		},
+		{
+			name:    "empty path segment",
+			path:    "user.",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric array index",
+			path:    "items.first.price",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := Marshal(doc)
+			require.NoError(t, err, "Marshal error")
+
+			got, err := RemovePath(raw, tc.path)
+			if tc.wantErr {
+				assert.Error(t, err, "expected RemovePath error")
+				return
+			}
+			require.NoError(t, err, "RemovePath error")
+
+			want, err := Marshal(tc.want)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, Raw(want), got, "expected document %v, got %v", Raw(want), got)
+		})
+	}
+}
+
+func TestSetPathInvalidDocument(t *testing.T) {
+	t.Parallel()
+
+	_, err := SetPath(Raw{0x01}, "a", 1)
+	assert.Error(t, err, "expected SetPath error for invalid document")
+
+	_, err = RemovePath(Raw{0x01}, "a")
+	assert.Error(t, err, "expected RemovePath error for invalid document")
+}
@@ -0,0 +1,250 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	tSchemaMarshaler      = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	tSchemaValueMarshaler = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
+)
+
+// schemaBSONTypes maps types with a fixed BSON representation to their $jsonSchema bsonType alias.
+var schemaBSONTypes = map[reflect.Type]string{
+	reflect.TypeOf(time.Time{}):               "date",
+	reflect.TypeOf(primitive.DateTime(0)):     "date",
+	reflect.TypeOf(primitive.ObjectID{}):      "objectId",
+	reflect.TypeOf(primitive.Decimal128{}):    "decimal",
+	reflect.TypeOf(primitive.Binary{}):        "binData",
+	reflect.TypeOf(primitive.Timestamp{}):     "timestamp",
+	reflect.TypeOf(primitive.Regex{}):         "regex",
+	reflect.TypeOf(primitive.JavaScript("")):  "javascript",
+	reflect.TypeOf(primitive.CodeWithScope{}): "javascriptWithScope",
+	reflect.TypeOf(primitive.Symbol("")):      "symbol",
+	reflect.TypeOf(primitive.DBPointer{}):     "dbPointer",
+	reflect.TypeOf(primitive.Undefined{}):     "undefined",
+	reflect.TypeOf(primitive.Null{}):          "null",
+	reflect.TypeOf(primitive.MinKey{}):        "minKey",
+	reflect.TypeOf(primitive.MaxKey{}):        "maxKey",
+	reflect.TypeOf(primitive.D{}):             "object",
+	reflect.TypeOf(primitive.M{}):             "object",
+	reflect.TypeOf(Raw{}):                     "object",
+	reflect.TypeOf(primitive.A{}):             "array",
+}
+
+// GenerateJSONSchema returns a $jsonSchema document describing the BSON documents produced by marshaling v, which
+// must be a struct or a pointer to a struct. The schema can be used as a collection validator, for example with
+// options.CreateCollectionOptions.SetValidator.
+//
+// The schema is derived from the bson struct tags and the Go types of the exported fields:
+//
+//   - Each field is described by a "bsonType" matching the BSON type the default registry encodes it as. Fields of
+//     types that can encode as more than one BSON type (e.g. int) list every possible type, and fields of interface
+//     types or types that implement Marshaler or ValueMarshaler accept any BSON type.
+//   - Nested structs are described with their own "properties" and "required" fields, and slices and arrays are
+//     described with an "items" schema for their element type.
+//   - Fields are required unless they are pointers or are tagged omitempty. Pointer, slice, and map fields also allow
+//     null because nil values are encoded as BSON null.
+//   - Fields tagged "-" are skipped and fields tagged inline are merged into the enclosing document.
+//
+// GenerateJSONSchema is best-effort: it does not consider custom codecs registered in a Registry or registry options
+// that change how values are encoded, such as encoding nil slices as empty arrays. Recursive types are not
+// supported and return an error.
+func GenerateJSONSchema(v interface{}) (D, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("GenerateJSONSchema requires a struct or a pointer to a struct, got %v", reflect.TypeOf(v))
+	}
+
+	sg := &schemaGenerator{visiting: make(map[reflect.Type]bool)}
+	schema, err := sg.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	return D{{"$jsonSchema", schema}}, nil
+}
+
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+// structSchema returns the object schema for the struct type t.
+func (sg *schemaGenerator) structSchema(t reflect.Type) (D, error) {
+	if sg.visiting[t] {
+		return nil, fmt.Errorf("cannot generate a JSON schema for recursive type %v", t)
+	}
+	sg.visiting[t] = true
+	defer delete(sg.visiting, t)
+
+	properties := D{}
+	required := A{}
+	if err := sg.appendFields(t, &properties, &required, false); err != nil {
+		return nil, err
+	}
+
+	schema := D{{"bsonType", "object"}}
+	if len(required) > 0 {
+		schema = append(schema, E{"required", required})
+	}
+	if len(properties) > 0 {
+		schema = append(schema, E{"properties", properties})
+	}
+	return schema, nil
+}
+
+// appendFields appends the property schemas of the fields of struct type t to properties and the names of the
+// required fields to required. If optional is true, none of the fields are required.
+func (sg *schemaGenerator) appendFields(t reflect.Type, properties *D, required *A, optional bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			// Unexported fields are not encoded by the default StructCodec.
+			continue
+		}
+
+		tags, err := bsoncodec.DefaultStructTagParser(sf)
+		if err != nil {
+			return err
+		}
+		if tags.Skip {
+			continue
+		}
+
+		if tags.Extra {
+			// Like inlined maps, extra fields hold keys that are not known ahead of time.
+			continue
+		}
+
+		ft := sf.Type
+		if tags.Inline {
+			inlineOptional := optional
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+				inlineOptional = true
+			}
+			switch ft.Kind() {
+			case reflect.Struct:
+				if sg.visiting[ft] {
+					return fmt.Errorf("cannot generate a JSON schema for recursive type %v", ft)
+				}
+				sg.visiting[ft] = true
+				err := sg.appendFields(ft, properties, required, inlineOptional)
+				delete(sg.visiting, ft)
+				if err != nil {
+					return err
+				}
+			case reflect.Map:
+				// Inlined map keys are not known ahead of time and $jsonSchema allows additional properties
+				// by default, so there is nothing to add.
+			default:
+				return fmt.Errorf("inline field %s must be a struct, a pointer to a struct, or a map", sf.Name)
+			}
+			continue
+		}
+
+		schema, err := sg.typeSchema(ft, tags.MinSize)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		*properties = append(*properties, E{tags.Name, schema})
+		if !optional && !tags.OmitEmpty && ft.Kind() != reflect.Ptr {
+			*required = append(*required, tags.Name)
+		}
+	}
+	return nil
+}
+
+// typeSchema returns the schema for a value of type t. An empty schema accepts any BSON type.
+func (sg *schemaGenerator) typeSchema(t reflect.Type, minSize bool) (D, error) {
+	nullable := false
+	if t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+
+	if bsonType, ok := schemaBSONTypes[t]; ok {
+		return schemaOfTypes(nullable && bsonType != "null", bsonType), nil
+	}
+	if t.Implements(tSchemaValueMarshaler) || reflect.PtrTo(t).Implements(tSchemaValueMarshaler) {
+		return D{}, nil
+	}
+	if t.Implements(tSchemaMarshaler) || reflect.PtrTo(t).Implements(tSchemaMarshaler) {
+		return schemaOfTypes(nullable, "object"), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return schemaOfTypes(nullable, "bool"), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return schemaOfTypes(nullable, "int"), nil
+	case reflect.Int64:
+		if minSize {
+			return schemaOfTypes(nullable, "int", "long"), nil
+		}
+		return schemaOfTypes(nullable, "long"), nil
+	case reflect.Int, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return schemaOfTypes(nullable, "int", "long"), nil
+	case reflect.Float32, reflect.Float64:
+		return schemaOfTypes(nullable, "double"), nil
+	case reflect.String:
+		return schemaOfTypes(nullable, "string"), nil
+	case reflect.Interface:
+		return D{}, nil
+	case reflect.Map:
+		return schemaOfTypes(true, "object"), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices and arrays are encoded as binary.
+			return schemaOfTypes(nullable || t.Kind() == reflect.Slice, "binData"), nil
+		}
+		items, err := sg.typeSchema(t.Elem(), minSize)
+		if err != nil {
+			return nil, err
+		}
+		schema := schemaOfTypes(nullable || t.Kind() == reflect.Slice, "array")
+		if len(items) > 0 {
+			schema = append(schema, E{"items", items})
+		}
+		return schema, nil
+	case reflect.Struct:
+		schema, err := sg.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		if nullable {
+			schema[0].Value = A{"object", "null"}
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("cannot generate a JSON schema for type %v", t)
+	}
+}
+
+// schemaOfTypes returns a schema that accepts the given BSON types. If nullable is true, null is also accepted.
+func schemaOfTypes(nullable bool, types ...string) D {
+	if nullable {
+		types = append(types, "null")
+	}
+	if len(types) == 1 {
+		return D{{"bsonType", types[0]}}
+	}
+	bsonTypes := make(A, 0, len(types))
+	for _, t := range types {
+		bsonTypes = append(bsonTypes, t)
+	}
+	return D{{"bsonType", bsonTypes}}
+}
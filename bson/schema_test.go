@@ -0,0 +1,160 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+type schemaAddress struct {
+	Street string `bson:"street"`
+	Zip    *int32 `bson:"zip"`
+}
+
+type schemaBase struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	Created time.Time          `bson:"created"`
+}
+
+type schemaUser struct {
+	Base       schemaBase        `bson:",inline"`
+	Name       string            `bson:"name"`
+	Age        int               `bson:"age,omitempty"`
+	Score      float64           `bson:"score"`
+	Active     bool              `bson:"active"`
+	Tags       []string          `bson:"tags"`
+	Addresses  []schemaAddress   `bson:"addresses"`
+	Home       *schemaAddress    `bson:"home"`
+	Work       schemaAddress     `bson:"work"`
+	Attrs      map[string]string `bson:"attrs,omitempty"`
+	Avatar     []byte            `bson:"avatar,omitempty"`
+	Extra      interface{}       `bson:"extra"`
+	Ignored    string            `bson:"-"`
+	unexported string
+}
+
+type schemaRecursive struct {
+	Children []schemaRecursive `bson:"children"`
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	addressProps := D{
+		{"street", D{{"bsonType", "string"}}},
+		{"zip", D{{"bsonType", A{"int", "null"}}}},
+	}
+	address := D{
+		{"bsonType", "object"},
+		{"required", A{"street"}},
+		{"properties", addressProps},
+	}
+
+	t.Run("nested structs and arrays", func(t *testing.T) {
+		t.Parallel()
+
+		want := D{{"$jsonSchema", D{
+			{"bsonType", "object"},
+			{"required", A{"_id", "created", "name", "score", "active", "tags", "addresses", "work", "extra"}},
+			{"properties", D{
+				{"_id", D{{"bsonType", "objectId"}}},
+				{"created", D{{"bsonType", "date"}}},
+				{"name", D{{"bsonType", "string"}}},
+				{"age", D{{"bsonType", A{"int", "long"}}}},
+				{"score", D{{"bsonType", "double"}}},
+				{"active", D{{"bsonType", "bool"}}},
+				{"tags", D{{"bsonType", A{"array", "null"}}, {"items", D{{"bsonType", "string"}}}}},
+				{"addresses", D{{"bsonType", A{"array", "null"}}, {"items", address}}},
+				{"home", D{
+					{"bsonType", A{"object", "null"}},
+					{"required", A{"street"}},
+					{"properties", addressProps},
+				}},
+				{"work", address},
+				{"attrs", D{{"bsonType", A{"object", "null"}}}},
+				{"avatar", D{{"bsonType", A{"binData", "null"}}}},
+				{"extra", D{}},
+			}},
+		}}}
+
+		got, err := GenerateJSONSchema(&schemaUser{})
+		assert.Nil(t, err, "GenerateJSONSchema error: %v", err)
+		assert.Equal(t, want, got, "expected schema %v, got %v", want, got)
+
+		_, err = Marshal(got)
+		assert.Nil(t, err, "Marshal error: %v", err)
+	})
+	t.Run("BSON types", func(t *testing.T) {
+		t.Parallel()
+
+		type types struct {
+			I32   int32                   `bson:"i32"`
+			I64   int64                   `bson:"i64"`
+			Min   int64                   `bson:"min,minsize"`
+			Dec   primitive.Decimal128    `bson:"dec"`
+			DT    primitive.DateTime      `bson:"dt"`
+			Doc   D                       `bson:"doc"`
+			Arr   A                       `bson:"arr"`
+			Raw   Raw                     `bson:"raw"`
+			Fixed [2]float32              `bson:"fixed"`
+			CWS   primitive.CodeWithScope `bson:"cws"`
+			DBP   primitive.DBPointer     `bson:"dbp"`
+			Undef primitive.Undefined     `bson:"undef"`
+			Null  *primitive.Null         `bson:"null"`
+		}
+		want := D{{"$jsonSchema", D{
+			{"bsonType", "object"},
+			{"required", A{"i32", "i64", "min", "dec", "dt", "doc", "arr", "raw", "fixed", "cws", "dbp", "undef"}},
+			{"properties", D{
+				{"i32", D{{"bsonType", "int"}}},
+				{"i64", D{{"bsonType", "long"}}},
+				{"min", D{{"bsonType", A{"int", "long"}}}},
+				{"dec", D{{"bsonType", "decimal"}}},
+				{"dt", D{{"bsonType", "date"}}},
+				{"doc", D{{"bsonType", "object"}}},
+				{"arr", D{{"bsonType", "array"}}},
+				{"raw", D{{"bsonType", "object"}}},
+				{"fixed", D{{"bsonType", "array"}, {"items", D{{"bsonType", "double"}}}}},
+				{"cws", D{{"bsonType", "javascriptWithScope"}}},
+				{"dbp", D{{"bsonType", "dbPointer"}}},
+				{"undef", D{{"bsonType", "undefined"}}},
+				{"null", D{{"bsonType", "null"}}},
+			}},
+		}}}
+
+		got, err := GenerateJSONSchema(types{})
+		assert.Nil(t, err, "GenerateJSONSchema error: %v", err)
+		assert.Equal(t, want, got, "expected schema %v, got %v", want, got)
+	})
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name string
+			val  interface{}
+		}{
+			{"nil", nil},
+			{"non-struct", 42},
+			{"recursive type", schemaRecursive{}},
+			{"unsupported field type", struct{ C chan int }{}},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := GenerateJSONSchema(tc.val)
+				assert.NotNil(t, err, "expected GenerateJSONSchema error, got nil")
+			})
+		}
+	})
+}
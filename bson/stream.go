@@ -0,0 +1,105 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// minDocumentSize is the size of an empty BSON document: a 4 byte length and a null terminator.
+const minDocumentSize = 5
+
+// DefaultMaxStreamDocumentSize is the default maximum size of the documents read by a StreamReader. It is the maximum
+// size of a document stored by the server, 16MiB, plus 16KiB of headroom for the fields the server adds to documents
+// it returns.
+const DefaultMaxStreamDocumentSize = 16*1024*1024 + 16*1024
+
+// StreamWriter writes a stream of BSON documents to an io.Writer. BSON documents start with their length, so the
+// documents are written back to back without any additional framing and can be read with a StreamReader.
+//
+// A StreamWriter is not safe for concurrent use.
+type StreamWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewStreamWriter returns a StreamWriter that writes documents to w.
+func NewStreamWriter(w io.Writer) *StreamWriter {
+	return &StreamWriter{w: w}
+}
+
+// WriteDoc marshals v with the default registry and writes the resulting document to the stream. v can be any value
+// that Marshal accepts, including a Raw document, which is written as is.
+func (sw *StreamWriter) WriteDoc(v interface{}) error {
+	buf, err := MarshalAppend(sw.buf[:0], v)
+	if err != nil {
+		return err
+	}
+	sw.buf = buf
+	_, err = sw.w.Write(buf)
+	return err
+}
+
+// StreamReader reads a stream of BSON documents, such as one written by a StreamWriter, from an io.Reader. Each
+// document is read with as many calls to Read as needed, so a StreamReader can be used with readers that return
+// partial documents, such as a net.Conn.
+//
+// A StreamReader is not safe for concurrent use.
+type StreamReader struct {
+	r       io.Reader
+	maxSize int32
+}
+
+// NewStreamReader returns a StreamReader that reads documents from r.
+func NewStreamReader(r io.Reader) *StreamReader {
+	return &StreamReader{r: r, maxSize: DefaultMaxStreamDocumentSize}
+}
+
+// SetMaxDocumentSize sets the maximum size in bytes of the documents read by sr. The length of a document is read from
+// the stream before the document, so the limit prevents a corrupt or malicious stream from causing a large
+// allocation. The default is DefaultMaxStreamDocumentSize.
+func (sr *StreamReader) SetMaxDocumentSize(size int32) {
+	sr.maxSize = size
+}
+
+// ReadDoc reads the next document from the stream. The returned document is newly allocated and owned by the caller.
+//
+// ReadDoc returns io.EOF if the stream ends before the next document, and io.ErrUnexpectedEOF if it ends in the
+// middle of a document. It returns an error if the length of a document is invalid or larger than the maximum
+// document size, or the document does not end with a null byte, after which the position in the stream is unknown and
+// no more documents can be read. The contents of the document are not otherwise validated.
+func (sr *StreamReader) ReadDoc() (Raw, error) {
+	var lengthBytes [4]byte
+	if _, err := io.ReadFull(sr.r, lengthBytes[:]); err != nil {
+		// ReadFull returns io.EOF only if no bytes were read, which is the end of the stream.
+		return nil, err
+	}
+
+	length := int32(binary.LittleEndian.Uint32(lengthBytes[:]))
+	if length < minDocumentSize {
+		return nil, fmt.Errorf("invalid document length %d", length)
+	}
+	if length > sr.maxSize {
+		return nil, fmt.Errorf("document length %d exceeds the maximum document size %d", length, sr.maxSize)
+	}
+
+	doc := make(Raw, length)
+	copy(doc, lengthBytes[:])
+	if _, err := io.ReadFull(sr.r, doc[4:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if doc[length-1] != 0x00 {
+		return nil, errors.New("document is not null terminated")
+	}
+	return doc, nil
+}
@@ -0,0 +1,179 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"testing/iotest"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	docs := []interface{}{
+		D{{"a", int32(1)}},
+		M{"b": "two"},
+		struct {
+			C []int32 `bson:"c"`
+		}{C: []int32{3}},
+		D{},
+	}
+	writeStream := func(t *testing.T) []byte {
+		t.Helper()
+
+		buf := new(bytes.Buffer)
+		sw := NewStreamWriter(buf)
+		for _, doc := range docs {
+			err := sw.WriteDoc(doc)
+			require.NoError(t, err, "WriteDoc error")
+		}
+		return buf.Bytes()
+	}
+	readAll := func(r io.Reader) ([]Raw, error) {
+		sr := NewStreamReader(r)
+		var got []Raw
+		for {
+			doc, err := sr.ReadDoc()
+			if err != nil {
+				return got, err
+			}
+			got = append(got, doc)
+		}
+	}
+	assertDocs := func(t *testing.T, got []Raw) {
+		t.Helper()
+
+		require.Equal(t, len(docs), len(got), "expected %d documents, got %d", len(docs), len(got))
+		for i, doc := range docs {
+			want, err := Marshal(doc)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, Raw(want), got[i], "expected document %d to be %v, got %v", i, Raw(want), got[i])
+		}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := readAll(bytes.NewReader(writeStream(t)))
+		assert.ErrorIs(t, err, io.EOF, "expected io.EOF at the end of the stream")
+		assertDocs(t, got)
+	})
+	t.Run("partial reads", func(t *testing.T) {
+		t.Parallel()
+
+		data := writeStream(t)
+		for name, r := range map[string]io.Reader{
+			"one byte":  iotest.OneByteReader(bytes.NewReader(data)),
+			"half":      iotest.HalfReader(bytes.NewReader(data)),
+			"data EOF":  iotest.DataErrReader(bytes.NewReader(data)),
+			"multi doc": io.MultiReader(bytes.NewReader(data[:7]), bytes.NewReader(data[7:])),
+		} {
+			got, err := readAll(r)
+			assert.ErrorIs(t, err, io.EOF, "expected io.EOF at the end of the %s stream", name)
+			assertDocs(t, got)
+		}
+	})
+	t.Run("raw documents", func(t *testing.T) {
+		t.Parallel()
+
+		raw, err := Marshal(D{{"x", "y"}})
+		require.NoError(t, err, "Marshal error")
+		buf := new(bytes.Buffer)
+		err = NewStreamWriter(buf).WriteDoc(Raw(raw))
+		require.NoError(t, err, "WriteDoc error")
+		assert.Equal(t, raw, buf.Bytes(), "expected the document to be written as is")
+	})
+	t.Run("empty stream", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewStreamReader(bytes.NewReader(nil)).ReadDoc()
+		assert.ErrorIs(t, err, io.EOF, "expected io.EOF for an empty stream")
+	})
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		data := writeStream(t)
+		first, err := Marshal(docs[0])
+		require.NoError(t, err, "Marshal error")
+
+		testCases := []struct {
+			name    string
+			data    []byte
+			numDocs int
+		}{
+			{"in length", data[:len(first)+2], 1},
+			{"after length", data[:len(first)+4], 1},
+			{"in body", data[:len(first)+6], 1},
+			{"missing terminator", data[:len(data)-1], len(docs) - 1},
+		}
+		for _, tc := range testCases {
+			got, err := readAll(bytes.NewReader(tc.data))
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "expected io.ErrUnexpectedEOF for %s", tc.name)
+			assert.Len(t, got, tc.numDocs, "expected %d documents before the error for %s", tc.numDocs, tc.name)
+		}
+	})
+	t.Run("invalid documents", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name string
+			data []byte
+		}{
+			{"negative length", []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+			{"length too small", []byte{0x04, 0x00, 0x00, 0x00}},
+			{"not null terminated", []byte{0x05, 0x00, 0x00, 0x00, 0x01}},
+			{"exceeds maximum size", []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+		}
+		for _, tc := range testCases {
+			_, err := NewStreamReader(bytes.NewReader(tc.data)).ReadDoc()
+			assert.NotNil(t, err, "expected an error for %s", tc.name)
+			assert.False(t, errors.Is(err, io.EOF), "expected an error other than io.EOF for %s, got %v", tc.name, err)
+		}
+	})
+	t.Run("maximum document size", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := Marshal(docs[0])
+		require.NoError(t, err, "Marshal error")
+
+		sr := NewStreamReader(bytes.NewReader(doc))
+		sr.SetMaxDocumentSize(int32(len(doc) - 1))
+		_, err = sr.ReadDoc()
+		assert.NotNil(t, err, "expected an error for a document larger than the maximum size")
+
+		sr = NewStreamReader(bytes.NewReader(doc))
+		sr.SetMaxDocumentSize(int32(len(doc)))
+		got, err := sr.ReadDoc()
+		require.NoError(t, err, "ReadDoc error")
+		assert.Equal(t, Raw(doc), got, "expected the document to be read")
+	})
+	t.Run("net.Conn", func(t *testing.T) {
+		t.Parallel()
+
+		client, server := net.Pipe()
+		go func() {
+			sw := NewStreamWriter(client)
+			for _, doc := range docs {
+				if err := sw.WriteDoc(doc); err != nil {
+					break
+				}
+			}
+			_ = client.Close()
+		}()
+
+		got, err := readAll(server)
+		assert.ErrorIs(t, err, io.EOF, "expected io.EOF after the writer closed the connection")
+		assertDocs(t, got)
+	})
+}
@@ -7,7 +7,10 @@
 package bson
 
 import (
+	"bufio"
 	"bytes"
+	"fmt"
+	"io"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
@@ -163,6 +166,36 @@ func UnmarshalExtJSONWithContext(dc bsoncodec.DecodeContext, data []byte, canoni
 	return unmarshalFromReader(dc, ejvr, val)
 }
 
+// UnmarshalExtJSONStream reads newline-delimited extended JSON documents from r, such as the output of mongoexport,
+// and calls fn with each document converted to BSON. Documents are read one line at a time, so the whole input is
+// never held in memory. Blank lines are skipped. fn may retain the Raw it is passed.
+//
+// If a line cannot be parsed, UnmarshalExtJSONStream stops and returns an error that includes the 1-based line number.
+// If fn returns an error, UnmarshalExtJSONStream stops and returns that error unchanged.
+func UnmarshalExtJSONStream(r io.Reader, canonical bool, fn func(Raw) error) error {
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("error reading line %d: %w", lineNum, readErr)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var doc Raw
+			if err := UnmarshalExtJSON(line, canonical, &doc); err != nil {
+				return fmt.Errorf("error parsing extended JSON on line %d: %w", lineNum, err)
+			}
+			if err := fn(doc); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
 func unmarshalFromReader(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val interface{}) error {
 	dec := decPool.Get().(*Decoder)
 	defer decPool.Put(dec)
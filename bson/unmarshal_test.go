@@ -8,8 +8,10 @@ package bson
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -17,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
@@ -793,3 +796,277 @@ func TestUnmarshalConcurrently(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestUnmarshalExtJSONStream(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		input := "{\"_id\": {\"$oid\": \"5ef7fdd91c19e3222b41b839\"}, \"x\": 1}\n" +
+			"\n" +
+			"  {\"y\": {\"$numberLong\": \"2\"}}\r\n" +
+			"{\"z\": \"no trailing newline\"}"
+
+		var docs []Raw
+		err := UnmarshalExtJSONStream(strings.NewReader(input), false, func(doc Raw) error {
+			docs = append(docs, doc)
+			return nil
+		})
+		assert.Nil(t, err, "UnmarshalExtJSONStream error: %v", err)
+		assert.Equal(t, 3, len(docs), "expected 3 documents, got %v", len(docs))
+
+		oid, _ := primitive.ObjectIDFromHex("5ef7fdd91c19e3222b41b839")
+		expected := []D{
+			{{"_id", oid}, {"x", int32(1)}},
+			{{"y", int64(2)}},
+			{{"z", "no trailing newline"}},
+		}
+		for i, doc := range docs {
+			var got D
+			err := Unmarshal(doc, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.Equal(t, expected[i], got, "expected document %v, got %v", expected[i], got)
+		}
+	})
+	t.Run("empty input", func(t *testing.T) {
+		err := UnmarshalExtJSONStream(strings.NewReader(""), true, func(Raw) error {
+			t.Fatal("expected callback not to be called")
+			return nil
+		})
+		assert.Nil(t, err, "UnmarshalExtJSONStream error: %v", err)
+	})
+
+	malformed := []struct {
+		name  string
+		input string
+		line  int
+		docs  int
+	}{
+		{"truncated document", "{\"x\": 1}\n{\"x\": \n{\"x\": 3}\n", 2, 1},
+		{"invalid extended JSON", "{\"x\": 1}\n{\"x\": 2}\n\n{\"x\": {\"$oid\": \"zzz\"}}\n", 4, 2},
+		{"not a document", "[1, 2]\n", 1, 0},
+		{"not JSON", "{\"x\": 1}\nhello\n", 2, 1},
+	}
+	for _, tc := range malformed {
+		t.Run(tc.name, func(t *testing.T) {
+			var count int
+			err := UnmarshalExtJSONStream(strings.NewReader(tc.input), true, func(Raw) error {
+				count++
+				return nil
+			})
+			assert.NotNil(t, err, "expected UnmarshalExtJSONStream error, got nil")
+			assert.True(t, strings.Contains(err.Error(), fmt.Sprintf("line %d", tc.line)),
+				"expected error to report line %d, got %v", tc.line, err)
+			assert.Equal(t, tc.docs, count, "expected %v documents before the error, got %v", tc.docs, count)
+		})
+	}
+
+	t.Run("callback error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var count int
+		err := UnmarshalExtJSONStream(strings.NewReader("{\"x\": 1}\n{\"x\": 2}\n{\"x\": 3}\n"), true, func(Raw) error {
+			count++
+			if count == 2 {
+				return errStop
+			}
+			return nil
+		})
+		assert.Equal(t, errStop, err, "expected error %v, got %v", errStop, err)
+		assert.Equal(t, 2, count, "expected 2 documents, got %v", count)
+	})
+}
+
+func TestUnmarshalDefaultValues(t *testing.T) {
+	type status string
+	type embedded struct {
+		Region string `bson:"region" bsondefault:"us-east-1"`
+	}
+	type account struct {
+		Name     string   `bson:"name"`
+		Status   status   `bson:"status" bsondefault:"active"`
+		Retries  int      `bson:"retries" bsondefault:"3"`
+		Verified bool     `bson:"verified" bsondefault:"true"`
+		Ratio    float64  `bson:"ratio" bsondefault:"0.5"`
+		Limit    *uint32  `bson:"limit" bsondefault:"10"`
+		Embedded embedded `bson:",inline"`
+	}
+
+	limit := uint32(10)
+	testCases := []struct {
+		name string
+		doc  D
+		want account
+	}{
+		{
+			name: "missing",
+			doc:  D{{"name", "a"}},
+			want: account{Name: "a", Status: "active", Retries: 3, Verified: true, Ratio: 0.5, Limit: &limit,
+				Embedded: embedded{Region: "us-east-1"}},
+		},
+		{
+			name: "present",
+			doc: D{{"name", "a"}, {"status", "closed"}, {"retries", int32(0)}, {"verified", false}, {"ratio", 2.0},
+				{"limit", int64(0)}, {"region", "eu-west-1"}},
+			want: account{Name: "a", Status: "closed", Retries: 0, Verified: false, Ratio: 2.0, Limit: new(uint32),
+				Embedded: embedded{Region: "eu-west-1"}},
+		},
+		{
+			name: "explicit null",
+			doc: D{{"status", nil}, {"retries", nil}, {"verified", nil}, {"ratio", nil}, {"limit", nil},
+				{"region", nil}},
+			want: account{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Marshal(tc.doc)
+			require.NoError(t, err, "Marshal error")
+
+			var got account
+			err = Unmarshal(data, &got)
+			require.NoError(t, err, "Unmarshal error")
+			assert.Equal(t, tc.want, got, "expected %+v, got %+v", tc.want, got)
+		})
+	}
+
+	t.Run("pointer defaults are not shared", func(t *testing.T) {
+		data, err := Marshal(D{})
+		require.NoError(t, err, "Marshal error")
+
+		var first, second account
+		require.NoError(t, Unmarshal(data, &first), "Unmarshal error")
+		require.NoError(t, Unmarshal(data, &second), "Unmarshal error")
+		*first.Limit = 20
+		assert.Equal(t, uint32(10), *second.Limit, "expected second limit to be 10, got %d", *second.Limit)
+	})
+	t.Run("invalid default", func(t *testing.T) {
+		type invalid struct {
+			Count int `bson:"count" bsondefault:"many"`
+		}
+		data, err := Marshal(D{})
+		require.NoError(t, err, "Marshal error")
+
+		var got invalid
+		err = Unmarshal(data, &got)
+		assert.NotNil(t, err, "expected error for invalid default, got nil")
+	})
+}
+
+func TestUnmarshalExtraFields(t *testing.T) {
+	type address struct {
+		City string `bson:"city"`
+	}
+	type withMap struct {
+		Name    string  `bson:"name"`
+		Address address `bson:"address"`
+		Extra   M       `bson:",extra"`
+	}
+	type withRaw struct {
+		Name    string  `bson:"name"`
+		Address address `bson:"address"`
+		Extra   Raw     `bson:",extra"`
+	}
+
+	original := D{
+		{"name", "Ada"},
+		{"version", int32(3)},
+		{"address", D{{"city", "London"}, {"zip", "N1"}}},
+		{"tags", A{"a", "b"}},
+		{"meta", D{{"source", "import"}, {"ts", primitive.DateTime(1700000000000)}}},
+	}
+	data, err := Marshal(original)
+	require.NoError(t, err, "Marshal error")
+
+	t.Run("map", func(t *testing.T) {
+		var got withMap
+		err := Unmarshal(data, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, "Ada", got.Name, "expected name %q, got %q", "Ada", got.Name)
+		assert.Equal(t, 3, len(got.Extra), "expected 3 extra fields, got %v", got.Extra)
+		assert.Equal(t, int32(3), got.Extra["version"], "expected version 3, got %v", got.Extra["version"])
+
+		got.Name = "Grace"
+		out, err := Marshal(got)
+		require.NoError(t, err, "Marshal error")
+		for _, key := range []string{"version", "tags", "meta"} {
+			want, _ := Raw(data).LookupErr(key)
+			gotVal, err := Raw(out).LookupErr(key)
+			require.NoError(t, err, "expected extra field %q to be encoded", key)
+			assert.Equal(t, want, gotVal, "expected %q to be %v, got %v", key, want, gotVal)
+		}
+		assert.Equal(t, "Grace", Raw(out).Lookup("name").StringValue(), "expected the modified name")
+	})
+	t.Run("raw", func(t *testing.T) {
+		var got withRaw
+		err := Unmarshal(data, &got)
+		require.NoError(t, err, "Unmarshal error")
+
+		wantExtra, err := Marshal(D{
+			{"version", int32(3)},
+			{"tags", A{"a", "b"}},
+			{"meta", D{{"source", "import"}, {"ts", primitive.DateTime(1700000000000)}}},
+		})
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, Raw(wantExtra), got.Extra, "expected extra %v, got %v", Raw(wantExtra), got.Extra)
+
+		got.Name = "Grace"
+		out, err := Marshal(got)
+		require.NoError(t, err, "Marshal error")
+		want, err := Marshal(D{
+			{"name", "Grace"},
+			{"address", D{{"city", "London"}}},
+			{"version", int32(3)},
+			{"tags", A{"a", "b"}},
+			{"meta", D{{"source", "import"}, {"ts", primitive.DateTime(1700000000000)}}},
+		})
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, Raw(want).String(), Raw(out).String(), "expected the extra fields to be encoded after the known fields")
+	})
+	t.Run("raw without extra fields", func(t *testing.T) {
+		known, err := Marshal(D{{"name", "Ada"}})
+		require.NoError(t, err, "Marshal error")
+
+		got := withRaw{Extra: Raw(data)}
+		err = Unmarshal(known, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Nil(t, got.Extra, "expected extra fields of a previous document to be cleared, got %v", got.Extra)
+
+		out, err := Marshal(got)
+		require.NoError(t, err, "Marshal error")
+		_, err = Raw(out).LookupErr("version")
+		assert.NotNil(t, err, "expected no extra fields to be encoded")
+	})
+	t.Run("colliding extra keys are not encoded", func(t *testing.T) {
+		extra, err := Marshal(D{{"name", "other"}, {"version", int32(4)}})
+		require.NoError(t, err, "Marshal error")
+
+		out, err := Marshal(withRaw{Name: "Ada", Extra: extra})
+		require.NoError(t, err, "Marshal error")
+		elems, err := Raw(out).Elements()
+		require.NoError(t, err, "Elements error")
+		assert.Equal(t, 3, len(elems), "expected name, address, and version, got %v", Raw(out))
+		assert.Equal(t, "Ada", Raw(out).Lookup("name").StringValue(), "expected the struct field to take precedence")
+	})
+	t.Run("invalid extra fields", func(t *testing.T) {
+		type multiple struct {
+			A M   `bson:",extra"`
+			B Raw `bson:",extra"`
+		}
+		type wrongType struct {
+			A string `bson:",extra"`
+		}
+		type extraAndInline struct {
+			A M `bson:",extra"`
+			B M `bson:",inline"`
+		}
+		type withExtra struct {
+			Extra Raw `bson:",extra"`
+		}
+		type inlineExtra struct {
+			Name  string    `bson:"name"`
+			Inner withExtra `bson:",inline"`
+		}
+		for _, v := range []interface{}{&multiple{}, &wrongType{}, &extraAndInline{}, &inlineExtra{}} {
+			err := Unmarshal(data, v)
+			assert.NotNil(t, err, "expected error for %T, got nil", v)
+		}
+	})
+}
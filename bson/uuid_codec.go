@@ -0,0 +1,225 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var tEmptyInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// LegacyUUIDByteOrder specifies the byte order of UUIDs stored as BSON binary subtype 0x03 (the legacy UUID subtype).
+// Legacy drivers for different languages wrote the 16 bytes of a UUID in different orders, so the order used by the
+// application that wrote the data must be known to decode it correctly. UUIDs stored as subtype 0x04 always use the
+// standard byte order.
+type LegacyUUIDByteOrder uint8
+
+const (
+	// LegacyUUIDStandard decodes subtype 0x03 UUIDs in the standard byte order, as written by the legacy Python
+	// driver and by this driver.
+	LegacyUUIDStandard LegacyUUIDByteOrder = iota
+
+	// LegacyUUIDJava decodes subtype 0x03 UUIDs written by the legacy Java driver, which reversed the byte order of
+	// each 8-byte half of the UUID.
+	LegacyUUIDJava
+
+	// LegacyUUIDCSharp decodes subtype 0x03 UUIDs written by the legacy C# driver, which reversed the byte order of
+	// the first three groups of the UUID (4, 2, and 2 bytes).
+	LegacyUUIDCSharp
+)
+
+// standardOrder returns a copy of the 16 bytes of a legacy UUID in the standard byte order.
+func (o LegacyUUIDByteOrder) standardOrder(b []byte) []byte {
+	out := append([]byte(nil), b...)
+	reverse := func(s []byte) {
+		for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+			s[i], s[j] = s[j], s[i]
+		}
+	}
+
+	switch o {
+	case LegacyUUIDJava:
+		reverse(out[0:8])
+		reverse(out[8:16])
+	case LegacyUUIDCSharp:
+		reverse(out[0:4])
+		reverse(out[4:6])
+		reverse(out[6:8])
+	}
+	return out
+}
+
+// RegisterUUIDCodec registers codecs on reg that encode and decode values of the UUID type t, which allows any UUID
+// library to be used with BSON. newFromBytes must convert the 16 bytes of a UUID in the standard byte order into a
+// value of type t, and toBytes must convert a value of type t into its 16 bytes.
+//
+// Values of type t are encoded as BSON binary subtype 0x04. Values of type t can be decoded from BSON binary subtype
+// 0x04, from BSON binary subtype 0x03 stored in the given legacy byte order, and from BSON null, which decodes to the
+// zero value.
+//
+// RegisterUUIDCodec also changes how BSON binary values are decoded into an empty interface, such as the values of a
+// bson.M: subtypes 0x03 and 0x04 are decoded into a value of type t, subtype 0x00 is decoded into a []byte, and all
+// other subtypes are still decoded into a primitive.Binary.
+//
+// For example, to use the github.com/google/uuid package:
+//
+//	reg := bson.NewRegistry()
+//	bson.RegisterUUIDCodec(
+//		reg,
+//		reflect.TypeOf(uuid.UUID{}),
+//		func(b []byte) (interface{}, error) { return uuid.FromBytes(b) },
+//		func(v interface{}) ([]byte, error) { u := v.(uuid.UUID); return u[:], nil },
+//		bson.LegacyUUIDStandard,
+//	)
+//
+// RegisterUUIDCodec panics if reg, t, newFromBytes, or toBytes is nil.
+func RegisterUUIDCodec(
+	reg *bsoncodec.Registry,
+	t reflect.Type,
+	newFromBytes func([]byte) (interface{}, error),
+	toBytes func(interface{}) ([]byte, error),
+	legacy LegacyUUIDByteOrder,
+) {
+	if reg == nil || t == nil || newFromBytes == nil || toBytes == nil {
+		panic(errors.New("arguments to RegisterUUIDCodec must not be nil"))
+	}
+
+	uc := &uuidCodec{
+		t:            t,
+		newFromBytes: newFromBytes,
+		toBytes:      toBytes,
+		legacy:       legacy,
+	}
+	// Look up the current empty interface decoder before replacing it so that non-binary values are still decoded
+	// by it.
+	uc.emptyInterfaceDecoder, _ = reg.LookupDecoder(tEmptyInterface)
+
+	reg.RegisterTypeEncoder(t, bsoncodec.ValueEncoderFunc(uc.EncodeValue))
+	reg.RegisterTypeDecoder(t, bsoncodec.ValueDecoderFunc(uc.DecodeValue))
+	if uc.emptyInterfaceDecoder != nil {
+		reg.RegisterTypeDecoder(tEmptyInterface, bsoncodec.ValueDecoderFunc(uc.EmptyInterfaceDecodeValue))
+	}
+}
+
+// uuidCodec is the codec registered by RegisterUUIDCodec.
+type uuidCodec struct {
+	t                     reflect.Type
+	newFromBytes          func([]byte) (interface{}, error)
+	toBytes               func(interface{}) ([]byte, error)
+	legacy                LegacyUUIDByteOrder
+	emptyInterfaceDecoder bsoncodec.ValueDecoder
+}
+
+// EncodeValue encodes a value of the UUID type as BSON binary subtype 0x04.
+func (uc *uuidCodec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != uc.t {
+		return bsoncodec.ValueEncoderError{Name: "UUIDEncodeValue", Types: []reflect.Type{uc.t}, Received: val}
+	}
+
+	b, err := uc.toBytes(val.Interface())
+	if err != nil {
+		return err
+	}
+	if len(b) != 16 {
+		return fmt.Errorf("cannot encode %v as a UUID: expected 16 bytes, got %d", uc.t, len(b))
+	}
+	return vw.WriteBinaryWithSubtype(b, bsontype.BinaryUUID)
+}
+
+// DecodeValue decodes BSON binary subtype 0x03 or 0x04, or BSON null, into a value of the UUID type.
+func (uc *uuidCodec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != uc.t {
+		return bsoncodec.ValueDecoderError{Name: "UUIDDecodeValue", Types: []reflect.Type{uc.t}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Binary:
+		data, subtype, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if subtype != bsontype.BinaryUUID && subtype != bsontype.BinaryUUIDOld {
+			return fmt.Errorf("cannot decode binary subtype %#x into a %v", subtype, uc.t)
+		}
+		v, err := uc.decodeUUID(data, subtype)
+		if err != nil {
+			return err
+		}
+		val.Set(v)
+		return nil
+	case bsontype.Null:
+		val.Set(reflect.Zero(uc.t))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(uc.t))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into a %v", vr.Type(), uc.t)
+	}
+}
+
+// EmptyInterfaceDecodeValue decodes BSON binary values into an empty interface based on their subtype and delegates
+// all other values to the empty interface decoder that was registered before the UUID codec.
+func (uc *uuidCodec) EmptyInterfaceDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if vr.Type() != bsontype.Binary {
+		return uc.emptyInterfaceDecoder.DecodeValue(dc, vr, val)
+	}
+	if !val.CanSet() || val.Type() != tEmptyInterface {
+		return bsoncodec.ValueDecoderError{Name: "EmptyInterfaceDecodeValue", Types: []reflect.Type{tEmptyInterface}, Received: val}
+	}
+
+	data, subtype, err := vr.ReadBinary()
+	if err != nil {
+		return err
+	}
+
+	var v reflect.Value
+	switch subtype {
+	case bsontype.BinaryUUID, bsontype.BinaryUUIDOld:
+		v, err = uc.decodeUUID(data, subtype)
+		if err != nil {
+			return err
+		}
+	case bsontype.BinaryGeneric:
+		v = reflect.ValueOf(append([]byte(nil), data...))
+	default:
+		v = reflect.ValueOf(primitive.Binary{Subtype: subtype, Data: append([]byte(nil), data...)})
+	}
+	val.Set(v)
+	return nil
+}
+
+// decodeUUID converts the data of a BSON binary value with a UUID subtype into a value of the UUID type.
+func (uc *uuidCodec) decodeUUID(data []byte, subtype byte) (reflect.Value, error) {
+	if len(data) != 16 {
+		return reflect.Value{}, fmt.Errorf("cannot decode binary subtype %#x into a %v: expected 16 bytes, got %d",
+			subtype, uc.t, len(data))
+	}
+
+	if subtype == bsontype.BinaryUUIDOld {
+		data = uc.legacy.standardOrder(data)
+	} else {
+		data = append([]byte(nil), data...)
+	}
+
+	u, err := uc.newFromBytes(data)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.ValueOf(u)
+	if !v.IsValid() || v.Type() != uc.t {
+		return reflect.Value{}, fmt.Errorf("UUID constructor returned %T, expected %v", u, uc.t)
+	}
+	return v, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/internal/uuid"
+)
+
+func newUUIDTestRegistry(legacy LegacyUUIDByteOrder) *bsoncodec.Registry {
+	reg := NewRegistry()
+	RegisterUUIDCodec(
+		reg,
+		reflect.TypeOf(uuid.UUID{}),
+		func(b []byte) (interface{}, error) {
+			var u uuid.UUID
+			copy(u[:], b)
+			return u, nil
+		},
+		func(v interface{}) ([]byte, error) {
+			u := v.(uuid.UUID)
+			return u[:], nil
+		},
+		legacy,
+	)
+	return reg
+}
+
+func marshalWithRegistry(t *testing.T, reg *bsoncodec.Registry, val interface{}) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	vw, err := bsonrw.NewBSONValueWriter(buf)
+	require.NoError(t, err, "NewBSONValueWriter error")
+	enc, err := NewEncoder(vw)
+	require.NoError(t, err, "NewEncoder error")
+	enc.SetRegistry(reg)
+	err = enc.Encode(val)
+	require.NoError(t, err, "Encode error")
+	return buf.Bytes()
+}
+
+func unmarshalWithRegistry(reg *bsoncodec.Registry, data []byte, val interface{}) error {
+	dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return err
+	}
+	dec.SetRegistry(reg)
+	return dec.Decode(val)
+}
+
+func TestUUIDCodec(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.UUID{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+	type withUUID struct {
+		ID  uuid.UUID  `bson:"id"`
+		Ptr *uuid.UUID `bson:"ptr"`
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newUUIDTestRegistry(LegacyUUIDStandard)
+		data := marshalWithRegistry(t, reg, withUUID{ID: id, Ptr: &id})
+
+		subtype, b := Raw(data).Lookup("id").Binary()
+		assert.Equal(t, bsontype.BinaryUUID, subtype, "expected subtype 0x04, got %#x", subtype)
+		assert.Equal(t, id[:], b, "expected UUID bytes %v, got %v", id[:], b)
+
+		var got withUUID
+		err := unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "Decode error")
+		assert.Equal(t, withUUID{ID: id, Ptr: &id}, got, "expected and actual documents are different")
+	})
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newUUIDTestRegistry(LegacyUUIDStandard)
+		data, err := Marshal(D{{"id", nil}, {"ptr", nil}})
+		require.NoError(t, err, "Marshal error")
+
+		got := withUUID{ID: id, Ptr: &id}
+		err = unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "Decode error")
+		assert.Equal(t, withUUID{}, got, "expected zero values, got %v", got)
+	})
+	t.Run("legacy subtype", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name   string
+			legacy LegacyUUIDByteOrder
+			stored []byte
+		}{
+			{
+				name:   "standard",
+				legacy: LegacyUUIDStandard,
+				stored: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			},
+			{
+				name:   "Java",
+				legacy: LegacyUUIDJava,
+				stored: []byte{0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x00, 0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88},
+			},
+			{
+				name:   "C#",
+				legacy: LegacyUUIDCSharp,
+				stored: []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				reg := newUUIDTestRegistry(tc.legacy)
+				data, err := Marshal(D{{"id", primitive.Binary{Subtype: bsontype.BinaryUUIDOld, Data: tc.stored}}})
+				require.NoError(t, err, "Marshal error")
+
+				var got withUUID
+				err = unmarshalWithRegistry(reg, data, &got)
+				require.NoError(t, err, "Decode error")
+				assert.Equal(t, id, got.ID, "expected UUID %v, got %v", id, got.ID)
+
+				// Re-encoding writes the standard subtype and byte order.
+				subtype, b := Raw(marshalWithRegistry(t, reg, got)).Lookup("id").Binary()
+				assert.Equal(t, bsontype.BinaryUUID, subtype, "expected subtype 0x04, got %#x", subtype)
+				assert.Equal(t, id[:], b, "expected UUID bytes %v, got %v", id[:], b)
+			})
+		}
+	})
+	t.Run("empty interface", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newUUIDTestRegistry(LegacyUUIDStandard)
+		data, err := Marshal(D{
+			{"uuid", primitive.Binary{Subtype: bsontype.BinaryUUID, Data: id[:]}},
+			{"generic", primitive.Binary{Subtype: bsontype.BinaryGeneric, Data: []byte{1, 2, 3}}},
+			{"md5", primitive.Binary{Subtype: bsontype.BinaryMD5, Data: []byte{4, 5, 6}}},
+			{"nested", D{{"uuid", primitive.Binary{Subtype: bsontype.BinaryUUID, Data: id[:]}}}},
+			{"n", int32(1)},
+		})
+		require.NoError(t, err, "Marshal error")
+
+		var got M
+		err = unmarshalWithRegistry(reg, data, &got)
+		require.NoError(t, err, "Decode error")
+
+		want := M{
+			"uuid":    id,
+			"generic": []byte{1, 2, 3},
+			"md5":     primitive.Binary{Subtype: bsontype.BinaryMD5, Data: []byte{4, 5, 6}},
+			"nested":  M{"uuid": id},
+			"n":       int32(1),
+		}
+		assert.Equal(t, want, got, "expected and actual documents are different")
+	})
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		reg := newUUIDTestRegistry(LegacyUUIDStandard)
+
+		testCases := []struct {
+			name string
+			val  interface{}
+		}{
+			{"wrong length", primitive.Binary{Subtype: bsontype.BinaryUUID, Data: []byte{1, 2, 3}}},
+			{"wrong subtype", primitive.Binary{Subtype: bsontype.BinaryMD5, Data: id[:]}},
+			{"wrong type", "0011223344556677"},
+		}
+		for _, tc := range testCases {
+			data, err := Marshal(D{{"id", tc.val}})
+			require.NoError(t, err, "Marshal error")
+
+			var got withUUID
+			err = unmarshalWithRegistry(reg, data, &got)
+			assert.Error(t, err, "expected Decode error for %s", tc.name)
+		}
+	})
+}
@@ -30,11 +30,15 @@ type CommandStartedEvent struct {
 	// Deprecated: Use ServerConnectionID64.
 	ServerConnectionID *int32
 	// ServerConnectionID64 contains the connection ID from the server of the operation. If the server does not
-	// return this value (e.g. on MDB < 4.2), it is unset.
+	// return this value (e.g. on MDB < 4.2), it is unset. The server reports the handshake's connectionId in its log
+	// messages as "conn<ID>", so this can be used to find the server log entries for the command.
 	ServerConnectionID64 *int64
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// Tags contains the values extracted from the operation's context by the ContextTagExtractor configured on the
+	// Client. If no extractor is configured, it is nil.
+	Tags map[string]string
 }
 
 // CommandFinishedEvent represents a generic command finishing.
@@ -58,6 +62,9 @@ type CommandFinishedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// Tags contains the values extracted from the operation's context by the ContextTagExtractor configured on the
+	// Client. If no extractor is configured, it is nil.
+	Tags map[string]string
 }
 
 // CommandSucceededEvent represents an event generated when a command's execution succeeds.
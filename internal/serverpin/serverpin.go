@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package serverpin pins the operations run with a Context to the first server one of them is sent to.
+package serverpin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+)
+
+// ErrPinnedServerRemoved is returned when selecting a server for an operation whose pinned server has been removed
+// from the topology.
+var ErrPinnedServerRemoved = errors.New("pinned server has been removed from the topology")
+
+// ErrPinnedServerUnsuitable is returned when selecting a server for an operation that cannot run on its pinned server,
+// such as a write while pinned to a secondary.
+var ErrPinnedServerUnsuitable = errors.New("pinned server does not satisfy the server selection criteria")
+
+type pinKey struct{}
+
+// Pin records the address of the server that the operations run with a pinned Context are sent to.
+type Pin struct {
+	mu   sync.Mutex
+	addr address.Address
+}
+
+// NewContext returns a copy of ctx that pins operations to the first server one of them is sent to. If ctx is
+// already pinned, it is returned unchanged so that nested pins share the same server.
+func NewContext(ctx context.Context) context.Context {
+	if FromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, pinKey{}, &Pin{})
+}
+
+// FromContext returns the Pin in ctx, or nil if ctx is not pinned.
+func FromContext(ctx context.Context) *Pin {
+	pin, _ := ctx.Value(pinKey{}).(*Pin)
+	return pin
+}
+
+// Address returns the address of the pinned server, or an empty address if no server has been pinned yet.
+func (p *Pin) Address() address.Address {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.addr
+}
+
+// Set pins the server with the given address if no server has been pinned yet.
+func (p *Pin) Set(addr address.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.addr == "" {
+		p.addr = addr
+	}
+}
+
+// Selector returns a ServerSelector that uses fallback until a server has been pinned and then selects only the
+// pinned server, as long as fallback would select it. It returns an error if the pinned server has been removed from
+// the topology or no longer satisfies fallback, such as a pinned primary that has become a secondary, because the
+// operation can then never be sent to the pinned server. This mirrors how transactions pin a mongos.
+func (p *Pin) Selector(fallback description.ServerSelector) description.ServerSelector {
+	return description.ServerSelectorFunc(func(t description.Topology, svrs []description.Server) ([]description.Server, error) {
+		addr := p.Address()
+		if addr == "" {
+			return fallback.SelectServer(t, svrs)
+		}
+
+		var pinned []description.Server
+		for _, candidate := range svrs {
+			if candidate.Addr == addr {
+				pinned = []description.Server{candidate}
+				break
+			}
+		}
+		if pinned == nil {
+			// The candidates do not include servers of an unknown kind, which the pinned server may only be until
+			// the next heartbeat, so wait for it unless it has been removed from the topology.
+			for _, server := range t.Servers {
+				if server.Addr == addr {
+					return nil, nil
+				}
+			}
+			return nil, fmt.Errorf("%w: %v", ErrPinnedServerRemoved, addr)
+		}
+
+		// Only the pinned server is passed to fallback so that selectors that choose between the candidates, such as
+		// the latency selector, cannot exclude it in favor of another server.
+		selected, err := fallback.SelectServer(t, pinned)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("%w: %v", ErrPinnedServerUnsuitable, addr)
+		}
+		return selected, nil
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package serverpin
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestPin(t *testing.T) {
+	servers := []description.Server{
+		{Addr: address.Address("a:27017"), Kind: description.RSPrimary},
+		{Addr: address.Address("b:27017"), Kind: description.RSSecondary},
+	}
+
+	t.Run("not pinned", func(t *testing.T) {
+		assert.Nil(t, FromContext(context.Background()), "expected no pin in context")
+	})
+	t.Run("nested pins share the server", func(t *testing.T) {
+		ctx := NewContext(context.Background())
+		pin := FromContext(ctx)
+		assert.NotNil(t, pin, "expected pin in context")
+
+		nested := NewContext(context.WithValue(ctx, struct{}{}, 1))
+		assert.True(t, FromContext(nested) == pin, "expected nested context to share the pin")
+	})
+	t.Run("selector", func(t *testing.T) {
+		pin := FromContext(NewContext(context.Background()))
+		selector := pin.Selector(description.ReadPrefSelector(readpref.Primary()))
+		topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: servers}
+
+		// Before a server is pinned, the fallback selector is used.
+		selected, err := selector.SelectServer(topo, servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, servers[:1], selected, "expected %v, got %v", servers[:1], selected)
+
+		// Once a server is pinned, only that server is selected and later pins are ignored.
+		pin.Set(servers[0].Addr)
+		pin.Set(servers[1].Addr)
+		assert.Equal(t, servers[0].Addr, pin.Address(), "expected pinned address %v, got %v", servers[0].Addr,
+			pin.Address())
+
+		selected, err = selector.SelectServer(topo, servers)
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, servers[:1], selected, "expected %v, got %v", servers[:1], selected)
+
+		// A pinned server of an unknown kind is not a candidate, so selection waits for it.
+		selected, err = selector.SelectServer(topo, servers[1:])
+		assert.Nil(t, err, "SelectServer error: %v", err)
+		assert.Equal(t, 0, len(selected), "expected no servers, got %v", selected)
+	})
+	t.Run("pinned server no longer satisfies the fallback selector", func(t *testing.T) {
+		pin := FromContext(NewContext(context.Background()))
+		pin.Set(servers[0].Addr)
+		selector := pin.Selector(description.ReadPrefSelector(readpref.Primary()))
+
+		// The pinned primary has become a secondary.
+		stepped := []description.Server{
+			{Addr: servers[0].Addr, Kind: description.RSSecondary},
+			{Addr: servers[1].Addr, Kind: description.RSPrimary},
+		}
+		topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: stepped}
+		_, err := selector.SelectServer(topo, stepped)
+		assert.ErrorIs(t, err, ErrPinnedServerUnsuitable)
+	})
+	t.Run("pinned server removed", func(t *testing.T) {
+		pin := FromContext(NewContext(context.Background()))
+		pin.Set(servers[0].Addr)
+		selector := pin.Selector(description.ReadPrefSelector(readpref.Primary()))
+
+		topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: servers[1:]}
+		_, err := selector.SelectServer(topo, servers[1:])
+		assert.ErrorIs(t, err, ErrPinnedServerRemoved)
+	})
+}
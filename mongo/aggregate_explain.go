@@ -0,0 +1,198 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AggregateExplain is the result of Collection.ExplainAggregate.
+type AggregateExplain struct {
+	// Stages are the stages of the pipeline in execution order. If the server runs the whole pipeline in its query
+	// engine, which MongoDB 5.2 and later can do for some pipelines, it contains a single "$cursor" stage. It is nil if
+	// the explain output contains per-shard output.
+	Stages []AggregateStageExplain
+
+	// Shards maps the name of each shard to the stages of the pipeline that ran on that shard. It is only set for a
+	// sharded cluster.
+	Shards map[string][]AggregateStageExplain
+
+	// Raw is the complete explain output.
+	Raw bson.Raw
+}
+
+// AggregateStageExplain contains the execution statistics of a single stage of an aggregation pipeline. The counts
+// and times are only reported by the server for the "executionStats" and "allPlansExecution" verbosities and are zero
+// otherwise.
+type AggregateStageExplain struct {
+	// Name is the name of the stage, e.g. "$group". The stage that reads documents from the collection is named
+	// "$cursor".
+	Name string
+
+	// ExecutionTimeMillis is the time in milliseconds the server estimates was spent executing the pipeline up to and
+	// including this stage. The time spent in the stage itself is the difference from the previous stage.
+	ExecutionTimeMillis int64
+
+	// DocsIn is the number of documents the stage received. For the "$cursor" stage, it is the number of documents
+	// examined in the collection.
+	DocsIn int64
+
+	// DocsOut is the number of documents the stage returned.
+	DocsOut int64
+}
+
+// ExplainAggregate explains an aggregate command with the given pipeline and returns the execution statistics of each
+// of its stages, which can be used to find the stage that dominates the execution time. The verbosity must be
+// "queryPlanner", "executionStats", or "allPlansExecution"; only the latter two run the pipeline and report counts and
+// times. The pipeline is not run for its results, so stages such as $out and $merge do not write any documents.
+//
+// The explain output format differs between server versions and deployments. ExplainAggregate parses the per-stage
+// output of a pipeline, the output of a pipeline that runs entirely in the query engine, and the per-shard output of a
+// sharded cluster. Statistics that are missing from the output are left as zero. The complete output is available in
+// the Raw field of the result.
+//
+// The explain is sent to a server selected by the collection's read preference. The collection's read concern is not
+// applied because the server only explains aggregations with the default "local" read concern.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainAggregate(ctx context.Context, pipeline interface{},
+	verbosity string) (*AggregateExplain, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	switch verbosity {
+	case "queryPlanner", "executionStats", "allPlansExecution":
+	default:
+		return nil, fmt.Errorf("explain verbosity must be \"queryPlanner\", \"executionStats\", or "+
+			"\"allPlansExecution\", got %q", verbosity)
+	}
+
+	pipelineArr, _, err := marshalAggregatePipeline(pipeline, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := bson.D{
+		{"aggregate", coll.name},
+		{"pipeline", bson.RawValue{Type: bsontype.Array, Value: pipelineArr}},
+		{"cursor", bson.D{}},
+	}
+	cmd, err := marshal(bson.D{{"explain", aggregate}, {"verbosity", verbosity}}, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := coll.db.RunCommand(ctx, bson.Raw(cmd), options.RunCmd().SetReadPreference(coll.readPreference)).Raw()
+	if err != nil {
+		return nil, err
+	}
+	return parseAggregateExplain(res)
+}
+
+func parseAggregateExplain(res bson.Raw) (*AggregateExplain, error) {
+	explain := &AggregateExplain{Raw: res}
+
+	shards, ok := res.Lookup("shards").DocumentOK()
+	if !ok {
+		stages, err := parseExplainStages(res)
+		if err != nil {
+			return nil, err
+		}
+		explain.Stages = stages
+		return explain, nil
+	}
+
+	elems, err := shards.Elements()
+	if err != nil {
+		return nil, err
+	}
+	explain.Shards = make(map[string][]AggregateStageExplain, len(elems))
+	for _, elem := range elems {
+		shard, ok := elem.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("explain output for shard %q is not a document", elem.Key())
+		}
+		stages, err := parseExplainStages(shard)
+		if err != nil {
+			return nil, fmt.Errorf("shard %q: %w", elem.Key(), err)
+		}
+		explain.Shards[elem.Key()] = stages
+	}
+	return explain, nil
+}
+
+// parseExplainStages parses the stages of the explain output of a single node. The output contains a stages array if
+// the pipeline has stages that run outside of the query engine, and only the queryPlanner and executionStats of a
+// find-like query otherwise.
+func parseExplainStages(doc bson.Raw) ([]AggregateStageExplain, error) {
+	arr, ok := doc.Lookup("stages").ArrayOK()
+	if !ok {
+		if _, err := doc.LookupErr("queryPlanner"); err != nil {
+			return nil, errors.New("explain output does not contain stages or a query plan")
+		}
+		stage := cursorStageExplain(doc)
+		if ms, ok := doc.Lookup("executionStats", "executionTimeMillis").AsInt64OK(); ok {
+			stage.ExecutionTimeMillis = ms
+		}
+		return []AggregateStageExplain{stage}, nil
+	}
+
+	values, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+	stages := make([]AggregateStageExplain, 0, len(values))
+	for i, val := range values {
+		stageDoc, ok := val.DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("explain stage %d is not a document", i)
+		}
+		first, err := stageDoc.IndexErr(0)
+		if err != nil {
+			return nil, fmt.Errorf("explain stage %d is empty", i)
+		}
+
+		stage := AggregateStageExplain{Name: first.Key()}
+		if stage.Name == "$cursor" {
+			if cursor, ok := first.Value().DocumentOK(); ok {
+				stage = cursorStageExplain(cursor)
+			}
+		} else if i > 0 {
+			stage.DocsIn = stages[i-1].DocsOut
+		}
+		// The per-stage statistics were added in MongoDB 4.4 and take precedence over those of the query.
+		if n, ok := stageDoc.Lookup("nReturned").AsInt64OK(); ok {
+			stage.DocsOut = n
+		}
+		if ms, ok := stageDoc.Lookup("executionTimeMillisEstimate").AsInt64OK(); ok {
+			stage.ExecutionTimeMillis = ms
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// cursorStageExplain returns the "$cursor" stage described by the queryPlanner and executionStats in doc.
+func cursorStageExplain(doc bson.Raw) AggregateStageExplain {
+	stage := AggregateStageExplain{Name: "$cursor"}
+	stats, ok := doc.Lookup("executionStats").DocumentOK()
+	if !ok {
+		return stage
+	}
+	stage.DocsIn, _ = stats.Lookup("totalDocsExamined").AsInt64OK()
+	stage.DocsOut, _ = stats.Lookup("nReturned").AsInt64OK()
+	if ms, ok := stats.Lookup("executionStages", "executionTimeMillisEstimate").AsInt64OK(); ok {
+		stage.ExecutionTimeMillis = ms
+	}
+	return stage
+}
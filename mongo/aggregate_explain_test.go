@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestParseAggregateExplain(t *testing.T) {
+	cursorStats := bson.D{
+		{"nReturned", int32(40)},
+		{"executionTimeMillis", int32(9)},
+		{"totalDocsExamined", int32(100)},
+		{"executionStages", bson.D{{"stage", "COLLSCAN"}, {"executionTimeMillisEstimate", int32(3)}}},
+	}
+	// The output of a two-stage pipeline on MongoDB 4.4 and later.
+	twoStages := bson.D{{"stages", bson.A{
+		bson.D{
+			{"$cursor", bson.D{{"queryPlanner", bson.D{}}, {"executionStats", cursorStats}}},
+			{"nReturned", int64(40)},
+			{"executionTimeMillisEstimate", int64(4)},
+		},
+		bson.D{
+			{"$group", bson.D{{"_id", "$x"}}},
+			{"nReturned", int64(5)},
+			{"executionTimeMillisEstimate", int64(12)},
+		},
+	}}}
+	wantTwoStages := []AggregateStageExplain{
+		{Name: "$cursor", ExecutionTimeMillis: 4, DocsIn: 100, DocsOut: 40},
+		{Name: "$group", ExecutionTimeMillis: 12, DocsIn: 40, DocsOut: 5},
+	}
+
+	testCases := []struct {
+		name       string
+		explain    bson.D
+		wantStages []AggregateStageExplain
+		wantShards map[string][]AggregateStageExplain
+		wantErr    bool
+	}{
+		{
+			name:       "two stages",
+			explain:    twoStages,
+			wantStages: wantTwoStages,
+		},
+		{
+			name: "without per-stage statistics",
+			explain: bson.D{{"stages", bson.A{
+				bson.D{{"$cursor", bson.D{{"queryPlanner", bson.D{}}, {"executionStats", cursorStats}}}},
+				bson.D{{"$project", bson.D{{"x", 1}}}},
+			}}},
+			wantStages: []AggregateStageExplain{
+				{Name: "$cursor", ExecutionTimeMillis: 3, DocsIn: 100, DocsOut: 40},
+				{Name: "$project", DocsIn: 40},
+			},
+		},
+		{
+			name:       "query engine only",
+			explain:    bson.D{{"queryPlanner", bson.D{}}, {"executionStats", cursorStats}},
+			wantStages: []AggregateStageExplain{{Name: "$cursor", ExecutionTimeMillis: 9, DocsIn: 100, DocsOut: 40}},
+		},
+		{
+			name:       "query planner verbosity",
+			explain:    bson.D{{"queryPlanner", bson.D{{"winningPlan", bson.D{{"stage", "COLLSCAN"}}}}}},
+			wantStages: []AggregateStageExplain{{Name: "$cursor"}},
+		},
+		{
+			name: "sharded",
+			explain: bson.D{
+				{"splitPipeline", bson.D{}},
+				{"shards", bson.D{
+					{"shard0", twoStages},
+					{"shard1", bson.D{{"queryPlanner", bson.D{}}, {"executionStats", cursorStats}}},
+				}},
+			},
+			wantShards: map[string][]AggregateStageExplain{
+				"shard0": wantTwoStages,
+				"shard1": {{Name: "$cursor", ExecutionTimeMillis: 9, DocsIn: 100, DocsOut: 40}},
+			},
+		},
+		{name: "unrecognized output", explain: bson.D{{"ok", 1}}, wantErr: true},
+		{name: "empty stage", explain: bson.D{{"stages", bson.A{bson.D{}}}}, wantErr: true},
+		{name: "invalid shard", explain: bson.D{{"shards", bson.D{{"shard0", 1}}}}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := bson.Marshal(tc.explain)
+			require.NoError(t, err, "Marshal error")
+
+			got, err := parseAggregateExplain(res)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected parse error, got nil")
+				return
+			}
+			require.NoError(t, err, "parse error")
+			assert.Equal(t, tc.wantStages, got.Stages, "expected stages %v, got %v", tc.wantStages, got.Stages)
+			assert.Equal(t, tc.wantShards, got.Shards, "expected shards %v, got %v", tc.wantShards, got.Shards)
+			assert.Equal(t, bson.Raw(res), got.Raw, "expected the raw explain output")
+		})
+	}
+}
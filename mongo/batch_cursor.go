@@ -63,3 +63,32 @@ type changeStreamCursor interface {
 	// KillCursor kills cursor on server without closing batch cursor
 	KillCursor(context.Context) error
 }
+
+// partialResultsCursor is the interface implemented by batch cursors that can report whether a sharded cluster
+// returned partial results.
+type partialResultsCursor interface {
+	// PartialResultsReturned returns true if any batch seen so far was incomplete because some shards were
+	// unavailable.
+	PartialResultsReturned() bool
+}
+
+// wrappingCursor is the interface implemented by batch cursors that add behavior to another batch cursor.
+type wrappingCursor interface {
+	// unwrap returns the batch cursor that is currently wrapped.
+	unwrap() batchCursor
+}
+
+// unwrapDriverCursor returns the driver.BatchCursor underneath bc and any batch cursors wrapping it, or nil if there
+// is none.
+func unwrapDriverCursor(bc batchCursor) *driver.BatchCursor {
+	for {
+		switch c := bc.(type) {
+		case *driver.BatchCursor:
+			return c
+		case wrappingCursor:
+			bc = c.unwrap()
+		default:
+			return nil
+		}
+	}
+}
@@ -10,8 +10,8 @@ import (
 	"context"
 	"errors"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -40,6 +40,7 @@ type bulkWrite struct {
 	result                   BulkWriteResult
 	let                      interface{}
 	bypassEmptyTsReplacement *bool
+	bypassAutoEncryption     *bool
 }
 
 func (bw *bulkWrite) execute(ctx context.Context) error {
@@ -119,6 +120,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.InsertedCount = res.N
+		batchRes.OperationTime, batchRes.ClusterTime = res.OperationTime, bson.Raw(res.ClusterTime)
 	case *DeleteOneModel, *DeleteManyModel:
 		res, err := bw.runDelete(ctx, batch)
 		if err != nil {
@@ -131,6 +133,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.DeletedCount = res.N
+		batchRes.OperationTime, batchRes.ClusterTime = res.OperationTime, bson.Raw(res.ClusterTime)
 	case *ReplaceOneModel, *UpdateOneModel, *UpdateManyModel:
 		res, err := bw.runUpdate(ctx, batch)
 		if err != nil {
@@ -145,6 +148,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 		batchRes.MatchedCount = res.N
 		batchRes.ModifiedCount = res.NModified
 		batchRes.UpsertedCount = int64(len(res.Upserted))
+		batchRes.OperationTime, batchRes.ClusterTime = res.OperationTime, bson.Raw(res.ClusterTime)
 		for _, upsert := range res.Upserted {
 			batchRes.UpsertedIDs[int64(batch.indexes[upsert.Index])] = upsert.ID
 		}
@@ -172,7 +176,8 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 		if err != nil {
 			return operation.InsertResult{}, err
 		}
-		doc, _, err = ensureID(doc, primitive.NilObjectID, bw.collection.bsonOpts, bw.collection.registry)
+		doc, _, err = ensureGeneratedID(doc, bw.collection.client.idGenerator, bw.collection.bsonOpts,
+			bw.collection.registry)
 		if err != nil {
 			return operation.InsertResult{}, err
 		}
@@ -211,6 +216,9 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.bypassEmptyTsReplacement != nil {
 		op.BypassEmptyTsReplacement(*bw.bypassEmptyTsReplacement)
 	}
+	if bw.bypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*bw.bypassAutoEncryption)
+	}
 
 	err := op.Execute(ctx)
 
@@ -284,6 +292,9 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 		retry = driver.RetryOncePerCommand
 	}
 	op = op.Retry(retry)
+	if bw.bypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*bw.bypassAutoEncryption)
+	}
 
 	err := op.Execute(ctx)
 
@@ -423,6 +434,9 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.bypassEmptyTsReplacement != nil {
 		op.BypassEmptyTsReplacement(*bw.bypassEmptyTsReplacement)
 	}
+	if bw.bypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*bw.bypassAutoEncryption)
+	}
 
 	err := op.Execute(ctx)
 
@@ -592,6 +606,13 @@ func (bw *bulkWrite) mergeResults(newResult BulkWriteResult) {
 	for index, upsertID := range newResult.UpsertedIDs {
 		bw.result.UpsertedIDs[index] = upsertID
 	}
+
+	if newResult.OperationTime != nil {
+		bw.result.OperationTime = newResult.OperationTime
+	}
+	if newResult.ClusterTime != nil {
+		bw.result.ClusterTime = newResult.ClusterTime
+	}
 }
 
 // WriteCommandKind is the type of command represented by a Write
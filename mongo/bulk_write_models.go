@@ -14,7 +14,7 @@ import (
 // represents a write.
 //
 // This interface is implemented by InsertOneModel, DeleteOneModel, DeleteManyModel, ReplaceOneModel, UpdateOneModel,
-// and UpdateManyModel. Custom implementations of this interface must not be used.
+// UpdateManyModel, and SegmentBoundaryModel. Custom implementations of this interface must not be used.
 type WriteModel interface {
 	writeModel()
 }
@@ -303,3 +303,15 @@ func (umm *UpdateManyModel) SetUpsert(upsert bool) *UpdateManyModel {
 }
 
 func (*UpdateManyModel) writeModel() {}
+
+// SegmentBoundaryModel separates the write models of a BulkWrite operation into segments that are executed
+// concurrently. It does not perform a write and can only be used if the SegmentConcurrency option is set. See
+// Collection.BulkWrite for the semantics of segmented bulk writes.
+type SegmentBoundaryModel struct{}
+
+// NewSegmentBoundaryModel creates a new *SegmentBoundaryModel.
+func NewSegmentBoundaryModel() *SegmentBoundaryModel {
+	return &SegmentBoundaryModel{}
+}
+
+func (*SegmentBoundaryModel) writeModel() {}
@@ -0,0 +1,144 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// bulkWriteSegment is a run of write models between SegmentBoundaryModel values that is executed as one bulk write.
+type bulkWriteSegment struct {
+	offset int // index of the first model of the segment in the models passed to BulkWrite
+	models []WriteModel
+
+	result *BulkWriteResult
+	err    error
+}
+
+// splitSegments splits models into segments at each SegmentBoundaryModel. Empty segments are omitted.
+func splitSegments(models []WriteModel) []bulkWriteSegment {
+	var segments []bulkWriteSegment
+	start := 0
+	for i := 0; i <= len(models); i++ {
+		if i < len(models) {
+			if _, ok := models[i].(*SegmentBoundaryModel); !ok {
+				continue
+			}
+		}
+		if i > start {
+			segments = append(segments, bulkWriteSegment{offset: start, models: models[start:i]})
+		}
+		start = i + 1
+	}
+	return segments
+}
+
+// validateSegments checks the SegmentConcurrency option in bwo and splits models into segments.
+func validateSegments(ctx context.Context, models []WriteModel,
+	bwo *options.BulkWriteOptions) ([]bulkWriteSegment, error) {
+
+	if concurrency := *bwo.SegmentConcurrency; concurrency < 1 {
+		return nil, fmt.Errorf("SegmentConcurrency must be at least 1, got %d", concurrency)
+	}
+	// Sessions cannot be used concurrently, so every segment must use its own implicit session.
+	if sessionFromContext(ctx) != nil {
+		return nil, errors.New("the SegmentConcurrency option cannot be used with an explicit session")
+	}
+
+	segments := splitSegments(models)
+	if len(segments) == 0 {
+		return nil, ErrEmptySlice
+	}
+	return segments, nil
+}
+
+// bulkWriteSegments executes segments concurrently, with at most bwo.SegmentConcurrency segments running at a time,
+// and merges their results.
+func (coll *Collection) bulkWriteSegments(ctx context.Context, segments []bulkWriteSegment,
+	bwo *options.BulkWriteOptions) (*BulkWriteResult, error) {
+
+	sem := make(chan struct{}, *bwo.SegmentConcurrency)
+	var wg sync.WaitGroup
+	for i := range segments {
+		seg := &segments[i]
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			seg.result, seg.err = coll.bulkWrite(ctx, seg.models, bwo)
+		}()
+	}
+	wg.Wait()
+
+	return mergeSegments(segments)
+}
+
+// mergeSegments combines the results and errors of executed segments, converting the indexes of upserted IDs and
+// write errors in each segment into indexes into the models passed to BulkWrite.
+func mergeSegments(segments []bulkWriteSegment) (*BulkWriteResult, error) {
+	merged := bulkWrite{
+		result: BulkWriteResult{UpsertedIDs: make(map[int64]interface{})},
+	}
+	bwErr := BulkWriteException{WriteErrors: make([]BulkWriteError, 0)}
+	labels := make(map[string]bool)
+	var cmdErr error
+
+	for _, seg := range segments {
+		if seg.result != nil {
+			res := *seg.result
+			res.UpsertedIDs = make(map[int64]interface{}, len(seg.result.UpsertedIDs))
+			for index, id := range seg.result.UpsertedIDs {
+				res.UpsertedIDs[index+int64(seg.offset)] = id
+			}
+			merged.mergeResults(res)
+		}
+		if seg.err == nil {
+			continue
+		}
+
+		var segErr BulkWriteException
+		if !errors.As(seg.err, &segErr) {
+			if cmdErr == nil {
+				cmdErr = seg.err
+			}
+			continue
+		}
+		for _, we := range segErr.WriteErrors {
+			we.Index += seg.offset
+			bwErr.WriteErrors = append(bwErr.WriteErrors, we)
+		}
+		if bwErr.WriteConcernError == nil {
+			bwErr.WriteConcernError = segErr.WriteConcernError
+		}
+		for _, label := range segErr.Labels {
+			if !labels[label] {
+				labels[label] = true
+				bwErr.Labels = append(bwErr.Labels, label)
+			}
+		}
+	}
+
+	if cmdErr != nil {
+		return &merged.result, cmdErr
+	}
+	if len(bwErr.WriteErrors) > 0 || bwErr.WriteConcernError != nil {
+		sort.SliceStable(bwErr.WriteErrors, func(i, j int) bool {
+			return bwErr.WriteErrors[i].Index < bwErr.WriteErrors[j].Index
+		})
+		return &merged.result, bwErr
+	}
+	return &merged.result, nil
+}
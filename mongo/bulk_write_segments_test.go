@@ -0,0 +1,151 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestSplitSegments(t *testing.T) {
+	t.Parallel()
+
+	insert := func(x int) WriteModel {
+		return NewInsertOneModel().SetDocument(bson.D{{"x", x}})
+	}
+	boundary := NewSegmentBoundaryModel()
+
+	testCases := []struct {
+		name        string
+		models      []WriteModel
+		wantOffsets []int
+		wantLens    []int
+	}{
+		{"no boundaries", []WriteModel{insert(1), insert(2)}, []int{0}, []int{2}},
+		{"boundaries", []WriteModel{insert(1), boundary, insert(2), insert(3), boundary, insert(4)},
+			[]int{0, 2, 5}, []int{1, 2, 1}},
+		{"empty segments", []WriteModel{boundary, insert(1), boundary, boundary, insert(2), boundary},
+			[]int{1, 4}, []int{1, 1}},
+		{"only boundaries", []WriteModel{boundary, boundary}, nil, nil},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			segments := splitSegments(tc.models)
+			var offsets, lens []int
+			for _, seg := range segments {
+				offsets = append(offsets, seg.offset)
+				lens = append(lens, len(seg.models))
+			}
+			assert.Equal(t, tc.wantOffsets, offsets, "expected offsets %v, got %v", tc.wantOffsets, offsets)
+			assert.Equal(t, tc.wantLens, lens, "expected segment lengths %v, got %v", tc.wantLens, lens)
+		})
+	}
+}
+
+func TestMergeSegments(t *testing.T) {
+	t.Parallel()
+
+	writeErr := func(index int) BulkWriteError {
+		return BulkWriteError{WriteError: WriteError{Index: index, Code: 11000, Message: "duplicate key"}}
+	}
+
+	t.Run("results and write errors", func(t *testing.T) {
+		t.Parallel()
+
+		segments := []bulkWriteSegment{
+			{
+				offset: 0,
+				result: &BulkWriteResult{InsertedCount: 1, UpsertedIDs: map[int64]interface{}{}},
+				err: BulkWriteException{
+					WriteErrors: []BulkWriteError{writeErr(1)},
+					Labels:      []string{"label"},
+				},
+			},
+			{
+				offset: 3,
+				result: &BulkWriteResult{
+					InsertedCount: 2,
+					UpsertedCount: 1,
+					UpsertedIDs:   map[int64]interface{}{1: "upserted"},
+				},
+			},
+			{
+				offset: 6,
+				result: &BulkWriteResult{DeletedCount: 1, UpsertedIDs: map[int64]interface{}{}},
+				err: BulkWriteException{
+					WriteErrors:       []BulkWriteError{writeErr(0)},
+					WriteConcernError: &WriteConcernError{Code: 64},
+					Labels:            []string{"label", "other"},
+				},
+			},
+		}
+
+		res, err := mergeSegments(segments)
+		wantRes := &BulkWriteResult{
+			InsertedCount: 3,
+			DeletedCount:  1,
+			UpsertedCount: 1,
+			UpsertedIDs:   map[int64]interface{}{4: "upserted"},
+		}
+		assert.Equal(t, wantRes, res, "expected result %v, got %v", wantRes, res)
+
+		var bwe BulkWriteException
+		assert.True(t, errors.As(err, &bwe), "expected BulkWriteException, got %v", err)
+		wantErr := BulkWriteException{
+			WriteErrors:       []BulkWriteError{writeErr(1), writeErr(6)},
+			WriteConcernError: &WriteConcernError{Code: 64},
+			Labels:            []string{"label", "other"},
+		}
+		assert.Equal(t, wantErr, bwe, "expected error %v, got %v", wantErr, bwe)
+	})
+	t.Run("command error", func(t *testing.T) {
+		t.Parallel()
+
+		cmdErr := errors.New("network error")
+		segments := []bulkWriteSegment{
+			{offset: 0, err: BulkWriteException{WriteErrors: []BulkWriteError{writeErr(0)}}},
+			{offset: 2, err: cmdErr},
+			{offset: 4, result: &BulkWriteResult{InsertedCount: 1, UpsertedIDs: map[int64]interface{}{}}},
+		}
+
+		res, err := mergeSegments(segments)
+		assert.Equal(t, cmdErr, err, "expected error %v, got %v", cmdErr, err)
+		assert.Equal(t, int64(1), res.InsertedCount, "expected inserted count 1, got %v", res.InsertedCount)
+	})
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		segments := []bulkWriteSegment{
+			{offset: 0, result: &BulkWriteResult{InsertedCount: 1, UpsertedIDs: map[int64]interface{}{}}},
+		}
+
+		_, err := mergeSegments(segments)
+		assert.Nil(t, err, "mergeSegments error: %v", err)
+	})
+}
+
+func TestBulkWriteSegmentsValidation(t *testing.T) {
+	t.Parallel()
+
+	coll := &Collection{}
+	models := []WriteModel{NewInsertOneModel().SetDocument(bson.D{{"x", 1}})}
+
+	_, err := coll.BulkWrite(context.Background(), models, options.BulkWrite().SetSegmentConcurrency(0))
+	assert.NotNil(t, err, "expected error for zero SegmentConcurrency, got nil")
+
+	_, err = coll.BulkWrite(context.Background(), []WriteModel{NewSegmentBoundaryModel()},
+		options.BulkWrite().SetSegmentConcurrency(2))
+	assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+}
@@ -0,0 +1,218 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+)
+
+// FindOneCache is a cache used by CachedCollection to store the documents returned by FindOne. Implementations must be
+// safe for concurrent use.
+type FindOneCache interface {
+	// Get returns the document stored for key and true, or nil and false if there is no unexpired entry for key.
+	Get(key string) (bson.Raw, bool)
+
+	// Set stores doc for key. If ttl is positive, the entry expires after ttl has elapsed.
+	Set(key string, doc bson.Raw, ttl time.Duration)
+
+	// Delete removes the entry for key, if any.
+	Delete(key string)
+}
+
+// CachedCollection is a read-through cache for FindOne results on a Collection. It is intended for reference data that
+// changes rarely. Cached documents are keyed by a hash of the collection namespace and the marshalled filter, so
+// filters should be order-preserving types such as bson.D. Map types with multiple keys such as bson.M marshal in a
+// random order and will not reliably hit the cache or be invalidated.
+//
+// Keeping the cache consistent with the collection is the caller's responsibility: entries are removed when their TTL
+// expires or when Invalidate is called with the same filter.
+//
+// A CachedCollection for a collection can be created by a call to NewCachedCollection.
+type CachedCollection struct {
+	coll  *Collection
+	cache FindOneCache
+	ttl   time.Duration
+
+	// findOne runs a FindOne against the server on a cache miss. It is only replaced in tests.
+	findOne func(context.Context, interface{}, ...*options.FindOneOptions) *SingleResult
+}
+
+// NewCachedCollection creates a CachedCollection that stores FindOne results for coll in cache. Each cached entry
+// expires after ttl. If ttl is zero or negative, entries do not expire and are only removed by Invalidate.
+func NewCachedCollection(coll *Collection, cache FindOneCache, ttl time.Duration) *CachedCollection {
+	return &CachedCollection{
+		coll:    coll,
+		cache:   cache,
+		ttl:     ttl,
+		findOne: coll.FindOne,
+	}
+}
+
+// Collection returns the Collection wrapped by the CachedCollection.
+func (cc *CachedCollection) Collection() *Collection {
+	return cc.coll
+}
+
+// FindOne returns the cached document matching filter if there is one, or executes Collection.FindOne and caches the
+// returned document otherwise. ErrNoDocuments and other errors are not cached.
+//
+// The cache is bypassed and the operation is always sent to the server if the context contains a session, if the
+// collection's read concern is "linearizable" or "snapshot", or if opts contains an option that changes which document
+// or which fields are returned (Collation, Let, Max, Min, Projection, ReturnKey, ShowRecordID, Skip, or Sort).
+func (cc *CachedCollection) FindOne(ctx context.Context, filter interface{},
+	opts ...*options.FindOneOptions) *SingleResult {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !cc.cacheable(ctx, opts) {
+		return cc.findOne(ctx, filter, opts...)
+	}
+
+	key, err := cc.key(filter)
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+
+	if doc, ok := cc.cache.Get(key); ok {
+		// Copy the document so a caller that modifies the result does not modify the cached entry.
+		return &SingleResult{
+			ctx:      ctx,
+			rdr:      append(bson.Raw(nil), doc...),
+			bsonOpts: cc.coll.bsonOpts,
+			reg:      cc.coll.registry,
+		}
+	}
+
+	res := cc.findOne(ctx, filter, opts...)
+	doc, err := res.Raw()
+	if err != nil {
+		return res
+	}
+
+	// Copy the document so the cached entry does not share memory with the cursor batch it was read from.
+	cc.cache.Set(key, append(bson.Raw(nil), doc...), cc.ttl)
+	return res
+}
+
+// Invalidate removes the cached document for filter, if any. The filter must marshal to the same document that was
+// passed to FindOne.
+func (cc *CachedCollection) Invalidate(filter interface{}) error {
+	key, err := cc.key(filter)
+	if err != nil {
+		return err
+	}
+
+	cc.cache.Delete(key)
+	return nil
+}
+
+// key returns the cache key for filter.
+func (cc *CachedCollection) key(filter interface{}) (string, error) {
+	f, err := marshal(filter, cc.coll.bsonOpts, cc.coll.registry)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(cc.coll.db.name + "." + cc.coll.name))
+	h.Write([]byte{0})
+	h.Write(f)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheable reports whether a FindOne with the given context and options may be served from the cache.
+func (cc *CachedCollection) cacheable(ctx context.Context, opts []*options.FindOneOptions) bool {
+	if sessionFromContext(ctx) != nil {
+		return false
+	}
+	if rc := cc.coll.readConcern; rc != nil {
+		switch rc.Level {
+		case readconcern.Linearizable().Level, readconcern.Snapshot().Level:
+			return false
+		}
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Collation != nil || opt.Let != nil || opt.Max != nil || opt.Min != nil || opt.Projection != nil ||
+			opt.ReturnKey != nil || opt.ShowRecordID != nil || opt.Skip != nil || opt.Sort != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// MemoryFindOneCache is an in-memory FindOneCache. Expired entries are removed lazily when they are read. The zero
+// value is not usable; create a MemoryFindOneCache with NewMemoryFindOneCache.
+type MemoryFindOneCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+var _ FindOneCache = (*MemoryFindOneCache)(nil)
+
+type memoryCacheEntry struct {
+	doc     bson.Raw
+	expires time.Time
+}
+
+// NewMemoryFindOneCache creates an empty MemoryFindOneCache.
+func NewMemoryFindOneCache() *MemoryFindOneCache {
+	return &MemoryFindOneCache{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get implements the FindOneCache interface.
+func (c *MemoryFindOneCache) Get(key string) (bson.Raw, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.doc, true
+}
+
+// Set implements the FindOneCache interface.
+func (c *MemoryFindOneCache) Set(key string, doc bson.Raw, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := memoryCacheEntry{doc: doc}
+	if ttl > 0 {
+		entry.expires = c.now().Add(ttl)
+	}
+	c.entries[key] = entry
+}
+
+// Delete implements the FindOneCache interface.
+func (c *MemoryFindOneCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
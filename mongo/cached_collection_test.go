@@ -0,0 +1,171 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+)
+
+// newTestCachedCollection returns a CachedCollection whose cache misses are served by a stub that returns doc, or
+// ErrNoDocuments if doc is nil, and a pointer to the number of times the stub was called.
+func newTestCachedCollection(
+	coll *Collection,
+	cache FindOneCache,
+	ttl time.Duration,
+	doc interface{},
+) (*CachedCollection, *int) {
+	var calls int
+	cc := NewCachedCollection(coll, cache, ttl)
+	cc.findOne = func(context.Context, interface{}, ...*options.FindOneOptions) *SingleResult {
+		calls++
+		if doc == nil {
+			return &SingleResult{err: ErrNoDocuments}
+		}
+		return NewSingleResultFromDocument(doc, nil, nil)
+	}
+	return cc, &calls
+}
+
+func TestCachedCollection(t *testing.T) {
+	filter := bson.D{{"_id", "USD"}}
+	doc := bson.D{{"_id", "USD"}, {"symbol", "$"}}
+
+	findOne := func(t *testing.T, cc *CachedCollection, opts ...*options.FindOneOptions) bson.M {
+		t.Helper()
+
+		var got bson.M
+		err := cc.FindOne(context.Background(), filter, opts...).Decode(&got)
+		require.NoError(t, err, "FindOne error")
+		return got
+	}
+
+	t.Run("miss then hit", func(t *testing.T) {
+		cc, calls := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, doc)
+
+		want := bson.M{"_id": "USD", "symbol": "$"}
+		assert.Equal(t, want, findOne(t, cc), "expected document from server")
+		assert.Equal(t, want, findOne(t, cc), "expected document from cache")
+		assert.Equal(t, 1, *calls, "expected 1 FindOne against the server")
+	})
+	t.Run("hits return copies", func(t *testing.T) {
+		cc, _ := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, doc)
+		_ = findOne(t, cc)
+
+		raw, err := cc.FindOne(context.Background(), filter).Raw()
+		require.NoError(t, err, "Raw error")
+		want := append(bson.Raw(nil), raw...)
+		for i := range raw {
+			raw[i] = 0
+		}
+
+		got, err := cc.FindOne(context.Background(), filter).Raw()
+		require.NoError(t, err, "Raw error")
+		assert.Equal(t, want, got, "expected modifying a result not to modify the cached document")
+	})
+	t.Run("different filters use different entries", func(t *testing.T) {
+		cc, calls := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, doc)
+
+		_ = findOne(t, cc)
+		err := cc.FindOne(context.Background(), bson.D{{"_id", "EUR"}}).Err()
+		require.NoError(t, err, "FindOne error")
+		assert.Equal(t, 2, *calls, "expected 2 FindOnes against the server")
+	})
+	t.Run("collections use different entries", func(t *testing.T) {
+		cache := NewMemoryFindOneCache()
+		cc1, calls1 := newTestCachedCollection(setupColl("cached1"), cache, 0, doc)
+		cc2, calls2 := newTestCachedCollection(setupColl("cached2"), cache, 0, doc)
+
+		_ = findOne(t, cc1)
+		_ = findOne(t, cc2)
+		assert.Equal(t, 1, *calls1, "expected 1 FindOne against the server for first collection")
+		assert.Equal(t, 1, *calls2, "expected 1 FindOne against the server for second collection")
+	})
+	t.Run("TTL expiry", func(t *testing.T) {
+		now := time.Now()
+		cache := NewMemoryFindOneCache()
+		cache.now = func() time.Time { return now }
+		cc, calls := newTestCachedCollection(setupColl("cached"), cache, time.Minute, doc)
+
+		_ = findOne(t, cc)
+		now = now.Add(59 * time.Second)
+		_ = findOne(t, cc)
+		assert.Equal(t, 1, *calls, "expected entry to be cached before TTL expires")
+
+		now = now.Add(time.Second)
+		_ = findOne(t, cc)
+		assert.Equal(t, 2, *calls, "expected entry to be refetched after TTL expires")
+	})
+	t.Run("invalidate", func(t *testing.T) {
+		cc, calls := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, doc)
+
+		_ = findOne(t, cc)
+		err := cc.Invalidate(filter)
+		require.NoError(t, err, "Invalidate error")
+		_ = findOne(t, cc)
+		assert.Equal(t, 2, *calls, "expected entry to be refetched after Invalidate")
+	})
+	t.Run("no documents are not cached", func(t *testing.T) {
+		cc, calls := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, nil)
+
+		for i := 0; i < 2; i++ {
+			err := cc.FindOne(context.Background(), filter).Err()
+			assert.ErrorIs(t, err, ErrNoDocuments, "expected ErrNoDocuments")
+		}
+		assert.Equal(t, 2, *calls, "expected 2 FindOnes against the server")
+	})
+	t.Run("bypass", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			coll *Collection
+			opts *options.FindOneOptions
+		}{
+			{
+				name: "linearizable read concern",
+				coll: setupColl("cached", options.Collection().SetReadConcern(readconcern.Linearizable())),
+			},
+			{
+				name: "snapshot read concern",
+				coll: setupColl("cached", options.Collection().SetReadConcern(readconcern.Snapshot())),
+			},
+			{
+				name: "projection",
+				coll: setupColl("cached"),
+				opts: options.FindOne().SetProjection(bson.D{{"symbol", 1}}),
+			},
+			{
+				name: "sort",
+				coll: setupColl("cached"),
+				opts: options.FindOne().SetSort(bson.D{{"_id", 1}}),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cc, calls := newTestCachedCollection(tc.coll, NewMemoryFindOneCache(), 0, doc)
+
+				_ = findOne(t, cc, tc.opts)
+				_ = findOne(t, cc, tc.opts)
+				assert.Equal(t, 2, *calls, "expected cache to be bypassed")
+			})
+		}
+	})
+	t.Run("options that do not change the result are cached", func(t *testing.T) {
+		cc, calls := newTestCachedCollection(setupColl("cached"), NewMemoryFindOneCache(), 0, doc)
+
+		opts := options.FindOne().SetComment("reference data").SetMaxTime(time.Second)
+		_ = findOne(t, cc, opts)
+		_ = findOne(t, cc, opts)
+		assert.Equal(t, 1, *calls, "expected 1 FindOne against the server")
+	})
+}
@@ -110,6 +110,7 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	cursorOpts := config.client.createBaseCursorOptions()
 
 	cursorOpts.MarshalValueEncoderFn = newEncoderFn(config.bsonOpts, config.registry)
+	cursorOpts.AwaitData = true
 
 	cs := &ChangeStream{
 		client:     config.client,
@@ -157,6 +158,11 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		cs.cursorOptions.BatchSize = *cs.options.BatchSize
 	}
 	if cs.options.MaxAwaitTime != nil {
+		if *cs.options.MaxAwaitTime < 0 {
+			cs.err = fmt.Errorf("invalid MaxAwaitTime %v: must not be negative", *cs.options.MaxAwaitTime)
+			closeImplicitSession(cs.sess)
+			return nil, cs.Err()
+		}
 		cs.cursorOptions.MaxTimeMS = int64(*cs.options.MaxAwaitTime / time.Millisecond)
 	}
 	if cs.options.Custom != nil {
@@ -440,7 +446,15 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocument", string(*cs.options.FullDocument))
 	}
 
-	if cs.options.FullDocumentBeforeChange != nil {
+	if fdbc := cs.options.FullDocumentBeforeChange; fdbc != nil {
+		switch *fdbc {
+		case options.FullDocumentBeforeChangeOff, options.FullDocumentBeforeChangeWhenAvailable,
+			options.FullDocumentBeforeChangeRequired:
+		default:
+			return nil, fmt.Errorf("invalid FullDocumentBeforeChange value %q: must be %q, %q, or %q", *fdbc,
+				options.FullDocumentBeforeChangeOff, options.FullDocumentBeforeChangeWhenAvailable,
+				options.FullDocumentBeforeChangeRequired)
+		}
 		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange", string(*cs.options.FullDocumentBeforeChange))
 	}
 
@@ -689,7 +703,7 @@ func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 
 		// ignore error from cursor close because if the cursor is deleted or errors we tried to close it and will remake and try to get next batch
 		_ = cs.cursor.Close(ctx)
-		if cs.err = cs.executeOperation(ctx, true); cs.err != nil {
+		if cs.err = cs.executeOperation(driver.WithinOperation(ctx), true); cs.err != nil {
 			return
 		}
 	}
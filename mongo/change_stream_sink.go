@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ChangeStreamSink is the interface implemented by destinations for change events, such as a Kafka or NATS producer.
+// It is used by PumpChangeStream.
+type ChangeStreamSink interface {
+	// Publish publishes a change event. The event is a copy owned by the sink, so it can be retained after Publish
+	// returns. Publish should only return nil once the event has been durably accepted by the destination.
+	Publish(ctx context.Context, event bson.Raw) error
+}
+
+// ResumeTokenStore is the interface implemented by types that persist change stream resume tokens. It is used by
+// PumpChangeStream to record the progress of a change stream.
+type ResumeTokenStore interface {
+	// SaveResumeToken persists token, replacing any previously saved token.
+	SaveResumeToken(ctx context.Context, token bson.Raw) error
+}
+
+// PumpChangeStream reads events from cs and publishes each of them to sink. After an event has been published
+// successfully, the resume token of cs is saved to store. If store is nil, resume tokens are not saved.
+//
+// PumpChangeStream runs until ctx expires, an error occurs, or the change stream is closed. If publishing an event
+// fails, PumpChangeStream returns the error without saving the resume token for that event, so a change stream resumed
+// from the last saved token will deliver the event again. This gives at-least-once delivery, so sinks must tolerate
+// duplicate events. To resume a change stream from a saved token, pass it to options.ChangeStreamOptions.SetStartAfter
+// when opening the change stream.
+//
+// PumpChangeStream does not close cs.
+func PumpChangeStream(ctx context.Context, cs *ChangeStream, sink ChangeStreamSink, store ResumeTokenStore) error {
+	if cs == nil {
+		return errors.New("change stream must not be nil")
+	}
+	if sink == nil {
+		return errors.New("change stream sink must not be nil")
+	}
+
+	for cs.Next(ctx) {
+		event := make(bson.Raw, len(cs.Current))
+		copy(event, cs.Current)
+
+		if err := sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("error publishing change event: %w", err)
+		}
+		if store == nil {
+			continue
+		}
+		if err := store.SaveResumeToken(ctx, cs.ResumeToken()); err != nil {
+			return fmt.Errorf("error saving resume token: %w", err)
+		}
+	}
+	return cs.Err()
+}
@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// testChangeStreamCursor is a testBatchCursor whose documents are change events with an _id resume token.
+type testChangeStreamCursor struct {
+	*testBatchCursor
+}
+
+func newTestChangeStreamCursor(numBatches, batchSize int) *testChangeStreamCursor {
+	tbc := &testBatchCursor{}
+
+	counter := int32(0)
+	for batch := 0; batch < numBatches; batch++ {
+		var docSequence []byte
+		for doc := 0; doc < batchSize; doc++ {
+			id := bsoncore.NewDocumentBuilder().AppendInt32("token", counter).Build()
+			docSequence = append(docSequence, bsoncore.NewDocumentBuilder().
+				AppendDocument("_id", id).
+				AppendInt32("n", counter).
+				Build()...)
+			counter++
+		}
+		tbc.batches = append(tbc.batches, &bsoncore.DocumentSequence{
+			Style: bsoncore.SequenceStyle,
+			Data:  docSequence,
+		})
+	}
+	return &testChangeStreamCursor{testBatchCursor: tbc}
+}
+
+func (tcsc *testChangeStreamCursor) PostBatchResumeToken() bsoncore.Document { return nil }
+func (tcsc *testChangeStreamCursor) KillCursor(context.Context) error        { return nil }
+
+type memorySink struct {
+	events []bson.Raw
+	failAt int
+	err    error
+}
+
+func (ms *memorySink) Publish(_ context.Context, event bson.Raw) error {
+	if ms.err != nil && len(ms.events) == ms.failAt {
+		return ms.err
+	}
+	ms.events = append(ms.events, event)
+	return nil
+}
+
+type memoryTokenStore struct {
+	tokens []bson.Raw
+}
+
+func (mts *memoryTokenStore) SaveResumeToken(_ context.Context, token bson.Raw) error {
+	mts.tokens = append(mts.tokens, token)
+	return nil
+}
+
+func TestPumpChangeStream(t *testing.T) {
+	tokenValue := func(t *testing.T, token bson.Raw) int32 {
+		t.Helper()
+
+		v, ok := token.Lookup("token").Int32OK()
+		assert.True(t, ok, "expected an int32 token, got %v", token)
+		return v
+	}
+
+	t.Run("publishes all events", func(t *testing.T) {
+		cs := &ChangeStream{cursor: newTestChangeStreamCursor(2, 3)}
+		sink := &memorySink{}
+		store := &memoryTokenStore{}
+
+		err := PumpChangeStream(bgCtx, cs, sink, store)
+		assert.Nil(t, err, "PumpChangeStream error: %v", err)
+
+		assert.Len(t, sink.events, 6, "expected 6 published events, got %d", len(sink.events))
+		assert.Len(t, store.tokens, 6, "expected 6 saved tokens, got %d", len(store.tokens))
+		for i, event := range sink.events {
+			n := event.Lookup("n").Int32()
+			assert.Equal(t, int32(i), n, "expected event %d, got %d", i, n)
+			got := tokenValue(t, store.tokens[i])
+			assert.Equal(t, int32(i), got, "expected token %d, got %d", i, got)
+		}
+	})
+	t.Run("publish failure does not advance token", func(t *testing.T) {
+		publishErr := errors.New("broker unavailable")
+		cs := &ChangeStream{cursor: newTestChangeStreamCursor(2, 3)}
+		sink := &memorySink{failAt: 4, err: publishErr}
+		store := &memoryTokenStore{}
+
+		err := PumpChangeStream(bgCtx, cs, sink, store)
+		assert.ErrorIs(t, err, publishErr, "expected error %v, got %v", publishErr, err)
+
+		assert.Len(t, sink.events, 4, "expected 4 published events, got %d", len(sink.events))
+		assert.Len(t, store.tokens, 4, "expected 4 saved tokens, got %d", len(store.tokens))
+		last := tokenValue(t, store.tokens[len(store.tokens)-1])
+		assert.Equal(t, int32(3), last, "expected last saved token 3, got %d", last)
+	})
+	t.Run("events are copies", func(t *testing.T) {
+		cs := &ChangeStream{cursor: newTestChangeStreamCursor(1, 2)}
+		sink := &memorySink{}
+
+		err := PumpChangeStream(bgCtx, cs, sink, nil)
+		assert.Nil(t, err, "PumpChangeStream error: %v", err)
+
+		cs.Current[len(cs.Current)-2] = 0xFF
+		n := sink.events[1].Lookup("n").Int32()
+		assert.Equal(t, int32(1), n, "expected published event to be unaffected, got %d", n)
+	})
+	t.Run("nil sink", func(t *testing.T) {
+		err := PumpChangeStream(bgCtx, &ChangeStream{}, nil, nil)
+		assert.NotNil(t, err, "expected an error for a nil sink, got nil")
+	})
+}
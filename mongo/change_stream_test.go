@@ -7,9 +7,12 @@
 package mongo
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestChangeStream(t *testing.T) {
@@ -26,4 +29,32 @@ func TestChangeStream(t *testing.T) {
 		err = cs.Close(bgCtx)
 		assert.Nil(t, err, "Close error: %v", err)
 	})
+	t.Run("invalid FullDocumentBeforeChange", func(t *testing.T) {
+		client, err := NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		coll := client.Database("db").Collection("coll")
+
+		opts := options.ChangeStream().SetFullDocumentBeforeChange(options.UpdateLookup)
+		_, err = coll.Watch(bgCtx, Pipeline{}, opts)
+		assert.NotNil(t, err, "expected Watch error, got nil")
+		assert.True(t, strings.Contains(err.Error(), "invalid FullDocumentBeforeChange"),
+			"expected invalid FullDocumentBeforeChange error, got %v", err)
+	})
+	t.Run("invalid MaxAwaitTime", func(t *testing.T) {
+		client, err := NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		coll := client.Database("db").Collection("coll")
+
+		opts := options.ChangeStream().SetMaxAwaitTime(-time.Second)
+		_, err = coll.Watch(bgCtx, Pipeline{}, opts)
+		assert.NotNil(t, err, "expected Watch error, got nil")
+		assert.True(t, strings.Contains(err.Error(), "invalid MaxAwaitTime"),
+			"expected invalid MaxAwaitTime error, got %v", err)
+
+		// A MaxAwaitTime of 0 means the option is unset.
+		opts = options.ChangeStream().SetMaxAwaitTime(0)
+		_, err = coll.Watch(bgCtx, Pipeline{}, opts)
+		assert.False(t, err != nil && strings.Contains(err.Error(), "invalid MaxAwaitTime"),
+			"expected no invalid MaxAwaitTime error, got %v", err)
+	})
 }
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -53,24 +54,29 @@ var (
 // The Client type opens and closes connections automatically and maintains a pool of idle connections. For
 // connection pool configuration options, see documentation for the ClientOptions type in the mongo/options package.
 type Client struct {
-	id             uuid.UUID
-	deployment     driver.Deployment
-	localThreshold time.Duration
-	retryWrites    bool
-	retryReads     bool
-	clock          *session.ClusterClock
-	readPreference *readpref.ReadPref
-	readConcern    *readconcern.ReadConcern
-	writeConcern   *writeconcern.WriteConcern
-	bsonOpts       *options.BSONOptions
-	registry       *bsoncodec.Registry
-	monitor        *event.CommandMonitor
-	serverAPI      *driver.ServerAPIOptions
-	serverMonitor  *event.ServerMonitor
-	sessionPool    *session.Pool
-	timeout        *time.Duration
-	httpClient     *http.Client
-	logger         *logger.Logger
+	id               uuid.UUID
+	deployment       driver.Deployment
+	localThreshold   time.Duration
+	retryWrites      bool
+	retryReads       bool
+	clock            *session.ClusterClock
+	readPreference   *readpref.ReadPref
+	readConcern      *readconcern.ReadConcern
+	writeConcern     *writeconcern.WriteConcern
+	bsonOpts         *options.BSONOptions
+	registry         *bsoncodec.Registry
+	monitor          *event.CommandMonitor
+	serverAPI        *driver.ServerAPIOptions
+	serverMonitor    *event.ServerMonitor
+	sessionPool      *session.Pool
+	timeout          *time.Duration
+	httpClient       *http.Client
+	deriveMaxTime    bool
+	guardEmptyFilter bool
+	idGenerator      func() interface{}
+	logger           *logger.Logger
+	serverVersions   sync.Map // map[serverVersionKey]ServerVersion
+	cursors          cursorTracker
 
 	// client-side encryption fields
 	keyVaultClientFLE  *Client
@@ -153,6 +159,17 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	if clientOpt.Monitor != nil {
 		client.monitor = clientOpt.Monitor
 	}
+	// IDGenerator
+	client.idGenerator = clientOpt.IDGenerator
+	// ContextTagExtractor
+	if clientOpt.ContextTagExtractor != nil && client.monitor != nil {
+		client.monitor = newTaggingCommandMonitor(client.monitor, clientOpt.ContextTagExtractor)
+	}
+	// SlowQueryThreshold
+	if clientOpt.SlowQueryThreshold != nil && clientOpt.SlowQueryHandler != nil {
+		client.monitor = newSlowQueryCommandMonitor(client.monitor, *clientOpt.SlowQueryThreshold,
+			clientOpt.SlowQueryHandler)
+	}
 	// ServerMonitor
 	if clientOpt.ServerMonitor != nil {
 		client.serverMonitor = clientOpt.ServerMonitor
@@ -188,6 +205,12 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	// Timeout
 	client.timeout = clientOpt.Timeout
 	client.httpClient = clientOpt.HTTPClient
+	if clientOpt.DeriveMaxTimeFromContext != nil {
+		client.deriveMaxTime = *clientOpt.DeriveMaxTimeFromContext
+	}
+	if clientOpt.GuardEmptyFilter != nil {
+		client.guardEmptyFilter = *clientOpt.GuardEmptyFilter
+	}
 	// WriteConcern
 	if clientOpt.WriteConcern != nil {
 		client.writeConcern = clientOpt.WriteConcern
@@ -229,6 +252,14 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 
 	client.serverAPI = topology.ServerAPIFromServerOptions(cfg.ServerOpts)
 
+	// RetryPredicate is set here rather than by the topology package so it is called with the errors returned to
+	// the application.
+	if pred := clientOpt.RetryPredicate; pred != nil {
+		cfg.RetryPredicate = func(err error, attempt int) bool {
+			return pred(replaceErrors(err), attempt)
+		}
+	}
+
 	if client.deployment == nil {
 		client.deployment, err = topology.New(cfg)
 		if err != nil {
@@ -304,7 +335,36 @@ func (c *Client) Connect(ctx context.Context) error {
 // connections will be closed, resulting in the failure of any in flight read
 // or write operations. If this method returns with no errors, all connections
 // associated with this Client have been closed.
+//
+// Disconnect does not wait for in-flight operations to complete. Use Drain to
+// stop the Client from starting new operations and wait for them first.
 func (c *Client) Disconnect(ctx context.Context) error {
+	return c.shutdown(ctx, false)
+}
+
+// Drain gracefully shuts down the Client. It stops the Client from starting
+// new operations, waits for the operations that are already in flight to
+// complete, and then disconnects the Client as described in Disconnect.
+// Operations started after Drain is called fail with ErrClientDraining, and
+// with ErrClientDisconnected once the Client is disconnected. Commands that
+// continue an in-flight operation, such as the later batches of a bulk write,
+// commitTransaction and abortTransaction, and change stream resumes, are not
+// rejected. The number of operations still in flight can be observed with
+// InFlightOperations. Cursor iteration (getMore) is not counted as an in-flight
+// operation and is not waited for.
+//
+// If ctx expires before the in-flight operations complete, Drain disconnects
+// the Client anyway, which closes the connections in use and causes those
+// operations to fail, and returns a DrainError that reports how many
+// operations were still in flight. If ctx has no deadline, Drain waits until
+// all in-flight operations complete.
+func (c *Client) Drain(ctx context.Context) error {
+	return c.shutdown(ctx, true)
+}
+
+// shutdown disconnects the Client, first draining the in-flight operations if
+// drain is true.
+func (c *Client) shutdown(ctx context.Context, drain bool) error {
 	if c.logger != nil {
 		defer c.logger.Close()
 	}
@@ -317,7 +377,27 @@ func (c *Client) Disconnect(ctx context.Context) error {
 		defer httputil.CloseIdleHTTPConnections(c.httpClient)
 	}
 
+	// End the pooled sessions before draining because ending them requires
+	// running an operation.
 	c.endSessions(ctx)
+
+	var inFlight int
+	if drainer, ok := c.deployment.(interface{ DrainOperations(context.Context) int }); ok && drain {
+		inFlight = drainer.DrainOperations(ctx)
+	}
+
+	if err := c.disconnect(ctx); err != nil {
+		return err
+	}
+	if inFlight > 0 {
+		return DrainError{InFlightOperations: inFlight, Wrapped: ctx.Err()}
+	}
+	return nil
+}
+
+// disconnect closes the auto encryption resources and the deployment of the
+// Client.
+func (c *Client) disconnect(ctx context.Context) error {
 	if c.mongocryptdFLE != nil {
 		if err := c.mongocryptdFLE.disconnect(ctx); err != nil {
 			return err
@@ -418,6 +498,9 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	if sopts.Snapshot != nil {
 		coreOpts.Snapshot = sopts.Snapshot
 	}
+	if sopts.InitialClusterTime != nil {
+		coreOpts.InitialClusterTime = sopts.InitialClusterTime
+	}
 
 	sess, err := session.NewClientSession(c.sessionPool, c.id, coreOpts)
 	if err != nil {
@@ -455,7 +538,9 @@ func (c *Client) endSessions(ctx context.Context) {
 			// Ignore all errors when ending sessions.
 			_, marshalVal, err := bson.MarshalValue(currentBatch)
 			if err == nil {
-				_ = op.SessionIDs(marshalVal).Execute(ctx)
+				// endSessions is part of shutting down the Client, so it must not
+				// wait for an operation slot or be rejected while draining.
+				_ = op.SessionIDs(marshalVal).Execute(driver.WithinOperation(ctx))
 			}
 
 			currentBatch = currentBatch[:0]
@@ -838,16 +923,57 @@ func (c *Client) NumberSessionsInProgress() int {
 	return int(c.sessionPool.CheckedOut())
 }
 
+// newAdminCommand returns a Command that runs cmd against the admin database on the server chosen by selector, using
+// the monitor, cluster clock, server API, timeout, logger, and authenticator of the Client.
+func (c *Client) newAdminCommand(cmd bsoncore.Document, selector description.ServerSelector) *operation.Command {
+	return operation.NewCommand(cmd).
+		CommandMonitor(c.monitor).ServerSelector(selector).ClusterClock(c.clock).
+		Database("admin").Deployment(c.deployment).ServerAPI(c.serverAPI).
+		Timeout(c.timeout).Logger(c.logger).Authenticator(c.authenticator)
+}
+
+// startOperation admits a logical operation that runs several commands, such as a bulk write, against the limit set
+// with options.ClientOptions.SetMaxConcurrentOperations, so that its commands are not limited or rejected
+// individually. The returned Context must be used for the commands of the operation and the returned function must be
+// called when the operation completes.
+func (c *Client) startOperation(ctx context.Context) (context.Context, func(), error) {
+	if c == nil || c.deployment == nil {
+		return ctx, func() {}, nil
+	}
+	limiter, ok := c.deployment.(driver.OperationLimiter)
+	if !ok || driver.IsWithinOperation(ctx) {
+		return ctx, func() {}, nil
+	}
+	release, err := limiter.AcquireOperation(ctx)
+	if err != nil {
+		return nil, nil, replaceErrors(err)
+	}
+	return driver.WithinOperation(ctx), release, nil
+}
+
+// InFlightOperations returns the number of operations that are currently executing on this client. Cursor iteration
+// (getMore) is not counted. See options.ClientOptions.SetMaxConcurrentOperations to bound this number.
+func (c *Client) InFlightOperations() int {
+	if counter, ok := c.deployment.(interface{ InFlightOperations() int }); ok {
+		return counter.InFlightOperations()
+	}
+	return 0
+}
+
 // Timeout returns the timeout set for this client.
 func (c *Client) Timeout() *time.Duration {
 	return c.timeout
 }
 
 func (c *Client) createBaseCursorOptions() driver.CursorOptions {
+	killer, ok := c.deployment.(driver.OperationKiller)
 	return driver.CursorOptions{
 		CommandMonitor: c.monitor,
 		Crypt:          c.cryptFLE,
 		ServerAPI:      c.serverAPI,
+		KillOnCancel:   ok && killer.KillOnCancel(),
+
+		DeriveMaxTimeFromContext: c.deriveMaxTime,
 	}
 }
 
@@ -0,0 +1,99 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/description"
+)
+
+// RunCommandResult is the result of running a command on a single server with Client.RunCommandOnAllMongos.
+type RunCommandResult struct {
+	// Address is the address of the server the command was run on.
+	Address string
+
+	// Result is the server's response to the command. It is nil if Err is not nil.
+	Result bson.Raw
+
+	// Err is the error returned by the command, if any.
+	Err error
+}
+
+// RunCommandOnAllMongos runs the given command against the database named db on every mongos of a sharded cluster
+// known to the Client. It is intended for administrative commands that affect only the mongos they are run on, such
+// as flushRouterConfig. The cmd parameter must be a document representing the command, and must be an
+// order-preserving type such as bson.D. Map types such as bson.M are not valid.
+//
+// The command is run on each mongos in turn, and a RunCommandResult is returned for every mongos in the order the
+// commands were run. An error for a single mongos is reported in its RunCommandResult and does not stop the command
+// from being run on the others. Only servers the Client has successfully connected to are included, so a mongos that
+// is down or has not been discovered yet is not part of the results.
+//
+// ErrNotSharded is returned if the Client is not connected to a sharded cluster, including a sharded cluster behind a
+// load balancer, where individual mongos servers cannot be addressed.
+func (c *Client) RunCommandOnAllMongos(ctx context.Context, db string, cmd interface{}) ([]RunCommandResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if isUnorderedMap(cmd) {
+		return nil, ErrMapForOrderedArgument{"cmd"}
+	}
+	database := c.Database(db)
+	cmdDoc, err := marshal(cmd, database.bsonOpts, database.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	topo, ok, err := c.discoverTopology(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || topo.Kind != description.Sharded {
+		return nil, ErrNotSharded
+	}
+
+	var results []RunCommandResult
+	for _, srv := range topo.Servers {
+		if srv.Kind != description.Mongos {
+			continue
+		}
+
+		op := c.newAdminCommand(cmdDoc, addressSelector(srv.Addr)).Database(db).Crypt(c.cryptFLE)
+
+		res := RunCommandResult{Address: srv.Addr.String()}
+		if err := op.Execute(ctx); err != nil {
+			res.Err = replaceErrors(err)
+		} else {
+			res.Result = bson.Raw(op.Result())
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// discoverTopology waits for the Client to discover at least one server so the topology kind is known and returns the
+// description of the topology. The returned bool is false if the Client's deployment cannot describe its topology.
+func (c *Client) discoverTopology(ctx context.Context) (description.Topology, bool, error) {
+	_, err := c.deployment.SelectServer(ctx, description.ServerSelectorFunc(func(
+		_ description.Topology,
+		candidates []description.Server,
+	) ([]description.Server, error) {
+		return candidates, nil
+	}))
+	if err != nil {
+		return description.Topology{}, false, replaceErrors(err)
+	}
+
+	describer, ok := c.deployment.(interface{ Description() description.Topology })
+	if !ok {
+		return description.Topology{}, false, nil
+	}
+	return describer.Description(), true, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/internal/serverpin"
+)
+
+// WithPinnedServer calls fn with a Context that pins the operations run with it to a single server, similar to how
+// the operations in a transaction on a sharded cluster are pinned to a single mongos. The first operation run with
+// the Context selects a server as usual, and every later operation is sent to the same server. The read preference of
+// a later operation is only checked against the pinned server, so, for example, a secondaryPreferred read is sent to a
+// pinned primary. This can be used to run a sequence of related operations that must see a consistent view of the
+// cluster without using a transaction, such as creating, using, and dropping a temporary collection through the same
+// mongos. Cursors created in fn continue to use the server that created them, even after fn returns.
+//
+// If an operation cannot run on the pinned server, such as a write after the pinned primary has become a secondary or
+// a read with a secondary read preference while pinned to a primary, or the pinned server has been removed from the
+// topology, the operation fails immediately with a server selection error. Operations run concurrently in fn before the
+// first one has been sent to a server may be sent to different servers. Calls to WithPinnedServer with a Context that
+// is already pinned reuse the existing pin.
+//
+// When the Client is connected through a load balancer, the only known server is the load balancer, so pinning has no
+// effect and the load balancer may route each operation to a different mongos. Use a transaction to pin operations to
+// a single mongos behind a load balancer.
+//
+// The error returned by fn is returned by WithPinnedServer.
+func (c *Client) WithPinnedServer(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return fn(serverpin.NewContext(ctx))
+}
@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// replicationPollInterval is the time WaitForReplication waits between replSetGetStatus commands.
+const replicationPollInterval = 100 * time.Millisecond
+
+// memberStateSecondary is the replSetGetStatus state of a secondary.
+const memberStateSecondary = 2
+
+// WaitForReplication blocks until every healthy secondary of the replica set the Client is connected to has applied
+// the oplog up to at least the operation time after, such as the value returned by Session.OperationTime after a
+// write. This can be used to make sure a write is visible before reading it from a secondary.
+//
+// The optimes of the secondaries are found by running the replSetGetStatus command, which is repeated until the
+// secondaries have caught up or ctx is done, in which case the error from ctx is returned. Secondaries that are
+// unreachable are not waited for. If the Client is not connected to a replica set, such as a standalone server or a
+// sharded cluster, WaitForReplication returns nil immediately.
+func (c *Client) WaitForReplication(ctx context.Context, after primitive.Timestamp) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	topo, ok, err := c.discoverTopology(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	switch topo.Kind {
+	case description.ReplicaSet, description.ReplicaSetWithPrimary, description.ReplicaSetNoPrimary:
+	default:
+		return nil
+	}
+
+	for {
+		replicated, err := c.secondariesReplicated(ctx, after)
+		if err != nil || replicated {
+			return err
+		}
+
+		timer := time.NewTimer(replicationPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// secondariesReplicated returns true if the optime of every healthy secondary reported by replSetGetStatus is at
+// least after.
+func (c *Client) secondariesReplicated(ctx context.Context, after primitive.Timestamp) (bool, error) {
+	var status struct {
+		Members []struct {
+			Health float64 `bson:"health"`
+			State  int     `bson:"state"`
+			Optime struct {
+				TS primitive.Timestamp `bson:"ts"`
+			} `bson:"optime"`
+		} `bson:"members"`
+	}
+	err := c.Database("admin").RunCommand(ctx, bson.D{{"replSetGetStatus", 1}},
+		options.RunCmd().SetReadPreference(readpref.PrimaryPreferred())).Decode(&status)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range status.Members {
+		if member.Health != 1 || member.State != memberStateSecondary {
+			continue
+		}
+		if member.Optime.TS.Before(after) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
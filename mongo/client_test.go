@@ -18,11 +18,13 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/integtest"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/tag"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/mongocrypt"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
@@ -85,6 +87,62 @@ func TestClient(t *testing.T) {
 		_, err = client.ListDatabaseNames(bgCtx, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("effective transaction options", func(t *testing.T) {
+		clientOpts := options.Client().ApplyURI("mongodb://localhost:27017").
+			SetReadConcern(readconcern.Local()).
+			SetWriteConcern(writeconcern.W1()).
+			SetReadPreference(readpref.Primary())
+		client := setupClient(clientOpts)
+		client.sessionPool = &session.Pool{}
+
+		t.Run("client", func(t *testing.T) {
+			sess, err := client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+
+			got := sess.EffectiveTransactionOptions()
+			want := &options.TransactionOptions{
+				ReadConcern:    readconcern.Local(),
+				ReadPreference: readpref.Primary(),
+				WriteConcern:   writeconcern.W1(),
+			}
+			assert.Equal(t, want, got, "expected options %v, got %v", want, got)
+		})
+		t.Run("session overrides client", func(t *testing.T) {
+			maxCommitTime := 5 * time.Second
+			sessOpts := options.Session().
+				SetDefaultReadConcern(readconcern.Majority()).
+				SetDefaultMaxCommitTime(&maxCommitTime)
+			sess, err := client.StartSession(sessOpts)
+			assert.Nil(t, err, "StartSession error: %v", err)
+
+			got := sess.EffectiveTransactionOptions()
+			want := &options.TransactionOptions{
+				ReadConcern:    readconcern.Majority(),
+				ReadPreference: readpref.Primary(),
+				WriteConcern:   writeconcern.W1(),
+				MaxCommitTime:  &maxCommitTime,
+			}
+			assert.Equal(t, want, got, "expected options %v, got %v", want, got)
+		})
+		t.Run("transaction overrides session", func(t *testing.T) {
+			sessOpts := options.Session().SetDefaultWriteConcern(writeconcern.Majority())
+			sess, err := client.StartSession(sessOpts)
+			assert.Nil(t, err, "StartSession error: %v", err)
+
+			err = sess.StartTransaction(options.Transaction().SetWriteConcern(writeconcern.Journaled()))
+			assert.Nil(t, err, "StartTransaction error: %v", err)
+			clientSess := sess.(*sessionImpl).clientSession
+			assert.Equal(t, writeconcern.Journaled(), clientSess.CurrentWc, "expected transaction write concern %v, got %v",
+				writeconcern.Journaled(), clientSess.CurrentWc)
+
+			// The options passed to StartTransaction do not change the defaults.
+			got := sess.EffectiveTransactionOptions()
+			assert.Equal(t, writeconcern.Majority(), got.WriteConcern, "expected write concern %v, got %v",
+				writeconcern.Majority(), got.WriteConcern)
+			assert.Equal(t, readconcern.Local(), got.ReadConcern, "expected read concern %v, got %v",
+				readconcern.Local(), got.ReadConcern)
+		})
+	})
 	t.Run("read preference", func(t *testing.T) {
 		t.Run("absent", func(t *testing.T) {
 			client := setupClient()
@@ -294,6 +352,122 @@ func TestClient(t *testing.T) {
 		client := setupClient(options.Client().SetWriteConcern(wc))
 		assert.Equal(t, wc, client.writeConcern, "mismatch; expected write concern %v, got %v", wc, client.writeConcern)
 	})
+	t.Run("run command on all mongos", func(t *testing.T) {
+		cmd := bson.D{{"flushRouterConfig", 1}}
+
+		t.Run("unordered map", func(t *testing.T) {
+			client := setupClient()
+			_, err := client.RunCommandOnAllMongos(bgCtx, "admin", bson.M{"flushRouterConfig": 1, "x": 1})
+			want := ErrMapForOrderedArgument{"cmd"}
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+		})
+		t.Run("disconnected", func(t *testing.T) {
+			client := setupClient()
+			_, err := client.RunCommandOnAllMongos(bgCtx, "admin", cmd)
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		})
+		testCases := []struct {
+			name string
+			kind description.TopologyKind
+			err  error
+		}{
+			{"replica set", description.ReplicaSetWithPrimary, ErrNotSharded},
+			{"single", description.Single, ErrNotSharded},
+			{"load balanced", description.LoadBalanced, ErrNotSharded},
+			{"sharded", description.Sharded, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client := setupClient()
+				client.deployment = describedDeployment{desc: description.Topology{
+					Kind:    tc.kind,
+					Servers: []description.Server{{Addr: "localhost:27017", Kind: description.Unknown}},
+				}}
+
+				results, err := client.RunCommandOnAllMongos(bgCtx, "admin", cmd)
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+				assert.Equal(t, 0, len(results), "expected no results, got %v", results)
+			})
+		}
+	})
+	t.Run("drain", func(t *testing.T) {
+		t.Run("times out with slow in-flight operation", func(t *testing.T) {
+			client := setupClient()
+			err := client.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+
+			// Simulate a slow operation by holding an operation slot until the end of the test.
+			release, err := client.deployment.(*topology.Topology).AcquireOperation(bgCtx)
+			assert.Nil(t, err, "AcquireOperation error: %v", err)
+			defer release()
+			assert.Equal(t, 1, client.InFlightOperations(), "expected 1 in-flight operation, got %v",
+				client.InFlightOperations())
+
+			ctx, cancel := context.WithTimeout(bgCtx, 20*time.Millisecond)
+			defer cancel()
+			err = client.Drain(ctx)
+			want := DrainError{InFlightOperations: 1, Wrapped: context.DeadlineExceeded}
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+			err = client.Ping(bgCtx, nil)
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		})
+		t.Run("waits for in-flight operation", func(t *testing.T) {
+			client := setupClient()
+			err := client.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+
+			release, err := client.deployment.(*topology.Topology).AcquireOperation(bgCtx)
+			assert.Nil(t, err, "AcquireOperation error: %v", err)
+
+			done := make(chan error, 1)
+			go func() {
+				done <- client.Drain(bgCtx)
+			}()
+
+			// New operations are rejected once the drain has started. Use a short timeout because a Ping that
+			// starts before the drain waits for server selection.
+			assert.Eventually(t, func() bool {
+				ctx, cancel := context.WithTimeout(bgCtx, 10*time.Millisecond)
+				defer cancel()
+				return errors.Is(client.Ping(ctx, nil), ErrClientDraining)
+			}, time.Second, time.Millisecond, "expected Ping to fail with %v", ErrClientDraining)
+
+			// Commands that continue an in-flight operation are not rejected.
+			ctx, cancel := context.WithTimeout(driver.WithinOperation(bgCtx), 10*time.Millisecond)
+			defer cancel()
+			err = client.Ping(ctx, nil)
+			assert.False(t, errors.Is(err, ErrClientDraining), "expected Ping not to fail with %v", ErrClientDraining)
+
+			release()
+			select {
+			case err := <-done:
+				assert.Nil(t, err, "Drain error: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for Drain to return")
+			}
+		})
+		t.Run("disconnect does not wait for in-flight operation", func(t *testing.T) {
+			client := setupClient()
+			err := client.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+
+			release, err := client.deployment.(*topology.Topology).AcquireOperation(bgCtx)
+			assert.Nil(t, err, "AcquireOperation error: %v", err)
+			defer release()
+
+			done := make(chan error, 1)
+			go func() {
+				done <- client.Disconnect(bgCtx)
+			}()
+			select {
+			case err := <-done:
+				assert.Nil(t, err, "Disconnect error: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for Disconnect to return")
+			}
+		})
+	})
 	t.Run("server monitor", func(t *testing.T) {
 		monitor := &event.ServerMonitor{}
 		client := setupClient(options.Client().SetServerMonitor(monitor))
@@ -502,3 +676,20 @@ func TestClient(t *testing.T) {
 		}
 	})
 }
+
+// describedDeployment is a driver.Deployment with a fixed topology description that selects no server.
+type describedDeployment struct {
+	desc description.Topology
+}
+
+func (d describedDeployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return nil, nil
+}
+
+func (d describedDeployment) Kind() description.TopologyKind {
+	return d.desc.Kind
+}
+
+func (d describedDeployment) Description() description.Topology {
+	return d.desc
+}
@@ -17,7 +17,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/csfle"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -28,6 +27,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 // Collection is a handle to a MongoDB collection. It is safe for concurrent use by multiple goroutines.
@@ -42,6 +42,8 @@ type Collection struct {
 	writeSelector  description.ServerSelector
 	bsonOpts       *options.BSONOptions
 	registry       *bsoncodec.Registry
+	autoCreate     *autoCreate
+	hints          *hintRegistry
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -117,6 +119,10 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		writeSelector:  writeSelector,
 		bsonOpts:       bsonOpts,
 		registry:       reg,
+		hints:          newHintRegistry(),
+	}
+	if collOpt.AutoCreateOptions != nil {
+		coll.autoCreate = newAutoCreate(collOpt.AutoCreateOptions)
 	}
 
 	return coll
@@ -133,6 +139,8 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		autoCreate:     coll.autoCreate,
+		hints:          coll.hints,
 	}
 }
 
@@ -159,6 +167,10 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 		copyColl.registry = optsColl.Registry
 	}
 
+	if optsColl.AutoCreateOptions != nil {
+		copyColl.autoCreate = newAutoCreate(optsColl.AutoCreateOptions)
+	}
+
 	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(copyColl.readPreference),
 		description.LatencySelector(copyColl.client.localThreshold),
@@ -184,6 +196,15 @@ func (coll *Collection) Database() *Database {
 // examples of how they should be used.
 //
 // The opts parameter can be used to specify options for the operation (see the options.BulkWriteOptions documentation.)
+//
+// If the SegmentConcurrency option is set, the models are split into segments at each SegmentBoundaryModel and each
+// non-empty segment is executed as a separate bulk write with the other options, so the Ordered option applies within
+// each segment. Up to SegmentConcurrency segments are executed at the same time, in no particular order, and every
+// segment is executed even if others fail. Each segment uses its own implicit session, so an explicit session cannot be
+// used. The returned BulkWriteResult combines the results of all segments, and the keys of its UpsertedIDs map and the
+// Index of each write error are indexes into models, counting the SegmentBoundaryModel values. If a segment fails with
+// an error other than a BulkWriteException, the error of the first such segment in models is returned. Otherwise, if
+// any segment fails, a BulkWriteException containing the write errors of all segments sorted by index is returned.
 func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	opts ...*options.BulkWriteOptions) (*BulkWriteResult, error) {
 
@@ -195,6 +216,32 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		ctx = context.Background()
 	}
 
+	bwo := options.MergeBulkWriteOptions(opts...)
+	var segments []bulkWriteSegment
+	if bwo.SegmentConcurrency != nil {
+		var err error
+		if segments, err = validateSegments(ctx, models, bwo); err != nil {
+			return nil, err
+		}
+	}
+
+	// The batches of a bulk write are separate commands, so the operation is
+	// admitted once rather than once per batch.
+	ctx, release, err := coll.client.startOperation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if segments != nil {
+		return coll.bulkWriteSegments(ctx, segments, bwo)
+	}
+	return coll.bulkWrite(ctx, models, bwo)
+}
+
+func (coll *Collection) bulkWrite(ctx context.Context, models []WriteModel,
+	bwo *options.BulkWriteOptions) (*BulkWriteResult, error) {
+
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		sess = session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
@@ -220,9 +267,14 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		if model == nil {
 			return nil, ErrNilDocument
 		}
+		if _, ok := model.(*SegmentBoundaryModel); ok {
+			return nil, errors.New("a SegmentBoundaryModel can only be used if the SegmentConcurrency option is set")
+		}
 	}
 
-	bwo := options.MergeBulkWriteOptions(opts...)
+	if err := coll.ensureCreated(ctx); err != nil {
+		return nil, err
+	}
 
 	op := bulkWrite{
 		comment:                  bwo.Comment,
@@ -235,6 +287,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		writeConcern:             wc,
 		let:                      bwo.Let,
 		bypassEmptyTsReplacement: bwo.BypassEmptyTsReplacement,
+		bypassAutoEncryption:     bwo.BypassAutoEncryption,
 	}
 
 	err = op.execute(ctx)
@@ -243,7 +296,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 }
 
 func (coll *Collection) insert(ctx context.Context, documents []interface{},
-	opts ...*options.InsertManyOptions) ([]interface{}, error) {
+	opts ...*options.InsertManyOptions) ([]interface{}, operation.WriteTimes, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -255,11 +308,11 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	for i, doc := range documents {
 		bsoncoreDoc, err := marshal(doc, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, operation.WriteTimes{}, err
 		}
-		bsoncoreDoc, id, err := ensureID(bsoncoreDoc, primitive.NilObjectID, coll.bsonOpts, coll.registry)
+		bsoncoreDoc, id, err := ensureGeneratedID(bsoncoreDoc, coll.client.idGenerator, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, operation.WriteTimes{}, err
 		}
 
 		docs[i] = bsoncoreDoc
@@ -274,7 +327,11 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 
 	err := coll.client.validSession(sess)
 	if err != nil {
-		return nil, err
+		return nil, operation.WriteTimes{}, err
+	}
+
+	if err := coll.ensureCreated(ctx); err != nil {
+		return nil, operation.WriteTimes{}, err
 	}
 
 	wc := coll.writeConcern
@@ -298,10 +355,13 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
 	}
+	if imo.BypassAutoEncryption != nil {
+		op = op.BypassAutoEncryption(*imo.BypassAutoEncryption)
+	}
 	if imo.Comment != nil {
 		comment, err := marshalValue(imo.Comment, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, operation.WriteTimes{}, err
 		}
 		op = op.Comment(comment)
 	}
@@ -311,6 +371,9 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	if imo.BypassEmptyTsReplacement != nil {
 		op = op.BypassEmptyTsReplacement(*imo.BypassEmptyTsReplacement)
 	}
+	if imo.Progress != nil {
+		op = op.Progress(imo.Progress)
+	}
 	retry := driver.RetryNone
 	if coll.client.retryWrites {
 		retry = driver.RetryOncePerCommand
@@ -318,9 +381,10 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	op = op.Retry(retry)
 
 	err = op.Execute(ctx)
+	times := op.Result().WriteTimes
 	var wce driver.WriteCommandError
 	if !errors.As(err, &wce) {
-		return result, err
+		return result, times, err
 	}
 
 	// remove the ids that had writeErrors from result
@@ -335,7 +399,7 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		result = append(result[:idIndex], result[idIndex+1:]...)
 	}
 
-	return result, err
+	return result, times, err
 }
 
 // InsertOne executes an insert command to insert a single document into the collection.
@@ -362,13 +426,20 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	if ioOpts.BypassEmptyTsReplacement != nil {
 		imOpts.BypassEmptyTsReplacement = ioOpts.BypassEmptyTsReplacement
 	}
-	res, err := coll.insert(ctx, []interface{}{document}, imOpts)
+	if ioOpts.BypassAutoEncryption != nil {
+		imOpts.SetBypassAutoEncryption(*ioOpts.BypassAutoEncryption)
+	}
+	res, times, err := coll.insert(ctx, []interface{}{document}, imOpts)
 
 	rr, err := processWriteError(err)
 	if rr&rrOne == 0 {
 		return nil, err
 	}
-	return &InsertOneResult{InsertedID: res[0]}, err
+	return &InsertOneResult{
+		InsertedID:    res[0],
+		OperationTime: times.OperationTime,
+		ClusterTime:   bson.Raw(times.ClusterTime),
+	}, err
 }
 
 // InsertMany executes an insert command to insert multiple documents into the collection. If write errors occur
@@ -389,13 +460,17 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		return nil, ErrEmptySlice
 	}
 
-	result, err := coll.insert(ctx, documents, opts...)
+	result, times, err := coll.insert(ctx, documents, opts...)
 	rr, err := processWriteError(err)
 	if rr&rrMany == 0 {
 		return nil, err
 	}
 
-	imResult := &InsertManyResult{InsertedIDs: result}
+	imResult := &InsertManyResult{
+		InsertedIDs:   result,
+		OperationTime: times.OperationTime,
+		ClusterTime:   bson.Raw(times.ClusterTime),
+	}
 	var writeException WriteException
 	if !errors.As(err, &writeException) {
 		return imResult, err
@@ -429,6 +504,11 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		return nil, err
 	}
 
+	do := options.MergeDeleteOptions(opts...)
+	if !deleteOne && coll.rejectEmptyFilter(f, do.AllowEmptyFilter) {
+		return nil, EmptyFilterError{Operation: "DeleteMany"}
+	}
+
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		sess = session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
@@ -454,7 +534,6 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if deleteOne {
 		limit = 1
 	}
-	do := options.MergeDeleteOptions(opts...)
 	didx, doc := bsoncore.AppendDocumentStart(nil)
 	doc = bsoncore.AppendDocumentElement(doc, "q", f)
 	doc = bsoncore.AppendInt32Element(doc, "limit", limit)
@@ -491,6 +570,9 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if do.Hint != nil {
 		op = op.Hint(true)
 	}
+	if do.BypassAutoEncryption != nil {
+		op = op.BypassAutoEncryption(*do.BypassAutoEncryption)
+	}
 	if do.Let != nil {
 		let, err := marshal(do.Let, coll.bsonOpts, coll.registry)
 		if err != nil {
@@ -509,7 +591,12 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if rr&expectedRr == 0 {
 		return nil, err
 	}
-	return &DeleteResult{DeletedCount: op.Result().N}, err
+	opRes := op.Result()
+	return &DeleteResult{
+		DeletedCount:  opRes.N,
+		OperationTime: opRes.OperationTime,
+		ClusterTime:   bson.Raw(opRes.ClusterTime),
+	}, err
 }
 
 // DeleteOne executes a delete command to delete at most one document from the collection.
@@ -533,7 +620,8 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 // The filter parameter must be a document containing query operators and can be used to select the documents to
 // be deleted. It cannot be nil. An empty document (e.g. bson.D{}) should be used to delete all documents in the
 // collection. If the filter does not match any documents, the operation will succeed and a DeleteResult with a
-// DeletedCount of 0 will be returned.
+// DeletedCount of 0 will be returned. If the client was configured with options.ClientOptions.SetGuardEmptyFilter, an
+// empty filter returns an EmptyFilterError unless the AllowEmptyFilter option is set to true.
 //
 // The opts parameter can be used to specify options for the operation (see the options.DeleteOptions documentation).
 //
@@ -544,6 +632,16 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 	return coll.delete(ctx, filter, false, rrMany, opts...)
 }
 
+// rejectEmptyFilter reports whether a multi-document write with the filter f must be rejected because the client
+// guards against empty filters and the operation does not explicitly allow them.
+func (coll *Collection) rejectEmptyFilter(f bsoncore.Document, allow *bool) bool {
+	if !coll.client.guardEmptyFilter || (allow != nil && *allow) {
+		return false
+	}
+	// An empty document consists of only its length and the null terminator.
+	return len(f) == 5
+}
+
 func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Document, update interface{}, multi bool,
 	expectedRr returnResult, checkDollarKey bool, opts ...*options.UpdateOptions) (*UpdateResult, error) {
 
@@ -581,6 +679,10 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		return nil, err
 	}
 
+	if err := coll.ensureCreated(ctx); err != nil {
+		return nil, err
+	}
+
 	wc := coll.writeConcern
 	if sess.TransactionRunning() {
 		wc = nil
@@ -619,6 +721,9 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	if uo.BypassEmptyTsReplacement != nil {
 		op.BypassEmptyTsReplacement(*uo.BypassEmptyTsReplacement)
 	}
+	if uo.BypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*uo.BypassAutoEncryption)
+	}
 	retry := driver.RetryNone
 	// retryable writes are only enabled updateOne/replaceOne operations
 	if !multi && coll.client.retryWrites {
@@ -637,6 +742,8 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		MatchedCount:  opRes.N,
 		ModifiedCount: opRes.NModified,
 		UpsertedCount: int64(len(opRes.Upserted)),
+		OperationTime: opRes.OperationTime,
+		ClusterTime:   bson.Raw(opRes.ClusterTime),
 	}
 	if len(opRes.Upserted) > 0 {
 		res.UpsertedID = opRes.Upserted[0].ID
@@ -700,7 +807,8 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 //
 // The filter parameter must be a document containing query operators and can be used to select the documents to be
 // updated. It cannot be nil. If the filter does not match any documents, the operation will succeed and an UpdateResult
-// with a MatchedCount of 0 will be returned.
+// with a MatchedCount of 0 will be returned. If the client was configured with options.ClientOptions.SetGuardEmptyFilter,
+// an empty filter returns an EmptyFilterError unless the AllowEmptyFilter option is set to true.
 //
 // The update parameter must be a document containing update operators
 // (https://www.mongodb.com/docs/manual/reference/operator/update/) and can be used to specify the modifications to be made
@@ -720,6 +828,9 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 	if err != nil {
 		return nil, err
 	}
+	if coll.rejectEmptyFilter(f, options.MergeUpdateOptions(opts...).AllowEmptyFilter) {
+		return nil, EmptyFilterError{Operation: "UpdateMany"}
+	}
 
 	return coll.updateOrReplace(ctx, f, update, true, rrMany, true, opts...)
 }
@@ -771,6 +882,7 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		uOpts.Let = opt.Let
 		uOpts.Comment = opt.Comment
 		uOpts.BypassEmptyTsReplacement = opt.BypassEmptyTsReplacement
+		uOpts.BypassAutoEncryption = opt.BypassAutoEncryption
 		updateOptions = append(updateOptions, uOpts)
 	}
 
@@ -855,7 +967,14 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 
 	ao := options.MergeAggregateOptions(a.opts...)
 
+	serverAPI, err := serverAPIWithOverrides(a.client.serverAPI, ao.ServerAPIStrict, ao.ServerAPIDeprecationErrors)
+	if err != nil {
+		return nil, err
+	}
+
 	cursorOpts := a.client.createBaseCursorOptions()
+	// The getMore commands must use the same API options as the aggregate command that created the cursor.
+	cursorOpts.ServerAPI = serverAPI
 
 	cursorOpts.MarshalValueEncoderFn = newEncoderFn(a.bsonOpts, a.registry)
 
@@ -871,11 +990,12 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		Collection(a.col).
 		Deployment(a.client.deployment).
 		Crypt(a.client.cryptFLE).
-		ServerAPI(a.client.serverAPI).
+		ServerAPI(serverAPI).
 		HasOutputStage(hasOutputStage).
 		Timeout(a.client.timeout).
 		MaxTime(ao.MaxTime).
-		Authenticator(a.client.authenticator)
+		Authenticator(a.client.authenticator).
+		DeriveMaxTimeFromContext(a.client.deriveMaxTime)
 
 	// Omit "maxTimeMS" from operations that return a user-managed cursor to
 	// prevent confusing "cursor not found" errors. To maintain existing
@@ -897,6 +1017,9 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 	if ao.BypassDocumentValidation != nil && *ao.BypassDocumentValidation {
 		op.BypassDocumentValidation(*ao.BypassDocumentValidation)
 	}
+	if ao.BypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*ao.BypassAutoEncryption)
+	}
 	if ao.Collation != nil {
 		op.Collation(bsoncore.Document(ao.Collation.ToDocument()))
 	}
@@ -963,7 +1086,11 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, a.client.bsonOpts, a.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	a.client.cursors.add(cursor, bc)
+	return cursor, nil
 }
 
 // CountDocuments returns the number of documents in the collection. For a fast count of the documents in the
@@ -987,6 +1114,11 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	if err != nil {
 		return 0, err
 	}
+	if countOpts.Hint == nil {
+		if filterDoc, ok := pipelineArr.Lookup("0", "$match").DocumentOK(); ok {
+			countOpts.Hint = coll.registeredHint(filterDoc)
+		}
+	}
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
@@ -1006,7 +1138,8 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
 		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(countOpts.MaxTime).Authenticator(coll.client.authenticator)
+		Timeout(coll.client.timeout).MaxTime(countOpts.MaxTime).Authenticator(coll.client.authenticator).
+		DeriveMaxTimeFromContext(coll.client.deriveMaxTime)
 	if countOpts.Collation != nil {
 		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
 	}
@@ -1091,7 +1224,8 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(co.MaxTime).Authenticator(coll.client.authenticator)
+		Timeout(coll.client.timeout).MaxTime(co.MaxTime).Authenticator(coll.client.authenticator).
+		DeriveMaxTimeFromContext(coll.client.deriveMaxTime)
 
 	if co.Comment != nil {
 		comment, err := marshalValue(co.Comment, coll.bsonOpts, coll.registry)
@@ -1158,7 +1292,8 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(option.MaxTime).Authenticator(coll.client.authenticator)
+		Timeout(coll.client.timeout).MaxTime(option.MaxTime).Authenticator(coll.client.authenticator).
+		DeriveMaxTimeFromContext(coll.client.deriveMaxTime)
 
 	if option.Collation != nil {
 		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
@@ -1257,23 +1392,107 @@ func (coll *Collection) find(
 		return nil, err
 	}
 
+	fo := options.MergeFindOptions(opts...)
+	if fo.Hint == nil {
+		fo.Hint = coll.registeredHint(f)
+	}
+
+	var resumeOp string
+	if fo.ResumableCursor != nil {
+		if fo.CursorType != nil && *fo.CursorType != options.NonTailable {
+			return nil, errors.New("a resumable cursor cannot be tailable")
+		}
+		var sort bsoncore.Document
+		if fo.Sort != nil {
+			if isUnorderedMap(fo.Sort) {
+				return nil, ErrMapForOrderedArgument{"sort"}
+			}
+			if sort, err = marshal(fo.Sort, coll.bsonOpts, coll.registry); err != nil {
+				return nil, err
+			}
+		}
+		if sort, resumeOp, err = resumableFindSort(*fo.ResumableCursor, sort); err != nil {
+			return nil, err
+		}
+		fo.Sort = bson.Raw(sort)
+	}
+
+	bc, err := coll.executeFind(ctx, f, sess, omitCSOTMaxTimeMS, fo)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor *Cursor
+	var cursorBC batchCursor = bc
+	if fo.ResumableCursor != nil {
+		key := *fo.ResumableCursor
+		cursorBC = newResumableBatchCursor(bc, key, func(ctx context.Context, last bsoncore.Value, returned int64) (batchCursor, error) {
+			resumeOpts := *fo
+			resumeOpts.Skip = nil
+			if fo.Limit != nil && *fo.Limit > 0 {
+				if returned >= *fo.Limit {
+					return driver.NewEmptyBatchCursor(), nil
+				}
+				resumeOpts.SetLimit(*fo.Limit - returned)
+			}
+			// Resuming continues the iteration of the cursor, which is not
+			// limited or rejected while the Client is draining.
+			resumeBC, err := coll.executeFind(driver.WithinOperation(ctx), resumeFindFilter(f, key, resumeOp, last), sess,
+				omitCSOTMaxTimeMS, &resumeOpts)
+			if err != nil {
+				return nil, err
+			}
+			coll.client.cursors.add(cursor, resumeBC)
+			return resumeBC, nil
+		})
+	}
+	// Prefetching requires the session to be used by the background getMore, so it is only enabled for implicit
+	// sessions, which are never used outside of the cursor.
+	if fo.Prefetch != nil && *fo.Prefetch && (sess == nil || sess.IsImplicit) {
+		cursorBC = newPrefetchBatchCursor(cursorBC)
+	}
+	cursor, err = newCursorWithSession(cursorBC, coll.bsonOpts, coll.registry, sess)
+	if err != nil {
+		return nil, err
+	}
+	coll.client.cursors.add(cursor, bc)
+	if fo.MaxResultBytes != nil {
+		cursor.SetMaxResultBytes(*fo.MaxResultBytes)
+	}
+	return cursor, nil
+}
+
+// executeFind runs a find command with the given filter and options in sess and returns the resulting batch cursor.
+func (coll *Collection) executeFind(
+	ctx context.Context,
+	f bsoncore.Document,
+	sess *session.Client,
+	omitCSOTMaxTimeMS bool,
+	fo *options.FindOptions,
+) (*driver.BatchCursor, error) {
 	rc := coll.readConcern
 	if sess.TransactionRunning() {
 		rc = nil
 	}
 
-	fo := options.MergeFindOptions(opts...)
+	serverAPI, err := serverAPIWithOverrides(coll.client.serverAPI, fo.ServerAPIStrict, fo.ServerAPIDeprecationErrors)
+	if err != nil {
+		return nil, err
+	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewFind(f).
 		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).
 		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(serverAPI).
 		Timeout(coll.client.timeout).MaxTime(fo.MaxTime).Logger(coll.client.logger).
-		OmitCSOTMaxTimeMS(omitCSOTMaxTimeMS).Authenticator(coll.client.authenticator)
+		OmitCSOTMaxTimeMS(omitCSOTMaxTimeMS).Authenticator(coll.client.authenticator).
+		DeriveMaxTimeFromContext(coll.client.deriveMaxTime)
 
 	cursorOpts := coll.client.createBaseCursorOptions()
+	// The getMore commands must use the same API options as the find command that created the cursor.
+	cursorOpts.ServerAPI = serverAPI
 
 	cursorOpts.MarshalValueEncoderFn = newEncoderFn(coll.bsonOpts, coll.registry)
 
@@ -1283,6 +1502,9 @@ func (coll *Collection) find(
 	if fo.AllowPartialResults != nil {
 		op.AllowPartialResults(*fo.AllowPartialResults)
 	}
+	if fo.BypassAutoEncryption != nil {
+		op.BypassAutoEncryption(*fo.BypassAutoEncryption)
+	}
 	if fo.BatchSize != nil {
 		cursorOpts.BatchSize = *fo.BatchSize
 		op.BatchSize(*fo.BatchSize)
@@ -1306,6 +1528,7 @@ func (coll *Collection) find(
 		case options.TailableAwait:
 			op.Tailable(true)
 			op.AwaitData(true)
+			cursorOpts.AwaitData = true
 		}
 	}
 	if fo.Hint != nil {
@@ -1400,7 +1623,7 @@ func (coll *Collection) find(
 	if err != nil {
 		return nil, replaceErrors(err)
 	}
-	return newCursorWithSession(bc, coll.bsonOpts, coll.registry, sess)
+	return bc, nil
 }
 
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
@@ -1419,30 +1642,40 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	fo := options.MergeFindOneOptions(opts...)
+	if fo.StalenessFallback != nil && *fo.StalenessFallback < 90*time.Second {
+		return &SingleResult{err: fmt.Errorf("staleness fallback (%s) must be greater than or equal to 90s",
+			*fo.StalenessFallback)}
+	}
+
 	findOpts := make([]*options.FindOptions, 0, len(opts))
 	for _, opt := range opts {
 		if opt == nil {
 			continue
 		}
 		findOpts = append(findOpts, &options.FindOptions{
-			AllowPartialResults: opt.AllowPartialResults,
-			BatchSize:           opt.BatchSize,
-			Collation:           opt.Collation,
-			Comment:             opt.Comment,
-			CursorType:          opt.CursorType,
-			Hint:                opt.Hint,
-			Max:                 opt.Max,
-			MaxAwaitTime:        opt.MaxAwaitTime,
-			MaxTime:             opt.MaxTime,
-			Min:                 opt.Min,
-			NoCursorTimeout:     opt.NoCursorTimeout,
-			OplogReplay:         opt.OplogReplay,
-			Projection:          opt.Projection,
-			ReturnKey:           opt.ReturnKey,
-			ShowRecordID:        opt.ShowRecordID,
-			Skip:                opt.Skip,
-			Snapshot:            opt.Snapshot,
-			Sort:                opt.Sort,
+			AllowPartialResults:        opt.AllowPartialResults,
+			BatchSize:                  opt.BatchSize,
+			BypassAutoEncryption:       opt.BypassAutoEncryption,
+			Collation:                  opt.Collation,
+			Comment:                    opt.Comment,
+			CursorType:                 opt.CursorType,
+			Hint:                       opt.Hint,
+			Let:                        opt.Let,
+			Max:                        opt.Max,
+			MaxAwaitTime:               opt.MaxAwaitTime,
+			MaxTime:                    opt.MaxTime,
+			Min:                        opt.Min,
+			NoCursorTimeout:            opt.NoCursorTimeout,
+			OplogReplay:                opt.OplogReplay,
+			Projection:                 opt.Projection,
+			ReturnKey:                  opt.ReturnKey,
+			ServerAPIStrict:            opt.ServerAPIStrict,
+			ServerAPIDeprecationErrors: opt.ServerAPIDeprecationErrors,
+			ShowRecordID:               opt.ShowRecordID,
+			Skip:                       opt.Skip,
+			Snapshot:                   opt.Snapshot,
+			Sort:                       opt.Sort,
 		})
 	}
 	// Unconditionally send a limit to make sure only one document is returned and the cursor is not kept open
@@ -1450,6 +1683,15 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	findOpts = append(findOpts, options.Find().SetLimit(-1))
 
 	cursor, err := coll.find(ctx, filter, false, findOpts...)
+	if fo.StalenessFallback != nil && coll.noPrimarySelected(ctx, err) {
+		secondaryColl, cloneErr := coll.Clone(options.Collection().
+			SetReadPreference(readpref.Secondary(readpref.WithMaxStaleness(*fo.StalenessFallback))))
+		if cloneErr != nil {
+			err = cloneErr
+		} else {
+			cursor, err = secondaryColl.find(ctx, filter, false, findOpts...)
+		}
+	}
 	return &SingleResult{
 		ctx:      ctx,
 		cur:      cursor,
@@ -1459,6 +1701,20 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	}
 }
 
+// noPrimarySelected returns true if err is a server selection error for a read that had to be sent to the primary,
+// which means that the read can fall back to a secondary. A read whose Context is done does not fall back.
+func (coll *Collection) noPrimarySelected(ctx context.Context, err error) bool {
+	var sse topology.ServerSelectionError
+	if !errors.As(err, &sse) || ctx.Err() != nil {
+		return false
+	}
+	if coll.readPreference != nil && coll.readPreference.Mode() != readpref.PrimaryMode {
+		return false
+	}
+	sess := sessionFromContext(ctx)
+	return sess == nil || !sess.TransactionRunning()
+}
+
 func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAndModify) *SingleResult {
 	if ctx == nil {
 		ctx = context.Background()
@@ -1476,6 +1732,10 @@ func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAnd
 		return &SingleResult{err: err}
 	}
 
+	if err := coll.ensureCreated(ctx); err != nil {
+		return &SingleResult{err: err}
+	}
+
 	wc := coll.writeConcern
 	if sess.TransactionRunning() {
 		wc = nil
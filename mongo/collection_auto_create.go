@@ -0,0 +1,64 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// errCodeNamespaceExists is the server error code returned by the create command if the collection already exists.
+const errCodeNamespaceExists = 48
+
+// autoCreate tracks whether a Collection configured with CollectionOptions.AutoCreateOptions has been created. It is
+// shared by copies of the Collection so the collection is only created once.
+type autoCreate struct {
+	opts *options.CreateCollectionOptions
+
+	mu      sync.Mutex
+	created bool
+}
+
+func newAutoCreate(opts *options.CreateCollectionOptions) *autoCreate {
+	return &autoCreate{opts: opts}
+}
+
+// ensureCreated creates the collection with the configured AutoCreateOptions if it has not been created by this
+// Collection yet. Concurrent writes wait for the first one to create the collection so that none of them can
+// implicitly create it first. If the collection already exists, it is left unchanged. If creating the collection
+// fails, the error is returned and the next write tries again.
+//
+// The create command is run outside of any session in ctx because collections with options such as time-series
+// collections cannot be created in a transaction.
+func (coll *Collection) ensureCreated(ctx context.Context) error {
+	ac := coll.autoCreate
+	if ac == nil {
+		return nil
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if ac.created {
+		return nil
+	}
+
+	err := coll.db.CreateCollection(context.WithValue(ctx, sessionKey{}, nil), coll.name, ac.opts)
+	if err != nil && !isNamespaceExistsError(err) {
+		return err
+	}
+	ac.created = true
+	return nil
+}
+
+func isNamespaceExistsError(err error) bool {
+	var ce CommandError
+	return errors.As(err, &ce) && ce.Code == errCodeNamespaceExists
+}
@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// hintRegistry maps hint keys of filter shapes to the names of the indexes that should be hinted for them. It is shared by copies of
+// the Collection so hints registered on a Collection also apply to the Collections cloned from it.
+type hintRegistry struct {
+	mu    sync.RWMutex
+	hints map[string]string
+}
+
+func newHintRegistry() *hintRegistry {
+	return &hintRegistry{hints: make(map[string]string)}
+}
+
+// lookup returns the index name registered for the shape of filter, if any.
+func (hr *hintRegistry) lookup(filter bsoncore.Document) (string, bool) {
+	if hr == nil {
+		return "", false
+	}
+
+	hr.mu.RLock()
+	defer hr.mu.RUnlock()
+
+	if len(hr.hints) == 0 {
+		return "", false
+	}
+	elems, err := filter.Elements()
+	if err != nil {
+		return "", false
+	}
+	keys := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		keys = append(keys, elem.Key())
+	}
+	indexName, ok := hr.hints[hintKey(keys)]
+	return indexName, ok
+}
+
+// hintKey returns the key of the hints registered for filters with the given top-level keys. Keys are sorted so that
+// the hint key does not depend on their order. Unlike QueryShape, only the top-level keys are used, so operators and
+// nested fields do not affect which hint applies.
+func hintKey(keys []string) string {
+	sort.Strings(keys)
+	return strings.Join(keys, "\x00")
+}
+
+// RegisterHint registers indexName as the index to hint for Find, FindOne, and CountDocuments operations on the
+// collection whose filter has the same shape as shape. Two filters have the same shape if they have the same set of
+// top-level keys, regardless of the order of the keys and of their values. For example, the shape bson.D{{"a", 1},
+// {"b", 1}} matches the filters bson.D{{"b", "x"}, {"a", bson.D{{"$gt", 5}}}} and bson.M{"a": 2, "b": 3}, but not
+// bson.D{{"a", 1}} or bson.D{{"a.c", 1}, {"b", 1}}.
+//
+// The registered hint is only applied if the operation does not set the Hint option. Registering a hint for a shape
+// that already has one replaces it. Hints are shared with Collections created from this one using Clone.
+func (coll *Collection) RegisterHint(shape bson.D, indexName string) {
+	keys := make([]string, 0, len(shape))
+	for _, elem := range shape {
+		keys = append(keys, elem.Key)
+	}
+
+	coll.hints.mu.Lock()
+	defer coll.hints.mu.Unlock()
+
+	coll.hints.hints[hintKey(keys)] = indexName
+}
+
+// registeredHint returns the index name registered for the shape of filter, or nil if there is none.
+func (coll *Collection) registeredHint(filter bsoncore.Document) interface{} {
+	if indexName, ok := coll.hints.lookup(filter); ok {
+		return indexName
+	}
+	return nil
+}
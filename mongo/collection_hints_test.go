@@ -0,0 +1,64 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestCollectionRegisterHint(t *testing.T) {
+	coll := setupColl("hints")
+	coll.RegisterHint(bson.D{{"a", 1}, {"b", 1}}, "a_1_b_1")
+	coll.RegisterHint(bson.D{{"c", 1}}, "c_1")
+
+	testCases := []struct {
+		name     string
+		filter   interface{}
+		expected interface{}
+	}{
+		{"same keys", bson.D{{"a", 1}, {"b", 2}}, "a_1_b_1"},
+		{"different key order", bson.D{{"b", 1}, {"a", 2}}, "a_1_b_1"},
+		{"operators", bson.D{{"a", bson.D{{"$gt", 5}}}, {"b", "x"}}, "a_1_b_1"},
+		{"map filter", bson.M{"a": 1, "b": 2}, "a_1_b_1"},
+		{"single key", bson.D{{"c", true}}, "c_1"},
+		{"subset of keys", bson.D{{"a", 1}}, nil},
+		{"superset of keys", bson.D{{"a", 1}, {"b", 1}, {"c", 1}}, nil},
+		{"dotted key", bson.D{{"a.x", 1}, {"b", 1}}, nil},
+		{"empty filter", bson.D{}, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := marshal(tc.filter, nil, nil)
+			assert.Nil(t, err, "marshal error: %v", err)
+
+			hint := coll.registeredHint(f)
+			assert.Equal(t, tc.expected, hint, "expected hint %v, got %v", tc.expected, hint)
+		})
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		coll.RegisterHint(bson.D{{"c", 1}}, "c_-1")
+
+		f, err := marshal(bson.D{{"c", 1}}, nil, nil)
+		assert.Nil(t, err, "marshal error: %v", err)
+		hint := coll.registeredHint(f)
+		assert.Equal(t, "c_-1", hint, "expected hint %v, got %v", "c_-1", hint)
+	})
+	t.Run("shared with clones", func(t *testing.T) {
+		clone, err := coll.Clone()
+		assert.Nil(t, err, "Clone error: %v", err)
+		clone.RegisterHint(bson.D{{"d", 1}}, "d_1")
+
+		f, err := marshal(bson.D{{"d", 1}}, nil, nil)
+		assert.Nil(t, err, "marshal error: %v", err)
+		hint := coll.registeredHint(f)
+		assert.Equal(t, "d_1", hint, "expected hint %v, got %v", "d_1", hint)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// FindOneAndReplaceBoth executes a findAndModify command to replace at most one document in the collection and
+// returns both the document as it was before the replacement and the document as it is after the replacement.
+//
+// The filter parameter must be a document containing query operators and can be used to select the document to be
+// replaced. It cannot be nil. If the filter does not match any documents, the error ErrNoDocuments is returned.
+//
+// The replacement parameter must be a document that will be used to replace the selected document. It cannot be nil
+// and cannot contain any update operators (https://www.mongodb.com/docs/manual/reference/operator/update/).
+//
+// The server only returns one image of the document, so the command is run with the ReturnDocument option set to
+// options.Before and the post-image is built from the replacement: it is the replacement document with the _id field
+// of the pre-image as its first field, which is the document the server stores. Because both images come from a
+// single atomic command, no other write can be applied to the document between them, unlike a separate read followed
+// by a replace. The post-image reflects the replacement as sent to the server, so it does not include changes made to
+// the document by later writes.
+//
+// The opts parameter can be used to specify options for the operation (see the options.FindOneAndReplaceOptions
+// documentation). The ReturnDocument option is ignored, and the Upsert and Projection options are not supported
+// because the post-image could not be built from the replacement.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/findAndModify/.
+func (coll *Collection) FindOneAndReplaceBoth(ctx context.Context, filter interface{}, replacement interface{},
+	opts ...*options.FindOneAndReplaceOptions) (before, after bson.Raw, err error) {
+
+	fo := options.MergeFindOneAndReplaceOptions(opts...)
+	if fo.Upsert != nil && *fo.Upsert {
+		return nil, nil, errors.New("upsert is not supported when returning both images")
+	}
+	if fo.Projection != nil {
+		return nil, nil, errors.New("projection is not supported when returning both images")
+	}
+
+	r, err := marshal(replacement, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts = append(opts, options.FindOneAndReplace().SetReturnDocument(options.Before))
+	before, err = coll.FindOneAndReplace(ctx, filter, bson.Raw(r), opts...).Raw()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := before.LookupErr("_id")
+	if err != nil {
+		return nil, nil, errors.New("pre-image does not contain an _id field")
+	}
+	return before, postImage(r, bsoncore.Value{Type: id.Type, Data: id.Value}), nil
+}
+
+// postImage returns the document stored by the server when a document with the given _id is replaced by replacement.
+// The server always stores the _id field first.
+func postImage(replacement bsoncore.Document, id bsoncore.Value) bson.Raw {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	dst = bsoncore.AppendValueElement(dst, "_id", id)
+	elems, _ := replacement.Elements()
+	for _, elem := range elems {
+		if elem.Key() != "_id" {
+			dst = append(dst, elem...)
+		}
+	}
+	dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+	return bson.Raw(dst)
+}
@@ -0,0 +1,186 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollStats contains the storage statistics of a collection returned by Collection.Stats. Statistics that the server
+// does not report, e.g. because of its version or storage engine, are zero. Sizes are in bytes unless the Scale option
+// is used.
+type CollStats struct {
+	// Namespace is the namespace of the collection in the form "database.collection".
+	Namespace string
+
+	// Shards is the number of shards that reported statistics. It is 1 for a collection that is not sharded.
+	Shards int
+
+	// Count is the number of documents in the collection.
+	Count int64
+
+	// Size is the total uncompressed size of the documents in the collection.
+	Size int64
+
+	// AvgObjSize is the average uncompressed size of a document in the collection in bytes. It is never scaled.
+	AvgObjSize int64
+
+	// StorageSize is the amount of storage allocated for the documents in the collection, which may be smaller than
+	// Size if the documents are compressed.
+	StorageSize int64
+
+	// FreeStorageSize is the amount of storage allocated for the documents in the collection that can be reused.
+	FreeStorageSize int64
+
+	// Capped is true if the collection is a capped collection.
+	Capped bool
+
+	// NumIndexes is the number of indexes on the collection.
+	NumIndexes int
+
+	// TotalIndexSize is the total size of all indexes on the collection.
+	TotalIndexSize int64
+
+	// TotalSize is the sum of StorageSize and TotalIndexSize.
+	TotalSize int64
+
+	// IndexSizes maps the name of each index to its size.
+	IndexSizes map[string]int64
+
+	// WiredTigerCache contains statistics about the collection's use of the WiredTiger cache. It is nil if the server
+	// does not use the WiredTiger storage engine.
+	WiredTigerCache *WiredTigerCacheStats
+
+	// dataBytes is the total uncompressed size of the documents in bytes, which AvgObjSize is computed from because
+	// Size is scaled.
+	dataBytes int64
+}
+
+// WiredTigerCacheStats contains statistics about a collection's use of the WiredTiger cache. Unlike the sizes in
+// CollStats, the values are never scaled.
+type WiredTigerCacheStats struct {
+	// BytesInCache is the size of the collection's data currently in the cache.
+	BytesInCache int64
+
+	// BytesReadIntoCache is the number of bytes read into the cache from disk.
+	BytesReadIntoCache int64
+
+	// BytesWrittenFromCache is the number of bytes written from the cache to disk.
+	BytesWrittenFromCache int64
+
+	// DirtyBytesInCache is the size of the modified data in the cache that has not been written to disk.
+	DirtyBytesInCache int64
+
+	// PagesReadIntoCache is the number of pages read into the cache from disk.
+	PagesReadIntoCache int64
+
+	// PagesWrittenFromCache is the number of pages written from the cache to disk.
+	PagesWrittenFromCache int64
+}
+
+// Stats returns the storage statistics of the collection by running an aggregation with the $collStats stage. For a
+// sharded collection, the statistics of all shards are summed. The $collStats stage requires MongoDB 3.4 or later.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CollStatsOptions
+// documentation).
+//
+// For more information about the $collStats stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/collStats/.
+func (coll *Collection) Stats(ctx context.Context, opts ...*options.CollStatsOptions) (*CollStats, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	so := options.MergeCollStatsOptions(opts...)
+	storageStats := bson.D{}
+	if so.Scale != nil {
+		storageStats = append(storageStats, bson.E{Key: "scale", Value: *so.Scale})
+	}
+	aggOpts := options.Aggregate()
+	if so.Comment != nil {
+		aggOpts.SetComment(*so.Comment)
+	}
+
+	cursor, err := coll.Aggregate(ctx, Pipeline{{{"$collStats", bson.D{{"storageStats", storageStats}}}}}, aggOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := &CollStats{
+		Namespace:  coll.db.Name() + "." + coll.Name(),
+		IndexSizes: make(map[string]int64),
+	}
+	for cursor.Next(ctx) {
+		storageStats, _ := cursor.Current.Lookup("storageStats").DocumentOK()
+		stats.add(storageStats)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// add adds the statistics of a single shard reported in the storageStats document of a $collStats result to cs.
+// Missing or non-numeric values are treated as zero.
+func (cs *CollStats) add(storageStats bson.Raw) {
+	num := func(doc bson.Raw, key string) int64 {
+		n, _ := doc.Lookup(key).AsInt64OK()
+		return n
+	}
+
+	cs.Shards++
+	count := num(storageStats, "count")
+	cs.Count += count
+	cs.Size += num(storageStats, "size")
+	// Unlike size, avgObjSize is not scaled, so the size of the documents in bytes is derived from it.
+	cs.dataBytes += num(storageStats, "avgObjSize") * count
+	if cs.Count > 0 {
+		cs.AvgObjSize = cs.dataBytes / cs.Count
+	}
+	cs.StorageSize += num(storageStats, "storageSize")
+	cs.FreeStorageSize += num(storageStats, "freeStorageSize")
+	cs.TotalIndexSize += num(storageStats, "totalIndexSize")
+	if total, ok := storageStats.Lookup("totalSize").AsInt64OK(); ok {
+		cs.TotalSize += total
+	} else {
+		// Servers before 4.4 do not report totalSize.
+		cs.TotalSize += num(storageStats, "storageSize") + num(storageStats, "totalIndexSize")
+	}
+	if capped, ok := storageStats.Lookup("capped").BooleanOK(); ok && capped {
+		cs.Capped = true
+	}
+
+	indexSizes, _ := storageStats.Lookup("indexSizes").DocumentOK()
+	elems, _ := indexSizes.Elements()
+	for _, elem := range elems {
+		size, _ := elem.Value().AsInt64OK()
+		cs.IndexSizes[elem.Key()] += size
+	}
+	// The number of indexes is the same on every shard.
+	if n := int(num(storageStats, "nindexes")); n > cs.NumIndexes {
+		cs.NumIndexes = n
+	}
+
+	cache, ok := storageStats.Lookup("wiredTiger", "cache").DocumentOK()
+	if !ok {
+		return
+	}
+	if cs.WiredTigerCache == nil {
+		cs.WiredTigerCache = &WiredTigerCacheStats{}
+	}
+	wt := cs.WiredTigerCache
+	wt.BytesInCache += num(cache, "bytes currently in the cache")
+	wt.BytesReadIntoCache += num(cache, "bytes read into cache")
+	wt.BytesWrittenFromCache += num(cache, "bytes written from cache")
+	wt.DirtyBytesInCache += num(cache, "tracked dirty bytes in the cache")
+	wt.PagesReadIntoCache += num(cache, "pages read into cache")
+	wt.PagesWrittenFromCache += num(cache, "pages written from cache")
+}
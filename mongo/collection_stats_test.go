@@ -0,0 +1,129 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestCollStats(t *testing.T) {
+	t.Parallel()
+
+	mustMarshal := func(t *testing.T, val interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(val)
+		require.NoError(t, err, "Marshal error")
+		return b
+	}
+	newStats := func() *CollStats {
+		return &CollStats{IndexSizes: make(map[string]int64)}
+	}
+
+	t.Run("single shard", func(t *testing.T) {
+		t.Parallel()
+
+		stats := newStats()
+		stats.add(mustMarshal(t, bson.D{
+			{"size", int32(1000)},
+			{"count", int32(10)},
+			{"avgObjSize", int32(100)},
+			{"storageSize", int64(4096)},
+			{"freeStorageSize", int32(0)},
+			{"capped", false},
+			{"wiredTiger", bson.D{{"cache", bson.D{
+				{"bytes currently in the cache", int32(2048)},
+				{"bytes read into cache", 512.0},
+				{"bytes written from cache", int64(1024)},
+				{"tracked dirty bytes in the cache", int32(0)},
+				{"pages read into cache", int32(1)},
+				{"pages written from cache", int32(2)},
+			}}}},
+			{"nindexes", int32(2)},
+			{"indexSizes", bson.D{{"_id_", int32(4096)}, {"x_1", int32(8192)}}},
+			{"totalIndexSize", int32(12288)},
+			{"totalSize", int64(16384)},
+		}))
+
+		want := &CollStats{
+			Shards:         1,
+			Count:          10,
+			Size:           1000,
+			AvgObjSize:     100,
+			StorageSize:    4096,
+			NumIndexes:     2,
+			TotalIndexSize: 12288,
+			TotalSize:      16384,
+			IndexSizes:     map[string]int64{"_id_": 4096, "x_1": 8192},
+			WiredTigerCache: &WiredTigerCacheStats{
+				BytesInCache:          2048,
+				BytesReadIntoCache:    512,
+				BytesWrittenFromCache: 1024,
+				PagesReadIntoCache:    1,
+				PagesWrittenFromCache: 2,
+			},
+			dataBytes: 1000,
+		}
+		assert.Equal(t, want, stats, "expected stats %+v, got %+v", want, stats)
+	})
+	t.Run("scaled", func(t *testing.T) {
+		t.Parallel()
+
+		stats := newStats()
+		stats.add(mustMarshal(t, bson.D{
+			{"size", int32(1)},
+			{"count", int32(20)},
+			{"avgObjSize", int32(100)},
+			{"storageSize", int32(4)},
+		}))
+
+		assert.Equal(t, int64(1), stats.Size, "expected scaled size 1, got %v", stats.Size)
+		assert.Equal(t, int64(100), stats.AvgObjSize, "expected unscaled average size 100, got %v", stats.AvgObjSize)
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+
+		stats := newStats()
+		stats.add(mustMarshal(t, bson.D{{"count", int32(3)}, {"size", "not a number"}}))
+
+		want := &CollStats{Shards: 1, Count: 3, IndexSizes: map[string]int64{}}
+		assert.Equal(t, want, stats, "expected stats %+v, got %+v", want, stats)
+	})
+	t.Run("shards are summed", func(t *testing.T) {
+		t.Parallel()
+
+		stats := newStats()
+		for i := 0; i < 2; i++ {
+			stats.add(mustMarshal(t, bson.D{
+				{"count", int32(5 * (i + 1))},
+				{"size", int32(500)},
+				{"avgObjSize", int32(100 * (i + 1))},
+				{"storageSize", int32(1000)},
+				{"nindexes", int32(1)},
+				{"indexSizes", bson.D{{"_id_", int32(100)}}},
+				{"totalIndexSize", int32(100)},
+				{"capped", true},
+			}))
+		}
+
+		assert.Equal(t, 2, stats.Shards, "expected 2 shards, got %v", stats.Shards)
+		assert.Equal(t, int64(15), stats.Count, "expected count 15, got %v", stats.Count)
+		// 5 documents of 100 bytes and 10 documents of 200 bytes.
+		assert.Equal(t, int64(166), stats.AvgObjSize, "expected average size 166, got %v", stats.AvgObjSize)
+		assert.Equal(t, int64(1000), stats.Size, "expected size 1000, got %v", stats.Size)
+		assert.Equal(t, 1, stats.NumIndexes, "expected 1 index, got %v", stats.NumIndexes)
+		assert.Equal(t, int64(200), stats.IndexSizes["_id_"], "expected _id_ index size 200, got %v",
+			stats.IndexSizes["_id_"])
+		assert.Equal(t, int64(2200), stats.TotalSize, "expected total size 2200, got %v", stats.TotalSize)
+		assert.True(t, stats.Capped, "expected collection to be capped")
+		assert.Nil(t, stats.WiredTigerCache, "expected no WiredTiger cache stats, got %v", stats.WiredTigerCache)
+	})
+}
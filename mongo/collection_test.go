@@ -7,15 +7,19 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 const (
@@ -75,6 +79,55 @@ func TestCollection(t *testing.T) {
 		}
 		compareColls(t, expected, coll)
 	})
+	t.Run("option precedence", func(t *testing.T) {
+		clientRP, dbRP, collRP := readpref.Primary(), readpref.Secondary(), readpref.Nearest()
+		clientRC, dbRC, collRC := readconcern.Local(), readconcern.Majority(), readconcern.Linearizable()
+		clientWC := writeconcern.New(writeconcern.W(1))
+		dbWC := writeconcern.New(writeconcern.W(2))
+		collWC := writeconcern.New(writeconcern.W(3))
+
+		client := setupClient(options.Client().
+			SetReadPreference(clientRP).SetReadConcern(clientRC).SetWriteConcern(clientWC))
+
+		// Each level sets only some options, so every option is resolved from a different level.
+		testCases := []struct {
+			name     string
+			dbOpts   *options.DatabaseOptions
+			collOpts *options.CollectionOptions
+			expected *Collection
+		}{
+			{
+				"client",
+				options.Database(),
+				options.Collection(),
+				&Collection{readPreference: clientRP, readConcern: clientRC, writeConcern: clientWC},
+			},
+			{
+				"database overrides client",
+				options.Database().SetReadPreference(dbRP).SetReadConcern(dbRC).SetWriteConcern(dbWC),
+				options.Collection(),
+				&Collection{readPreference: dbRP, readConcern: dbRC, writeConcern: dbWC},
+			},
+			{
+				"collection overrides database",
+				options.Database().SetReadPreference(dbRP).SetReadConcern(dbRC).SetWriteConcern(dbWC),
+				options.Collection().SetReadPreference(collRP).SetReadConcern(collRC).SetWriteConcern(collWC),
+				&Collection{readPreference: collRP, readConcern: collRC, writeConcern: collWC},
+			},
+			{
+				"mixed levels",
+				options.Database().SetReadConcern(dbRC),
+				options.Collection().SetWriteConcern(collWC),
+				&Collection{readPreference: clientRP, readConcern: dbRC, writeConcern: collWC},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				coll := client.Database("foo", tc.dbOpts).Collection("bar", tc.collOpts)
+				compareColls(t, tc.expected, coll)
+			})
+		}
+	})
 	t.Run("replace topology error", func(t *testing.T) {
 		coll := setupColl("foo")
 		doc := bson.D{}
@@ -128,6 +181,80 @@ func TestCollection(t *testing.T) {
 		err = coll.FindOneAndUpdate(bgCtx, doc, update).Err()
 		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
 	})
+	t.Run("auto create", func(t *testing.T) {
+		createOpts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+		coll := setupColl("foo", options.Collection().SetAutoCreateOptions(createOpts))
+		require.NotNil(t, coll.autoCreate, "expected auto create state to be set")
+
+		_, err := coll.InsertOne(bgCtx, bson.D{})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		assert.False(t, coll.autoCreate.created, "expected collection not to be marked created after an error")
+
+		clone, err := coll.Clone()
+		require.NoError(t, err, "Clone error")
+		assert.True(t, coll.autoCreate == clone.autoCreate, "expected clone to share auto create state")
+
+		clone, err = coll.Clone(options.Collection().SetAutoCreateOptions(options.CreateCollection()))
+		require.NoError(t, err, "Clone error")
+		assert.True(t, coll.autoCreate != clone.autoCreate, "expected clone to have new auto create state")
+
+		assert.Nil(t, setupColl("foo").autoCreate, "expected no auto create state by default")
+	})
+	t.Run("guard empty filter", func(t *testing.T) {
+		guarded := setupClient(options.Client().ApplyURI("mongodb://localhost:27017").SetGuardEmptyFilter(true))
+		coll := guarded.Database(testDbName).Collection("foo")
+		update := bson.D{{"$set", bson.D{{"x", 1}}}}
+
+		_, err := coll.DeleteMany(bgCtx, bson.D{})
+		want := EmptyFilterError{Operation: "DeleteMany"}
+		assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+		_, err = coll.UpdateMany(bgCtx, bson.M{}, update)
+		want = EmptyFilterError{Operation: "UpdateMany"}
+		assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+		_, err = coll.DeleteMany(bgCtx, bson.D{}, options.Delete().SetAllowEmptyFilter(false))
+		assert.True(t, errors.As(err, &EmptyFilterError{}), "expected EmptyFilterError, got %v", err)
+
+		// Operations that are explicitly allowed, have a non-empty filter, or affect at most one document are not
+		// rejected and fail because the client is not connected.
+		_, err = coll.DeleteMany(bgCtx, bson.D{}, options.Delete().SetAllowEmptyFilter(true))
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = coll.UpdateMany(bgCtx, bson.D{}, update, options.Update().SetAllowEmptyFilter(true))
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = coll.DeleteMany(bgCtx, bson.D{{"x", 1}})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = coll.DeleteOne(bgCtx, bson.D{})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = coll.UpdateOne(bgCtx, bson.D{}, update)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		// Empty filters are allowed by default.
+		_, err = setupColl("foo").DeleteMany(bgCtx, bson.D{})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("staleness fallback", func(t *testing.T) {
+		coll := setupColl("foo")
+
+		err := coll.FindOne(bgCtx, bson.D{}, options.FindOne().SetStalenessFallback(time.Minute)).Err()
+		assert.NotNil(t, err, "expected error for a staleness fallback under 90s, got nil")
+
+		err = coll.FindOne(bgCtx, bson.D{}, options.FindOne().SetStalenessFallback(90*time.Second)).Err()
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		// A read falls back only if server selection timed out while its Context was still live.
+		selectionErr := topology.ServerSelectionError{Wrapped: errors.New("server selection timeout")}
+		assert.True(t, coll.noPrimarySelected(bgCtx, selectionErr), "expected the read to fall back")
+
+		ctx, cancel := context.WithTimeout(bgCtx, time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+		assert.False(t, coll.noPrimarySelected(ctx, selectionErr), "expected no fallback after the deadline passed")
+	})
 	t.Run("database accessor", func(t *testing.T) {
 		coll := setupColl("bar")
 		dbName := coll.Database().Name()
@@ -170,6 +297,12 @@ func TestCollection(t *testing.T) {
 		_, err = coll.ReplaceOne(bgCtx, doc, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
+		_, err = coll.ReplaceOneVersioned(bgCtx, nil, doc, "version", 1)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+		_, err = coll.ReplaceOneVersioned(bgCtx, doc, nil, "version", 1)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
 		_, err = coll.CountDocuments(bgCtx, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
@@ -191,6 +324,12 @@ func TestCollection(t *testing.T) {
 		err = coll.FindOneAndReplace(bgCtx, doc, nil).Err()
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
+		_, _, err = coll.FindOneAndReplaceBoth(bgCtx, nil, doc)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+		_, _, err = coll.FindOneAndReplaceBoth(bgCtx, doc, nil)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
 		err = coll.FindOneAndUpdate(bgCtx, nil, doc).Err()
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
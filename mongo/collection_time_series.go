@@ -0,0 +1,71 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// IsTimeSeries executes a listCollections command to determine if the collection is a time-series collection. If it
+// is, the time-series options of the collection are also returned. If the collection does not exist, IsTimeSeries
+// returns false and a nil error.
+//
+// For more information about time-series collections, see https://www.mongodb.com/docs/manual/core/timeseries-collections/.
+func (coll *Collection) IsTimeSeries(ctx context.Context) (bool, *TimeSeriesInfo, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	collSpecs, err := coll.db.ListCollectionSpecifications(ctx, bson.D{{"name", coll.name}})
+	if err != nil {
+		return false, nil, err
+	}
+	if len(collSpecs) == 0 {
+		return false, nil, nil
+	}
+	if len(collSpecs) > 1 {
+		return false, nil, fmt.Errorf("expected 1 or 0 results from listCollections, got %v", len(collSpecs))
+	}
+	if collSpecs[0].Type != "timeseries" {
+		return false, nil, nil
+	}
+
+	info, err := decodeTimeSeriesInfo(collSpecs[0].Options)
+	if err != nil {
+		return false, nil, fmt.Errorf("error decoding time-series options of collection %v: %w", coll.name, err)
+	}
+	return true, info, nil
+}
+
+// decodeTimeSeriesInfo decodes the time-series options from the options document of a time-series collection.
+func decodeTimeSeriesInfo(collOpts bson.Raw) (*TimeSeriesInfo, error) {
+	var opts struct {
+		TimeSeries struct {
+			TimeField             string `bson:"timeField"`
+			MetaField             string `bson:"metaField"`
+			Granularity           string `bson:"granularity"`
+			BucketMaxSpanSeconds  int64  `bson:"bucketMaxSpanSeconds"`
+			BucketRoundingSeconds int64  `bson:"bucketRoundingSeconds"`
+		} `bson:"timeseries"`
+	}
+	if err := bson.Unmarshal(collOpts, &opts); err != nil {
+		return nil, err
+	}
+
+	ts := opts.TimeSeries
+	return &TimeSeriesInfo{
+		TimeField:      ts.TimeField,
+		MetaField:      ts.MetaField,
+		Granularity:    ts.Granularity,
+		BucketMaxSpan:  time.Duration(ts.BucketMaxSpanSeconds) * time.Second,
+		BucketRounding: time.Duration(ts.BucketRoundingSeconds) * time.Second,
+	}, nil
+}
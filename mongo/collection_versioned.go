@@ -0,0 +1,99 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ErrVersionConflict is returned by Collection.ReplaceOneVersioned if a document matches the filter but its version
+// field does not have the expected value.
+var ErrVersionConflict = errors.New("document version does not match the expected version")
+
+// ReplaceOneVersioned executes an update command to replace at most one document in the collection if the document's
+// version field has the expected value. This can be used to implement optimistic concurrency control.
+//
+// The filter parameter must be a document containing query operators and can be used to select the document to be
+// replaced. It cannot be nil. The filter is combined with a condition that the versionField field of the document is
+// equal to expected.
+//
+// The replacement parameter must be a document that will be used to replace the selected document. It cannot be nil
+// and cannot contain any update operators. Any versionField field in the replacement is overwritten with expected + 1.
+//
+// The versionField parameter must be the name of a top-level field. If a document matches the filter but its version
+// differs from expected, the replacement is not applied and ErrVersionConflict is returned along with an UpdateResult
+// with a MatchedCount of 0. If no document matches the filter, the operation succeeds with a MatchedCount of 0.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ReplaceOptions documentation).
+// The Upsert option is not supported.
+func (coll *Collection) ReplaceOneVersioned(ctx context.Context, filter interface{}, replacement interface{},
+	versionField string, expected int64, opts ...*options.ReplaceOptions) (*UpdateResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if versionField == "" || strings.ContainsAny(versionField, ".$") {
+		return nil, errors.New("version field must be the name of a top-level field")
+	}
+	if ro := options.MergeReplaceOptions(opts...); ro.Upsert != nil && *ro.Upsert {
+		return nil, errors.New("upsert is not supported with a version check")
+	}
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := marshal(replacement, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	versionedFilter := bson.D{{"$and", bson.A{bson.Raw(f), bson.D{{versionField, expected}}}}}
+	res, err := coll.ReplaceOne(ctx, versionedFilter, bson.Raw(setVersion(r, versionField, expected+1)), opts...)
+	if err != nil || res.MatchedCount > 0 {
+		return res, err
+	}
+
+	// Nothing matched, so check if that was caused by the version condition. Read from the primary so the document
+	// that was just missed by the replace is seen.
+	primaryColl, err := coll.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return res, err
+	}
+	err = primaryColl.FindOne(ctx, bson.Raw(f), options.FindOne().SetProjection(bson.D{{"_id", 1}})).Err()
+	switch {
+	case err == nil:
+		return res, ErrVersionConflict
+	case errors.Is(err, ErrNoDocuments):
+		return res, nil
+	default:
+		return res, err
+	}
+}
+
+// setVersion returns a copy of doc with the versionField field set to version.
+func setVersion(doc bsoncore.Document, versionField string, version int64) bsoncore.Document {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		if elem.Key() != versionField {
+			dst = append(dst, elem...)
+		}
+	}
+	dst = bsoncore.AppendInt64Element(dst, versionField, version)
+	dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+	return dst
+}
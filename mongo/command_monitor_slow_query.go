@@ -0,0 +1,91 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// slowQueryMonitor reports commands that take at least threshold to complete to handler.
+type slowQueryMonitor struct {
+	threshold time.Duration
+	handler   func(options.SlowQueryInfo)
+
+	// started maps the request ID of each in-progress command to its started event.
+	started sync.Map
+}
+
+// newSlowQueryCommandMonitor returns a CommandMonitor that calls handler for every command that takes at least
+// threshold to complete and forwards all events to monitor, which may be nil.
+func newSlowQueryCommandMonitor(
+	monitor *event.CommandMonitor,
+	threshold time.Duration,
+	handler func(options.SlowQueryInfo),
+) *event.CommandMonitor {
+	if monitor == nil {
+		monitor = &event.CommandMonitor{}
+	}
+	sqm := &slowQueryMonitor{threshold: threshold, handler: handler}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			sqm.started.Store(evt.RequestID, evt)
+			if monitor.Started != nil {
+				monitor.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			sqm.finished(evt.CommandFinishedEvent, "")
+			if monitor.Succeeded != nil {
+				monitor.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			sqm.finished(evt.CommandFinishedEvent, evt.Failure)
+			if monitor.Failed != nil {
+				monitor.Failed(ctx, evt)
+			}
+		},
+	}
+}
+
+// finished removes the started event for the command and calls the handler if the command was slow.
+func (sqm *slowQueryMonitor) finished(evt event.CommandFinishedEvent, failure string) {
+	v, ok := sqm.started.LoadAndDelete(evt.RequestID)
+	if !ok || evt.Duration < sqm.threshold {
+		return
+	}
+	started := v.(*event.CommandStartedEvent)
+
+	sqm.handler(options.SlowQueryInfo{
+		CommandName:  evt.CommandName,
+		Namespace:    commandNamespace(started.DatabaseName, started.CommandName, started.Command),
+		Duration:     evt.Duration,
+		Command:      started.Command,
+		ConnectionID: evt.ConnectionID,
+		Failure:      failure,
+	})
+}
+
+// commandNamespace returns the namespace targeted by the command. Most collection commands store the collection name
+// as the value of the command name, except getMore, which stores it in the "collection" field.
+func commandNamespace(db, name string, cmd bson.Raw) string {
+	key := name
+	if name == "getMore" {
+		key = "collection"
+	}
+	if coll, ok := cmd.Lookup(key).StringValueOK(); ok && coll != "" {
+		return db + "." + coll
+	}
+	return db
+}
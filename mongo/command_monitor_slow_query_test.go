@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestSlowQueryCommandMonitor(t *testing.T) {
+	const threshold = 100 * time.Millisecond
+
+	marshalCmd := func(t *testing.T, cmd bson.D) bson.Raw {
+		t.Helper()
+
+		raw, err := bson.Marshal(cmd)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return raw
+	}
+	runCommand := func(monitor *event.CommandMonitor, requestID int64, cmd bson.Raw, duration time.Duration, failure string) {
+		name := cmd.Index(0).Key()
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command:      cmd,
+			DatabaseName: "db",
+			CommandName:  name,
+			RequestID:    requestID,
+			ConnectionID: "localhost:27017[-1]",
+		})
+		finished := event.CommandFinishedEvent{
+			Duration:     duration,
+			CommandName:  name,
+			DatabaseName: "db",
+			RequestID:    requestID,
+			ConnectionID: "localhost:27017[-1]",
+		}
+		if failure != "" {
+			monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: failure})
+			return
+		}
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+	}
+
+	t.Run("slow commands are reported", func(t *testing.T) {
+		var infos []options.SlowQueryInfo
+		monitor := newSlowQueryCommandMonitor(nil, threshold, func(info options.SlowQueryInfo) {
+			infos = append(infos, info)
+		})
+
+		find := marshalCmd(t, bson.D{{"find", "coll"}, {"filter", bson.D{{"x", 1}}}})
+		runCommand(monitor, 1, find, threshold-time.Millisecond, "")
+		assert.Equal(t, 0, len(infos), "expected no slow queries, got %v", infos)
+
+		runCommand(monitor, 2, find, 2*threshold, "")
+		runCommand(monitor, 3, marshalCmd(t, bson.D{{"getMore", int64(1)}, {"collection", "coll"}}), threshold, "")
+		runCommand(monitor, 4, marshalCmd(t, bson.D{{"ping", 1}}), threshold, "boom")
+
+		want := []options.SlowQueryInfo{
+			{
+				CommandName:  "find",
+				Namespace:    "db.coll",
+				Duration:     2 * threshold,
+				Command:      find,
+				ConnectionID: "localhost:27017[-1]",
+			},
+			{
+				CommandName:  "getMore",
+				Namespace:    "db.coll",
+				Duration:     threshold,
+				Command:      marshalCmd(t, bson.D{{"getMore", int64(1)}, {"collection", "coll"}}),
+				ConnectionID: "localhost:27017[-1]",
+			},
+			{
+				CommandName:  "ping",
+				Namespace:    "db",
+				Duration:     threshold,
+				Command:      marshalCmd(t, bson.D{{"ping", 1}}),
+				ConnectionID: "localhost:27017[-1]",
+				Failure:      "boom",
+			},
+		}
+		assert.Equal(t, want, infos, "expected slow queries %v, got %v", want, infos)
+	})
+	t.Run("events are forwarded", func(t *testing.T) {
+		var started, succeeded, failed int
+		var reported int
+		monitor := newSlowQueryCommandMonitor(&event.CommandMonitor{
+			Started:   func(context.Context, *event.CommandStartedEvent) { started++ },
+			Succeeded: func(context.Context, *event.CommandSucceededEvent) { succeeded++ },
+			Failed:    func(context.Context, *event.CommandFailedEvent) { failed++ },
+		}, threshold, func(options.SlowQueryInfo) { reported++ })
+
+		ping := marshalCmd(t, bson.D{{"ping", 1}})
+		runCommand(monitor, 1, ping, threshold, "")
+		runCommand(monitor, 2, ping, time.Millisecond, "boom")
+
+		assert.Equal(t, 2, started, "expected 2 started events, got %d", started)
+		assert.Equal(t, 1, succeeded, "expected 1 succeeded event, got %d", succeeded)
+		assert.Equal(t, 1, failed, "expected 1 failed event, got %d", failed)
+		assert.Equal(t, 1, reported, "expected 1 slow query, got %d", reported)
+	})
+	t.Run("NewClient", func(t *testing.T) {
+		monitor := &event.CommandMonitor{}
+
+		client, err := NewClient(options.Client().SetMonitor(monitor))
+		assert.Nil(t, err, "NewClient error: %v", err)
+		assert.True(t, client.monitor == monitor, "expected monitor to be used as-is without a slow query handler")
+
+		client, err = NewClient(options.Client().SetSlowQueryThreshold(threshold, func(options.SlowQueryInfo) {}))
+		assert.Nil(t, err, "NewClient error: %v", err)
+		assert.NotNil(t, client.monitor, "expected a monitor to be set")
+
+		client, err = NewClient(options.Client().SetMonitor(monitor).
+			SetSlowQueryThreshold(threshold, func(options.SlowQueryInfo) {}))
+		assert.Nil(t, err, "NewClient error: %v", err)
+		assert.True(t, client.monitor != monitor, "expected monitor to be wrapped")
+	})
+}
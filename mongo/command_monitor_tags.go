@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// newTaggingCommandMonitor returns a CommandMonitor that sets the Tags field of every event to the values returned by
+// extract for the operation's Context and then forwards the event to monitor. Callbacks that are nil in monitor are
+// left nil so the operation layer can skip building events that would not be published.
+func newTaggingCommandMonitor(
+	monitor *event.CommandMonitor,
+	extract func(context.Context) map[string]string,
+) *event.CommandMonitor {
+	tagged := &event.CommandMonitor{}
+
+	if monitor.Started != nil {
+		tagged.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+			evt.Tags = copyTags(extract(ctx))
+			monitor.Started(ctx, evt)
+		}
+	}
+	if monitor.Succeeded != nil {
+		tagged.Succeeded = func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			evt.Tags = copyTags(extract(ctx))
+			monitor.Succeeded(ctx, evt)
+		}
+	}
+	if monitor.Failed != nil {
+		tagged.Failed = func(ctx context.Context, evt *event.CommandFailedEvent) {
+			evt.Tags = copyTags(extract(ctx))
+			monitor.Failed(ctx, evt)
+		}
+	}
+	return tagged
+}
+
+// copyTags returns a copy of tags so that events do not share a map with the extractor or with each other.
+func copyTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
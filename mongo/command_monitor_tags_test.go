@@ -0,0 +1,75 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type requestIDKey struct{}
+
+func TestTaggingCommandMonitor(t *testing.T) {
+	extract := func(ctx context.Context) map[string]string {
+		id, ok := ctx.Value(requestIDKey{}).(string)
+		if !ok {
+			return nil
+		}
+		return map[string]string{"requestID": id}
+	}
+
+	t.Run("events carry tags", func(t *testing.T) {
+		var started *event.CommandStartedEvent
+		var succeeded *event.CommandSucceededEvent
+		var failed *event.CommandFailedEvent
+		monitor := newTaggingCommandMonitor(&event.CommandMonitor{
+			Started:   func(_ context.Context, evt *event.CommandStartedEvent) { started = evt },
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) { succeeded = evt },
+			Failed:    func(_ context.Context, evt *event.CommandFailedEvent) { failed = evt },
+		}, extract)
+
+		ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+		monitor.Started(ctx, &event.CommandStartedEvent{})
+		monitor.Succeeded(ctx, &event.CommandSucceededEvent{})
+		monitor.Failed(ctx, &event.CommandFailedEvent{})
+
+		want := map[string]string{"requestID": "abc"}
+		assert.Equal(t, want, started.Tags, "expected started tags %v, got %v", want, started.Tags)
+		assert.Equal(t, want, succeeded.Tags, "expected succeeded tags %v, got %v", want, succeeded.Tags)
+		assert.Equal(t, want, failed.Tags, "expected failed tags %v, got %v", want, failed.Tags)
+
+		started.Tags["requestID"] = "changed"
+		assert.Equal(t, "abc", succeeded.Tags["requestID"], "expected events not to share tags")
+
+		monitor.Started(context.Background(), &event.CommandStartedEvent{})
+		assert.Nil(t, started.Tags, "expected nil tags, got %v", started.Tags)
+	})
+	t.Run("nil callbacks are preserved", func(t *testing.T) {
+		monitor := newTaggingCommandMonitor(&event.CommandMonitor{
+			Started: func(context.Context, *event.CommandStartedEvent) {},
+		}, extract)
+
+		assert.NotNil(t, monitor.Started, "expected Started to be set")
+		assert.Nil(t, monitor.Succeeded, "expected Succeeded to be nil")
+		assert.Nil(t, monitor.Failed, "expected Failed to be nil")
+	})
+	t.Run("NewClient", func(t *testing.T) {
+		monitor := &event.CommandMonitor{}
+
+		client, err := NewClient(options.Client().SetMonitor(monitor))
+		assert.Nil(t, err, "NewClient error: %v", err)
+		assert.True(t, client.monitor == monitor, "expected monitor to be used as-is without an extractor")
+
+		client, err = NewClient(options.Client().SetMonitor(monitor).SetContextTagExtractor(extract))
+		assert.Nil(t, err, "NewClient error: %v", err)
+		assert.True(t, client.monitor != monitor, "expected monitor to be wrapped")
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultCopyBatchSize is the number of documents CopyCollection inserts at a time if CopyOptions.BatchSize is not set.
+const defaultCopyBatchSize = 1000
+
+// CopyOptions represents options that can be used to configure CopyCollection.
+type CopyOptions struct {
+	// BatchSize is the number of documents that are read from the source collection and inserted into the
+	// destination collection at a time. The default value is 1000.
+	BatchSize int32
+
+	// Filter is a query filter selecting the documents to copy. The default value is nil, which copies all documents.
+	Filter interface{}
+
+	// CopyIndexes specifies whether the indexes of the source collection are created on the destination collection
+	// after the documents are copied. The _id index is never copied because every collection already has one.
+	CopyIndexes bool
+}
+
+// CopyResult is the result of CopyCollection.
+type CopyResult struct {
+	// DocumentsCopied is the number of documents inserted into the destination collection. If CopyCollection returns
+	// an error, this is the number of documents copied before the error.
+	DocumentsCopied int64
+
+	// IndexesCopied is the number of indexes created on the destination collection.
+	IndexesCopied int
+}
+
+// CopyCollection copies documents from src to dst. The collections can belong to different databases and to Clients
+// connected to different clusters. Documents are read from src with a cursor and inserted into dst with ordered bulk
+// writes of opts.BatchSize documents, so the _id of each document is preserved. Documents already in dst are not
+// removed, and copying a document whose _id is already in dst returns a duplicate key error.
+//
+// CopyCollection does not run in a transaction. If ctx is cancelled or an operation fails, CopyCollection returns the
+// error along with a CopyResult reporting the number of documents that were copied so far, which remain in dst.
+//
+// If opts.CopyIndexes is true, the indexes of src are created on dst with the same keys and options after all
+// documents are copied.
+func CopyCollection(ctx context.Context, src, dst *Collection, opts CopyOptions) (CopyResult, error) {
+	var result CopyResult
+	if src == nil || dst == nil {
+		return result, errors.New("source and destination collections must not be nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultCopyBatchSize
+	}
+	filter := opts.Filter
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	cursor, err := src.Find(ctx, filter, options.Find().SetBatchSize(batchSize))
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(context.Background())
+
+	insertOpts := options.BulkWrite().SetOrdered(true)
+	models := make([]WriteModel, 0, batchSize)
+	flush := func() error {
+		if len(models) == 0 {
+			return nil
+		}
+		res, err := dst.BulkWrite(ctx, models, insertOpts)
+		if res != nil {
+			result.DocumentsCopied += res.InsertedCount
+		}
+		models = models[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		// The cursor reuses its buffer for later batches, so each document is copied before it is queued.
+		doc := make(bson.Raw, len(cursor.Current))
+		copy(doc, cursor.Current)
+		models = append(models, NewInsertOneModel().SetDocument(doc))
+		if len(models) < int(batchSize) {
+			continue
+		}
+		if err := flush(); err != nil {
+			return result, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return result, err
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	if opts.CopyIndexes {
+		result.IndexesCopied, err = copyIndexes(ctx, src, dst)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// copyIndexes creates the indexes of src on dst, except for the _id index and clustered indexes, and returns the
+// number of indexes created. The index specifications returned by listIndexes are passed to createIndexes as-is so
+// that all index options are preserved.
+func copyIndexes(ctx context.Context, src, dst *Collection) (int, error) {
+	cursor, err := src.Indexes().List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var indexes []bson.D
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return 0, err
+	}
+
+	specs := make([]bson.D, 0, len(indexes))
+	for _, index := range indexes {
+		var name string
+		var clustered bool
+		spec := make(bson.D, 0, len(index))
+		for _, elem := range index {
+			switch elem.Key {
+			case "name":
+				name, _ = elem.Value.(string)
+			case "clustered":
+				clustered, _ = elem.Value.(bool)
+			case "ns":
+				// Servers before 4.4 include the namespace of the source collection, which createIndexes rejects
+				// if it does not match the destination collection.
+				continue
+			}
+			spec = append(spec, elem)
+		}
+		if name == "_id_" || clustered {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return 0, nil
+	}
+
+	cmd := bson.D{{"createIndexes", dst.Name()}, {"indexes", specs}}
+	if err := dst.Database().RunCommand(ctx, cmd).Err(); err != nil {
+		return 0, fmt.Errorf("error creating indexes on %s: %w", dst.Name(), err)
+	}
+	return len(specs), nil
+}
@@ -0,0 +1,37 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestCopyCollection(t *testing.T) {
+	t.Parallel()
+
+	coll := setupColl("foo")
+	testCases := []struct {
+		name     string
+		src, dst *Collection
+	}{
+		{"nil source", nil, coll},
+		{"nil destination", coll, nil},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := CopyCollection(context.Background(), tc.src, tc.dst, CopyOptions{})
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.Equal(t, CopyResult{}, res, "expected empty result, got %v", res)
+		})
+	}
+}
@@ -38,6 +38,14 @@ type Cursor struct {
 	registry      *bsoncodec.Registry
 	clientSession *session.Client
 
+	// maxResultBytes limits the total size of the documents decoded by All. It is zero if there is no limit.
+	maxResultBytes int64
+
+	// tracker is the cursorTracker of the Client that created the Cursor and trackerSeq is the sequence number of the
+	// Cursor in it. tracker is nil if the Cursor is not tracked.
+	tracker    *cursorTracker
+	trackerSeq uint64
+
 	err error
 }
 
@@ -123,6 +131,14 @@ func NewCursorFromDocuments(documents []interface{}, err error, registry *bsonco
 // ID returns the ID of this cursor, or 0 if the cursor has been closed or exhausted.
 func (c *Cursor) ID() int64 { return c.bc.ID() }
 
+// Partial returns true if the server reported that some of the batches returned so far are incomplete because some
+// shards were unavailable. This can only happen for a Find run with the AllowPartialResults option against a sharded
+// cluster. Because each batch is reported separately, the result is only final once the cursor has been exhausted.
+func (c *Cursor) Partial() bool {
+	prc, ok := c.bc.(partialResultsCursor)
+	return ok && prc.PartialResultsReturned()
+}
+
 // Next gets the next document for this cursor. It returns true if there were no errors and the cursor has not been
 // exhausted.
 //
@@ -185,6 +201,7 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 			// Is the cursor ID zero?
 			if c.bc.ID() == 0 {
 				c.closeImplicitSession()
+				c.untrack()
 				return false
 			}
 			// empty batch, but cursor is still valid.
@@ -198,6 +215,7 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 		// close the implicit session if this was the last getMore
 		if c.bc.ID() == 0 {
 			c.closeImplicitSession()
+			c.untrack()
 		}
 
 		// Use the new batch to update the batch and batchLength fields. Consume the first document in the batch.
@@ -240,6 +258,12 @@ func getDecoder(
 		if opts.DefaultDocumentM {
 			dec.DefaultDocumentM()
 		}
+		if opts.IntegralNumbersAsInt64 {
+			dec.IntegralNumbersAsInt64()
+		}
+		if opts.NumbersAsFloat64 {
+			dec.NumbersAsFloat64()
+		}
 		if opts.UseJSONStructTags {
 			dec.UseJSONStructTags()
 		}
@@ -282,6 +306,7 @@ func (c *Cursor) Err() error { return c.err }
 // the first call, any subsequent calls will not change the state.
 func (c *Cursor) Close(ctx context.Context) error {
 	defer c.closeImplicitSession()
+	defer c.untrack()
 	return replaceErrors(c.bc.Close(ctx))
 }
 
@@ -308,6 +333,7 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 
 	elementType := sliceVal.Type().Elem()
 	var index int
+	var resultBytes int64
 	var err error
 
 	// Defer a call to Close to try to clean up the cursor server-side when all
@@ -317,7 +343,7 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 
 	batch := c.batch // exhaust the current batch before iterating the batch cursor
 	for {
-		sliceVal, index, err = c.addFromBatch(sliceVal, elementType, batch, index)
+		sliceVal, index, err = c.addFromBatch(sliceVal, elementType, batch, index, &resultBytes)
 		if err != nil {
 			return err
 		}
@@ -337,6 +363,38 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 	return nil
 }
 
+// Drain exhausts the cursor without decoding its documents and then closes it. It is intended for commands that are
+// run for their side effects, where the cursor must still be iterated so the server can release its resources.
+// Drain issues getMore commands until the server reports that the cursor is exhausted, discarding each batch, so it
+// allocates less than calling Next in a loop. The documents of the current batch are discarded as well.
+//
+// Drain returns the first error that occurs while getting batches. If an error occurs or ctx expires, the cursor is
+// closed, which kills it on the server. Drain must not be used with tailable cursors, which are never exhausted.
+func (c *Cursor) Drain(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Use context.Background() so Close kills the cursor on the server even if ctx has expired.
+	defer c.Close(context.Background())
+
+	if c.err != nil {
+		return c.err
+	}
+	c.Current = nil
+	c.batchLength = 0
+	for c.bc.ID() != 0 {
+		if c.bc.Next(ctx) {
+			continue
+		}
+		if err := replaceErrors(c.bc.Err()); err != nil {
+			c.err = err
+			return err
+		}
+	}
+	return nil
+}
+
 // RemainingBatchLength returns the number of documents left in the current batch. If this returns zero, the subsequent
 // call to Next or TryNext will do a network request to fetch the next batch.
 func (c *Cursor) RemainingBatchLength() int {
@@ -346,7 +404,7 @@ func (c *Cursor) RemainingBatchLength() int {
 // addFromBatch adds all documents from batch to sliceVal starting at the given index. It returns the new slice value,
 // the next empty index in the slice, and an error if one occurs.
 func (c *Cursor) addFromBatch(sliceVal reflect.Value, elemType reflect.Type, batch *bsoncore.DocumentSequence,
-	index int) (reflect.Value, int, error) {
+	index int, resultBytes *int64) (reflect.Value, int, error) {
 
 	docs, err := batch.Documents()
 	if err != nil {
@@ -354,6 +412,13 @@ func (c *Cursor) addFromBatch(sliceVal reflect.Value, elemType reflect.Type, bat
 	}
 
 	for _, doc := range docs {
+		if c.maxResultBytes > 0 {
+			*resultBytes += int64(len(doc))
+			if *resultBytes > c.maxResultBytes {
+				return sliceVal, index, ErrMaxResultBytesExceeded
+			}
+		}
+
 		if sliceVal.Len() == index {
 			// slice is full
 			newElem := reflect.New(elemType)
@@ -383,6 +448,13 @@ func (c *Cursor) closeImplicitSession() {
 	}
 }
 
+// untrack removes the Cursor from the active cursors of the Client that created it.
+func (c *Cursor) untrack() {
+	if c.tracker != nil {
+		c.tracker.remove(c.trackerSeq)
+	}
+}
+
 // SetBatchSize sets the number of documents to fetch from the database with
 // each iteration of the cursor's "Next" method. Note that some operations set
 // an initial cursor batch size, so this setting only affects subsequent
@@ -407,12 +479,19 @@ func (c *Cursor) SetComment(comment interface{}) {
 	c.bc.SetComment(comment)
 }
 
+// SetMaxResultBytes sets the maximum total size in bytes of the BSON documents
+// that All decodes. If the limit is exceeded, All closes the cursor and returns
+// ErrMaxResultBytesExceeded. A value of zero or less means there is no limit,
+// which is the default.
+func (c *Cursor) SetMaxResultBytes(maxBytes int64) {
+	c.maxResultBytes = maxBytes
+}
+
 // BatchCursorFromCursor returns a driver.BatchCursor for the given Cursor. If there is no underlying
 // driver.BatchCursor, nil is returned.
 //
 // Deprecated: This is an unstable function because the driver.BatchCursor type exists in the "x" package. Neither this
 // function nor the driver.BatchCursor type should be used by applications and may be changed or removed in any release.
 func BatchCursorFromCursor(c *Cursor) *driver.BatchCursor {
-	bc, _ := c.bc.(*driver.BatchCursor)
-	return bc
+	return unwrapDriverCursor(c.bc)
 }
@@ -8,6 +8,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -193,6 +194,30 @@ func TestCursor(t *testing.T) {
 			assert.True(t, tbc.closed, "expected batch cursor to be closed but was not")
 		})
 
+		t.Run("max result bytes", func(t *testing.T) {
+			// Each {foo: <int32>} document is 14 bytes, so the limit is hit in the middle of the second batch.
+			const docSize = 14
+
+			var docs []bson.D
+			tbc := newTestBatchCursor(3, 4)
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+			cursor.SetMaxResultBytes(6 * docSize)
+
+			err = cursor.All(context.Background(), &docs)
+			assert.ErrorIs(t, err, ErrMaxResultBytesExceeded, "expected error %v, got %v", ErrMaxResultBytesExceeded, err)
+			assert.True(t, tbc.closed, "expected batch cursor to be closed but was not")
+			assert.Len(t, docs, 0, "expected no docs to be returned, got %v", len(docs))
+
+			cursor, err = newCursor(newTestBatchCursor(3, 4), nil, nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+			cursor.SetMaxResultBytes(12 * docSize)
+
+			err = cursor.All(context.Background(), &docs)
+			require.NoError(t, err, "All error: %v", err)
+			assert.Len(t, docs, 12, "expected 12 docs, got %v", len(docs))
+		})
+
 		t.Run("does not error given interface as parameter", func(t *testing.T) {
 			var docs interface{} = []bson.D{}
 
@@ -231,9 +256,162 @@ func TestCursor(t *testing.T) {
 
 			want := []myDocument{{A: 0}, {A: 1}, {A: 2}, {A: 3}, {A: 4}}
 
+			assert.Equal(t, want, got, "expected and actual All results are different")
+		})
+		t.Run("with number normalization BSONOptions", func(t *testing.T) {
+			cursor, err := newCursor(
+				newTestBatchCursor(1, 3),
+				&options.BSONOptions{
+					NumbersAsFloat64: true,
+				},
+				nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+
+			var got []bson.M
+			err = cursor.All(context.Background(), &got)
+			require.NoError(t, err, "All error: %v", err)
+
+			want := []bson.M{{"foo": float64(0)}, {"foo": float64(1)}, {"foo": float64(2)}}
+
 			assert.Equal(t, want, got, "expected and actual All results are different")
 		})
 	})
+	t.Run("Partial", func(t *testing.T) {
+		t.Run("not supported by batch cursor", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(1, 1), nil, nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+
+			assert.False(t, cursor.Partial(), "expected Partial to be false")
+		})
+		t.Run("reported by batch cursor", func(t *testing.T) {
+			for _, partial := range []bool{false, true} {
+				bc := &testPartialBatchCursor{testBatchCursor: newTestBatchCursor(1, 1), partial: partial}
+				cursor, err := newCursor(bc, nil, nil)
+				require.NoError(t, err, "newCursor error: %v", err)
+
+				assert.Equal(t, partial, cursor.Partial(), "expected Partial to be %v", partial)
+			}
+		})
+	})
+	t.Run("BatchCursorFromCursor", func(t *testing.T) {
+		dbc := &driver.BatchCursor{}
+		wrapped := &prefetchBatchCursor{bc: newResumableBatchCursor(dbc, "_id", nil)}
+		cursor := &Cursor{bc: wrapped}
+		assert.Equal(t, dbc, BatchCursorFromCursor(cursor), "expected the wrapped driver.BatchCursor")
+
+		cursor = &Cursor{bc: newTestBatchCursor(1, 1)}
+		assert.Nil(t, BatchCursorFromCursor(cursor), "expected nil for a cursor without a driver.BatchCursor")
+	})
+	t.Run("Drain", func(t *testing.T) {
+		t.Run("exhausts all batches and closes", func(t *testing.T) {
+			tbc := newTestBatchCursor(3, 5)
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+
+			err = cursor.Drain(context.Background())
+			require.NoError(t, err, "Drain error: %v", err)
+			assert.Len(t, tbc.batches, 0, "expected all batches to be consumed, %v remaining", len(tbc.batches))
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+			assert.Equal(t, 0, cursor.RemainingBatchLength(), "expected no remaining documents")
+			assert.False(t, cursor.Next(context.Background()), "expected Next to return false after Drain")
+		})
+		t.Run("returns batch cursor error", func(t *testing.T) {
+			getMoreErr := errors.New("getMore error")
+			tbc := &testErrBatchCursor{testBatchCursor: newTestBatchCursor(3, 5), failAfter: 1, err: getMoreErr}
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error: %v", err)
+
+			err = cursor.Drain(context.Background())
+			assert.ErrorIs(t, err, getMoreErr, "expected error %v, got %v", getMoreErr, err)
+			assert.ErrorIs(t, cursor.Err(), getMoreErr, "expected cursor error %v, got %v", getMoreErr, cursor.Err())
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+		})
+		t.Run("returns existing cursor error", func(t *testing.T) {
+			mockErr := errors.New("mock error")
+			cursor, err := NewCursorFromDocuments([]interface{}{bson.D{{"x", 1}}}, mockErr, nil)
+			require.NoError(t, err, "NewCursorFromDocuments error: %v", err)
+
+			err = cursor.Drain(context.Background())
+			assert.ErrorIs(t, err, mockErr, "expected error %v, got %v", mockErr, err)
+		})
+	})
+}
+
+// testErrBatchCursor is a testBatchCursor whose Next call fails after failAfter batches.
+type testErrBatchCursor struct {
+	*testBatchCursor
+	failAfter int
+	err       error
+	lastErr   error
+}
+
+func (tebc *testErrBatchCursor) Next(ctx context.Context) bool {
+	if tebc.failAfter == 0 {
+		tebc.lastErr = tebc.err
+		return false
+	}
+	tebc.failAfter--
+	return tebc.testBatchCursor.Next(ctx)
+}
+
+func (tebc *testErrBatchCursor) Err() error {
+	return tebc.lastErr
+}
+
+func BenchmarkCursorDrain(b *testing.B) {
+	const (
+		numBatches = 10
+		batchSize  = 100
+	)
+
+	b.Run("Next loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			tbc := newTestBatchCursor(numBatches, batchSize)
+			b.StartTimer()
+
+			cursor, err := newCursor(tbc, nil, nil)
+			if err != nil {
+				b.Fatalf("newCursor error: %v", err)
+			}
+
+			ctx := context.Background()
+			for cursor.Next(ctx) {
+			}
+			if err := cursor.Err(); err != nil {
+				b.Fatalf("cursor error: %v", err)
+			}
+			_ = cursor.Close(ctx)
+		}
+	})
+	b.Run("Drain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			tbc := newTestBatchCursor(numBatches, batchSize)
+			b.StartTimer()
+
+			cursor, err := newCursor(tbc, nil, nil)
+			if err != nil {
+				b.Fatalf("newCursor error: %v", err)
+			}
+
+			if err := cursor.Drain(context.Background()); err != nil {
+				b.Fatalf("Drain error: %v", err)
+			}
+		}
+	})
+}
+
+// testPartialBatchCursor is a testBatchCursor that reports a fixed value for PartialResultsReturned.
+type testPartialBatchCursor struct {
+	*testBatchCursor
+	partial bool
+}
+
+func (tpbc *testPartialBatchCursor) PartialResultsReturned() bool {
+	return tpbc.partial
 }
 
 func TestNewCursorFromDocuments(t *testing.T) {
@@ -0,0 +1,115 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// CursorInfo describes a Cursor that is open on the server.
+type CursorInfo struct {
+	// ID is the server-side ID of the cursor.
+	ID int64
+
+	// Namespace is the namespace the cursor was created on in the form "database.collection". For cursors created by
+	// commands that are not run against a collection, such as listCollections, the collection part is the name
+	// reported by the server (e.g. "$cmd.listCollections").
+	Namespace string
+
+	// Address is the address of the server the cursor was created on.
+	Address address.Address
+
+	// Created is the time the Cursor was created.
+	Created time.Time
+
+	// Age is the time between Created and the call to Client.ActiveCursors that returned this CursorInfo.
+	Age time.Duration
+}
+
+// cursorTracker records the Cursors created by a Client that are still open on the server. Cursors are keyed by a
+// sequence number stored on the Cursor rather than by the Cursor itself, so that a leaked Cursor can still be garbage
+// collected while it is reported as active.
+type cursorTracker struct {
+	mu      sync.Mutex
+	cursors map[uint64]CursorInfo
+	next    uint64
+}
+
+// add starts tracking cursor if it is open on the server. If cursor is already tracked, for example because its
+// query was resumed with a new server-side cursor, the previous entry is replaced.
+func (ct *cursorTracker) add(cursor *Cursor, bc *driver.BatchCursor) {
+	if cursor == nil || bc == nil {
+		return
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if cursor.tracker == ct {
+		delete(ct.cursors, cursor.trackerSeq)
+		cursor.tracker = nil
+	}
+	if bc.ID() == 0 {
+		return
+	}
+
+	if ct.cursors == nil {
+		ct.cursors = make(map[uint64]CursorInfo)
+	}
+	ct.next++
+	ct.cursors[ct.next] = CursorInfo{
+		ID:        bc.ID(),
+		Namespace: bc.Namespace(),
+		Address:   bc.ServerAddress(),
+		Created:   time.Now(),
+	}
+	cursor.tracker = ct
+	cursor.trackerSeq = ct.next
+}
+
+// remove stops tracking the cursor with the given sequence number. It is a no-op if the cursor is not tracked.
+func (ct *cursorTracker) remove(seq uint64) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	delete(ct.cursors, seq)
+}
+
+// list returns the tracked cursors, oldest first.
+func (ct *cursorTracker) list() []CursorInfo {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	seqs := make([]uint64, 0, len(ct.cursors))
+	for seq := range ct.cursors {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool {
+		return seqs[i] < seqs[j]
+	})
+
+	now := time.Now()
+	infos := make([]CursorInfo, len(seqs))
+	for i, seq := range seqs {
+		infos[i] = ct.cursors[seq]
+		infos[i].Age = now.Sub(infos[i].Created)
+	}
+	return infos
+}
+
+// ActiveCursors returns information about the Cursors created by the Client that are still open on the server, oldest
+// first. A Cursor stops being active when it is closed or when it is exhausted and the server closes it, so a Cursor
+// that stays in the list for a long time is likely leaked and should be closed. Cursors whose results fit in their
+// first batch are never open on the server and are not included. Change streams are not included.
+func (c *Client) ActiveCursors() []CursorInfo {
+	return c.cursors.list()
+}
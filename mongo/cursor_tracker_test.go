@@ -0,0 +1,78 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+func TestCursorTracker(t *testing.T) {
+	t.Parallel()
+
+	newBatchCursor := func(t *testing.T, id int64) *driver.BatchCursor {
+		t.Helper()
+
+		bc, err := driver.NewBatchCursor(driver.CursorResponse{
+			ID:         id,
+			Database:   "db",
+			Collection: "coll",
+			FirstBatch: new(bsoncore.DocumentSequence),
+			Desc:       description.Server{Addr: address.Address("localhost:27017")},
+		}, nil, nil, driver.CursorOptions{})
+		require.NoError(t, err, "NewBatchCursor error")
+		return bc
+	}
+	track := func(t *testing.T, ct *cursorTracker, id int64) *Cursor {
+		t.Helper()
+
+		bc := newBatchCursor(t, id)
+		cursor, err := newCursor(bc, nil, nil)
+		require.NoError(t, err, "newCursor error")
+		ct.add(cursor, bc)
+		return cursor
+	}
+
+	var ct cursorTracker
+	first := track(t, &ct, 1)
+	track(t, &ct, 2)
+	track(t, &ct, 0)
+
+	got := ct.list()
+	require.Len(t, got, 2, "expected 2 tracked cursors")
+	for i, id := range []int64{1, 2} {
+		assert.Equal(t, id, got[i].ID, "expected cursor ID %v, got %v", id, got[i].ID)
+		assert.Equal(t, "db.coll", got[i].Namespace, "expected namespace %q, got %q", "db.coll", got[i].Namespace)
+		assert.Equal(t, address.Address("localhost:27017"), got[i].Address, "expected address %q, got %q",
+			"localhost:27017", got[i].Address)
+	}
+
+	first.untrack()
+	got = ct.list()
+	require.Len(t, got, 1, "expected 1 tracked cursor")
+	assert.Equal(t, int64(2), got[0].ID, "expected cursor ID 2, got %v", got[0].ID)
+
+	// Removing an untracked cursor is a no-op.
+	first.untrack()
+	assert.Len(t, ct.list(), 1, "expected 1 tracked cursor")
+
+	// Tracking a cursor again, as done when its query is resumed, replaces its entry.
+	second := track(t, &ct, 3)
+	ct.add(second, newBatchCursor(t, 4))
+	got = ct.list()
+	require.Len(t, got, 2, "expected 2 tracked cursors")
+	assert.Equal(t, int64(4), got[1].ID, "expected cursor ID 4, got %v", got[1].ID)
+
+	ct.add(second, newBatchCursor(t, 0))
+	assert.Len(t, ct.list(), 1, "expected 1 tracked cursor")
+}
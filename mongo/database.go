@@ -165,6 +165,10 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	if err != nil {
 		return nil, sess, err
 	}
+	serverAPI, err := serverAPIWithOverrides(db.client.serverAPI, ro.ServerAPIStrict, ro.ServerAPIDeprecationErrors)
+	if err != nil {
+		return nil, sess, err
+	}
 	readSelect := description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(ro.ReadPreference),
 		description.LatencySelector(db.client.localThreshold),
@@ -188,7 +192,7 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	return op.Session(sess).CommandMonitor(db.client.monitor).
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).
-		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI).
+		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(serverAPI).
 		Timeout(db.client.timeout).Logger(db.client.logger).Authenticator(db.client.authenticator), sess, nil
 }
 
@@ -273,7 +277,11 @@ func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, db.bsonOpts, db.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	db.client.cursors.add(cursor, bc)
+	return cursor, nil
 }
 
 // Drop drops the database on the server. This method ignores "namespace not found" errors so it is safe to drop
@@ -437,7 +445,11 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, db.bsonOpts, db.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	db.client.cursors.add(cursor, bc)
+	return cursor, nil
 }
 
 // ListCollectionNames executes a listCollections command and returns a slice containing the names of the collections
@@ -0,0 +1,122 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collectionSpecOptions is used to decode the options document of a listCollections result.
+type collectionSpecOptions struct {
+	Validator        bson.Raw `bson:"validator"`
+	ValidationLevel  string   `bson:"validationLevel"`
+	ValidationAction string   `bson:"validationAction"`
+	Collation        *struct {
+		Locale          string `bson:"locale"`
+		CaseLevel       bool   `bson:"caseLevel"`
+		CaseFirst       string `bson:"caseFirst"`
+		Strength        int    `bson:"strength"`
+		NumericOrdering bool   `bson:"numericOrdering"`
+		Alternate       string `bson:"alternate"`
+		MaxVariable     string `bson:"maxVariable"`
+		Normalization   bool   `bson:"normalization"`
+		Backwards       bool   `bson:"backwards"`
+	} `bson:"collation"`
+	Capped   bool       `bson:"capped"`
+	Size     int64      `bson:"size"`
+	Max      int64      `bson:"max"`
+	ViewOn   string     `bson:"viewOn"`
+	Pipeline []bson.Raw `bson:"pipeline"`
+}
+
+// ListCollectionSpecs executes a listCollections command and returns a slice of CollectionSpec instances representing
+// the collections and views in the database. Unlike ListCollectionSpecifications, the options of each collection are
+// decoded into typed fields, such as the validator, default collation, capped collection limits, time-series options,
+// and view definition.
+//
+// The filter parameter must be a document containing query operators and can be used to select which collections
+// are included in the result. It cannot be nil. An empty document (e.g. bson.D{}) should be used to include all
+// collections.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ListCollectionsOptions
+// documentation). If the NameOnly option is used, the server does not return the options of the collections, so only
+// the Name and Type fields are set.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/listCollections/.
+func (db *Database) ListCollectionSpecs(ctx context.Context, filter interface{},
+	opts ...*options.ListCollectionsOptions) ([]CollectionSpec, error) {
+
+	collSpecs, err := db.ListCollectionSpecifications(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make([]CollectionSpec, 0, len(collSpecs))
+	for _, cs := range collSpecs {
+		spec, err := newCollectionSpec(cs)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding options of collection %v: %w", cs.Name, err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// newCollectionSpec decodes the options of cs into a CollectionSpec.
+func newCollectionSpec(cs *CollectionSpecification) (CollectionSpec, error) {
+	spec := CollectionSpec{
+		Name:     cs.Name,
+		Type:     cs.Type,
+		ReadOnly: cs.ReadOnly,
+		UUID:     cs.UUID,
+		Options:  cs.Options,
+		IDIndex:  cs.IDIndex,
+	}
+	if len(cs.Options) == 0 {
+		return spec, nil
+	}
+
+	var opts collectionSpecOptions
+	if err := bson.Unmarshal(cs.Options, &opts); err != nil {
+		return CollectionSpec{}, err
+	}
+
+	spec.Validator = opts.Validator
+	spec.ValidationLevel = opts.ValidationLevel
+	spec.ValidationAction = opts.ValidationAction
+	if c := opts.Collation; c != nil {
+		spec.Collation = &options.Collation{
+			Locale:          c.Locale,
+			CaseLevel:       c.CaseLevel,
+			CaseFirst:       c.CaseFirst,
+			Strength:        c.Strength,
+			NumericOrdering: c.NumericOrdering,
+			Alternate:       c.Alternate,
+			MaxVariable:     c.MaxVariable,
+			Normalization:   c.Normalization,
+			Backwards:       c.Backwards,
+		}
+	}
+	spec.Capped = opts.Capped
+	spec.SizeInBytes = opts.Size
+	spec.MaxDocuments = opts.Max
+	spec.ViewOn = opts.ViewOn
+	spec.Pipeline = opts.Pipeline
+
+	if cs.Type == "timeseries" {
+		ts, err := decodeTimeSeriesInfo(cs.Options)
+		if err != nil {
+			return CollectionSpec{}, err
+		}
+		spec.TimeSeries = ts
+	}
+	return spec, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestNewCollectionSpec(t *testing.T) {
+	t.Parallel()
+
+	mustMarshal := func(t *testing.T, val interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(val)
+		require.NoError(t, err, "Marshal error")
+		return b
+	}
+
+	t.Run("validator and collation", func(t *testing.T) {
+		t.Parallel()
+
+		validator := mustMarshal(t, bson.D{{"x", bson.D{{"$type", "int"}}}})
+		opts := mustMarshal(t, bson.D{
+			{"validator", validator},
+			{"validationLevel", "moderate"},
+			{"validationAction", "warn"},
+			{"collation", bson.D{{"locale", "en_US"}, {"caseLevel", true}, {"strength", 2}, {"version", "57.1"}}},
+		})
+
+		spec, err := newCollectionSpec(&CollectionSpecification{Name: "coll", Type: "collection", Options: opts})
+		require.NoError(t, err, "newCollectionSpec error")
+		assert.Equal(t, validator, spec.Validator, "expected validator %v, got %v", validator, spec.Validator)
+		assert.Equal(t, "moderate", spec.ValidationLevel, "expected validation level %q, got %q", "moderate",
+			spec.ValidationLevel)
+		assert.Equal(t, "warn", spec.ValidationAction, "expected validation action %q, got %q", "warn",
+			spec.ValidationAction)
+		want := &options.Collation{Locale: "en_US", CaseLevel: true, Strength: 2}
+		assert.Equal(t, want, spec.Collation, "expected collation %v, got %v", want, spec.Collation)
+		assert.Nil(t, spec.TimeSeries, "expected no time-series options, got %v", spec.TimeSeries)
+		assert.Equal(t, opts, spec.Options, "expected options %v, got %v", opts, spec.Options)
+	})
+	t.Run("capped", func(t *testing.T) {
+		t.Parallel()
+
+		opts := mustMarshal(t, bson.D{{"capped", true}, {"size", int32(4096)}, {"max", 10.0}})
+
+		spec, err := newCollectionSpec(&CollectionSpecification{Name: "coll", Type: "collection", Options: opts})
+		require.NoError(t, err, "newCollectionSpec error")
+		assert.True(t, spec.Capped, "expected collection to be capped")
+		assert.Equal(t, int64(4096), spec.SizeInBytes, "expected size 4096, got %d", spec.SizeInBytes)
+		assert.Equal(t, int64(10), spec.MaxDocuments, "expected max 10, got %d", spec.MaxDocuments)
+	})
+	t.Run("time-series", func(t *testing.T) {
+		t.Parallel()
+
+		opts := mustMarshal(t, bson.D{{"timeseries", bson.D{
+			{"timeField", "ts"},
+			{"granularity", "seconds"},
+			{"bucketMaxSpanSeconds", int32(3600)},
+		}}})
+
+		spec, err := newCollectionSpec(&CollectionSpecification{Name: "coll", Type: "timeseries", Options: opts})
+		require.NoError(t, err, "newCollectionSpec error")
+		want := &TimeSeriesInfo{TimeField: "ts", Granularity: "seconds", BucketMaxSpan: time.Hour}
+		assert.Equal(t, want, spec.TimeSeries, "expected time-series options %v, got %v", want, spec.TimeSeries)
+	})
+	t.Run("view", func(t *testing.T) {
+		t.Parallel()
+
+		stage := mustMarshal(t, bson.D{{"$match", bson.D{{"x", 1}}}})
+		opts := mustMarshal(t, bson.D{{"viewOn", "source"}, {"pipeline", bson.A{stage}}})
+
+		spec, err := newCollectionSpec(&CollectionSpecification{Name: "view", Type: "view", Options: opts})
+		require.NoError(t, err, "newCollectionSpec error")
+		assert.Equal(t, "source", spec.ViewOn, "expected viewOn %q, got %q", "source", spec.ViewOn)
+		assert.Equal(t, []bson.Raw{stage}, spec.Pipeline, "expected pipeline %v, got %v", []bson.Raw{stage},
+			spec.Pipeline)
+	})
+	t.Run("name only", func(t *testing.T) {
+		t.Parallel()
+
+		spec, err := newCollectionSpec(&CollectionSpecification{Name: "coll", Type: "collection"})
+		require.NoError(t, err, "newCollectionSpec error")
+		assert.Equal(t, CollectionSpec{Name: "coll", Type: "collection"}, spec, "expected only name and type to be set")
+	})
+}
@@ -0,0 +1,170 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+)
+
+// Server error codes used to classify errors that are not covered by the driver's code tables.
+const (
+	errCodeUnauthorized         = 13
+	errCodeAuthenticationFailed = 18
+	errCodeWriteConflict        = 112
+)
+
+// ErrorClass is a broad category of errors returned by the driver. Use ClassifyError to get the class of an error.
+type ErrorClass int
+
+// These constants are the error classes returned by ClassifyError.
+const (
+	// ErrorClassNone is the class of a nil error.
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassOther is the class of errors that do not belong to any other class, such as invalid arguments.
+	ErrorClassOther
+	// ErrorClassServer is the class of server errors that do not belong to a more specific class.
+	ErrorClassServer
+	// ErrorClassAuth is the class of authentication and authorization errors.
+	ErrorClassAuth
+	// ErrorClassTimeout is the class of errors caused by a timeout. See IsTimeout.
+	ErrorClassTimeout
+	// ErrorClassNetwork is the class of network errors. See IsNetworkError.
+	ErrorClassNetwork
+	// ErrorClassNotPrimary is the class of errors returned when an operation that requires a primary was sent to a
+	// server that is not the primary.
+	ErrorClassNotPrimary
+	// ErrorClassNodeRecovering is the class of errors returned by a server that is recovering or shutting down.
+	ErrorClassNodeRecovering
+	// ErrorClassWriteConflict is the class of errors caused by a write conflicting with another operation.
+	ErrorClassWriteConflict
+	// ErrorClassDuplicateKey is the class of duplicate key errors. See IsDuplicateKeyError.
+	ErrorClassDuplicateKey
+)
+
+// String returns a human-readable name for the error class.
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassOther:
+		return "other"
+	case ErrorClassServer:
+		return "server"
+	case ErrorClassAuth:
+		return "auth"
+	case ErrorClassTimeout:
+		return "timeout"
+	case ErrorClassNetwork:
+		return "network"
+	case ErrorClassNotPrimary:
+		return "not primary"
+	case ErrorClassNodeRecovering:
+		return "node recovering"
+	case ErrorClassWriteConflict:
+		return "write conflict"
+	case ErrorClassDuplicateKey:
+		return "duplicate key"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyError returns the class of err. If err belongs to several classes, the first of the following that
+// applies is returned: auth, timeout, network, not primary, node recovering, write conflict, duplicate key, server,
+// and other. For example, a network timeout is classified as ErrorClassTimeout.
+func ClassifyError(err error) ErrorClass {
+	switch {
+	case err == nil:
+		return ErrorClassNone
+	case IsAuthError(err):
+		return ErrorClassAuth
+	case IsTimeout(err):
+		return ErrorClassTimeout
+	case IsNetworkError(err):
+		return ErrorClassNetwork
+	case IsNotPrimary(err):
+		return ErrorClassNotPrimary
+	case hasDriverError(err, driver.Error.NodeIsRecovering) || hasDriverError(err, driver.Error.NodeIsShuttingDown):
+		return ErrorClassNodeRecovering
+	case IsWriteConflict(err):
+		return ErrorClassWriteConflict
+	case IsDuplicateKeyError(err):
+		return ErrorClassDuplicateKey
+	}
+
+	if se := ServerError(nil); errors.As(err, &se) {
+		return ErrorClassServer
+	}
+	return ErrorClassOther
+}
+
+// IsAuthError returns true if err was caused by a failure to authenticate or by the authenticated user not being
+// authorized to run a command.
+func IsAuthError(err error) bool {
+	if errors.As(err, new(*auth.Error)) {
+		return true
+	}
+	if se := ServerError(nil); errors.As(err, &se) {
+		return se.HasErrorCode(errCodeUnauthorized) || se.HasErrorCode(errCodeAuthenticationFailed)
+	}
+	return false
+}
+
+// IsNotPrimary returns true if err was returned because an operation that requires a primary was sent to a server
+// that is not the primary.
+func IsNotPrimary(err error) bool {
+	return hasDriverError(err, driver.Error.NotPrimary)
+}
+
+// IsWriteConflict returns true if err was caused by a write conflicting with another operation, such as a concurrent
+// write to the same document in a transaction.
+func IsWriteConflict(err error) bool {
+	if se := ServerError(nil); errors.As(err, &se) {
+		return se.HasErrorCode(errCodeWriteConflict)
+	}
+	return false
+}
+
+// IsRetryableWrite returns true if err has the RetryableWriteError or NetworkError label, or has an error code that
+// older servers use to indicate that a write can be retried.
+func IsRetryableWrite(err error) bool {
+	if errorHasLabel(err, driver.RetryableWriteError) || IsNetworkError(err) {
+		return true
+	}
+	return hasDriverError(err, func(de driver.Error) bool { return de.RetryableWrite(nil) })
+}
+
+// hasDriverError returns true if pred returns true for the command error or write concern error contained in err.
+// The errors are converted back to driver.Error values so they can be checked against the driver's error code tables.
+func hasDriverError(err error, pred func(driver.Error) bool) bool {
+	if de := (driver.Error{}); errors.As(err, &de) && pred(de) {
+		return true
+	}
+	if ce := (CommandError{}); errors.As(err, &ce) && pred(driver.Error{Code: ce.Code, Message: ce.Message, Labels: ce.Labels}) {
+		return true
+	}
+
+	var labels []string
+	var wce *WriteConcernError
+	if we := (WriteException{}); errors.As(err, &we) {
+		labels, wce = we.Labels, we.WriteConcernError
+	} else if bwe := (BulkWriteException{}); errors.As(err, &bwe) {
+		labels, wce = bwe.Labels, bwe.WriteConcernError
+	} else {
+		return false
+	}
+
+	de := driver.Error{Labels: labels}
+	if wce != nil {
+		de.Code = int32(wce.Code)
+		de.Message = wce.Message
+	}
+	return pred(de)
+}
@@ -27,6 +27,10 @@ var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 // ErrClientDisconnected is returned when disconnected Client is used to run an operation.
 var ErrClientDisconnected = errors.New("client is disconnected")
 
+// ErrClientDraining is returned when an operation is started on a Client that is being drained by Client.Drain or
+// Client.Disconnect.
+var ErrClientDraining = errors.New("client is draining")
+
 // ErrNilDocument is returned when a nil document is passed to a CRUD method.
 var ErrNilDocument = errors.New("document is nil")
 
@@ -36,6 +40,17 @@ var ErrNilValue = errors.New("value is nil")
 // ErrEmptySlice is returned when an empty slice is passed to a CRUD method that requires a non-empty slice.
 var ErrEmptySlice = errors.New("must provide at least one element in input slice")
 
+// ErrMaxResultBytesExceeded is returned by Cursor.All when the documents decoded from the cursor exceed the limit set
+// with Cursor.SetMaxResultBytes or options.FindOptions.SetMaxResultBytes.
+var ErrMaxResultBytesExceeded = errors.New("cursor results exceed the maximum result size")
+
+// ErrServerAPIOverrideWithoutVersion is returned when an operation overrides the server API strict or deprecation
+// errors settings but the Client was not configured with a server API version.
+var ErrServerAPIOverrideWithoutVersion = errors.New("server API overrides require a server API version to be set on the Client")
+
+// ErrNotSharded is returned by Client.RunCommandOnAllMongos if the Client is not connected to a sharded cluster.
+var ErrNotSharded = errors.New("client is not connected to a sharded cluster")
+
 // ErrMapForOrderedArgument is returned when a map with multiple keys is passed to a CRUD method for an ordered parameter
 type ErrMapForOrderedArgument struct {
 	ParamName string
@@ -55,6 +70,9 @@ func replaceErrors(err error) error {
 	if errors.Is(err, topology.ErrTopologyClosed) {
 		return ErrClientDisconnected
 	}
+	if errors.Is(err, topology.ErrTopologyDraining) {
+		return ErrClientDraining
+	}
 	if de, ok := err.(driver.Error); ok {
 		return CommandError{
 			Code:    de.Code,
@@ -84,6 +102,10 @@ func replaceErrors(err error) error {
 
 		return ce
 	}
+	var oe interface{ OperationLimit() int }
+	if errors.As(err, &oe) {
+		return OverloadedError{MaxConcurrentOperations: oe.OperationLimit()}
+	}
 	if me, ok := err.(mongocrypt.Error); ok {
 		return MongocryptError{Code: me.Code, Message: me.Message}
 	}
@@ -225,6 +247,72 @@ func (e MongocryptdError) Unwrap() error {
 	return e.Wrapped
 }
 
+// OverloadedError is returned when an operation is rejected because the number of in-flight operations has reached
+// the limit set with options.ClientOptions.SetMaxConcurrentOperations and
+// options.ClientOptions.SetRejectOverloadedOperations is set.
+type OverloadedError struct {
+	MaxConcurrentOperations int
+}
+
+// Error implements the error interface.
+func (e OverloadedError) Error() string {
+	return fmt.Sprintf("operation rejected: %d operations are already in flight", e.MaxConcurrentOperations)
+}
+
+// EmptyFilterError is returned by Collection.DeleteMany and Collection.UpdateMany when the filter is empty and the
+// client was configured with options.ClientOptions.SetGuardEmptyFilter, unless the AllowEmptyFilter option of the
+// operation is set to true.
+type EmptyFilterError struct {
+	// Operation is the name of the rejected operation, e.g. "DeleteMany".
+	Operation string
+}
+
+// Error implements the error interface.
+func (e EmptyFilterError) Error() string {
+	return fmt.Sprintf("%s with an empty filter would affect every document in the collection; set AllowEmptyFilter "+
+		"to run it", e.Operation)
+}
+
+// IndexRollbackError is returned by IndexView.CreateMany when the operation fails with the RollbackOnError option set
+// and some of the indexes created by the operation could not be dropped.
+type IndexRollbackError struct {
+	// Err is the error returned by the createIndexes command.
+	Err error
+
+	// RollbackErrors are the errors that occurred while dropping the created indexes.
+	RollbackErrors []error
+}
+
+// Error implements the error interface.
+func (e IndexRollbackError) Error() string {
+	return fmt.Sprintf("%v; failed to roll back created indexes: %v", e.Err, e.RollbackErrors)
+}
+
+// Unwrap returns the error returned by the createIndexes command.
+func (e IndexRollbackError) Unwrap() error {
+	return e.Err
+}
+
+// DrainError is returned by Client.Drain when the context expires before all in-flight operations complete. The
+// Client is disconnected regardless.
+type DrainError struct {
+	// InFlightOperations is the number of operations that were still in flight when the context expired.
+	InFlightOperations int
+
+	// Wrapped is the context error.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e DrainError) Error() string {
+	return fmt.Sprintf("client disconnected with %d operations still in flight: %v", e.InFlightOperations, e.Wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e DrainError) Unwrap() error {
+	return e.Wrapped
+}
+
 // LabeledError is an interface for errors with labels.
 type LabeledError interface {
 	error
@@ -7,11 +7,15 @@
 package mongo
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 )
 
 func TestErrorMessages(t *testing.T) {
@@ -70,3 +74,80 @@ func TestErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		desc           string
+		err            error
+		class          ErrorClass
+		retryableWrite bool
+	}{
+		{"nil", nil, ErrorClassNone, false},
+		{"client error", ErrNilDocument, ErrorClassOther, false},
+		{"server error", CommandError{Code: 2, Name: "BadValue"}, ErrorClassServer, false},
+		{"authentication failed", CommandError{Code: 18, Name: "AuthenticationFailed"}, ErrorClassAuth, false},
+		{"unauthorized", CommandError{Code: 13, Name: "Unauthorized"}, ErrorClassAuth, false},
+		{
+			"handshake auth error",
+			driver.Error{Labels: []string{driver.NetworkError}, Wrapped: fmt.Errorf("connection failed: %w", &auth.Error{})},
+			ErrorClassAuth,
+			true,
+		},
+		{"max time expired", CommandError{Code: 50, Name: "MaxTimeMSExpired"}, ErrorClassTimeout, false},
+		{"context deadline", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), ErrorClassTimeout, false},
+		{"network error", CommandError{Labels: []string{driver.NetworkError}}, ErrorClassNetwork, true},
+		{"not primary", CommandError{Code: 10107, Name: "NotWritablePrimary"}, ErrorClassNotPrimary, true},
+		{"not primary no secondary ok", CommandError{Code: 13435}, ErrorClassNotPrimary, true},
+		{"legacy not primary message", CommandError{Message: driver.LegacyNotPrimaryErrMsg}, ErrorClassNotPrimary, false},
+		{
+			"not primary write concern error",
+			WriteException{WriteConcernError: &WriteConcernError{Code: 10107}},
+			ErrorClassNotPrimary,
+			true,
+		},
+		{"node recovering", CommandError{Code: 13436, Name: "NotPrimaryOrSecondary"}, ErrorClassNodeRecovering, true},
+		{"shutdown in progress", CommandError{Code: 91, Name: "ShutdownInProgress"}, ErrorClassNodeRecovering, true},
+		{
+			"write conflict",
+			CommandError{Code: 112, Name: "WriteConflict", Labels: []string{driver.TransientTransactionError}},
+			ErrorClassWriteConflict,
+			false,
+		},
+		{
+			"duplicate key",
+			WriteException{WriteErrors: WriteErrors{{Code: 11000}}},
+			ErrorClassDuplicateKey,
+			false,
+		},
+		{
+			"retryable write label",
+			WriteException{Labels: []string{driver.RetryableWriteError}},
+			ErrorClassServer,
+			true,
+		},
+		{
+			"bulk write retryable code",
+			BulkWriteException{WriteConcernError: &WriteConcernError{Code: 262, Name: "ExceededTimeLimit"}},
+			ErrorClassServer,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			class := ClassifyError(tc.err)
+			assert.Equal(t, tc.class, class, "expected class %v, got %v", tc.class, class)
+			assert.Equal(t, tc.class == ErrorClassAuth, IsAuthError(tc.err),
+				"expected IsAuthError %v", tc.class == ErrorClassAuth)
+			assert.Equal(t, tc.class == ErrorClassNotPrimary, IsNotPrimary(tc.err),
+				"expected IsNotPrimary %v", tc.class == ErrorClassNotPrimary)
+			assert.Equal(t, tc.class == ErrorClassWriteConflict, IsWriteConflict(tc.err),
+				"expected IsWriteConflict %v", tc.class == ErrorClassWriteConflict)
+			assert.Equal(t, tc.retryableWrite, IsRetryableWrite(tc.err), "expected IsRetryableWrite %v",
+				tc.retryableWrite)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// FindAfterWrite executes a find command like Find, but the server does not run the query until it has applied all
+// operations up to afterOpTime. afterOpTime is usually the OperationTime of a prior write result, so a read sent to a
+// secondary observes that write without the reader having to share a causally consistent Session with the writer.
+// This allows read-your-writes across processes or services that pass the operation time along with a request.
+//
+// The operation time is sent as the "afterClusterTime" field of the read concern, which is added to the collection's
+// read concern or to a read concern with no level if the collection does not have one. The read is sent to the
+// server selected by the collection's read preference. FindAfterWrite requires a replica set or sharded cluster and
+// returns an error if ctx contains a Session; use Session.AdvanceOperationTime with a causally consistent Session
+// instead. An error is also returned if afterOpTime is the zero Timestamp.
+//
+// For more information about causal consistency, see
+// https://www.mongodb.com/docs/manual/core/read-isolation-consistency-recency/#causal-consistency.
+func (coll *Collection) FindAfterWrite(ctx context.Context, filter interface{}, afterOpTime primitive.Timestamp,
+	opts ...*options.FindOptions) (*Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if afterOpTime.IsZero() {
+		return nil, errors.New("afterOpTime must not be the zero Timestamp")
+	}
+	if sessionFromContext(ctx) != nil {
+		return nil, errors.New("FindAfterWrite cannot be used with a Session; advance the Session's operation time instead")
+	}
+	if coll.client.sessionPool == nil {
+		return nil, ErrClientDisconnected
+	}
+
+	// afterClusterTime is only attached to the read concern of a causally consistent session, so the find runs in an
+	// implicit session that is made causally consistent from afterOpTime. The session is ended when the cursor is
+	// closed like any other implicit session.
+	sess := session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
+	sess.Consistent = true
+	if err := sess.AdvanceOperationTime(&afterOpTime); err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	ctx = NewSessionContext(ctx, &sessionImpl{
+		clientSession: sess,
+		client:        coll.client,
+		deployment:    coll.client.deployment,
+	})
+
+	// A read concern document is needed to carry afterClusterTime.
+	target := coll
+	if coll.readConcern == nil {
+		target = coll.copy()
+		target.readConcern = readconcern.New()
+	}
+	return target.Find(ctx, filter, opts...)
+}
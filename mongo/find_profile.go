@@ -0,0 +1,165 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ProfileInfo contains the execution statistics recorded by the database profiler for a find command.
+type ProfileInfo struct {
+	// DocsExamined is the number of documents in the collection that the server scanned to run the query.
+	DocsExamined int64 `bson:"docsExamined"`
+
+	// KeysExamined is the number of index keys that the server scanned to run the query.
+	KeysExamined int64 `bson:"keysExamined"`
+
+	// NReturned is the number of documents returned in the first batch.
+	NReturned int64 `bson:"nreturned"`
+
+	// Millis is the time in milliseconds the server spent running the command.
+	Millis int64 `bson:"millis"`
+
+	// PlanSummary is a summary of the query plan, e.g. "COLLSCAN" or "IXSCAN { x: 1 }".
+	PlanSummary string `bson:"planSummary"`
+
+	// Err is the error that occurred while reading the profiler entry, e.g. because the user is not authorized to
+	// read the "system.profile" collection. If it is set, the other fields are not set.
+	Err error `bson:"-"`
+}
+
+// FindWithProfile executes a find command like Find and also returns the execution statistics that the database
+// profiler recorded for the command. See the Collection.Find documentation for more information about the filter and
+// opts parameters.
+//
+// The statistics are read back from the "system.profile" collection of the server that ran the find, so the profiler
+// must be enabled for the database at level 2, or at level 1 with a "slowms" threshold that the query exceeds. See
+// https://www.mongodb.com/docs/manual/tutorial/manage-the-database-profiler/ for more information. FindWithProfile
+// does not change the profiling level. If the profiler did not record the command, or the deployment is sharded or
+// load balanced, the returned ProfileInfo is nil.
+//
+// An error is only returned if the find command fails. If the find succeeds but the profiler entry cannot be read,
+// the Cursor is returned along with a ProfileInfo whose Err field reports the error.
+//
+// To identify the profiler entry, FindWithProfile appends a unique marker to the Comment option (or uses the marker
+// as the comment if none is set). The statistics only describe the initial find command and not any subsequent
+// getMore commands issued while iterating the Cursor.
+func (coll *Collection) FindWithProfile(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions) (*Cursor, *ProfileInfo, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fo := options.MergeFindOptions(opts...)
+	comment := "profile:" + primitive.NewObjectID().Hex()
+	if fo.Comment != nil && *fo.Comment != "" {
+		comment = *fo.Comment + " " + comment
+	}
+	fo.Comment = &comment
+
+	cur, err := coll.Find(ctx, filter, fo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := coll.readProfile(ctx, cur, comment)
+	if err != nil {
+		return cur, &ProfileInfo{Err: err}, nil
+	}
+	return cur, info, nil
+}
+
+// readProfile reads the profiler entry for the find command that created cur and was sent with the given comment.
+func (coll *Collection) readProfile(ctx context.Context, cur *Cursor, comment string) (*ProfileInfo, error) {
+	bc := unwrapDriverCursor(cur.bc)
+	if bc == nil {
+		return nil, nil
+	}
+	srv, ok := bc.Server().(*topology.SelectedServer)
+	if !ok {
+		return nil, nil
+	}
+	desc := srv.Description()
+	if desc.Kind == description.Sharded || desc.Kind == description.LoadBalanced {
+		return nil, nil
+	}
+
+	filter, err := marshal(bson.D{
+		{"op", "query"},
+		{"ns", coll.db.name + "." + coll.name},
+		{"command.comment", comment},
+	}, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	// The profiler only records operations run on the local node, so the lookup has to be sent to the server that
+	// ran the find rather than one chosen by the collection's read preference. The read preference only allows the
+	// command to run on a secondary.
+	op := operation.NewFind(filter).
+		Limit(1).SingleBatch(true).
+		CommandMonitor(coll.client.monitor).ServerSelector(addressSelector(desc.Addr)).
+		ClusterClock(coll.client.clock).Database(coll.db.name).Collection("system.profile").
+		Deployment(coll.client.deployment).ReadPreference(readpref.Nearest()).
+		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).Authenticator(coll.client.authenticator)
+	if err := op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	res, err := op.Result(driver.CursorOptions{})
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	defer res.Close(ctx)
+
+	doc, err := res.Batch().Next()
+	if errors.Is(err, io.EOF) {
+		// The profiler did not record the command.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dec, err := getDecoder(bson.Raw(doc), coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	var info ProfileInfo
+	if err := dec.Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// addressSelector returns a ServerSelector that only selects the server with the given address.
+func addressSelector(addr address.Address) description.ServerSelector {
+	return description.ServerSelectorFunc(func(
+		_ description.Topology,
+		candidates []description.Server,
+	) ([]description.Server, error) {
+		for _, candidate := range candidates {
+			if candidate.Addr == addr {
+				return []description.Server{candidate}, nil
+			}
+		}
+		return nil, nil
+	})
+}
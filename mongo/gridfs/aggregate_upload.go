@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package gridfs
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AggregateFormat specifies how UploadFromAggregate encodes aggregation results in the uploaded file.
+type AggregateFormat int
+
+// These constants are the supported aggregation result encodings.
+const (
+	// AggregateFormatExtJSON writes each result as a line of canonical extended JSON followed by a newline.
+	AggregateFormatExtJSON AggregateFormat = iota
+	// AggregateFormatBSON writes the results as a stream of concatenated BSON documents.
+	AggregateFormatBSON
+)
+
+// UploadFromAggregate runs the aggregation pipeline against coll and streams the results into a new file with the
+// given filename, using format to encode each result. The files collection document for the uploaded file is
+// returned.
+//
+// The context is used for the aggregation and its getMore commands and is checked between results. If the context
+// has a deadline, it is also used as the write deadline for the upload. Otherwise, the deadline set by
+// SetWriteDeadline is used. If the aggregation, the encoding of a result, or the upload fails, or the context is
+// cancelled, any chunks that were already written are deleted and the error is returned.
+func (b *Bucket) UploadFromAggregate(
+	ctx context.Context,
+	coll *mongo.Collection,
+	pipeline interface{},
+	filename string,
+	format AggregateFormat,
+	opts ...*options.UploadOptions,
+) (*File, error) {
+	if format != AggregateFormatExtJSON && format != AggregateFormatBSON {
+		return nil, fmt.Errorf("invalid AggregateFormat %d", format)
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	fileID := primitive.NewObjectID()
+	us, err := b.OpenUploadStreamWithID(fileID, filename, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	writeDeadline := b.writeDeadline
+	if deadline, ok := ctx.Deadline(); ok {
+		writeDeadline = deadline
+	}
+	if err = us.SetWriteDeadline(writeDeadline); err != nil {
+		return nil, err
+	}
+
+	if err = writeAggregateResults(ctx, cursor, us, format); err == nil {
+		err = us.Close()
+	}
+	if err != nil {
+		// The context's deadline may have already passed, so use the bucket's write deadline to delete the chunks.
+		_ = us.SetWriteDeadline(b.writeDeadline)
+		_ = us.Abort()
+		return nil, err
+	}
+
+	var file File
+	if err = b.filesColl.FindOne(ctx, bson.D{{"_id", fileID}}).Decode(&file); err != nil {
+		return nil, fmt.Errorf("error decoding files collection document: %w", err)
+	}
+	return &file, nil
+}
+
+// writeAggregateResults writes every document returned by cursor to us in the given format.
+func writeAggregateResults(ctx context.Context, cursor *mongo.Cursor, us *UploadStream, format AggregateFormat) error {
+	for cursor.Next(ctx) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data := []byte(cursor.Current)
+		if format == AggregateFormatExtJSON {
+			var err error
+			data, err = bson.MarshalExtJSON(cursor.Current, true, false)
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+		}
+
+		if _, err := us.Write(data); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
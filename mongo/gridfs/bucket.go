@@ -23,6 +23,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 )
 
 // TODO: add sessions options
@@ -33,6 +34,10 @@ const DefaultChunkSize int32 = 255 * 1024 // 255 KiB
 // ErrFileNotFound occurs if a user asks to download a file with a file ID that isn't found in the files collection.
 var ErrFileNotFound = errors.New("file with given parameters not found")
 
+// ErrFileAlreadyUploaded occurs if a user attempts to resume an upload for a file ID that already has a files
+// collection document.
+var ErrFileAlreadyUploaded = errors.New("file with given ID has already been uploaded")
+
 // ErrMissingChunkSize occurs when downloading a file if the files collection document is missing the "chunkSize" field.
 var ErrMissingChunkSize = errors.New("files collection document does not contain a 'chunkSize' field")
 
@@ -136,6 +141,70 @@ func (b *Bucket) OpenUploadStreamWithID(fileID interface{}, filename string, opt
 	return newUploadStream(upload, fileID, filename, b.chunksColl, b.filesColl), nil
 }
 
+// OpenUploadStreamResumable creates an upload stream that resumes an interrupted upload for the file with the given
+// file ID. Chunks that were previously written for the file are kept up to the first missing or partial chunk, and any
+// chunks after that point are deleted. The returned stream's Offset method reports the number of bytes that were kept,
+// so the caller must write the remainder of the file starting at that offset. The chunk size must match the chunk size
+// used by the interrupted upload. If the file ID does not have any chunks, this behaves like OpenUploadStreamWithID.
+//
+// ErrFileAlreadyUploaded is returned if the upload for the file ID was already completed.
+func (b *Bucket) OpenUploadStreamResumable(
+	fileID interface{},
+	filename string,
+	opts ...*options.UploadOptions,
+) (*ResumableUploadStream, error) {
+	ctx, cancel := deadlineContext(b.writeDeadline)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	if err := b.checkFirstWrite(ctx); err != nil {
+		return nil, err
+	}
+
+	upload, err := b.parseUploadOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// must use primary read pref mode to see the chunks that were written by the interrupted upload
+	filesColl, err := b.filesColl.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return nil, err
+	}
+	chunksColl, err := b.chunksColl.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = filesColl.FindOne(ctx, bson.D{{"_id", fileID}}, options.FindOne().SetProjection(bson.D{{"_id", 1}})).Err()
+	if err == nil {
+		return nil, ErrFileAlreadyUploaded
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	numChunks, err := countResumableChunks(ctx, chunksColl, fileID, upload.chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the partial chunk and any chunks after a gap so they can be rewritten.
+	_, err = b.chunksColl.DeleteMany(ctx, bson.D{
+		{"files_id", fileID},
+		{"n", bson.D{{"$gte", numChunks}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	us := newUploadStream(upload, fileID, filename, b.chunksColl, b.filesColl)
+	us.chunkIndex = int(numChunks)
+	us.fileLen = int64(numChunks) * int64(upload.chunkSize)
+	return &ResumableUploadStream{UploadStream: us, offset: us.fileLen}, nil
+}
+
 // UploadFromStream creates a fileID and uploads a file given a source stream.
 //
 // If this upload requires a custom write deadline to be set on the bucket, it cannot be done concurrently with other
@@ -419,6 +488,14 @@ func (b *Bucket) openDownloadStream(filter interface{}, opts ...*options.FindOpt
 		defer cancel()
 	}
 
+	return b.openDownloadStreamContext(ctx, filter, opts...)
+}
+
+func (b *Bucket) openDownloadStreamContext(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (*DownloadStream, error) {
 	cursor, err := b.findFile(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
@@ -458,6 +535,14 @@ func deadlineContext(deadline time.Time) (context.Context, context.CancelFunc) {
 	return context.WithDeadline(context.Background(), deadline)
 }
 
+// streamContext is like deadlineContext but marks the returned Context as part of an operation that is already in
+// flight. The writes of an UploadStream complete an upload, so they are not limited or rejected while the Client is
+// draining.
+func streamContext(deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := deadlineContext(deadline)
+	return driver.WithinOperation(ctx), cancel
+}
+
 func (b *Bucket) downloadToStream(ds *DownloadStream, stream io.Writer) (int64, error) {
 	err := ds.SetReadDeadline(b.readDeadline)
 	if err != nil {
@@ -666,3 +751,75 @@ func (b *Bucket) parseUploadOptions(opts ...*options.UploadOptions) (*Upload, er
 
 	return upload, nil
 }
+
+// countResumableChunks returns the number of contiguous, full chunks starting at index 0 that are stored for the file.
+func countResumableChunks(
+	ctx context.Context,
+	chunksColl *mongo.Collection,
+	fileID interface{},
+	chunkSize int32,
+) (int32, error) {
+	findOpts := options.Find().SetSort(bson.D{{"n", 1}}).SetProjection(bson.D{{"_id", 0}, {"n", 1}})
+	cursor, err := chunksColl.Find(ctx, bson.D{{"files_id", fileID}}, findOpts)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var numChunks int32
+	for cursor.Next(ctx) {
+		var chunk struct {
+			N int32 `bson:"n"`
+		}
+		if err := cursor.Decode(&chunk); err != nil {
+			return 0, err
+		}
+		if chunk.N != numChunks {
+			break
+		}
+		numChunks++
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+	if numChunks == 0 {
+		return 0, nil
+	}
+
+	// Only the last chunk written by an interrupted upload can be partial, so checking the first and last chunks is
+	// enough to detect a partial chunk or a chunk size that does not match the original upload.
+	first, err := chunkDataSize(ctx, chunksColl, fileID, 0)
+	if err != nil {
+		return 0, err
+	}
+	if first > int(chunkSize) || (numChunks > 1 && first != int(chunkSize)) {
+		return 0, ErrWrongSize
+	}
+
+	last := first
+	if numChunks > 1 {
+		if last, err = chunkDataSize(ctx, chunksColl, fileID, numChunks-1); err != nil {
+			return 0, err
+		}
+	}
+	if last > int(chunkSize) {
+		return 0, ErrWrongSize
+	}
+	if last < int(chunkSize) {
+		numChunks--
+	}
+
+	return numChunks, nil
+}
+
+// chunkDataSize returns the length of the data stored in the chunk at index n for the file.
+func chunkDataSize(ctx context.Context, chunksColl *mongo.Collection, fileID interface{}, n int32) (int, error) {
+	var chunk struct {
+		Data []byte `bson:"data"`
+	}
+	err := chunksColl.FindOne(ctx, bson.D{{"files_id", fileID}, {"n", n}}).Decode(&chunk)
+	if err != nil {
+		return 0, err
+	}
+	return len(chunk.Data), nil
+}
@@ -0,0 +1,226 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package gridfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TransformMetadataKey is the key in the metadata of a file uploaded with OpenUploadStreamWithTransform that records
+// the name of the transform applied to the file contents.
+const TransformMetadataKey = "transform"
+
+// UploadTransform wraps the writer for the stored file contents in a writer that encodes the data written to it, for
+// example by compressing it. Calling Close on the returned writer must flush any buffered data to w, but must not
+// close w. The writer returned by gzip.NewWriter can be used as an UploadTransform.
+type UploadTransform func(w io.Writer) (io.WriteCloser, error)
+
+// DownloadTransform wraps a reader for the stored file contents in a reader that decodes them, for example by
+// decompressing them. If the returned reader implements io.Closer, it is closed when the download stream is closed.
+type DownloadTransform func(r io.Reader) (io.Reader, error)
+
+// TransformUploadStream is used to upload a file whose contents are encoded by an UploadTransform. Data written to
+// the stream is passed through the transform before it is stored. After an upload is complete, the Close method must
+// be called to flush the transform and write the file metadata.
+type TransformUploadStream struct {
+	// FileID is the ID of the file being uploaded.
+	FileID interface{}
+
+	us     *UploadStream
+	w      io.WriteCloser
+	closed bool
+}
+
+// Write encodes p with the stream's transform and writes the result to the file. Implements the io.Writer interface.
+func (tus *TransformUploadStream) Write(p []byte) (int, error) {
+	if tus.closed {
+		return 0, ErrStreamClosed
+	}
+	return tus.w.Write(p)
+}
+
+// Close flushes the transform and writes the file metadata to the files collection. If the transform cannot be
+// flushed, the upload is aborted and the error is returned.
+func (tus *TransformUploadStream) Close() error {
+	if tus.closed {
+		return ErrStreamClosed
+	}
+
+	tus.closed = true
+	if err := tus.w.Close(); err != nil {
+		_ = tus.us.Abort()
+		return err
+	}
+	return tus.us.Close()
+}
+
+// Abort closes the stream and deletes all file chunks that have already been written.
+func (tus *TransformUploadStream) Abort() error {
+	if tus.closed {
+		return ErrStreamClosed
+	}
+
+	tus.closed = true
+	return tus.us.Abort()
+}
+
+// SetWriteDeadline sets the write deadline for this stream.
+func (tus *TransformUploadStream) SetWriteDeadline(t time.Time) error {
+	if tus.closed {
+		return ErrStreamClosed
+	}
+	return tus.us.SetWriteDeadline(t)
+}
+
+// TransformDownloadStream is an io.Reader that downloads a file from a GridFS bucket and decodes its contents with a
+// DownloadTransform.
+type TransformDownloadStream struct {
+	ds *DownloadStream
+	r  io.Reader
+}
+
+// Read reads the decoded file contents into p. Implements the io.Reader interface.
+func (tds *TransformDownloadStream) Read(p []byte) (int, error) {
+	if tds.ds.closed {
+		return 0, ErrStreamClosed
+	}
+	return tds.r.Read(p)
+}
+
+// Close closes the reader returned by the transform if it implements io.Closer and then closes the download stream.
+func (tds *TransformDownloadStream) Close() error {
+	if tds.ds.closed {
+		return ErrStreamClosed
+	}
+
+	var err error
+	if closer, ok := tds.r.(io.Closer); ok {
+		err = closer.Close()
+	}
+	if closeErr := tds.ds.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// SetReadDeadline sets the read deadline for this stream.
+func (tds *TransformDownloadStream) SetReadDeadline(t time.Time) error {
+	return tds.ds.SetReadDeadline(t)
+}
+
+// GetFile returns a File object representing the file being downloaded. The File's Length is the number of bytes
+// stored, which is the length of the encoded contents.
+func (tds *TransformDownloadStream) GetFile() *File {
+	return tds.ds.GetFile()
+}
+
+// OpenUploadStreamWithTransform creates a file ID and opens a stream that encodes the data written to it with
+// transform before uploading it as a file with the given filename. If name is not empty, it is recorded under
+// TransformMetadataKey in the file's metadata, replacing any value for that key in the metadata specified in opts.
+// The Length of the stored file is the length of the encoded contents.
+//
+// If the context has a deadline, it is used as the write deadline for the stream. Otherwise, the deadline set by
+// SetWriteDeadline is used.
+func (b *Bucket) OpenUploadStreamWithTransform(
+	ctx context.Context,
+	filename string,
+	name string,
+	transform UploadTransform,
+	opts ...*options.UploadOptions,
+) (*TransformUploadStream, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if transform == nil {
+		return nil, errors.New("upload transform must not be nil")
+	}
+
+	us, err := b.OpenUploadStreamWithID(primitive.NewObjectID(), filename, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	writeDeadline := b.writeDeadline
+	if deadline, ok := ctx.Deadline(); ok {
+		writeDeadline = deadline
+	}
+	if err := us.SetWriteDeadline(writeDeadline); err != nil {
+		return nil, err
+	}
+
+	w, err := transform(us)
+	if err != nil {
+		_ = us.Abort()
+		return nil, err
+	}
+	if name != "" {
+		us.metadata = setTransformMetadata(us.metadata, name)
+	}
+	return &TransformUploadStream{FileID: us.FileID, us: us, w: w}, nil
+}
+
+// OpenDownloadStreamWithTransform opens a stream that downloads the file with the given ID and decodes its contents
+// with transform. The transform is not chosen based on the transform name recorded in the file's metadata, so the
+// caller must use the transform that matches the one used to upload the file.
+//
+// If the context has a deadline, it is used for finding the file and as the read deadline for the stream. Otherwise,
+// the deadline set by SetReadDeadline is used.
+func (b *Bucket) OpenDownloadStreamWithTransform(
+	ctx context.Context,
+	fileID interface{},
+	transform DownloadTransform,
+) (*TransformDownloadStream, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if transform == nil {
+		return nil, errors.New("download transform must not be nil")
+	}
+
+	readDeadline := b.readDeadline
+	if deadline, ok := ctx.Deadline(); ok {
+		readDeadline = deadline
+	} else if !readDeadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, readDeadline)
+		defer cancel()
+	}
+
+	ds, err := b.openDownloadStreamContext(ctx, bson.D{{"_id", fileID}})
+	if err != nil {
+		return nil, err
+	}
+	if err := ds.SetReadDeadline(readDeadline); err != nil {
+		_ = ds.Close()
+		return nil, err
+	}
+
+	r, err := transform(ds)
+	if err != nil {
+		_ = ds.Close()
+		return nil, err
+	}
+	return &TransformDownloadStream{ds: ds, r: r}, nil
+}
+
+// setTransformMetadata returns metadata with TransformMetadataKey set to name.
+func setTransformMetadata(metadata bson.D, name string) bson.D {
+	for i, elem := range metadata {
+		if elem.Key == TransformMetadataKey {
+			metadata[i].Value = name
+			return metadata
+		}
+	}
+	return append(metadata, bson.E{TransformMetadataKey, name})
+}
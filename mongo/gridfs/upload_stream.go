@@ -44,6 +44,20 @@ type UploadStream struct {
 	writeDeadline time.Time
 }
 
+// ResumableUploadStream is an UploadStream that continues an interrupted upload. Bytes written to the stream are
+// appended after the last chunk that was fully written by the interrupted upload.
+type ResumableUploadStream struct {
+	*UploadStream
+
+	offset int64
+}
+
+// Offset returns the number of bytes of the file that were already stored when the upload was resumed. The caller
+// should write the file contents starting at this offset.
+func (rus *ResumableUploadStream) Offset() int64 {
+	return rus.offset
+}
+
 // NewUploadStream creates a new upload stream.
 func newUploadStream(upload *Upload, fileID interface{}, filename string, chunks, files *mongo.Collection) *UploadStream {
 	return &UploadStream{
@@ -63,7 +77,7 @@ func (us *UploadStream) Close() error {
 		return ErrStreamClosed
 	}
 
-	ctx, cancel := deadlineContext(us.writeDeadline)
+	ctx, cancel := streamContext(us.writeDeadline)
 	if cancel != nil {
 		defer cancel()
 	}
@@ -101,7 +115,7 @@ func (us *UploadStream) Write(p []byte) (int, error) {
 
 	var ctx context.Context
 
-	ctx, cancel := deadlineContext(us.writeDeadline)
+	ctx, cancel := streamContext(us.writeDeadline)
 	if cancel != nil {
 		defer cancel()
 	}
@@ -132,7 +146,7 @@ func (us *UploadStream) Abort() error {
 		return ErrStreamClosed
 	}
 
-	ctx, cancel := deadlineContext(us.writeDeadline)
+	ctx, cancel := streamContext(us.writeDeadline)
 	if cancel != nil {
 		defer cancel()
 	}
@@ -0,0 +1,205 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+const (
+	// indexEstimateSampleSize is the number of documents sampled by IndexView.EstimateBuild.
+	indexEstimateSampleSize = 1000
+
+	// indexEntryOverhead is the approximate number of bytes an index entry needs in addition to its key values,
+	// mostly for the record ID of the indexed document.
+	indexEntryOverhead = 8
+
+	// indexScanBytesPerSecond and indexKeysPerSecond are conservative rates at which a server scans the collection
+	// and sorts and inserts keys during an index build.
+	indexScanBytesPerSecond = 64 << 20
+	indexKeysPerSecond      = 250000
+
+	// indexBackgroundBuildThreshold is the estimated build time above which a build is advised to be scheduled for
+	// a low-traffic window.
+	indexBackgroundBuildThreshold = time.Minute
+)
+
+// IndexBuildEstimate is a heuristic estimate of the size of an index and the time it takes to build it, returned by
+// IndexView.EstimateBuild.
+type IndexBuildEstimate struct {
+	// DocumentCount is the number of documents in the collection.
+	DocumentCount int64
+
+	// DataSize is the uncompressed size of the documents in the collection in bytes.
+	DataSize int64
+
+	// SampleSize is the number of documents that were sampled to estimate the index keys.
+	SampleSize int
+
+	// EntriesPerDocument is the average number of index entries per sampled document. It is greater than 1 for
+	// multikey indexes on array fields.
+	EntriesPerDocument float64
+
+	// Cardinality is the ratio of distinct keys to index entries in the sample, between 0 and 1. A value close to 1
+	// means that the index is very selective.
+	Cardinality float64
+
+	// EstimatedBytes is the estimated uncompressed size of the index in bytes.
+	EstimatedBytes int64
+
+	// EstimatedBuildTime is the estimated time it takes to build the index on an otherwise idle server.
+	EstimatedBuildTime time.Duration
+
+	// BackgroundAdvised is true if the build is expected to take long enough that it should be scheduled for a
+	// low-traffic window. Index builds hold exclusive locks at their start and end and compete with the application
+	// for resources while they run.
+	BackgroundAdvised bool
+}
+
+// EstimateBuild estimates the size of the index described by model and the time it takes to build it without creating
+// the index. The estimate is based on the collection statistics reported by the $collStats aggregation stage and on
+// the keys of a random sample of documents selected with the $sample stage.
+//
+// The estimate is a heuristic intended for scheduling index builds, not an exact prediction. Storage engine
+// compression, the server's hardware, and concurrent load can all cause the actual size and build time to differ
+// significantly. Options such as PartialFilterExpression and Sparse are ignored, so the estimate is an upper bound for
+// partial and sparse indexes. Text and geospatial keys are estimated like regular keys on the same fields.
+//
+// The model parameter is validated in the same way as for CreateOne.
+func (iv IndexView) EstimateBuild(ctx context.Context, model IndexModel) (*IndexBuildEstimate, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if model.Keys == nil {
+		return nil, fmt.Errorf("index model keys cannot be nil")
+	}
+	if isUnorderedMap(model.Keys) {
+		return nil, ErrMapForOrderedArgument{"keys"}
+	}
+	keys, err := marshal(model.Keys, iv.coll.bsonOpts, iv.coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getOrGenerateIndexName(keys, model); err != nil {
+		return nil, err
+	}
+	if model.Options != nil {
+		if _, err := iv.createOptionsDoc(model.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	stats, err := iv.coll.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := iv.coll.Aggregate(ctx, Pipeline{{{"$sample", bson.D{{"size", indexEstimateSampleSize}}}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sample []bson.Raw
+	for cursor.Next(ctx) {
+		sample = append(sample, append(bson.Raw(nil), cursor.Current...))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return newIndexBuildEstimate(keys, stats.Count, stats.Size, sample), nil
+}
+
+// newIndexBuildEstimate estimates the build of an index with the given keys on a collection with count documents of
+// total size bytes from a sample of its documents.
+func newIndexBuildEstimate(keys bsoncore.Document, count, size int64, sample []bson.Raw) *IndexBuildEstimate {
+	est := &IndexBuildEstimate{
+		DocumentCount: count,
+		DataSize:      size,
+		SampleSize:    len(sample),
+	}
+
+	elems, _ := keys.Elements()
+	var entries, entryBytes int
+	distinct := make(map[string]struct{})
+	for _, doc := range sample {
+		for _, key := range indexKeys(elems, doc) {
+			entries++
+			entryBytes += len(key) + indexEntryOverhead
+			distinct[key] = struct{}{}
+		}
+	}
+
+	var avgEntryBytes float64
+	if entries > 0 {
+		est.EntriesPerDocument = float64(entries) / float64(len(sample))
+		est.Cardinality = float64(len(distinct)) / float64(entries)
+		avgEntryBytes = float64(entryBytes) / float64(entries)
+	}
+
+	totalEntries := est.EntriesPerDocument * float64(count)
+	est.EstimatedBytes = int64(totalEntries * avgEntryBytes)
+
+	buildSeconds := float64(size)/indexScanBytesPerSecond + totalEntries/indexKeysPerSecond
+	est.EstimatedBuildTime = time.Duration(buildSeconds * float64(time.Second))
+	est.BackgroundAdvised = est.EstimatedBuildTime >= indexBackgroundBuildThreshold
+
+	return est
+}
+
+// indexKeys returns the index keys generated for doc by an index with the given key elements, encoded as the
+// concatenation of the raw key values. A document generates one key for each element of an indexed array. Missing
+// fields are indexed as null.
+func indexKeys(keyElems []bsoncore.Element, doc bson.Raw) []string {
+	keys := []string{""}
+	for _, elem := range keyElems {
+		val, err := doc.LookupErr(strings.Split(elem.Key(), ".")...)
+		if err != nil {
+			val = bson.RawValue{Type: bsontype.Null}
+		}
+
+		values := []bson.RawValue{val}
+		if arr, ok := val.ArrayOK(); ok {
+			if vals, err := arr.Values(); err == nil && len(vals) > 0 {
+				values = vals
+			}
+		}
+
+		next := make([]string, 0, len(keys)*len(values))
+		for _, prefix := range keys {
+			for _, v := range values {
+				next = append(next, prefix+indexKeyValue(elem.Value(), v))
+			}
+		}
+		keys = next
+	}
+	return keys
+}
+
+// indexKeyValue returns the encoding of v as a key of an index field with the given key type.
+func indexKeyValue(keyType bsoncore.Value, v bson.RawValue) string {
+	key := string(v.Type) + string(v.Value)
+	if s, ok := keyType.StringValueOK(); ok && s == "hashed" {
+		// Hashed indexes store a 64-bit hash of the value instead of the value itself.
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(key))
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, h.Sum64())
+		return string(b)
+	}
+	return key
+}
@@ -0,0 +1,123 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestNewIndexBuildEstimate(t *testing.T) {
+	marshalDocs := func(t *testing.T, docs ...bson.D) []bson.Raw {
+		t.Helper()
+
+		raws := make([]bson.Raw, 0, len(docs))
+		for _, doc := range docs {
+			raw, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			raws = append(raws, raw)
+		}
+		return raws
+	}
+	keys := func(t *testing.T, doc bson.D) bsoncore.Document {
+		t.Helper()
+
+		raw, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return raw
+	}
+
+	sample := marshalDocs(t,
+		bson.D{{"a", int32(1)}, {"b", bson.D{{"c", "x"}}}, {"tags", bson.A{"p", "q"}}},
+		bson.D{{"a", int32(2)}, {"b", bson.D{{"c", "x"}}}, {"tags", bson.A{"p"}}},
+		bson.D{{"a", int32(3)}, {"tags", bson.A{}}},
+		bson.D{{"a", int32(3)}},
+	)
+
+	testCases := []struct {
+		name         string
+		keys         bson.D
+		entries      float64
+		cardinality  float64
+		avgEntrySize int64
+	}{
+		// Each int32 key is 1 type byte and 4 value bytes.
+		{"single field", bson.D{{"a", 1}}, 1, 0.75, 1 + 4 + indexEntryOverhead},
+		// Missing fields are indexed as null, which has no value bytes.
+		{"dotted path", bson.D{{"b.c", 1}}, 1, 0.5, (2*(1+6)+2*1)/4 + indexEntryOverhead},
+		// Array elements are indexed individually and empty arrays are indexed as a single key.
+		{"multikey", bson.D{{"tags", 1}}, 5.0 / 4, 0.8, (3*(1+6)+1*(1+5)+1*1)/5 + indexEntryOverhead},
+		{"hashed", bson.D{{"a", "hashed"}}, 1, 0.75, 8 + indexEntryOverhead},
+		{"compound", bson.D{{"a", 1}, {"b.c", -1}}, 1, 0.75, 1 + 4 + (2*(1+6)+2*1)/4 + indexEntryOverhead},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			const count = 1000
+			est := newIndexBuildEstimate(keys(t, tc.keys), count, 64*count, sample)
+
+			assert.Equal(t, int64(count), est.DocumentCount, "expected document count %v, got %v", count,
+				est.DocumentCount)
+			assert.Equal(t, len(sample), est.SampleSize, "expected sample size %v, got %v", len(sample), est.SampleSize)
+			assert.Equal(t, tc.entries, est.EntriesPerDocument, "expected %v entries per document, got %v",
+				tc.entries, est.EntriesPerDocument)
+			assert.Equal(t, tc.cardinality, est.Cardinality, "expected cardinality %v, got %v", tc.cardinality,
+				est.Cardinality)
+
+			entries := int64(tc.entries * count)
+			assert.True(t, est.EstimatedBytes >= entries*tc.avgEntrySize && est.EstimatedBytes <= entries*(tc.avgEntrySize+1),
+				"expected about %v bytes, got %v", entries*tc.avgEntrySize, est.EstimatedBytes)
+			assert.False(t, est.BackgroundAdvised, "expected a background build not to be advised")
+		})
+	}
+
+	t.Run("empty sample", func(t *testing.T) {
+		est := newIndexBuildEstimate(keys(t, bson.D{{"a", 1}}), 0, 0, nil)
+		assert.Equal(t, &IndexBuildEstimate{}, est, "expected empty estimate, got %+v", est)
+	})
+	t.Run("large collection", func(t *testing.T) {
+		const count = 100_000_000
+		est := newIndexBuildEstimate(keys(t, bson.D{{"a", 1}}), count, 64*count, sample)
+
+		assert.True(t, est.EstimatedBuildTime > time.Minute, "expected a long build, got %v", est.EstimatedBuildTime)
+		assert.True(t, est.BackgroundAdvised, "expected a background build to be advised")
+	})
+}
+
+func TestEstimateBuildValidation(t *testing.T) {
+	iv := setupColl("foo").Indexes()
+
+	testCases := []struct {
+		name  string
+		model IndexModel
+		want  error
+	}{
+		{"nil keys", IndexModel{}, nil},
+		{"multi-key map", IndexModel{Keys: bson.M{"a": 1, "b": 1}}, ErrMapForOrderedArgument{"keys"}},
+		{"invalid key value", IndexModel{Keys: bson.D{{"a", true}}}, ErrInvalidIndexValue},
+		{
+			"invalid options",
+			IndexModel{Keys: bson.D{{"a", 1}}, Options: options.Index().SetStorageEngine(42)},
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iv.EstimateBuild(context.Background(), tc.model)
+			assert.NotNil(t, err, "expected error, got nil")
+			if tc.want != nil {
+				assert.Equal(t, tc.want, err, "expected error %v, got %v", tc.want, err)
+			}
+			assert.NotEqual(t, ErrClientDisconnected, err, "expected the model to be rejected before running commands")
+		})
+	}
+}
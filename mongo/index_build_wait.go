@@ -0,0 +1,111 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// defaultIndexBuildPoll is the interval at which WaitForBuild checks the index if the poll argument is not positive.
+const defaultIndexBuildPoll = 500 * time.Millisecond
+
+// WaitForBuild blocks until the index with the given name exists on the collection and is no longer being built,
+// checking every poll interval. A poll interval that is not positive defaults to 500ms. It is intended for
+// migrations that must not proceed until an index created with CreateOne or CreateMany is ready to be used.
+//
+// An index is considered built once it is returned by the listIndexes command and no createIndexes operation for it
+// is reported by the $currentOp aggregation stage. Servers running 4.4 and later only list indexes that are ready,
+// while earlier servers list background index builds as soon as they start, so $currentOp is needed to detect them.
+// If $currentOp fails with an Unauthorized error because the user does not have the privileges to run it with
+// allUsers, only listIndexes is used. Other errors are returned.
+//
+// An index that does not exist is treated as a build that has not started yet, so ctx should have a deadline. If ctx
+// expires before the index is built, WaitForBuild returns an error that wraps ctx.Err(), and IsTimeout reports true
+// for it if the deadline was exceeded.
+func (iv IndexView) WaitForBuild(ctx context.Context, indexName string, poll time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if indexName == "" {
+		return errors.New("index name must not be empty")
+	}
+	if poll <= 0 {
+		poll = defaultIndexBuildPoll
+	}
+
+	useCurrentOp := true
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		names, err := iv.indexNames(ctx)
+		if err != nil {
+			return iv.waitError(ctx, indexName, err)
+		}
+		if names[indexName] {
+			building := false
+			if useCurrentOp {
+				building, err = iv.indexBuildInProgress(ctx, indexName)
+				if se := ServerError(nil); errors.As(err, &se) && se.HasErrorCode(errCodeUnauthorized) {
+					// The user cannot run $currentOp, so fall back to listIndexes for the rest of the wait.
+					useCurrentOp = false
+					building, err = false, nil
+				}
+				if err != nil {
+					return iv.waitError(ctx, indexName, err)
+				}
+			}
+			if !building {
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return iv.waitError(ctx, indexName, ctx.Err())
+		}
+	}
+}
+
+// waitError returns the error for a WaitForBuild call that failed with err. If ctx is done, the error wraps ctx.Err()
+// so that it can be recognized as a timeout.
+func (iv IndexView) waitError(ctx context.Context, indexName string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("index %q was not built before the context was done: %w", indexName, ctxErr)
+	}
+	return fmt.Errorf("error waiting for index %q to be built: %w", indexName, err)
+}
+
+// indexBuildInProgress reports whether $currentOp reports a createIndexes operation that builds the index with the
+// given name on the collection.
+func (iv IndexView) indexBuildInProgress(ctx context.Context, indexName string) (bool, error) {
+	pipeline := Pipeline{
+		{{"$currentOp", bson.D{{"allUsers", true}, {"idleConnections", false}}}},
+		{{"$match", bson.D{
+			{"ns", bson.D{{"$in", bson.A{
+				iv.coll.db.name + "." + iv.coll.name,
+				iv.coll.db.name + ".$cmd",
+			}}}},
+			{"command.createIndexes", iv.coll.name},
+			{"command.indexes.name", indexName},
+		}}},
+		{{"$limit", 1}},
+	}
+	cursor, err := iv.coll.client.Database("admin").Aggregate(ctx, pipeline)
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close(context.Background())
+
+	building := cursor.Next(ctx)
+	return building, cursor.Err()
+}
@@ -0,0 +1,41 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestIndexViewWaitError(t *testing.T) {
+	var iv IndexView
+	listErr := errors.New("listIndexes error")
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		err := iv.waitError(ctx, "x_1", listErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "expected error %v, got %v", context.DeadlineExceeded, err)
+		assert.True(t, IsTimeout(err), "expected IsTimeout to be true for %v", err)
+	})
+	t.Run("command error", func(t *testing.T) {
+		err := iv.waitError(context.Background(), "x_1", listErr)
+		assert.ErrorIs(t, err, listErr, "expected error %v, got %v", listErr, err)
+		assert.False(t, IsTimeout(err), "expected IsTimeout to be false for %v", err)
+	})
+}
+
+func TestIndexViewWaitForBuildEmptyName(t *testing.T) {
+	err := IndexView{}.WaitForBuild(context.Background(), "", time.Millisecond)
+	assert.NotNil(t, err, "expected an error, got nil")
+}
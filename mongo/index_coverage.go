@@ -0,0 +1,118 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CoversQuery explains a find command with the given filter, sort, and projection and reports which index its winning
+// plan uses and whether the query is covered by that index, meaning that the server can answer it from the index keys
+// alone without fetching any documents. A nil sort or projection is omitted from the command. It is intended for tests
+// that assert that a query is served efficiently, and it does not run the query.
+//
+// If the winning plan does not scan an index, such as a collection scan, CoversQuery returns an empty index name and
+// false. If the plan scans several indexes, the name of the first one is returned. On a sharded cluster, the query is
+// only reported as covered if the winning plan of every shard is covered.
+//
+// A query can only be covered if the projection excludes every field that is not in the index, including "_id".
+func (iv IndexView) CoversQuery(ctx context.Context, filter, sort, projection bson.D) (string, bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	find := bson.D{{"find", iv.coll.name}, {"filter", filter}}
+	if sort != nil {
+		find = append(find, bson.E{Key: "sort", Value: sort})
+	}
+	if projection != nil {
+		find = append(find, bson.E{Key: "projection", Value: projection})
+	}
+	coll := iv.coll
+	cmd, err := marshal(bson.D{{"explain", find}, {"verbosity", "queryPlanner"}}, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return "", false, err
+	}
+
+	res, err := coll.db.RunCommand(ctx, bson.Raw(cmd), options.RunCmd().SetReadPreference(coll.readPreference)).Raw()
+	if err != nil {
+		return "", false, err
+	}
+	plan, err := res.LookupErr("queryPlanner", "winningPlan")
+	if err != nil {
+		return "", false, fmt.Errorf("explain output does not contain a winning plan: %w", err)
+	}
+
+	var stats planCoverage
+	stats.walk(plan)
+	return stats.indexName, stats.covered(), nil
+}
+
+// planCoverage records the stages of a query plan that determine whether it is covered by an index.
+type planCoverage struct {
+	indexName string
+	indexScan bool
+	fetch     bool
+}
+
+// covered reports whether the plan scans an index and does not read any documents.
+func (pc *planCoverage) covered() bool {
+	return pc.indexScan && !pc.fetch
+}
+
+// walk visits the stage in val and all of its input stages. It handles the classic plan format, the format of the
+// slot-based execution engine, which nests the plan in a queryPlan field, and the per-shard plans of a sharded
+// cluster.
+func (pc *planCoverage) walk(val bson.RawValue) {
+	plan, ok := val.DocumentOK()
+	if !ok {
+		return
+	}
+
+	stage, _ := plan.Lookup("stage").StringValueOK()
+	switch stage {
+	case "IXSCAN", "COUNT_SCAN", "DISTINCT_SCAN":
+		pc.indexScan = true
+		if pc.indexName == "" {
+			pc.indexName, _ = plan.Lookup("indexName").StringValueOK()
+		}
+	case "FETCH", "COLLSCAN", "IDHACK", "EXPRESS_IXSCAN", "EXPRESS_CLUSTERED_IXSCAN":
+		// These stages read full documents. IDHACK and the EXPRESS stages look up documents by an index, which is
+		// still reported as the index used.
+		pc.fetch = true
+		name, _ := plan.Lookup("indexName").StringValueOK()
+		if stage == "IDHACK" {
+			name = "_id_"
+		}
+		if pc.indexName == "" {
+			pc.indexName = name
+		}
+	}
+
+	for _, key := range []string{"inputStage", "queryPlan", "winningPlan"} {
+		if child, err := plan.LookupErr(key); err == nil {
+			pc.walk(child)
+		}
+	}
+	for _, key := range []string{"inputStages", "shards"} {
+		arr, ok := plan.Lookup(key).ArrayOK()
+		if !ok {
+			continue
+		}
+		values, _ := arr.Values()
+		for _, child := range values {
+			pc.walk(child)
+		}
+	}
+}
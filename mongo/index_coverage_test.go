@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestPlanCoverage(t *testing.T) {
+	ixscan := func(name string) bson.D {
+		return bson.D{{"stage", "IXSCAN"}, {"keyPattern", bson.D{{"x", 1}}}, {"indexName", name}}
+	}
+
+	testCases := []struct {
+		name      string
+		plan      bson.D
+		wantIndex string
+		covered   bool
+	}{
+		{
+			"covered",
+			bson.D{{"stage", "PROJECTION_COVERED"}, {"inputStage", ixscan("x_1")}},
+			"x_1",
+			true,
+		},
+		{
+			"fetch",
+			bson.D{{"stage", "FETCH"}, {"inputStage", ixscan("x_1")}},
+			"x_1",
+			false,
+		},
+		{
+			"collection scan",
+			bson.D{{"stage", "COLLSCAN"}, {"direction", "forward"}},
+			"",
+			false,
+		},
+		{
+			"_id lookup",
+			bson.D{{"stage", "IDHACK"}},
+			"_id_",
+			false,
+		},
+		{
+			"slot-based engine",
+			bson.D{{"queryPlan", bson.D{{"stage", "PROJECTION_COVERED"}, {"inputStage", ixscan("x_1")}}}},
+			"x_1",
+			true,
+		},
+		{
+			"or of index scans",
+			bson.D{{"stage", "PROJECTION_COVERED"}, {"inputStage", bson.D{
+				{"stage", "OR"},
+				{"inputStages", bson.A{ixscan("x_1"), ixscan("y_1")}},
+			}}},
+			"x_1",
+			true,
+		},
+		{
+			"sharded with one shard fetching",
+			bson.D{{"stage", "SHARD_MERGE"}, {"shards", bson.A{
+				bson.D{{"shardName", "a"}, {"winningPlan", bson.D{{"stage", "PROJECTION_COVERED"},
+					{"inputStage", ixscan("x_1")}}}},
+				bson.D{{"shardName", "b"}, {"winningPlan", bson.D{{"stage", "FETCH"},
+					{"inputStage", ixscan("x_1")}}}},
+			}}},
+			"x_1",
+			false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := bson.Marshal(bson.D{{"plan", tc.plan}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var pc planCoverage
+			pc.walk(bson.Raw(doc).Lookup("plan"))
+			assert.Equal(t, tc.wantIndex, pc.indexName, "expected index %q, got %q", tc.wantIndex, pc.indexName)
+			covered := pc.covered()
+			assert.Equal(t, tc.covered, covered, "expected covered to be %v, got %v", tc.covered, covered)
+		})
+	}
+}
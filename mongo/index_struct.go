@@ -0,0 +1,348 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var tTime = reflect.TypeOf(time.Time{})
+
+// structIndex is an index described by the index struct tags of one or more fields.
+type structIndex struct {
+	keys   bson.D
+	group  string
+	name   string
+	unique bool
+	sparse bool
+	ttl    *int32
+}
+
+func (si *structIndex) model() IndexModel {
+	opts := options.Index()
+	if si.name != "" {
+		opts.SetName(si.name)
+	}
+	if si.unique {
+		opts.SetUnique(true)
+	}
+	if si.sparse {
+		opts.SetSparse(true)
+	}
+	if si.ttl != nil {
+		opts.SetExpireAfterSeconds(*si.ttl)
+	}
+	return IndexModel{Keys: si.keys, Options: opts}
+}
+
+// EnsureFromStruct creates the indexes described by the "index" struct tags of v, which must be a struct or a pointer
+// to a struct, and returns the names of the indexes that were created. Indexes whose keys match the keys of an index
+// that already exists on the collection are skipped, so EnsureFromStruct can be called every time an application
+// starts. The options of an existing index are not compared or changed. Because a collection can have at most one text
+// index, a text index matches an existing text index with the same non-text keys even if its text fields differ.
+//
+// The field names of the indexes are the BSON field names of the struct fields, as determined by their "bson" struct
+// tags. Fields of embedded or nested struct types are indexed with dotted field names, except for inline fields.
+// The "index" tag has the following grammar:
+//
+//	index:"<index>[;<index>...]"
+//	<index>: <option>[,<option>...]
+//
+// Each <index> describes an index that includes the field. The options of an index are:
+//
+//   - 1, -1, text, hashed, 2d, or 2dsphere: the index type of the field. The default is 1 (ascending).
+//   - unique: create a unique index.
+//   - sparse: create a sparse index.
+//   - ttl:<duration>: create a TTL index that expires documents the given time after the date in the field. The duration
+//     is a number of seconds or a Go duration such as "24h". TTL indexes can only have one field.
+//   - compound:<group>: add the field to the compound index named group. The fields of a compound index are ordered
+//     as they appear in the struct, and the unique and sparse options apply to the whole index if they are set on any
+//     of its fields.
+//   - name:<name>: the name of the index. The default name is generated from its keys.
+//
+// Example usage:
+//
+//	type User struct {
+//		Email     string    `bson:"email" index:"unique"`
+//		LastName  string    `bson:"lastName" index:"compound:contact,1"`
+//		FirstName string    `bson:"firstName" index:"compound:contact,1"`
+//		CreatedAt time.Time `bson:"createdAt" index:"ttl:720h"`
+//	}
+//
+//	names, err := coll.Indexes().EnsureFromStruct(ctx, User{})
+func (iv IndexView) EnsureFromStruct(ctx context.Context, v interface{}) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("EnsureFromStruct requires a struct or a pointer to a struct, got %T", v)
+	}
+	indexes, err := parseStructIndexes(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) == 0 {
+		return []string{}, nil
+	}
+
+	specs, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []IndexModel
+	for _, si := range indexes {
+		keys, err := bson.Marshal(si.keys)
+		if err != nil {
+			return nil, err
+		}
+		exists := false
+		for _, spec := range specs {
+			if indexKeysEqual(spec.KeysDocument, keys) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			models = append(models, si.model())
+		}
+	}
+	if len(models) == 0 {
+		return []string{}, nil
+	}
+	return iv.CreateMany(ctx, models)
+}
+
+// indexKeysEqual returns true if a and b are index key documents with the same fields in the same order and the
+// same index types. Numeric index types are compared by value, so {a: 1} and {a: 1.0} are equal.
+//
+// The server lists the keys of a text index as {_fts: "text", _ftsx: 1} in place of the text fields, so the text
+// fields of both documents are replaced the same way before they are compared. The text fields themselves are not
+// compared, which is sufficient because a collection can have at most one text index.
+func indexKeysEqual(a, b bson.Raw) bool {
+	aElems, err := textIndexKeys(a)
+	if err != nil {
+		return false
+	}
+	bElems, err := textIndexKeys(b)
+	if err != nil || len(aElems) != len(bElems) {
+		return false
+	}
+	for i := range aElems {
+		if aElems[i].key != bElems[i].key {
+			return false
+		}
+		aVal, bVal := aElems[i].val, bElems[i].val
+		aNum, aIsNum := aVal.AsInt64OK()
+		bNum, bIsNum := bVal.AsInt64OK()
+		switch {
+		case aIsNum && bIsNum:
+			if aNum != bNum {
+				return false
+			}
+		case !aVal.Equal(bVal):
+			return false
+		}
+	}
+	return true
+}
+
+// indexKey is a field of an index key document.
+type indexKey struct {
+	key string
+	val bson.RawValue
+}
+
+// textIndexKeys returns the fields of the index key document keys with its text fields replaced by the _fts and _ftsx
+// fields that the server lists for text indexes.
+func textIndexKeys(keys bson.Raw) ([]indexKey, error) {
+	elems, err := keys.Elements()
+	if err != nil {
+		return nil, err
+	}
+	fts := bson.RawValue{Type: bsontype.String, Value: bsoncore.AppendString(nil, "text")}
+	ftsx := bson.RawValue{Type: bsontype.Int32, Value: bsoncore.AppendInt32(nil, 1)}
+
+	normalized := make([]indexKey, 0, len(elems))
+	text := false
+	for _, elem := range elems {
+		if elem.Key() == "_ftsx" {
+			continue
+		}
+		if s, ok := elem.Value().StringValueOK(); ok && s == "text" {
+			if !text {
+				normalized = append(normalized, indexKey{"_fts", fts}, indexKey{"_ftsx", ftsx})
+				text = true
+			}
+			continue
+		}
+		normalized = append(normalized, indexKey{elem.Key(), elem.Value()})
+	}
+	return normalized, nil
+}
+
+// parseStructIndexes returns the indexes described by the index struct tags of the fields of t, in the order they
+// first appear.
+func parseStructIndexes(t reflect.Type) ([]*structIndex, error) {
+	var indexes []*structIndex
+	groups := make(map[string]*structIndex)
+	err := walkIndexFields(t, "", map[reflect.Type]bool{}, func(field, tag string) error {
+		for _, spec := range strings.Split(tag, ";") {
+			si, err := parseIndexSpec(field, spec)
+			if err != nil {
+				return err
+			}
+			if si.group == "" {
+				indexes = append(indexes, si)
+				continue
+			}
+			group, ok := groups[si.group]
+			if !ok {
+				groups[si.group] = si
+				indexes = append(indexes, si)
+				continue
+			}
+			if si.ttl != nil {
+				return fmt.Errorf("TTL index option on field %q of compound index %q", field, si.group)
+			}
+			group.keys = append(group.keys, si.keys...)
+			group.unique = group.unique || si.unique
+			group.sparse = group.sparse || si.sparse
+			if group.name == "" {
+				group.name = si.name
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, si := range indexes {
+		if si.group != "" && si.ttl != nil && len(si.keys) > 1 {
+			return nil, fmt.Errorf("TTL index option on compound index %q", si.group)
+		}
+	}
+	return indexes, nil
+}
+
+// walkIndexFields calls fn with the dotted BSON field name and index tag of each field of t that has an index tag,
+// recursing into fields of struct types. seen holds the struct types being walked to stop recursive types.
+func walkIndexFields(t reflect.Type, prefix string, seen map[reflect.Type]bool, fn func(field, tag string) error) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(sf)
+		if err != nil {
+			return err
+		}
+		if tags.Skip {
+			continue
+		}
+
+		name := prefix + tags.Name
+		if tag, ok := sf.Tag.Lookup("index"); ok {
+			if err := fn(name, tag); err != nil {
+				return err
+			}
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct || ft == tTime {
+			continue
+		}
+		nested := name + "."
+		if tags.Inline {
+			nested = prefix
+		}
+		if err := walkIndexFields(ft, nested, seen, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseIndexSpec parses the options of one index in an index struct tag on field.
+func parseIndexSpec(field, spec string) (*structIndex, error) {
+	si := &structIndex{}
+	var indexType interface{} = int32(1)
+	for _, opt := range strings.Split(spec, ",") {
+		opt = strings.TrimSpace(opt)
+		key, value, hasValue := strings.Cut(opt, ":")
+		switch {
+		case opt == "":
+		case opt == "1":
+			indexType = int32(1)
+		case opt == "-1":
+			indexType = int32(-1)
+		case opt == "text" || opt == "hashed" || opt == "2d" || opt == "2dsphere":
+			indexType = opt
+		case opt == "unique":
+			si.unique = true
+		case opt == "sparse":
+			si.sparse = true
+		case hasValue && key == "ttl":
+			seconds, err := parseTTL(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL %q in index tag of field %q: %w", value, field, err)
+			}
+			si.ttl = &seconds
+		case hasValue && key == "compound" && value != "":
+			si.group = value
+		case hasValue && key == "name" && value != "":
+			si.name = value
+		default:
+			return nil, fmt.Errorf("invalid option %q in index tag of field %q", opt, field)
+		}
+	}
+	si.keys = bson.D{{field, indexType}}
+	return si, nil
+}
+
+// parseTTL parses a TTL given as a number of seconds or as a Go duration.
+func parseTTL(s string) (int32, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 32); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("TTL must not be negative")
+		}
+		return int32(seconds), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d/time.Second > 1<<31-1 {
+		return 0, fmt.Errorf("TTL must be between 0 and 2147483647 seconds")
+	}
+	return int32(d / time.Second), nil
+}
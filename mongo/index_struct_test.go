@@ -0,0 +1,167 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestParseStructIndexes(t *testing.T) {
+	t.Parallel()
+
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	type address struct {
+		City string `bson:"city" index:"1"`
+	}
+	type base struct {
+		Tenant string `bson:"tenant" index:"hashed"`
+	}
+	type node struct {
+		Name string `bson:"name" index:"text"`
+		Next *node  `bson:"next"`
+	}
+
+	testCases := []struct {
+		name string
+		val  interface{}
+		want []*structIndex
+	}{
+		{
+			name: "unique",
+			val: struct {
+				Email string `bson:"email" index:"unique"`
+				Other string `bson:"other"`
+			}{},
+			want: []*structIndex{
+				{keys: bson.D{{"email", int32(1)}}, unique: true},
+			},
+		},
+		{
+			name: "compound",
+			val: struct {
+				LastName  string `bson:"lastName" index:"compound:contact,1"`
+				Email     string `bson:"email" index:"unique,sparse,name:by_email"`
+				FirstName string `bson:"firstName" index:"compound:contact,-1,unique"`
+			}{},
+			want: []*structIndex{
+				{keys: bson.D{{"lastName", int32(1)}, {"firstName", int32(-1)}}, group: "contact", unique: true},
+				{keys: bson.D{{"email", int32(1)}}, name: "by_email", unique: true, sparse: true},
+			},
+		},
+		{
+			name: "ttl",
+			val: struct {
+				CreatedAt time.Time `bson:"createdAt" index:"ttl:3600"`
+				ExpiresAt time.Time `bson:"expiresAt" index:"-1;ttl:24h"`
+			}{},
+			want: []*structIndex{
+				{keys: bson.D{{"createdAt", int32(1)}}, ttl: int32Ptr(3600)},
+				{keys: bson.D{{"expiresAt", int32(-1)}}},
+				{keys: bson.D{{"expiresAt", int32(1)}}, ttl: int32Ptr(86400)},
+			},
+		},
+		{
+			name: "nested and inline",
+			val: struct {
+				Address address `bson:"address"`
+				Base    base    `bson:",inline"`
+				Root    node    `bson:"root"`
+			}{},
+			want: []*structIndex{
+				{keys: bson.D{{"address.city", int32(1)}}},
+				{keys: bson.D{{"tenant", "hashed"}}},
+				{keys: bson.D{{"root.name", "text"}}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseStructIndexes(reflect.TypeOf(tc.val))
+			require.NoError(t, err, "parseStructIndexes error")
+			assert.Equal(t, tc.want, got, "expected indexes to match")
+		})
+	}
+}
+
+func TestParseStructIndexesErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		val  interface{}
+	}{
+		{
+			name: "unknown option",
+			val: struct {
+				A string `bson:"a" index:"descending"`
+			}{},
+		},
+		{
+			name: "invalid ttl",
+			val: struct {
+				A time.Time `bson:"a" index:"ttl:soon"`
+			}{},
+		},
+		{
+			name: "negative ttl",
+			val: struct {
+				A time.Time `bson:"a" index:"ttl:-5"`
+			}{},
+		},
+		{
+			name: "ttl on compound index",
+			val: struct {
+				A time.Time `bson:"a" index:"compound:g,ttl:60"`
+				B string    `bson:"b" index:"compound:g"`
+			}{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseStructIndexes(reflect.TypeOf(tc.val))
+			assert.NotNil(t, err, "expected an error, got nil")
+		})
+	}
+}
+
+func TestIndexKeysEqual(t *testing.T) {
+	t.Parallel()
+
+	marshal := func(d bson.D) bson.Raw {
+		raw, err := bson.Marshal(d)
+		require.NoError(t, err, "Marshal error")
+		return raw
+	}
+
+	keys := marshal(bson.D{{"a", int32(1)}, {"b", "text"}})
+	assert.True(t, indexKeysEqual(keys, marshal(bson.D{{"a", 1.0}, {"b", "text"}})), "expected numeric types to match")
+	assert.False(t, indexKeysEqual(keys, marshal(bson.D{{"b", "text"}, {"a", int32(1)}})), "expected order to matter")
+	assert.False(t, indexKeysEqual(keys, marshal(bson.D{{"a", int32(-1)}, {"b", "text"}})), "expected direction to matter")
+	assert.False(t, indexKeysEqual(keys, marshal(bson.D{{"a", int32(1)}})), "expected length to matter")
+
+	// Text indexes are listed by the server with _fts and _ftsx keys in place of the text fields.
+	listed := marshal(bson.D{{"a", int32(1)}, {"_fts", "text"}, {"_ftsx", int32(1)}})
+	assert.True(t, indexKeysEqual(keys, listed), "expected a listed text index to match")
+	assert.True(t, indexKeysEqual(marshal(bson.D{{"a", int32(1)}, {"b", "text"}, {"c", "text"}}), listed),
+		"expected a listed text index to match several text fields")
+	assert.False(t, indexKeysEqual(marshal(bson.D{{"b", "text"}}), listed), "expected non-text fields to matter")
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -129,7 +130,11 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, iv.coll.bsonOpts, iv.coll.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	iv.coll.client.cursors.add(cursor, bc)
+	return cursor, nil
 }
 
 // ListSpecifications executes a List command and returns a slice of returned IndexSpecifications
@@ -173,7 +178,9 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 // given, it will be generated from the Keys document.
 //
 // The opts parameter can be used to specify options for this operation (see the options.CreateIndexesOptions
-// documentation).
+// documentation). If the RollbackOnError option is set and the operation fails, the indexes created by this call are
+// dropped before the error is returned, even if ctx has expired. Indexes that already existed before the call are
+// never dropped. If dropping any of the indexes fails, an IndexRollbackError wrapping the original error is returned.
 //
 // For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createIndexes/.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
@@ -254,6 +261,14 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 	option := options.MergeCreateIndexesOptions(opts...)
 
+	var existing map[string]bool
+	if option.RollbackOnError != nil && *option.RollbackOnError {
+		existing, err = iv.indexNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO(GODRIVER-3038): This operation should pass CSE to the CreateIndexes
 	// Crypt setter to be applied to the operation.
 	//
@@ -275,12 +290,60 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	err = op.Execute(ctx)
 	if err != nil {
 		_, err = processWriteError(err)
+		if existing != nil {
+			err = iv.rollbackCreate(ctx, names, existing, err)
+		}
 		return nil, err
 	}
 
 	return names, nil
 }
 
+// indexNames returns the set of names of the indexes on the collection.
+func (iv IndexView) indexNames(ctx context.Context) (map[string]bool, error) {
+	specs, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		set[spec.Name] = true
+	}
+	return set, nil
+}
+
+// indexRollbackTimeout bounds the time spent dropping the indexes created by a failed CreateMany call.
+const indexRollbackTimeout = 10 * time.Second
+
+// rollbackCreate drops the indexes in names that exist on the collection but are not in existing after a failed
+// CreateMany call. It returns createErr, or an IndexRollbackError wrapping createErr if the indexes could not be
+// dropped. The create often fails because ctx expired, so the rollback ignores the deadline and cancellation of ctx
+// and runs for up to indexRollbackTimeout instead.
+func (iv IndexView) rollbackCreate(ctx context.Context, names []string, existing map[string]bool, createErr error) error {
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, indexRollbackTimeout)
+	defer cancel()
+
+	current, err := iv.indexNames(ctx)
+	if err != nil {
+		return IndexRollbackError{Err: createErr, RollbackErrors: []error{err}}
+	}
+
+	var rollbackErrs []error
+	for _, name := range names {
+		if existing[name] || !current[name] {
+			continue
+		}
+		if _, err := iv.DropOne(ctx, name); err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Errorf("error dropping index %q: %w", name, err))
+		}
+	}
+	if len(rollbackErrs) > 0 {
+		return IndexRollbackError{Err: createErr, RollbackErrors: rollbackErrs}
+	}
+	return createErr
+}
+
 func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Document, error) {
 	optsDoc := bsoncore.Document{}
 	if opts.Background != nil {
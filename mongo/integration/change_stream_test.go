@@ -340,8 +340,9 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 
 		e := mt.GetStartedEvent()
 		assert.NotNil(mt, e, "expected getMore event, got nil")
-		_, err = e.Command.LookupErr("maxTimeMS")
+		maxTimeMS, err := e.Command.LookupErr("maxTimeMS")
 		assert.Nil(mt, err, "field maxTimeMS not found in command %v", e.Command)
+		assert.Equal(mt, int64(100), maxTimeMS.AsInt64(), "expected maxTimeMS 100, got %v", maxTimeMS)
 	})
 	mt.RunOpts("resume token", noClientOpts, func(mt *mtest.T) {
 		// Prose tests to make assertions on resume tokens for change streams that have not done a getMore yet
@@ -810,6 +811,46 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 
 		wg.Wait()
 	})
+
+	preImagesOpts := mtest.NewOptions().
+		MinServerVersion("6.0").
+		CreateClient(true).
+		CollectionCreateOptions(options.CreateCollection().SetChangeStreamPreAndPostImages(bson.M{"enabled": true}))
+
+	mt.RunOpts("full document before change", preImagesOpts, func(mt *mtest.T) {
+		type idValue struct {
+			ID    int32  `bson:"_id"`
+			Value string `bson:"value"`
+		}
+
+		_, err := mt.Coll.InsertOne(context.Background(), idValue{ID: 1, Value: "before"})
+		require.NoError(mt, err, "InsertOne error")
+
+		opts := options.ChangeStream().SetFullDocumentBeforeChange(options.FullDocumentBeforeChangeRequired)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", int32(1)}},
+			bson.D{{"$set", bson.D{{"value", "after"}}}})
+		require.NoError(mt, err, "UpdateOne error")
+
+		nextCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.True(mt, cs.Next(nextCtx), "expected Next to return true, got false (error %v)", cs.Err())
+
+		var got struct {
+			OperationType            string  `bson:"operationType"`
+			FullDocumentBeforeChange idValue `bson:"fullDocumentBeforeChange"`
+		}
+		err = cs.Decode(&got)
+		require.NoError(mt, err, "Decode error")
+
+		assert.Equal(mt, "update", got.OperationType, "expected operation type %q, got %q", "update", got.OperationType)
+		want := idValue{ID: 1, Value: "before"}
+		assert.Equal(mt, want, got.FullDocumentBeforeChange, "expected pre-image %v, got %v", want,
+			got.FullDocumentBeforeChange)
+	})
 }
 
 func closeStream(cs *mongo.ChangeStream) {
@@ -399,6 +399,41 @@ func TestClientSideEncryptionCustomCrypt(t *testing.T) {
 		assert.Equal(mt, cc.numBypassAutoEncryptionCalls, 2,
 			"expected 2 calls to BypassAutoEncryption, got %v", cc.numBypassAutoEncryptionCalls)
 	})
+	mt.Run("per-operation bypass auto encryption", func(mt *mtest.T) {
+		aeOpts := options.AutoEncryption().
+			SetKmsProviders(kmsProvidersMap).
+			SetKeyVaultNamespace("keyvault.datakeys").
+			SetExtraOptions(getCryptSharedLibExtraOptions())
+		clientOpts := options.Client().
+			ApplyURI(mtest.ClusterURI()).
+			SetAutoEncryptionOptions(aeOpts)
+		cc := &customCrypt{}
+		clientOpts.Crypt = cc
+		integtest.AddTestServerAPIVersion(clientOpts)
+
+		client, err := mongo.Connect(context.Background(), clientOpts)
+		defer client.Disconnect(context.Background())
+		assert.Nil(mt, err, "Connect error: %v", err)
+
+		coll := client.Database("db").Collection("coll")
+		defer func() { _ = coll.Drop(context.Background()) }()
+
+		doc := bson.D{{"foo", "bar"}, {"ssn", "ciphertext"}}
+		_, err = coll.InsertOne(context.Background(), doc, options.InsertOne().SetBypassAutoEncryption(true))
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+
+		res := coll.FindOne(context.Background(), bson.D{{"foo", "bar"}}, options.FindOne().SetBypassAutoEncryption(true))
+		assert.Nil(mt, res.Err(), "FindOne error: %v", res.Err())
+
+		rawRes, err := res.Raw()
+		assert.Nil(mt, err, "Raw error: %v", err)
+		ssn, ok := rawRes.Lookup("ssn").StringValueOK()
+		assert.True(mt, ok, "expected 'ssn' value to be type string, got %T", ssn)
+		assert.Equal(mt, ssn, "ciphertext", "expected 'ssn' value %q, got %q", "ciphertext", ssn)
+
+		assert.Equal(mt, cc.numEncryptCalls, 0,
+			"expected 0 calls to Encrypt, got %v", cc.numEncryptCalls)
+	})
 }
 
 func TestFLE2CreateCollection(t *testing.T) {
@@ -8,6 +8,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -333,6 +334,85 @@ func TestClient(t *testing.T) {
 			_ = client.Disconnect(context.Background())
 		})
 	})
+	mt.RunOpts("server version", noClientOpts, func(mt *mtest.T) {
+		mt.Run("matches server", func(mt *mtest.T) {
+			mt.ClearEvents()
+
+			version, err := mt.Client.ServerVersion(context.Background())
+			assert.Nil(mt, err, "ServerVersion error: %v", err)
+			// Only compare the major and minor versions because the test server version may have a suffix.
+			majorMinor := fmt.Sprintf("%d.%d", version.Major, version.Minor)
+			cmp := mtest.CompareServerVersions(majorMinor, mtest.ServerVersion())
+			assert.Equal(mt, 0, cmp, "expected version %v, got %v", mtest.ServerVersion(), version)
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "buildInfo", evt.CommandName, "expected command %q, got %q", "buildInfo",
+				evt.CommandName)
+		})
+		mt.Run("cached", func(mt *mtest.T) {
+			first, err := mt.Client.ServerVersion(context.Background())
+			assert.Nil(mt, err, "ServerVersion error: %v", err)
+			mt.ClearEvents()
+
+			second, err := mt.Client.ServerVersion(context.Background())
+			assert.Nil(mt, err, "ServerVersion error: %v", err)
+			assert.Equal(mt, first, second, "expected version %v, got %v", first, second)
+			evt := mt.GetStartedEvent()
+			assert.Nil(mt, evt, "expected no commands to be sent, got %v", evt)
+		})
+	})
+	mt.RunOpts("server parameters", noClientOpts, func(mt *mtest.T) {
+		fcvOpts := mtest.NewOptions().MinServerVersion("3.6").Topologies(mtest.Single, mtest.ReplicaSet)
+		mt.RunOpts("featureCompatibilityVersion", fcvOpts, func(mt *mtest.T) {
+			mt.ClearEvents()
+
+			val, err := mt.Client.GetServerParameter(context.Background(), "featureCompatibilityVersion")
+			assert.Nil(mt, err, "GetServerParameter error: %v", err)
+			version, ok := val.Document().Lookup("version").StringValueOK()
+			assert.True(mt, ok, "expected featureCompatibilityVersion to have a version, got %v", val)
+			assert.NotEqual(mt, "", version, "expected a non-empty featureCompatibilityVersion")
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "getParameter", evt.CommandName, "expected command %q, got %q", "getParameter",
+				evt.CommandName)
+			assert.Equal(mt, "admin", evt.DatabaseName, "expected database %q, got %q", "admin", evt.DatabaseName)
+		})
+		mt.Run("all parameters", func(mt *mtest.T) {
+			params, err := mt.Client.GetAllServerParameters(context.Background())
+			assert.Nil(mt, err, "GetAllServerParameters error: %v", err)
+			_, err = params.LookupErr("authenticationMechanisms")
+			assert.Nil(mt, err, "expected authenticationMechanisms parameter in %v", params)
+			_, err = params.LookupErr("ok")
+			assert.NotNil(mt, err, "expected ok field to be removed from %v", params)
+		})
+		mt.Run("address", func(mt *mtest.T) {
+			addr := mtest.ClusterConnString().Hosts[0]
+			val, err := mt.Client.GetServerParameter(context.Background(), "authenticationMechanisms",
+				options.ServerParameter().SetAddress(addr))
+			assert.Nil(mt, err, "GetServerParameter error: %v", err)
+			assert.Equal(mt, bson.TypeArray, val.Type, "expected type %v, got %v", bson.TypeArray, val.Type)
+		})
+		mt.Run("unknown parameter", func(mt *mtest.T) {
+			_, err := mt.Client.GetServerParameter(context.Background(), "notARealParameter")
+			assert.NotNil(mt, err, "expected GetServerParameter error, got nil")
+		})
+	})
+	mt.RunOpts("server connection ID", mtest.NewOptions().MinServerVersion("4.2"), func(mt *mtest.T) {
+		mt.ClearEvents()
+
+		res, err := mt.Client.Database("admin").RunCommand(context.Background(), bson.D{{handshake.LegacyHello, 1}}).Raw()
+		assert.Nil(mt, err, "RunCommand error: %v", err)
+		connID, ok := res.Lookup("connectionId").AsInt64OK()
+		assert.True(mt, ok, "expected connectionId in response %v", res)
+
+		started := mt.GetStartedEvent()
+		assert.NotNil(mt, started.ServerConnectionID64, "expected started event to have a server connection ID")
+		assert.Equal(mt, connID, *started.ServerConnectionID64, "expected server connection ID %v, got %v", connID,
+			*started.ServerConnectionID64)
+		succeeded := mt.GetSucceededEvent()
+		assert.NotNil(mt, succeeded.ServerConnectionID64, "expected succeeded event to have a server connection ID")
+		assert.Equal(mt, connID, *succeeded.ServerConnectionID64, "expected server connection ID %v, got %v", connID,
+			*succeeded.ServerConnectionID64)
+	})
 	mt.RunOpts("disconnect", noClientOpts, func(mt *mtest.T) {
 		mt.Run("nil context", func(mt *mtest.T) {
 			err := mt.Client.Disconnect(nil)
@@ -347,6 +427,106 @@ func TestClient(t *testing.T) {
 			assert.Equal(mt, mongo.ErrClientDisconnected, err, "expected error %v, got %v", mongo.ErrClientDisconnected, err)
 		})
 	})
+	mt.RunOpts("run command on all mongos", noClientOpts, func(mt *mtest.T) {
+		cmd := bson.D{{"flushRouterConfig", 1}}
+
+		shardedOpts := mtest.NewOptions().Topologies(mtest.Sharded)
+		mt.RunOpts("sharded", shardedOpts, func(mt *mtest.T) {
+			mt.ClearEvents()
+			results, err := mt.Client.RunCommandOnAllMongos(context.Background(), "admin", cmd)
+			assert.Nil(mt, err, "RunCommandOnAllMongos error: %v", err)
+			assert.True(mt, len(results) > 0, "expected at least 1 result, got %v", len(results))
+
+			for _, res := range results {
+				assert.Nil(mt, res.Err, "flushRouterConfig error on %v: %v", res.Address, res.Err)
+				ok, _ := res.Result.Lookup("ok").AsInt64OK()
+				assert.Equal(mt, int64(1), ok, "expected ok 1 from %v, got %v", res.Address, res.Result)
+
+				started := mt.GetStartedEvent()
+				require.NotNil(mt, started, "expected started event for %v", res.Address)
+				assert.Equal(mt, "flushRouterConfig", started.CommandName,
+					"expected command flushRouterConfig, got %v", started.CommandName)
+			}
+		})
+		notShardedOpts := mtest.NewOptions().Topologies(mtest.Single, mtest.ReplicaSet)
+		mt.RunOpts("not sharded", notShardedOpts, func(mt *mtest.T) {
+			_, err := mt.Client.RunCommandOnAllMongos(context.Background(), "admin", cmd)
+			assert.Equal(mt, mongo.ErrNotSharded, err, "expected error %v, got %v", mongo.ErrNotSharded, err)
+		})
+	})
+	mt.RunOpts("wait for replication", noClientOpts, func(mt *mtest.T) {
+		rsOpts := mtest.NewOptions().Topologies(mtest.ReplicaSet).MinServerVersion("3.6")
+		mt.RunOpts("replica set", rsOpts, func(mt *mtest.T) {
+			sess, err := mt.Client.StartSession()
+			assert.Nil(mt, err, "StartSession error: %v", err)
+			defer sess.EndSession(context.Background())
+
+			coll := mt.Coll
+			err = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+				_, err := coll.InsertOne(sc, bson.D{{"x", 1}})
+				return err
+			})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			opTime := sess.OperationTime()
+			require.NotNil(mt, opTime, "expected operation time, got nil")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err = mt.Client.WaitForReplication(ctx, *opTime)
+			assert.Nil(mt, err, "WaitForReplication error: %v", err)
+
+			// Reading the document from a secondary must now see it.
+			secondaryColl, err := coll.Clone(options.Collection().SetReadPreference(readpref.Secondary()))
+			assert.Nil(mt, err, "Clone error: %v", err)
+			count, err := secondaryColl.CountDocuments(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, int64(1), count, "expected 1 document on secondary, got %v", count)
+		})
+		mt.RunOpts("replica set timeout", rsOpts, func(mt *mtest.T) {
+			// No secondary can reach an operation time far in the future.
+			future := primitive.Timestamp{T: uint32(time.Now().Add(time.Hour).Unix())}
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			err := mt.Client.WaitForReplication(ctx, future)
+			assert.ErrorIs(mt, err, context.DeadlineExceeded)
+		})
+		mt.RunOpts("standalone", mtest.NewOptions().Topologies(mtest.Single), func(mt *mtest.T) {
+			future := primitive.Timestamp{T: uint32(time.Now().Add(time.Hour).Unix())}
+			err := mt.Client.WaitForReplication(context.Background(), future)
+			assert.Nil(mt, err, "WaitForReplication error: %v", err)
+		})
+	})
+	mt.RunOpts("with pinned server", mtest.NewOptions().Topologies(mtest.ReplicaSet, mtest.Sharded), func(mt *mtest.T) {
+		secondaryColl, err := mt.Coll.Clone(options.Collection().SetReadPreference(readpref.SecondaryPreferred()))
+		assert.Nil(mt, err, "Clone error: %v", err)
+
+		mt.ClearEvents()
+		err = mt.Client.WithPinnedServer(context.Background(), func(ctx context.Context) error {
+			if _, err := mt.Coll.InsertOne(ctx, bson.D{{"x", 1}}); err != nil {
+				return err
+			}
+			// The pinned primary is the only candidate, so the secondaryPreferred read is sent to it.
+			if err := secondaryColl.FindOne(ctx, bson.D{{"x", 1}}).Err(); err != nil {
+				return err
+			}
+			if _, err := mt.Coll.CountDocuments(ctx, bson.D{}); err != nil {
+				return err
+			}
+			return mt.Coll.Drop(ctx)
+		})
+		assert.Nil(mt, err, "WithPinnedServer error: %v", err)
+
+		var commands []string
+		addrs := make(map[string]bool)
+		for evt := mt.GetStartedEvent(); evt != nil; evt = mt.GetStartedEvent() {
+			commands = append(commands, evt.CommandName)
+			addrs[strings.SplitN(evt.ConnectionID, "[", 2)[0]] = true
+		}
+		assert.Equal(mt, []string{"insert", "find", "aggregate", "drop"}, commands,
+			"expected insert, find, aggregate, and drop commands, got %v", commands)
+		assert.Equal(mt, 1, len(addrs), "expected all commands to be sent to 1 server, got %v", addrs)
+	})
 	mt.RunOpts("end sessions", mtest.NewOptions().MinServerVersion("3.6"), func(mt *mtest.T) {
 		_, err := mt.Client.ListDatabases(context.Background(), bson.D{})
 		assert.Nil(mt, err, "ListDatabases error: %v", err)
@@ -743,6 +923,35 @@ func TestClient(t *testing.T) {
 		MinServerVersion("4.2").
 		// Expliticly enable retryable reads and retryable writes.
 		ClientOptions(options.Client().SetRetryReads(true).SetRetryWrites(true))
+	// customCode is an error code that is not retryable by the standard rules.
+	const customCode = 2
+	var predicateErrs []error
+	retryPredicateOpts := mtest.NewOptions().
+		Topologies(mtest.ReplicaSet, mtest.Sharded).
+		MinServerVersion("4.4").
+		ClientOptions(options.Client().SetRetryReads(true).SetRetryWrites(true).
+			SetRetryPredicate(func(err error, attempt int) bool {
+				predicateErrs = append(predicateErrs, err)
+				var ce mongo.CommandError
+				return attempt == 1 && errors.As(err, &ce) && ce.Code == customCode
+			}))
+	mt.RunOpts("retry predicate", retryPredicateOpts, func(mt *mtest.T) {
+		predicateErrs = nil
+		mt.SetFailPoint(mtest.FailPoint{
+			ConfigureFailPoint: "failCommand",
+			Mode:               mtest.FailPointMode{Times: 1},
+			Data: mtest.FailPointData{
+				FailCommands: []string{"insert"},
+				ErrorCode:    customCode,
+			},
+		})
+
+		mt.ClearEvents()
+		_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+		assert.Len(mt, mt.GetAllStartedEvents(), 2, "expected the insert to be retried once")
+		assert.Len(mt, predicateErrs, 1, "expected the predicate to be called once")
+	})
 	mt.RunOpts("operations don't retry after a context timeout", opts, func(mt *mtest.T) {
 		testCases := []struct {
 			desc      string
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -275,6 +277,53 @@ func TestCollection(t *testing.T) {
 			gotIndex := we.WriteErrors[0].Index
 			assert.Equal(mt, numDocs+1, gotIndex, "expected index %v, got %v", numDocs+1, gotIndex)
 		})
+		mt.RunOpts("ID generator", mtest.NewOptions().ClientOptions(options.Client().SetIDGenerator(func() interface{} {
+			return "generated"
+		})), func(mt *mtest.T) {
+			docs := []interface{}{bson.D{{"_id", int32(1)}}, bson.D{{"x", int32(2)}}}
+
+			res, err := mt.Coll.InsertMany(context.Background(), docs)
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			want := []interface{}{int32(1), "generated"}
+			assert.Equal(mt, want, res.InsertedIDs, "expected inserted IDs %v, got %v", want, res.InsertedIDs)
+
+			err = mt.Coll.FindOne(context.Background(), bson.D{{"_id", "generated"}}).Err()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+		})
+		mt.RunOpts("progress", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			maxBatchCount := int(mtest.MockDescription.MaxBatchCount)
+			numDocs := 2*maxBatchCount + 50
+			var docs []interface{}
+			for i := 0; i < numDocs; i++ {
+				docs = append(docs, bson.D{{"_id", int32(i)}})
+			}
+
+			var responses []bson.D
+			var wantProgress []int
+			for inserted := 0; inserted < numDocs; inserted += maxBatchCount {
+				count := maxBatchCount
+				if numDocs-inserted < maxBatchCount {
+					count = numDocs - inserted
+				}
+				responses = append(responses, mtest.CreateSuccessResponse(bson.E{"n", count}))
+				wantProgress = append(wantProgress, inserted+count)
+			}
+			mt.AddMockResponses(responses...)
+
+			var gotProgress []int
+			progress := func(inserted, total int) {
+				assert.Equal(mt, numDocs, total, "expected total %v, got %v", numDocs, total)
+				gotProgress = append(gotProgress, inserted)
+			}
+			res, err := mt.Coll.InsertMany(context.Background(), docs, options.InsertMany().SetProgress(progress))
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			assert.Equal(mt, wantProgress, gotProgress, "expected progress %v, got %v", wantProgress, gotProgress)
+
+			assert.Equal(mt, numDocs, len(res.InsertedIDs), "expected %v inserted IDs, got %v", numDocs, len(res.InsertedIDs))
+			for i, id := range res.InsertedIDs {
+				assert.Equal(mt, int32(i), id, "expected inserted ID %v, got %v", i, id)
+			}
+		})
 		wcCollOpts := options.Collection().SetWriteConcern(impossibleWc)
 		wcTestOpts := mtest.NewOptions().CollectionOptions(wcCollOpts).Topologies(mtest.ReplicaSet)
 		mt.RunOpts("write concern error", wcTestOpts, func(mt *mtest.T) {
@@ -366,6 +415,21 @@ func TestCollection(t *testing.T) {
 			assert.Nil(mt, err, "DeleteMany error: %v", err)
 			assert.Equal(mt, int64(0), res.DeletedCount, "expected DeletedCount 0, got %v", res.DeletedCount)
 		})
+		guardOpts := mtest.NewOptions().ClientOptions(options.Client().SetGuardEmptyFilter(true))
+		mt.RunOpts("guarded empty filter", guardOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			_, err := mt.Coll.DeleteMany(context.Background(), bson.D{})
+			assert.True(mt, errors.As(err, &mongo.EmptyFilterError{}), "expected EmptyFilterError, got %v", err)
+
+			count, err := mt.Coll.CountDocuments(context.Background(), bson.D{})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, int64(5), count, "expected no documents to be deleted, got count %v", count)
+
+			opts := options.Delete().SetAllowEmptyFilter(true)
+			res, err := mt.Coll.DeleteMany(context.Background(), bson.D{}, opts)
+			assert.Nil(mt, err, "DeleteMany error: %v", err)
+			assert.Equal(mt, int64(5), res.DeletedCount, "expected DeletedCount 5, got %v", res.DeletedCount)
+		})
 		mt.RunOpts("not found with options", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
 			opts := options.Delete().SetCollation(&options.Collation{Locale: "en_US"})
@@ -438,6 +502,31 @@ func TestCollection(t *testing.T) {
 			assert.Equal(mt, int64(1), res.ModifiedCount, "expected matched count 1, got %v", res.ModifiedCount)
 			assert.Nil(mt, res.UpsertedID, "expected upserted ID nil, got %v", res.UpsertedID)
 		})
+		mt.Run("upsert helper", func(mt *mtest.T) {
+			type account struct {
+				ID        string `bson:"_id"`
+				Email     string `bson:"email"`
+				CreatedBy string `bson:"createdBy"`
+			}
+			upsert := func(mt *mtest.T, acct account) {
+				mt.Helper()
+
+				update, err := mongo.Upsert(acct, []string{"createdBy"})
+				assert.Nil(mt, err, "Upsert error: %v", err)
+				_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", acct.ID}}, update,
+					options.Update().SetUpsert(true))
+				assert.Nil(mt, err, "UpdateOne error: %v", err)
+			}
+
+			upsert(mt, account{ID: "a1", Email: "old@example.com", CreatedBy: "importer"})
+			upsert(mt, account{ID: "a1", Email: "new@example.com", CreatedBy: "sync"})
+
+			var got account
+			err := mt.Coll.FindOne(context.Background(), bson.D{{"_id", "a1"}}).Decode(&got)
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			want := account{ID: "a1", Email: "new@example.com", CreatedBy: "importer"}
+			assert.Equal(mt, want, got, "expected document %v, got %v", want, got)
+		})
 		mt.Run("not found", func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
 			filter := bson.D{{"x", 0}}
@@ -460,6 +549,25 @@ func TestCollection(t *testing.T) {
 			assert.Equal(mt, int64(0), res.ModifiedCount, "expected matched count 0, got %v", res.ModifiedCount)
 			assert.NotNil(mt, res.UpsertedID, "expected upserted ID, got nil")
 		})
+		mt.RunOpts("let", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			filter := bson.D{{"$expr", bson.D{{"$eq", bson.A{"$x", "$$target"}}}}}
+			update := mongo.Pipeline{{{"$set", bson.D{{"x", bson.D{{"$add", bson.A{"$x", "$$increment"}}}}}}}}
+			opts := options.Update().SetLet(bson.D{{"target", 2}, {"increment", 10}})
+
+			res, err := mt.Coll.UpdateOne(context.Background(), filter, update, opts)
+			assert.Nil(mt, err, "UpdateOne error: %v", err)
+			assert.Equal(mt, int64(1), res.ModifiedCount, "expected modified count 1, got %v", res.ModifiedCount)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "update", evt.CommandName, "expected 'update' event, got '%v'", evt.CommandName)
+			_, err = evt.Command.LookupErr("let")
+			assert.Nil(mt, err, "expected 'let' in command %v", evt.Command)
+
+			count, err := mt.Coll.CountDocuments(context.Background(), bson.D{{"x", 12}})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, int64(1), count, "expected 1 updated document, got %v", count)
+		})
 		mt.Run("write error", func(mt *mtest.T) {
 			filter := bson.D{{"_id", "foo"}}
 			update := bson.D{{"$set", bson.D{{"_id", 3.14159}}}}
@@ -732,6 +840,41 @@ func TestCollection(t *testing.T) {
 			assert.True(mt, ok, "expected error type %v, got %v", mongo.WriteException{}, err)
 			assert.NotNil(mt, we.WriteConcernError, "expected write concern error, got nil")
 		})
+		mt.Run("versioned", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"x", 1}, {"version", int64(3)}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			filter := bson.D{{"_id", 1}}
+
+			// Success path: the version matches, so the document is replaced and the version is bumped.
+			res, err := mt.Coll.ReplaceOneVersioned(context.Background(), filter, bson.D{{"x", 2}}, "version", 3)
+			assert.Nil(mt, err, "ReplaceOneVersioned error: %v", err)
+			assert.Equal(mt, int64(1), res.MatchedCount, "expected matched count 1, got %v", res.MatchedCount)
+			assert.Equal(mt, int64(1), res.ModifiedCount, "expected modified count 1, got %v", res.ModifiedCount)
+
+			var doc struct {
+				X       int32
+				Version int64
+			}
+			err = mt.Coll.FindOne(context.Background(), filter).Decode(&doc)
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			assert.Equal(mt, int32(2), doc.X, "expected x 2, got %v", doc.X)
+			assert.Equal(mt, int64(4), doc.Version, "expected version 4, got %v", doc.Version)
+
+			// Conflict path: the stale version does not match, so the document is left unchanged.
+			res, err = mt.Coll.ReplaceOneVersioned(context.Background(), filter, bson.D{{"x", 3}}, "version", 3)
+			assert.ErrorIs(mt, err, mongo.ErrVersionConflict)
+			assert.Equal(mt, int64(0), res.MatchedCount, "expected matched count 0, got %v", res.MatchedCount)
+
+			err = mt.Coll.FindOne(context.Background(), filter).Decode(&doc)
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			assert.Equal(mt, int32(2), doc.X, "expected x 2, got %v", doc.X)
+			assert.Equal(mt, int64(4), doc.Version, "expected version 4, got %v", doc.Version)
+
+			// No document matches the filter, which is not a conflict.
+			res, err = mt.Coll.ReplaceOneVersioned(context.Background(), bson.D{{"_id", 2}}, bson.D{{"x", 3}}, "version", 4)
+			assert.Nil(mt, err, "ReplaceOneVersioned error: %v", err)
+			assert.Equal(mt, int64(0), res.MatchedCount, "expected matched count 0, got %v", res.MatchedCount)
+		})
 	})
 	mt.RunOpts("aggregate", noClientOpts, func(mt *mtest.T) {
 		mt.Run("success", func(mt *mtest.T) {
@@ -796,6 +939,13 @@ func TestCollection(t *testing.T) {
 				return mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, options.Aggregate().SetBatchSize(3))
 			})
 		})
+		mt.RunOpts("server API overrides apply to getMore", serverAPIOverrideOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			assertGetMoreUsesServerAPIStrict(mt, "aggregate", func() (*mongo.Cursor, error) {
+				opts := options.Aggregate().SetBatchSize(3).SetServerAPIStrict(true)
+				return mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, opts)
+			})
+		})
 		mt.Run("Custom", func(mt *mtest.T) {
 			// Custom options should be a BSON map of option names to Marshalable option values.
 			// We use "allowDiskUse" as an example.
@@ -817,6 +967,31 @@ func TestCollection(t *testing.T) {
 			assert.True(mt, ok, "expected field 'allowDiskUse' to be boolean, got %v", aduVal.Type.String())
 			assert.True(mt, adu, "expected field 'allowDiskUse' to be true, got false")
 		})
+		explainOpts := mtest.NewOptions().MinServerVersion("4.4").Topologies(mtest.Single, mtest.ReplicaSet)
+		mt.RunOpts("explain", explainOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			// $bucketAuto is never run by the query engine, so the output contains a stage for it after the $cursor
+			// stage that runs the $match.
+			pipeline := mongo.Pipeline{
+				{{"$match", bson.D{{"x", bson.D{{"$gte", 2}}}}}},
+				{{"$bucketAuto", bson.D{{"groupBy", "$x"}, {"buckets", 2}}}},
+			}
+			explain, err := mt.Coll.ExplainAggregate(context.Background(), pipeline, "executionStats")
+			assert.Nil(mt, err, "ExplainAggregate error: %v", err)
+			assert.Equal(mt, 2, len(explain.Stages), "expected 2 stages, got %v", explain.Stages)
+
+			cursor, bucket := explain.Stages[0], explain.Stages[1]
+			assert.Equal(mt, "$cursor", cursor.Name, "expected first stage $cursor, got %q", cursor.Name)
+			assert.Equal(mt, int64(4), cursor.DocsOut, "expected $cursor to return 4 documents, got %v", cursor.DocsOut)
+			assert.Equal(mt, "$bucketAuto", bucket.Name, "expected second stage $bucketAuto, got %q", bucket.Name)
+			assert.Equal(mt, int64(4), bucket.DocsIn, "expected $bucketAuto to receive 4 documents, got %v",
+				bucket.DocsIn)
+			assert.Equal(mt, int64(2), bucket.DocsOut, "expected $bucketAuto to return 2 documents, got %v",
+				bucket.DocsOut)
+
+			_, err = mt.Coll.ExplainAggregate(context.Background(), pipeline, "verbose")
+			assert.NotNil(mt, err, "expected error for invalid verbosity, got nil")
+		})
 	})
 	mt.RunOpts("count documents", noClientOpts, func(mt *mtest.T) {
 		mt.Run("success", func(mt *mtest.T) {
@@ -1096,6 +1271,220 @@ func TestCollection(t *testing.T) {
 				return mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetBatchSize(3))
 			})
 		})
+		mt.RunOpts("server API overrides apply to getMore", serverAPIOverrideOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			assertGetMoreUsesServerAPIStrict(mt, "find", func() (*mongo.Cursor, error) {
+				opts := options.Find().SetBatchSize(3).SetServerAPIStrict(true)
+				return mt.Coll.Find(context.Background(), bson.D{}, opts)
+			})
+		})
+		failPointOpts := mtest.NewOptions().MinServerVersion("4.0").Topologies(mtest.ReplicaSet)
+		mt.RunOpts("resumable cursor", failPointOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			mt.SetFailPoint(mtest.FailPoint{
+				ConfigureFailPoint: "failCommand",
+				Mode: mtest.FailPointMode{
+					Times: 1,
+				},
+				Data: mtest.FailPointData{
+					FailCommands: []string{"getMore"},
+					ErrorCode:    43,
+				},
+			})
+
+			opts := options.Find().SetBatchSize(2).SetSort(bson.D{{"x", 1}}).SetResumableCursor("x")
+			cursor, err := mt.Coll.Find(context.Background(), bson.D{}, opts)
+			assert.Nil(mt, err, "Find error: %v", err)
+
+			var results []int32
+			for cursor.Next(context.Background()) {
+				results = append(results, cursor.Current.Lookup("x").Int32())
+			}
+			assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+			expected := []int32{1, 2, 3, 4, 5}
+			assert.Equal(mt, expected, results, "expected results %v, got %v", expected, results)
+
+			finds := 0
+			for _, evt := range mt.GetAllStartedEvents() {
+				if evt.CommandName == "find" {
+					finds++
+				}
+			}
+			assert.Equal(mt, 2, finds, "expected the query to be resumed with a second find, got %d finds", finds)
+		})
+	})
+	profileOpts := mtest.NewOptions().CreateClient(false).Topologies(mtest.Single, mtest.ReplicaSet)
+	mt.RunOpts("find with profile", profileOpts, func(mt *mtest.T) {
+		setProfilingLevel := func(mt *mtest.T, level int32) {
+			mt.Helper()
+
+			err := mt.DB.RunCommand(context.Background(), bson.D{{"profile", level}}).Err()
+			assert.Nil(mt, err, "profile error: %v", err)
+		}
+
+		mt.Run("profiler enabled", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			setProfilingLevel(mt, 2)
+			defer setProfilingLevel(mt, 0)
+
+			opts := options.Find().SetComment("slow query detector")
+			cursor, info, err := mt.Coll.FindWithProfile(context.Background(), bson.D{{"x", bson.D{{"$gte", 3}}}}, opts)
+			assert.Nil(mt, err, "FindWithProfile error: %v", err)
+			defer cursor.Close(context.Background())
+
+			require.NotNil(mt, info, "expected profile info, got nil")
+			assert.Nil(mt, info.Err, "profile error: %v", info.Err)
+			assert.Equal(mt, int64(5), info.DocsExamined, "expected 5 documents examined, got %v", info.DocsExamined)
+			assert.Equal(mt, int64(0), info.KeysExamined, "expected 0 keys examined, got %v", info.KeysExamined)
+			assert.Equal(mt, int64(3), info.NReturned, "expected 3 documents returned, got %v", info.NReturned)
+			assert.Equal(mt, "COLLSCAN", info.PlanSummary, "expected plan summary COLLSCAN, got %v", info.PlanSummary)
+
+			var docs []bson.Raw
+			err = cursor.All(context.Background(), &docs)
+			assert.Nil(mt, err, "All error: %v", err)
+			assert.Equal(mt, 3, len(docs), "expected 3 documents, got %v", len(docs))
+		})
+		mt.Run("profiler disabled", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			setProfilingLevel(mt, 0)
+
+			cursor, info, err := mt.Coll.FindWithProfile(context.Background(), bson.D{})
+			assert.Nil(mt, err, "FindWithProfile error: %v", err)
+			defer cursor.Close(context.Background())
+
+			assert.Nil(mt, info, "expected no profile info, got %v", info)
+		})
+	})
+	mt.RunOpts("auto create", noClientOpts, func(mt *mtest.T) {
+		cappedOpts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+		isCapped := func(mt *mtest.T, name string) bool {
+			mt.Helper()
+
+			specs, err := mt.DB.ListCollectionSpecifications(context.Background(), bson.D{{"name", name}})
+			assert.Nil(mt, err, "ListCollectionSpecifications error: %v", err)
+			require.Len(mt, specs, 1, "expected 1 collection specification")
+
+			capped, _ := specs[0].Options.Lookup("capped").BooleanOK()
+			return capped
+		}
+
+		mt.Run("creates collection with options", func(mt *mtest.T) {
+			coll := mt.CreateCollection(mtest.Collection{
+				Name: "autoCreate",
+				Opts: options.Collection().SetAutoCreateOptions(cappedOpts),
+			}, false)
+
+			_, err := coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			_, err = coll.InsertOne(context.Background(), bson.D{{"x", 2}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			assert.True(mt, isCapped(mt, coll.Name()), "expected collection to be created as capped")
+		})
+		mt.Run("existing collection is unchanged", func(mt *mtest.T) {
+			coll := mt.CreateCollection(mtest.Collection{
+				Name: "autoCreateExisting",
+				Opts: options.Collection().SetAutoCreateOptions(cappedOpts),
+			}, true)
+
+			_, err := coll.UpdateOne(context.Background(), bson.D{{"x", 1}}, bson.D{{"$set", bson.D{{"x", 1}}}},
+				options.Update().SetUpsert(true))
+			assert.Nil(mt, err, "UpdateOne error: %v", err)
+
+			assert.False(mt, isCapped(mt, coll.Name()), "expected existing collection not to be capped")
+		})
+	})
+	mt.RunOpts("registered hint", noClientOpts, func(mt *mtest.T) {
+		assertHint := func(mt *mtest.T, commandName string, expected interface{}) {
+			mt.Helper()
+
+			evt := mt.GetStartedEvent()
+			assert.NotNil(mt, evt, "expected %v event, got nil", commandName)
+			assert.Equal(mt, commandName, evt.CommandName, "expected command %v, got %v", commandName,
+				evt.CommandName)
+
+			hint, err := evt.Command.LookupErr("hint")
+			if expected == nil {
+				assert.NotNil(mt, err, "expected no hint, got %v", hint)
+				return
+			}
+			assert.Nil(mt, err, "hint not found in command %v", evt.Command)
+			assert.Equal(mt, expected, hint.StringValue(), "expected hint %v, got %v", expected, hint)
+		}
+
+		initCollection(mt, mt.Coll)
+		for _, name := range []string{"x_1", "x_-1"} {
+			keys := bson.D{{"x", 1}}
+			if name == "x_-1" {
+				keys = bson.D{{"x", -1}}
+			}
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: keys})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+		}
+		mt.Coll.RegisterHint(bson.D{{"x", 1}}, "x_1")
+
+		mt.ClearEvents()
+		cursor, err := mt.Coll.Find(context.Background(), bson.D{{"x", bson.D{{"$gt", 2}}}})
+		assert.Nil(mt, err, "Find error: %v", err)
+		_ = cursor.Close(context.Background())
+		assertHint(mt, "find", "x_1")
+
+		mt.ClearEvents()
+		_, err = mt.Coll.CountDocuments(context.Background(), bson.D{{"x", 3}})
+		assert.Nil(mt, err, "CountDocuments error: %v", err)
+		assertHint(mt, "aggregate", "x_1")
+
+		// An explicit hint overrides the registered one.
+		mt.ClearEvents()
+		cursor, err = mt.Coll.Find(context.Background(), bson.D{{"x", 3}}, options.Find().SetHint("x_-1"))
+		assert.Nil(mt, err, "Find error: %v", err)
+		_ = cursor.Close(context.Background())
+		assertHint(mt, "find", "x_-1")
+
+		mt.ClearEvents()
+		_, err = mt.Coll.CountDocuments(context.Background(), bson.D{{"x", 3}}, options.Count().SetHint("x_-1"))
+		assert.Nil(mt, err, "CountDocuments error: %v", err)
+		assertHint(mt, "aggregate", "x_-1")
+
+		// Filters with a different shape are not hinted.
+		mt.ClearEvents()
+		cursor, err = mt.Coll.Find(context.Background(), bson.D{{"x", 3}, {"y", 1}})
+		assert.Nil(mt, err, "Find error: %v", err)
+		_ = cursor.Close(context.Background())
+		assertHint(mt, "find", nil)
+	})
+	mt.RunOpts("is time series", noClientOpts, func(mt *mtest.T) {
+		mt.RunOpts("time-series collection", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+			tsOpts := options.TimeSeries().SetTimeField("ts").SetMetaField("sensor").SetGranularity("minutes")
+			coll := mt.CreateCollection(mtest.Collection{
+				Name:       "timeSeries",
+				CreateOpts: options.CreateCollection().SetTimeSeriesOptions(tsOpts),
+			}, true)
+
+			isTimeSeries, info, err := coll.IsTimeSeries(context.Background())
+			assert.Nil(mt, err, "IsTimeSeries error: %v", err)
+			assert.True(mt, isTimeSeries, "expected collection to be time-series")
+			require.NotNil(mt, info, "expected time-series info, got nil")
+			assert.Equal(mt, "ts", info.TimeField, "expected time field %q, got %q", "ts", info.TimeField)
+			assert.Equal(mt, "sensor", info.MetaField, "expected meta field %q, got %q", "sensor", info.MetaField)
+			assert.Equal(mt, "minutes", info.Granularity, "expected granularity %q, got %q", "minutes",
+				info.Granularity)
+		})
+		mt.Run("regular collection", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			isTimeSeries, info, err := mt.Coll.IsTimeSeries(context.Background())
+			assert.Nil(mt, err, "IsTimeSeries error: %v", err)
+			assert.False(mt, isTimeSeries, "expected collection not to be time-series")
+			assert.Nil(mt, info, "expected no time-series info, got %v", info)
+		})
+		mt.Run("missing collection", func(mt *mtest.T) {
+			isTimeSeries, info, err := mt.DB.Collection("missing").IsTimeSeries(context.Background())
+			assert.Nil(mt, err, "IsTimeSeries error: %v", err)
+			assert.False(mt, isTimeSeries, "expected missing collection not to be time-series")
+			assert.Nil(mt, info, "expected no time-series info, got %v", info)
+		})
 	})
 	mt.RunOpts("find one", noClientOpts, func(mt *mtest.T) {
 		mt.Run("limit", func(mt *mtest.T) {
@@ -1120,6 +1509,16 @@ func TestCollection(t *testing.T) {
 			got := x.Int32()
 			assert.Equal(mt, int32(1), got, "expected x value 1, got %v", got)
 		})
+		mt.RunOpts("let", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			filter := bson.D{{"$expr", bson.D{{"$eq", bson.A{"$x", "$$target"}}}}}
+			opts := options.FindOne().SetLet(bson.D{{"target", 3}})
+
+			res, err := mt.Coll.FindOne(context.Background(), filter, opts).Raw()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			got := res.Lookup("x").Int32()
+			assert.Equal(mt, int32(3), got, "expected x value 3, got %v", got)
+		})
 		mt.RunOpts("options", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
 
@@ -1230,6 +1629,49 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		rsOpts := mtest.NewOptions().Topologies(mtest.ReplicaSet).MinServerVersion("3.4")
+		mt.RunOpts("staleness fallback", rsOpts, func(mt *mtest.T) {
+			var hello struct {
+				Hosts   []string `bson:"hosts"`
+				Primary string   `bson:"primary"`
+			}
+			err := mt.Client.Database("admin").RunCommand(context.Background(), bson.D{{"hello", 1}}).Decode(&hello)
+			assert.Nil(mt, err, "hello error: %v", err)
+			if len(hello.Hosts) < 2 {
+				mt.Skip("skipping because the replica set has no secondaries")
+			}
+
+			sess, err := mt.Client.StartSession()
+			assert.Nil(mt, err, "StartSession error: %v", err)
+			defer sess.EndSession(context.Background())
+			err = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+				_, err := mt.Coll.InsertOne(sc, bson.D{{"x", 1}})
+				return err
+			})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			err = mt.Client.WaitForReplication(context.Background(), *sess.OperationTime())
+			assert.Nil(mt, err, "WaitForReplication error: %v", err)
+
+			// Simulate an unavailable primary by refusing all connections to it.
+			mt.ResetClient(options.Client().
+				SetHosts(hello.Hosts).
+				SetDialer(&blockedAddrDialer{blocked: hello.Primary}).
+				SetServerSelectionTimeout(time.Second))
+
+			err = mt.Coll.FindOne(context.Background(), bson.D{{"x", 1}}).Err()
+			assert.NotNil(mt, err, "expected FindOne without fallback to fail")
+
+			mt.ClearEvents()
+			opts := options.FindOne().SetStalenessFallback(90 * time.Second)
+			res, err := mt.Coll.FindOne(context.Background(), bson.D{{"x", 1}}, opts).Raw()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			assert.Equal(mt, int32(1), res.Lookup("x").Int32(), "expected x value 1, got %v", res.Lookup("x"))
+
+			evt := mt.GetStartedEvent()
+			require.NotNil(mt, evt, "expected find event, got nil")
+			addr := strings.SplitN(evt.ConnectionID, "[", 2)[0]
+			assert.NotEqual(mt, hello.Primary, addr, "expected find to be sent to a secondary")
+		})
 	})
 	mt.RunOpts("find one and delete", noClientOpts, func(mt *mtest.T) {
 		mt.Run("found", func(mt *mtest.T) {
@@ -1366,6 +1808,38 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		mt.Run("both images", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			filter := bson.D{{"x", 3}}
+			replacement := bson.D{{"y", 3}, {"z", "replaced"}}
+
+			before, after, err := mt.Coll.FindOneAndReplaceBoth(context.Background(), filter, replacement,
+				options.FindOneAndReplace().SetReturnDocument(options.After))
+			assert.Nil(mt, err, "FindOneAndReplaceBoth error: %v", err)
+			x, err := before.LookupErr("x")
+			assert.Nil(mt, err, "x not found in pre-image %v", before)
+			assert.Equal(mt, int32(3), x.Int32(), "expected x value 3, got %v", x)
+			assert.Equal(mt, before.Lookup("_id"), after.Lookup("_id"), "expected pre-image _id %v, got %v",
+				before.Lookup("_id"), after.Lookup("_id"))
+
+			stored, err := mt.Coll.FindOne(context.Background(), bson.D{{"_id", before.Lookup("_id")}}).Raw()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			assert.Equal(mt, stored, after, "expected post-image %v, got %v", stored, after)
+		})
+		mt.Run("both images not found", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+
+			before, after, err := mt.Coll.FindOneAndReplaceBoth(context.Background(), bson.D{{"x", 6}},
+				bson.D{{"y", 6}})
+			assert.Equal(mt, mongo.ErrNoDocuments, err, "expected error %v, got %v", mongo.ErrNoDocuments, err)
+			assert.Nil(mt, before, "expected no pre-image, got %v", before)
+			assert.Nil(mt, after, "expected no post-image, got %v", after)
+		})
+		mt.Run("both images upsert", func(mt *mtest.T) {
+			_, _, err := mt.Coll.FindOneAndReplaceBoth(context.Background(), bson.D{{"x", 6}}, bson.D{{"y", 6}},
+				options.FindOneAndReplace().SetUpsert(true))
+			assert.NotNil(mt, err, "expected FindOneAndReplaceBoth error, got nil")
+		})
 		wcCollOpts := options.Collection().SetWriteConcern(impossibleWc)
 		wcTestOpts := mtest.NewOptions().CollectionOptions(wcCollOpts).Topologies(mtest.ReplicaSet).MinServerVersion("3.2")
 		mt.RunOpts("write concern error", wcTestOpts, func(mt *mtest.T) {
@@ -1860,6 +2334,326 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		mt.Run("segments", func(mt *mtest.T) {
+			insert := func(id int32) mongo.WriteModel {
+				return mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", id}})
+			}
+			models := []mongo.WriteModel{
+				insert(1),
+				insert(1), // duplicate key error stops the rest of this segment
+				insert(2),
+				mongo.NewSegmentBoundaryModel(),
+				insert(3),
+				insert(4),
+				mongo.NewSegmentBoundaryModel(),
+				mongo.NewUpdateOneModel().SetFilter(bson.D{{"_id", 5}}).
+					SetUpdate(bson.D{{"$set", bson.D{{"x", 1}}}}).SetUpsert(true),
+			}
+			res, err := mt.Coll.BulkWrite(context.Background(), models, options.BulkWrite().SetSegmentConcurrency(2))
+
+			bwe, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %v, got %v", mongo.BulkWriteException{}, err)
+			assert.Equal(mt, 1, len(bwe.WriteErrors), "expected 1 write error, got %v", len(bwe.WriteErrors))
+			assert.Equal(mt, 1, bwe.WriteErrors[0].Index, "expected write error index 1, got %v", bwe.WriteErrors[0].Index)
+			assert.Equal(mt, int64(3), res.InsertedCount, "expected inserted count 3, got %v", res.InsertedCount)
+			assert.Equal(mt, int64(1), res.UpsertedCount, "expected upserted count 1, got %v", res.UpsertedCount)
+			wantUpserted := map[int64]interface{}{7: int32(5)}
+			assert.Equal(mt, wantUpserted, res.UpsertedIDs, "expected upserted IDs %v, got %v", wantUpserted, res.UpsertedIDs)
+
+			cursor, err := mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+			assert.Nil(mt, err, "Find error: %v", err)
+			var docs []struct {
+				ID int32 `bson:"_id"`
+			}
+			err = cursor.All(context.Background(), &docs)
+			assert.Nil(mt, err, "All error: %v", err)
+			var ids []int32
+			for _, doc := range docs {
+				ids = append(ids, doc.ID)
+			}
+			wantIDs := []int32{1, 3, 4, 5}
+			assert.Equal(mt, wantIDs, ids, "expected documents %v, got %v", wantIDs, ids)
+		})
+	})
+	mt.RunOpts("write times", mtest.NewOptions().MinServerVersion("3.6").Topologies(mtest.ReplicaSet, mtest.Sharded),
+		func(mt *mtest.T) {
+			assertTimes := func(mt *mtest.T, op string, operationTime *primitive.Timestamp, clusterTime bson.Raw) {
+				mt.Helper()
+
+				assert.NotNil(mt, operationTime, "expected %s result to have an operation time", op)
+				assert.NotNil(mt, clusterTime, "expected %s result to have a cluster time", op)
+				_, _, ok := clusterTime.Lookup("clusterTime").TimestampOK()
+				assert.True(mt, ok, "expected %s cluster time to contain a timestamp, got %v", op, clusterTime)
+			}
+
+			insertOne, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			assertTimes(mt, "InsertOne", insertOne.OperationTime, insertOne.ClusterTime)
+
+			insertMany, err := mt.Coll.InsertMany(context.Background(), []interface{}{bson.D{{"x", 2}}, bson.D{{"x", 3}}})
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+			assertTimes(mt, "InsertMany", insertMany.OperationTime, insertMany.ClusterTime)
+
+			update, err := mt.Coll.UpdateOne(context.Background(), bson.D{{"x", 1}}, bson.D{{"$inc", bson.D{{"x", 10}}}})
+			assert.Nil(mt, err, "UpdateOne error: %v", err)
+			assertTimes(mt, "UpdateOne", update.OperationTime, update.ClusterTime)
+
+			del, err := mt.Coll.DeleteOne(context.Background(), bson.D{{"x", 2}})
+			assert.Nil(mt, err, "DeleteOne error: %v", err)
+			assertTimes(mt, "DeleteOne", del.OperationTime, del.ClusterTime)
+
+			bulk, err := mt.Coll.BulkWrite(context.Background(), []mongo.WriteModel{
+				mongo.NewInsertOneModel().SetDocument(bson.D{{"x", 4}}),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{"x", 3}}),
+			})
+			assert.Nil(mt, err, "BulkWrite error: %v", err)
+			assertTimes(mt, "BulkWrite", bulk.OperationTime, bulk.ClusterTime)
+
+			// The operation time of a later write must not be before the operation time of an earlier one.
+			assert.True(mt, bulk.OperationTime.Compare(*insertOne.OperationTime) >= 0,
+				"expected BulkWrite operation time %v to be at least InsertOne operation time %v",
+				bulk.OperationTime, insertOne.OperationTime)
+		})
+	mt.RunOpts("find after write", mtest.NewOptions().MinServerVersion("3.6").Topologies(mtest.ReplicaSet),
+		func(mt *mtest.T) {
+			res, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"x", "written"}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			require.NotNil(mt, res.OperationTime, "expected InsertOne result to have an operation time")
+
+			secondary, err := mt.Coll.Clone(options.Collection().SetReadPreference(readpref.Secondary()))
+			assert.Nil(mt, err, "Clone error: %v", err)
+
+			mt.ClearEvents()
+			cursor, err := secondary.FindAfterWrite(context.Background(), bson.D{{"_id", 1}}, *res.OperationTime)
+			assert.Nil(mt, err, "FindAfterWrite error: %v", err)
+			var docs []bson.D
+			err = cursor.All(context.Background(), &docs)
+			assert.Nil(mt, err, "All error: %v", err)
+			want := []bson.D{{{"_id", int32(1)}, {"x", "written"}}}
+			assert.Equal(mt, want, docs, "expected documents %v, got %v", want, docs)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "find", evt.CommandName, "expected command 'find', got %q", evt.CommandName)
+			ts, inc := evt.Command.Lookup("readConcern", "afterClusterTime").Timestamp()
+			gotTime := primitive.Timestamp{T: ts, I: inc}
+			assert.True(mt, gotTime.Equal(*res.OperationTime), "expected afterClusterTime %v, got %v",
+				*res.OperationTime, gotTime)
+
+			_, err = mt.Coll.FindAfterWrite(context.Background(), bson.D{}, primitive.Timestamp{})
+			assert.NotNil(mt, err, "expected FindAfterWrite error for zero operation time, got nil")
+		})
+	mt.Run("loader", func(mt *mtest.T) {
+		type item struct {
+			ID int32 `bson:"_id"`
+			X  int32 `bson:"x"`
+		}
+		docs := []interface{}{item{ID: 1, X: 10}, item{ID: 2, X: 20}, item{ID: 3, X: 30}}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		loader := mongo.NewLoader[int32, item](mt.Coll, mongo.LoaderOptions{Wait: 50 * time.Millisecond})
+		mt.ClearEvents()
+		vals, errs := loader.LoadMany(context.Background(), []int32{3, 1, 4, 2, 1})
+
+		want := []item{{ID: 3, X: 30}, {ID: 1, X: 10}, {}, {ID: 2, X: 20}, {ID: 1, X: 10}}
+		assert.Equal(mt, want, vals, "expected values %v, got %v", want, vals)
+		for i, err := range errs {
+			if i == 2 {
+				assert.True(mt, errors.Is(err, mongo.ErrNoDocuments), "expected ErrNoDocuments, got %v", err)
+				continue
+			}
+			assert.Nil(mt, err, "Load error: %v", err)
+		}
+
+		var finds int
+		for _, evt := range mt.GetAllStartedEvents() {
+			if evt.CommandName == "find" {
+				finds++
+			}
+		}
+		assert.Equal(mt, 1, finds, "expected 1 find command, got %d", finds)
+	})
+	mt.RunOpts("find random", mtest.NewOptions().MinServerVersion("3.2"), func(mt *mtest.T) {
+		docs := make([]interface{}, 0, 20)
+		for i := 0; i < 20; i++ {
+			docs = append(docs, bson.D{{"_id", int32(i)}, {"even", i%2 == 0}})
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		cursor, err := mt.Coll.FindRandom(context.Background(), bson.D{{"even", true}}, 4)
+		assert.Nil(mt, err, "FindRandom error: %v", err)
+		var sampled []bson.Raw
+		err = cursor.All(context.Background(), &sampled)
+		assert.Nil(mt, err, "All error: %v", err)
+		assert.Equal(mt, 4, len(sampled), "expected 4 documents, got %d", len(sampled))
+		for _, doc := range sampled {
+			assert.True(mt, doc.Lookup("even").Boolean(), "expected only documents matching the filter, got %v", doc)
+		}
+
+		// Fewer documents than the sample size match the filter, so all of them are returned.
+		cursor, err = mt.Coll.FindRandom(context.Background(), bson.D{{"_id", bson.D{{"$lt", 3}}}}, 10)
+		assert.Nil(mt, err, "FindRandom error: %v", err)
+		sampled = nil
+		err = cursor.All(context.Background(), &sampled)
+		assert.Nil(mt, err, "All error: %v", err)
+		assert.Equal(mt, 3, len(sampled), "expected 3 documents, got %d", len(sampled))
+	})
+	mt.Run("paginator", func(mt *mtest.T) {
+		// Documents 1-3 and 4-5 tie on both sort keys, so they are ordered by _id.
+		docs := []interface{}{
+			bson.D{{"_id", int32(3)}, {"group", "a"}, {"score", int32(10)}},
+			bson.D{{"_id", int32(1)}, {"group", "a"}, {"score", int32(10)}},
+			bson.D{{"_id", int32(2)}, {"group", "a"}, {"score", int32(10)}},
+			bson.D{{"_id", int32(5)}, {"group", "a"}, {"score", int32(5)}},
+			bson.D{{"_id", int32(4)}, {"group", "a"}, {"score", int32(5)}},
+			bson.D{{"_id", int32(6)}, {"group", "b"}, {"score", int32(20)}},
+			bson.D{{"_id", int32(7)}, {"group", "b"}, {"score", int32(1)}},
+			bson.D{{"_id", int32(8)}, {"group", "c"}, {"score", int32(99)}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		newPaginator := func() *mongo.Paginator {
+			p, err := mongo.NewPaginator(mt.Coll, bson.D{{"group", bson.D{{"$ne", "c"}}}}, mongo.PaginatorOptions{
+				Sort:     bson.D{{"group", 1}, {"score", -1}},
+				PageSize: 2,
+			})
+			assert.Nil(mt, err, "NewPaginator error: %v", err)
+			return p
+		}
+		ids := func(page []bson.Raw) []int32 {
+			var got []int32
+			for _, doc := range page {
+				got = append(got, doc.Lookup("_id").Int32())
+			}
+			return got
+		}
+
+		want := [][]int32{{1, 2}, {3, 4}, {5, 6}, {7}}
+		p := newPaginator()
+		var tokens []string
+		for i, wantIDs := range want {
+			page, token, err := p.Next(context.Background())
+			assert.Nil(mt, err, "Next error: %v", err)
+			assert.Equal(mt, wantIDs, ids(page), "expected page %d to be %v, got %v", i, wantIDs, ids(page))
+			if i == len(want)-1 {
+				assert.Equal(mt, "", token, "expected no token after the last page, got %q", token)
+				break
+			}
+			assert.NotEqual(mt, "", token, "expected a token after page %d", i)
+			tokens = append(tokens, token)
+		}
+		page, token, err := p.Next(context.Background())
+		assert.Nil(mt, err, "Next error: %v", err)
+		assert.Equal(mt, 0, len(page), "expected no documents after the last page, got %v", ids(page))
+		assert.Equal(mt, "", token, "expected no token after the last page, got %q", token)
+
+		// A new Paginator resumed from a token continues where the original one left off.
+		resumed := newPaginator()
+		err = resumed.Resume(tokens[1])
+		assert.Nil(mt, err, "Resume error: %v", err)
+		page, _, err = resumed.Next(context.Background())
+		assert.Nil(mt, err, "Next error: %v", err)
+		assert.Equal(mt, want[2], ids(page), "expected page %v, got %v", want[2], ids(page))
+	})
+	mt.RunOpts("stats", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"x", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		stats, err := mt.Coll.Stats(context.Background())
+		assert.Nil(mt, err, "Stats error: %v", err)
+		wantNS := mt.DB.Name() + "." + mt.Coll.Name()
+		assert.Equal(mt, wantNS, stats.Namespace, "expected namespace %q, got %q", wantNS, stats.Namespace)
+		assert.Equal(mt, int64(5), stats.Count, "expected count 5, got %v", stats.Count)
+		assert.True(mt, stats.Size > 0, "expected positive size, got %v", stats.Size)
+		assert.Equal(mt, stats.Size/5, stats.AvgObjSize, "expected average object size %v, got %v", stats.Size/5,
+			stats.AvgObjSize)
+		assert.Equal(mt, 2, stats.NumIndexes, "expected 2 indexes, got %v", stats.NumIndexes)
+		_, ok := stats.IndexSizes["x_1"]
+		assert.True(mt, ok, "expected size of index x_1, got %v", stats.IndexSizes)
+		assert.False(mt, stats.Capped, "expected collection not to be capped")
+	})
+	mt.RunOpts("copy collection", noClientOpts, func(mt *mtest.T) {
+		mt.Run("documents and indexes", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys:    bson.D{{"x", 1}},
+				Options: options.Index().SetUnique(true).SetName("x_unique"),
+			})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+			dst := mt.CreateCollection(mtest.Collection{Name: "copy_dst", DB: "copy_dst_db"}, false)
+
+			res, err := mongo.CopyCollection(context.Background(), mt.Coll, dst, mongo.CopyOptions{
+				BatchSize:   2,
+				CopyIndexes: true,
+			})
+			assert.Nil(mt, err, "CopyCollection error: %v", err)
+			assert.Equal(mt, int64(5), res.DocumentsCopied, "expected 5 documents copied, got %v", res.DocumentsCopied)
+			assert.Equal(mt, 1, res.IndexesCopied, "expected 1 index copied, got %v", res.IndexesCopied)
+
+			cursor, err := dst.Find(context.Background(), bson.D{}, options.Find().SetSort(bson.D{{"x", 1}}))
+			assert.Nil(mt, err, "Find error: %v", err)
+			var docs []bson.Raw
+			err = cursor.All(context.Background(), &docs)
+			assert.Nil(mt, err, "All error: %v", err)
+			assert.Equal(mt, 5, len(docs), "expected 5 documents, got %v", len(docs))
+			for i, doc := range docs {
+				x := doc.Lookup("x").Int32()
+				assert.Equal(mt, int32(i+1), x, "expected x %v, got %v", i+1, x)
+			}
+
+			specs, err := dst.Indexes().ListSpecifications(context.Background())
+			assert.Nil(mt, err, "ListSpecifications error: %v", err)
+			var found bool
+			for _, spec := range specs {
+				if spec.Name == "x_unique" {
+					found = true
+					assert.True(mt, spec.Unique != nil && *spec.Unique, "expected copied index to be unique")
+				}
+			}
+			assert.True(mt, found, "expected index x_unique to be copied, got %v", specs)
+		})
+		mt.Run("filter", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			dst := mt.CreateCollection(mtest.Collection{Name: "copy_dst"}, false)
+
+			res, err := mongo.CopyCollection(context.Background(), mt.Coll, dst, mongo.CopyOptions{
+				Filter: bson.D{{"x", bson.D{{"$gt", 3}}}},
+			})
+			assert.Nil(mt, err, "CopyCollection error: %v", err)
+			assert.Equal(mt, int64(2), res.DocumentsCopied, "expected 2 documents copied, got %v", res.DocumentsCopied)
+			assert.Equal(mt, 0, res.IndexesCopied, "expected no indexes copied, got %v", res.IndexesCopied)
+			count, err := dst.CountDocuments(context.Background(), bson.D{})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, int64(2), count, "expected 2 documents, got %v", count)
+		})
+		// Documents are only guaranteed to be read in insertion order from an unsharded collection.
+		unshardedOpts := mtest.NewOptions().Topologies(mtest.Single, mtest.ReplicaSet)
+		mt.RunOpts("partial progress", unshardedOpts, func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			dst := mt.CreateCollection(mtest.Collection{Name: "copy_dst"}, false)
+			third, err := mt.Coll.FindOne(context.Background(), bson.D{{"x", 3}}).Raw()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			_, err = dst.InsertOne(context.Background(), third)
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			res, err := mongo.CopyCollection(context.Background(), mt.Coll, dst, mongo.CopyOptions{BatchSize: 1})
+			assert.NotNil(mt, err, "expected duplicate key error, got nil")
+			assert.True(mt, mongo.IsDuplicateKeyError(err), "expected duplicate key error, got %v", err)
+			assert.Equal(mt, int64(2), res.DocumentsCopied, "expected 2 documents copied, got %v", res.DocumentsCopied)
+		})
+		mt.Run("cancelled context", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			dst := mt.CreateCollection(mtest.Collection{Name: "copy_dst"}, false)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			res, err := mongo.CopyCollection(ctx, mt.Coll, dst, mongo.CopyOptions{})
+			assert.True(mt, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+			assert.Equal(mt, int64(0), res.DocumentsCopied, "expected no documents copied, got %v", res.DocumentsCopied)
+		})
 	})
 }
 
@@ -2287,6 +3081,33 @@ func assertGetMoreCommandsAreMonitored(mt *mtest.T, cmdName string, cursorFn fun
 	assert.Equal(mt, "getMore", evt.CommandName, "expected command 'getMore', got %q", evt.CommandName)
 }
 
+// serverAPIOverrideOpts configures a client that declares an API version without strict mode so that the per-operation
+// ServerAPIStrict override can be observed.
+var serverAPIOverrideOpts = mtest.NewOptions().MinServerVersion("5.0").
+	ClientOptions(options.Client().SetServerAPIOptions(options.ServerAPI(options.ServerAPIVersion1)))
+
+// This is a helper function to ensure that the getMore commands of a cursor are sent with the same API options as the
+// command that created the cursor. The cursorFn parameter should be a function that yields a cursor created with the
+// ServerAPIStrict option set to true which requires at least one getMore to be fully iterated.
+func assertGetMoreUsesServerAPIStrict(mt *mtest.T, cmdName string, cursorFn func() (*mongo.Cursor, error)) {
+	mt.Helper()
+	mt.ClearEvents()
+
+	cursor, err := cursorFn()
+	assert.Nil(mt, err, "error creating cursor: %v", err)
+	var docs []bson.D
+	err = cursor.All(context.Background(), &docs)
+	assert.Nil(mt, err, "All error: %v", err)
+
+	for _, want := range []string{cmdName, "getMore"} {
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, want, evt.CommandName, "expected command %q, got %q", want, evt.CommandName)
+		strict, ok := evt.Command.Lookup("apiStrict").BooleanOK()
+		assert.True(mt, ok && strict, "expected %q command to have apiStrict true, got %v", want,
+			evt.Command.Lookup("apiStrict"))
+	}
+}
+
 // This is a helper function to ensure that sending killCursors commands for a cursor results in command monitoring
 // events being published. The cursorFn parameter should be a function that yields a cursor which is open on the server.
 func assertKillCursorsCommandsAreMonitored(mt *mtest.T, cmdName string, cursorFn func() (*mongo.Cursor, error)) {
@@ -2303,3 +3124,16 @@ func assertKillCursorsCommandsAreMonitored(mt *mtest.T, cmdName string, cursorFn
 	evt = mt.GetStartedEvent()
 	assert.Equal(mt, "killCursors", evt.CommandName, "expected command 'killCursors', got %q", evt.CommandName)
 }
+
+// blockedAddrDialer is a dialer that refuses to connect to the blocked address and dials all other addresses normally.
+type blockedAddrDialer struct {
+	net.Dialer
+	blocked string
+}
+
+func (bad *blockedAddrDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if address == bad.blocked {
+		return nil, fmt.Errorf("connection to %v refused", address)
+	}
+	return bad.Dialer.DialContext(ctx, network, address)
+}
@@ -47,6 +47,45 @@ func TestCursor(t *testing.T) {
 		ce := err.(mongo.CommandError)
 		assert.Equal(mt, int32(errorCursorNotFound), ce.Code, "expected error code %v, got %v", errorCursorNotFound, ce.Code)
 	})
+	killOnCancelOpts := mtest.NewOptions().
+		MinServerVersion("3.2").
+		RequireAPIVersion(false).
+		ClientOptions(options.Client().SetKillOnCancel(true))
+	mt.RunOpts("cursor is killed on context cancellation", killOnCancelOpts, func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		c, err := mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetBatchSize(2))
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer c.Close(context.Background())
+
+		id := c.ID()
+		assert.True(mt, c.Next(context.Background()), "expected Next true, got false")
+		assert.True(mt, c.Next(context.Background()), "expected Next true, got false")
+
+		canceledCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mt.ClearEvents()
+
+		assert.False(mt, c.Next(canceledCtx), "expected Next false, got true")
+		assert.True(mt, errors.Is(c.Err(), context.Canceled), "expected context.Canceled error, got %v", c.Err())
+
+		// The getMore is interrupted, so the cursor is killed without calling Close.
+		var killed bool
+		for evt := mt.GetStartedEvent(); evt != nil; evt = mt.GetStartedEvent() {
+			if evt.CommandName == "killCursors" {
+				killed = true
+			}
+		}
+		assert.True(mt, killed, "expected a killCursors command to be sent")
+		assert.Equal(mt, int64(0), c.ID(), "expected cursor ID 0, got %v", c.ID())
+
+		err = mt.DB.RunCommand(context.Background(), bson.D{
+			{"getMore", id},
+			{"collection", mt.Coll.Name()},
+		}).Err()
+		var ce mongo.CommandError
+		assert.True(mt, errors.As(err, &ce), "expected a CommandError, got %v", err)
+		assert.Equal(mt, int32(errorCursorNotFound), ce.Code, "expected error code %v, got %v", errorCursorNotFound, ce.Code)
+	})
 	mt.RunOpts("try next", noClientOpts, func(mt *mtest.T) {
 		// Skip tests if running against serverless, as capped collections are banned.
 		if os.Getenv("SERVERLESS") == "serverless" {
@@ -275,6 +314,44 @@ func TestCursor(t *testing.T) {
 		})
 	})
 	// For versions < 3.2, the first find will get all the documents
+	mt.RunOpts("active cursors", mtest.NewOptions().MinServerVersion("3.2"), func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		assert.Equal(mt, 0, len(mt.Client.ActiveCursors()), "expected no active cursors, got %v",
+			mt.Client.ActiveCursors())
+
+		first, err := mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetBatchSize(2))
+		assert.Nil(mt, err, "Find error: %v", err)
+		second, err := mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, options.Aggregate().SetBatchSize(2))
+		assert.Nil(mt, err, "Aggregate error: %v", err)
+
+		// A cursor whose results fit in the first batch is never open on the server.
+		all, err := mt.Coll.Find(context.Background(), bson.D{})
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer all.Close(context.Background())
+
+		active := mt.Client.ActiveCursors()
+		assert.Equal(mt, 2, len(active), "expected 2 active cursors, got %v", active)
+		wantNS := mt.DB.Name() + "." + mt.Coll.Name()
+		for i, want := range []*mongo.Cursor{first, second} {
+			assert.Equal(mt, want.ID(), active[i].ID, "expected cursor ID %v, got %v", want.ID(), active[i].ID)
+			assert.Equal(mt, wantNS, active[i].Namespace, "expected namespace %q, got %q", wantNS, active[i].Namespace)
+			assert.NotEqual(mt, "", active[i].Address.String(), "expected cursor to have a server address")
+			assert.True(mt, active[i].Age >= 0, "expected non-negative age, got %v", active[i].Age)
+		}
+
+		err = first.Close(context.Background())
+		assert.Nil(mt, err, "Close error: %v", err)
+		active = mt.Client.ActiveCursors()
+		assert.Equal(mt, 1, len(active), "expected 1 active cursor, got %v", active)
+		assert.Equal(mt, second.ID(), active[0].ID, "expected cursor ID %v, got %v", second.ID(), active[0].ID)
+
+		// Exhausting a cursor also removes it.
+		for second.Next(context.Background()) {
+		}
+		assert.Nil(mt, second.Err(), "cursor error: %v", second.Err())
+		active = mt.Client.ActiveCursors()
+		assert.Equal(mt, 0, len(active), "expected no active cursors, got %v", active)
+	})
 	mt.RunOpts("set batchSize", mtest.NewOptions().MinServerVersion("3.2"), func(mt *mtest.T) {
 		initCollection(mt, mt.Coll)
 		mt.ClearEvents()
@@ -18,6 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/handshake"
+	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -342,6 +343,87 @@ func TestDatabase(t *testing.T) {
 		})
 	})
 
+	mt.RunOpts("list collection specs", noClientOpts, func(mt *mtest.T) {
+		listSpec := func(mt *mtest.T, name string) mongo.CollectionSpec {
+			mt.Helper()
+
+			specs, err := mt.DB.ListCollectionSpecs(context.Background(), bson.D{{"name", name}})
+			assert.Nil(mt, err, "ListCollectionSpecs error: %v", err)
+			require.Equal(mt, 1, len(specs), "expected 1 CollectionSpec, got %d", len(specs))
+			return specs[0]
+		}
+
+		mt.RunOpts("validator and collation", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
+			validator := bson.D{{"x", bson.D{{"$type", "int"}}}}
+			collation := &options.Collation{Locale: "en_US", Strength: 2}
+			createOpts := options.CreateCollection().
+				SetValidator(validator).
+				SetValidationLevel("moderate").
+				SetValidationAction("warn").
+				SetCollation(collation)
+			mt.CreateCollection(mtest.Collection{Name: "specs-validator", CreateOpts: createOpts}, true)
+
+			spec := listSpec(mt, "specs-validator")
+			assert.Equal(mt, "collection", spec.Type, "expected type %q, got %q", "collection", spec.Type)
+			wantValidator, err := bson.Marshal(validator)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			assert.Equal(mt, bson.Raw(wantValidator), spec.Validator, "expected validator %v, got %v",
+				bson.Raw(wantValidator), spec.Validator)
+			assert.Equal(mt, "moderate", spec.ValidationLevel, "expected validation level %q, got %q", "moderate",
+				spec.ValidationLevel)
+			assert.Equal(mt, "warn", spec.ValidationAction, "expected validation action %q, got %q", "warn",
+				spec.ValidationAction)
+			require.NotNil(mt, spec.Collation, "expected collation, got nil")
+			assert.Equal(mt, "en_US", spec.Collation.Locale, "expected locale %q, got %q", "en_US",
+				spec.Collation.Locale)
+			assert.Equal(mt, 2, spec.Collation.Strength, "expected strength 2, got %d", spec.Collation.Strength)
+			assert.False(mt, spec.Capped, "expected collection not to be capped")
+			assert.Nil(mt, spec.TimeSeries, "expected no time-series options, got %v", spec.TimeSeries)
+		})
+		mt.Run("capped", func(mt *mtest.T) {
+			createOpts := options.CreateCollection().SetCapped(true).SetSizeInBytes(4096).SetMaxDocuments(10)
+			mt.CreateCollection(mtest.Collection{Name: "specs-capped", CreateOpts: createOpts}, true)
+
+			spec := listSpec(mt, "specs-capped")
+			assert.True(mt, spec.Capped, "expected collection to be capped")
+			assert.Equal(mt, int64(4096), spec.SizeInBytes, "expected size 4096, got %d", spec.SizeInBytes)
+			assert.Equal(mt, int64(10), spec.MaxDocuments, "expected max 10, got %d", spec.MaxDocuments)
+		})
+		mt.RunOpts("time-series", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+			tsOpts := options.TimeSeries().SetTimeField("ts").SetMetaField("sensor").SetGranularity("hours")
+			createOpts := options.CreateCollection().SetTimeSeriesOptions(tsOpts)
+			mt.CreateCollection(mtest.Collection{Name: "specs-timeseries", CreateOpts: createOpts}, true)
+
+			spec := listSpec(mt, "specs-timeseries")
+			assert.Equal(mt, "timeseries", spec.Type, "expected type %q, got %q", "timeseries", spec.Type)
+			require.NotNil(mt, spec.TimeSeries, "expected time-series options, got nil")
+			assert.Equal(mt, "ts", spec.TimeSeries.TimeField, "expected time field %q, got %q", "ts",
+				spec.TimeSeries.TimeField)
+			assert.Equal(mt, "sensor", spec.TimeSeries.MetaField, "expected meta field %q, got %q", "sensor",
+				spec.TimeSeries.MetaField)
+			assert.Equal(mt, "hours", spec.TimeSeries.Granularity, "expected granularity %q, got %q", "hours",
+				spec.TimeSeries.Granularity)
+		})
+		mt.RunOpts("view", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
+			pipeline := mongo.Pipeline{{{"$match", bson.D{{"x", 1}}}}}
+			err := mt.DB.CreateView(context.Background(), "specs-view", mt.Coll.Name(), pipeline)
+			assert.Nil(mt, err, "CreateView error: %v", err)
+			defer func() {
+				_ = mt.DB.Collection("specs-view").Drop(context.Background())
+			}()
+
+			spec := listSpec(mt, "specs-view")
+			assert.Equal(mt, "view", spec.Type, "expected type %q, got %q", "view", spec.Type)
+			assert.Equal(mt, mt.Coll.Name(), spec.ViewOn, "expected viewOn %q, got %q", mt.Coll.Name(), spec.ViewOn)
+			require.Equal(mt, 1, len(spec.Pipeline), "expected 1 pipeline stage, got %d", len(spec.Pipeline))
+			wantStage, err := bson.Marshal(pipeline[0])
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			assert.Equal(mt, bson.Raw(wantStage), spec.Pipeline[0], "expected stage %v, got %v",
+				bson.Raw(wantStage), spec.Pipeline[0])
+			assert.Nil(mt, spec.IDIndex, "expected no _id index for a view, got %v", spec.IDIndex)
+		})
+	})
+
 	mt.RunOpts("run command cursor", noClientOpts, func(mt *mtest.T) {
 		var data []interface{}
 		for i := 0; i < 5; i++ {
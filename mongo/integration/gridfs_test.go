@@ -8,6 +8,7 @@ package integration
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"io"
 	"math/rand"
@@ -525,6 +526,209 @@ func TestGridFS(x *testing.T) {
 		}
 	})
 
+	mt.Run("resumable upload", func(mt *mtest.T) {
+		fileData := []byte("abcdefghijklmn")
+		var chunkSize int32 = 4
+		uploadOpts := options.GridFSUpload().SetChunkSizeBytes(chunkSize)
+
+		testCases := []struct {
+			name           string
+			storedChunks   [][]byte
+			expectedOffset int64
+		}{
+			{"no stored chunks", nil, 0},
+			{"full chunks", [][]byte{fileData[0:4], fileData[4:8]}, 8},
+			{"partial final chunk", [][]byte{fileData[0:4], fileData[4:8], fileData[8:10]}, 8},
+			{"single partial chunk", [][]byte{fileData[0:2]}, 0},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				bucket, err := gridfs.NewBucket(mt.DB)
+				assert.Nil(mt, err, "NewBucket error: %v", err)
+				defer func() { _ = bucket.Drop() }()
+
+				fileID := primitive.NewObjectID()
+				for n, data := range tc.storedChunks {
+					chunk := bson.D{
+						{"_id", primitive.NewObjectID()},
+						{"files_id", fileID},
+						{"n", int32(n)},
+						{"data", primitive.Binary{Data: data}},
+					}
+					_, err := bucket.GetChunksCollection().InsertOne(context.Background(), chunk)
+					assert.Nil(mt, err, "InsertOne error for chunks collection: %v", err)
+				}
+
+				us, err := bucket.OpenUploadStreamResumable(fileID, "file", uploadOpts)
+				assert.Nil(mt, err, "OpenUploadStreamResumable error: %v", err)
+				assert.Equal(mt, tc.expectedOffset, us.Offset(), "expected offset %v, got %v", tc.expectedOffset, us.Offset())
+
+				_, err = us.Write(fileData[us.Offset():])
+				assert.Nil(mt, err, "Write error: %v", err)
+				err = us.Close()
+				assert.Nil(mt, err, "Close error: %v", err)
+
+				var downloadBuffer bytes.Buffer
+				n, err := bucket.DownloadToStream(fileID, &downloadBuffer)
+				assert.Nil(mt, err, "DownloadToStream error: %v", err)
+				assert.Equal(mt, int64(len(fileData)), n, "expected length %v, got %v", len(fileData), n)
+				assert.Equal(mt, fileData, downloadBuffer.Bytes(), "expected bytes %s, got %s", fileData, downloadBuffer.Bytes())
+
+				_, err = bucket.OpenUploadStreamResumable(fileID, "file", uploadOpts)
+				assert.Equal(mt, gridfs.ErrFileAlreadyUploaded, err,
+					"expected error %v, got %v", gridfs.ErrFileAlreadyUploaded, err)
+			})
+		}
+		mt.Run("mismatched chunk size", func(mt *mtest.T) {
+			bucket, err := gridfs.NewBucket(mt.DB)
+			assert.Nil(mt, err, "NewBucket error: %v", err)
+			defer func() { _ = bucket.Drop() }()
+
+			fileID := primitive.NewObjectID()
+			chunk := bson.D{
+				{"_id", primitive.NewObjectID()},
+				{"files_id", fileID},
+				{"n", int32(0)},
+				{"data", primitive.Binary{Data: fileData[0:8]}},
+			}
+			_, err = bucket.GetChunksCollection().InsertOne(context.Background(), chunk)
+			assert.Nil(mt, err, "InsertOne error for chunks collection: %v", err)
+
+			_, err = bucket.OpenUploadStreamResumable(fileID, "file", uploadOpts)
+			assert.Equal(mt, gridfs.ErrWrongSize, err, "expected error %v, got %v", gridfs.ErrWrongSize, err)
+		})
+	})
+
+	mt.Run("upload from aggregate", func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"x", int32(1)}, {"y", "a"}},
+			bson.D{{"x", int32(2)}, {"y", "b"}},
+			bson.D{{"x", int32(3)}, {"y", "c"}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+		pipeline := mongo.Pipeline{
+			{{"$match", bson.D{{"x", bson.D{{"$gte", 2}}}}}},
+			{{"$sort", bson.D{{"x", 1}}}},
+			{{"$project", bson.D{{"_id", 0}}}},
+		}
+		expected := docs[1:]
+
+		testCases := []struct {
+			name   string
+			format gridfs.AggregateFormat
+			decode func(mt *mtest.T, data []byte) []bson.D
+		}{
+			{"extended JSON", gridfs.AggregateFormatExtJSON, func(mt *mtest.T, data []byte) []bson.D {
+				var results []bson.D
+				for _, line := range bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) {
+					var doc bson.D
+					err := bson.UnmarshalExtJSON(line, true, &doc)
+					assert.Nil(mt, err, "UnmarshalExtJSON error: %v", err)
+					results = append(results, doc)
+				}
+				return results
+			}},
+			{"BSON", gridfs.AggregateFormatBSON, func(mt *mtest.T, data []byte) []bson.D {
+				var results []bson.D
+				for len(data) > 0 {
+					raw, err := bson.NewFromIOReader(bytes.NewReader(data))
+					assert.Nil(mt, err, "NewFromIOReader error: %v", err)
+					var doc bson.D
+					err = bson.Unmarshal(raw, &doc)
+					assert.Nil(mt, err, "Unmarshal error: %v", err)
+					results = append(results, doc)
+					data = data[len(raw):]
+				}
+				return results
+			}},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				bucket, err := gridfs.NewBucket(mt.DB)
+				assert.Nil(mt, err, "NewBucket error: %v", err)
+				defer func() { _ = bucket.Drop() }()
+
+				file, err := bucket.UploadFromAggregate(context.Background(), mt.Coll, pipeline, "results", tc.format)
+				assert.Nil(mt, err, "UploadFromAggregate error: %v", err)
+				assert.Equal(mt, "results", file.Name, "expected file name %q, got %q", "results", file.Name)
+
+				var downloadBuffer bytes.Buffer
+				n, err := bucket.DownloadToStream(file.ID, &downloadBuffer)
+				assert.Nil(mt, err, "DownloadToStream error: %v", err)
+				assert.Equal(mt, file.Length, n, "expected length %v, got %v", file.Length, n)
+
+				results := tc.decode(mt, downloadBuffer.Bytes())
+				assert.Equal(mt, len(expected), len(results), "expected %v results, got %v", len(expected), len(results))
+				for i, doc := range results {
+					assert.Equal(mt, expected[i], doc, "expected result %v, got %v", expected[i], doc)
+				}
+			})
+		}
+		mt.Run("partial file is deleted on error", func(mt *mtest.T) {
+			bucket, err := gridfs.NewBucket(mt.DB)
+			assert.Nil(mt, err, "NewBucket error: %v", err)
+			defer func() { _ = bucket.Drop() }()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = bucket.UploadFromAggregate(ctx, mt.Coll, pipeline, "results", gridfs.AggregateFormatExtJSON)
+			assert.NotNil(mt, err, "expected UploadFromAggregate error, got nil")
+
+			assertGridFSCollectionState(mt, bucket.GetFilesCollection(), "fs.files", 0)
+			assertGridFSCollectionState(mt, bucket.GetChunksCollection(), "fs.chunks", 0)
+		})
+	})
+
+	mt.Run("transform", func(mt *mtest.T) {
+		bucket, err := gridfs.NewBucket(mt.DB)
+		assert.Nil(mt, err, "NewBucket error: %v", err)
+		defer func() { _ = bucket.Drop() }()
+
+		fileData := bytes.Repeat([]byte("compressible file contents "), 1000)
+		uploadOpts := options.GridFSUpload().SetChunkSizeBytes(1024).SetMetadata(bson.D{{"owner", "reports"}})
+		us, err := bucket.OpenUploadStreamWithTransform(context.Background(), "report.txt", "gzip",
+			func(w io.Writer) (io.WriteCloser, error) {
+				return gzip.NewWriter(w), nil
+			}, uploadOpts)
+		assert.Nil(mt, err, "OpenUploadStreamWithTransform error: %v", err)
+		_, err = us.Write(fileData)
+		assert.Nil(mt, err, "Write error: %v", err)
+		err = us.Close()
+		assert.Nil(mt, err, "Close error: %v", err)
+
+		ds, err := bucket.OpenDownloadStreamWithTransform(context.Background(), us.FileID,
+			func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			})
+		assert.Nil(mt, err, "OpenDownloadStreamWithTransform error: %v", err)
+		got, err := io.ReadAll(ds)
+		assert.Nil(mt, err, "ReadAll error: %v", err)
+		assert.Equal(mt, fileData, got, "expected downloaded data to match uploaded data")
+
+		file := ds.GetFile()
+		assert.True(mt, file.Length < int64(len(fileData)),
+			"expected stored length %v to be less than the uploaded length %v", file.Length, len(fileData))
+		wantMetadata := bson.D{{"owner", "reports"}, {gridfs.TransformMetadataKey, "gzip"}}
+		var gotMetadata bson.D
+		err = bson.Unmarshal(file.Metadata, &gotMetadata)
+		assert.Nil(mt, err, "Unmarshal error: %v", err)
+		assert.Equal(mt, wantMetadata, gotMetadata, "expected metadata %v, got %v", wantMetadata, gotMetadata)
+
+		err = ds.Close()
+		assert.Nil(mt, err, "Close error: %v", err)
+
+		// The stored file contents are the compressed bytes.
+		var stored bytes.Buffer
+		_, err = bucket.DownloadToStream(us.FileID, &stored)
+		assert.Nil(mt, err, "DownloadToStream error: %v", err)
+		zr, err := gzip.NewReader(&stored)
+		assert.Nil(mt, err, "gzip.NewReader error: %v", err)
+		got, err = io.ReadAll(zr)
+		assert.Nil(mt, err, "ReadAll error: %v", err)
+		assert.Equal(mt, fileData, got, "expected decompressed stored data to match uploaded data")
+	})
+
 	// Regression test for a bug introduced in GODRIVER-2346.
 	mt.Run("Find", func(mt *mtest.T) {
 		bucket, err := gridfs.NewBucket(mt.DB)
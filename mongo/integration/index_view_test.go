@@ -9,6 +9,7 @@ package integration
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -486,6 +487,66 @@ func TestIndexView(t *testing.T) {
 				Name: indexNames[1],
 			})
 		})
+		mt.RunOpts("rollback on error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			ns := mt.DB.Name() + "." + mt.Coll.Name()
+			idIndex := bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}}
+			fooIndex := bson.D{{"v", 2}, {"key", bson.D{{"foo", 1}}}, {"name", "foo_1"}}
+			barIndex := bson.D{{"v", 2}, {"key", bson.D{{"bar", 1}}}, {"name", "bar_1"}}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, idIndex, fooIndex),
+				// Fail after the first index is built, leaving it on the collection.
+				mtest.CreateCommandErrorResponse(mtest.CommandError{
+					Code:    86,
+					Message: "index baz_1 failed",
+					Name:    "IndexKeySpecsConflict",
+				}),
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, idIndex, fooIndex, barIndex),
+				mtest.CreateSuccessResponse(bson.E{"nIndexesWas", 3}),
+			)
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"bar", 1}}},
+				{Keys: bson.D{{"baz", 1}}},
+				{Keys: bson.D{{"foo", 1}}},
+			}, options.CreateIndexes().SetRollbackOnError(true))
+			cmdErr, ok := err.(mongo.CommandError)
+			assert.True(mt, ok, "expected mongo.CommandError, got %T", err)
+			assert.Equal(mt, int32(86), cmdErr.Code, "expected error code 86, got %v", cmdErr.Code)
+
+			var commands []string
+			for _, evt := range mt.GetAllStartedEvents() {
+				commands = append(commands, evt.CommandName)
+			}
+			want := []string{"listIndexes", "createIndexes", "listIndexes", "dropIndexes"}
+			assert.Equal(mt, want, commands, "expected commands %v, got %v", want, commands)
+
+			// Only the index created by CreateMany is dropped, not foo_1, which existed before the call.
+			dropped := mt.GetAllStartedEvents()[3].Command.Lookup("index").StringValue()
+			assert.Equal(mt, "bar_1", dropped, "expected index bar_1 to be dropped, got %v", dropped)
+		})
+		mt.RunOpts("rollback error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			ns := mt.DB.Name() + "." + mt.Coll.Name()
+			barIndex := bson.D{{"v", 2}, {"key", bson.D{{"bar", 1}}}, {"name", "bar_1"}}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch),
+				mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 86, Name: "IndexKeySpecsConflict"}),
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, barIndex),
+				mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 27, Name: "IndexNotFound"}),
+			)
+
+			_, err := mt.Coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+				{Keys: bson.D{{"bar", 1}}},
+				{Keys: bson.D{{"baz", 1}}},
+			}, options.CreateIndexes().SetRollbackOnError(true))
+			rollbackErr, ok := err.(mongo.IndexRollbackError)
+			assert.True(mt, ok, "expected mongo.IndexRollbackError, got %T", err)
+			assert.Equal(mt, 1, len(rollbackErr.RollbackErrors), "expected 1 rollback error, got %v",
+				rollbackErr.RollbackErrors)
+
+			var cmdErr mongo.CommandError
+			assert.True(mt, errors.As(err, &cmdErr), "expected error to wrap mongo.CommandError, got %v", err)
+			assert.Equal(mt, int32(86), cmdErr.Code, "expected error code 86, got %v", cmdErr.Code)
+		})
 	})
 	mt.RunOpts("list specifications", noClientOpts, func(mt *mtest.T) {
 		mt.Run("verify results", func(mt *mtest.T) {
@@ -747,6 +808,130 @@ func TestIndexView(t *testing.T) {
 			assert.True(mt, cmp.Equal(specs, expectedSpecs), "expected specifications to match: %v", cmp.Diff(specs, expectedSpecs))
 		})
 	})
+	mt.RunOpts("estimate build", mtest.NewOptions().MinServerVersion("3.6"), func(mt *mtest.T) {
+		const numDocs = 1000
+		docs := make([]interface{}, 0, numDocs)
+		for i := 0; i < numDocs; i++ {
+			docs = append(docs, bson.D{{"x", int32(i)}, {"tag", int32(i % 10)}})
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		est, err := mt.Coll.Indexes().EstimateBuild(context.Background(), mongo.IndexModel{Keys: bson.D{{"x", 1}}})
+		assert.Nil(mt, err, "EstimateBuild error: %v", err)
+		assert.Equal(mt, int64(numDocs), est.DocumentCount, "expected document count %v, got %v", numDocs,
+			est.DocumentCount)
+		assert.True(mt, est.SampleSize > 0, "expected documents to be sampled")
+		assert.True(mt, est.Cardinality > 0.9, "expected high cardinality, got %v", est.Cardinality)
+		// Each entry holds a 4-byte int32 key plus overhead, so the estimate should be tens of kilobytes.
+		assert.True(mt, est.EstimatedBytes >= 4*numDocs && est.EstimatedBytes <= 64*numDocs,
+			"expected estimated size between %v and %v bytes, got %v", 4*numDocs, 64*numDocs, est.EstimatedBytes)
+		assert.False(mt, est.BackgroundAdvised, "expected a background build not to be advised for %v documents", numDocs)
+
+		specs, err := mt.Coll.Indexes().ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		assert.Equal(mt, 1, len(specs), "expected only the _id index to exist, got %v", specs)
+
+		est, err = mt.Coll.Indexes().EstimateBuild(context.Background(), mongo.IndexModel{Keys: bson.D{{"tag", 1}}})
+		assert.Nil(mt, err, "EstimateBuild error: %v", err)
+		assert.True(mt, est.Cardinality < 0.1, "expected low cardinality, got %v", est.Cardinality)
+	})
+	mt.Run("wait for build", func(mt *mtest.T) {
+		const numDocs = 20000
+		docs := make([]interface{}, 0, numDocs)
+		for i := 0; i < numDocs; i++ {
+			docs = append(docs, bson.D{{"x", int32(i)}, {"s", fmt.Sprintf("value-%06d", i)}})
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		iv := mt.Coll.Indexes()
+		name, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"s", 1}, {"x", -1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err = iv.WaitForBuild(ctx, name, 10*time.Millisecond)
+		assert.Nil(mt, err, "WaitForBuild error: %v", err)
+		verifyIndexExists(mt, iv, index{Name: name})
+
+		mt.Run("times out for missing index", func(mt *mtest.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := mt.Coll.Indexes().WaitForBuild(ctx, "missing_1", 10*time.Millisecond)
+			assert.ErrorIs(mt, err, context.DeadlineExceeded, "expected error %v, got %v", context.DeadlineExceeded, err)
+			assert.True(mt, mongo.IsTimeout(err), "expected IsTimeout to be true for %v", err)
+		})
+	})
+	mt.Run("ensure from struct", func(mt *mtest.T) {
+		type user struct {
+			Email     string    `bson:"email" index:"unique"`
+			LastName  string    `bson:"lastName" index:"compound:contact,1"`
+			FirstName string    `bson:"firstName" index:"compound:contact,-1"`
+			CreatedAt time.Time `bson:"createdAt" index:"ttl:3600"`
+		}
+
+		iv := mt.Coll.Indexes()
+		names, err := iv.EnsureFromStruct(context.Background(), user{})
+		assert.Nil(mt, err, "EnsureFromStruct error: %v", err)
+		assert.Equal(mt, []string{"email_1", "lastName_1_firstName_-1", "createdAt_1"}, names,
+			"expected created index names to match")
+
+		emailIndex := getIndexDoc(mt, iv, bson.D{{"email", int32(1)}})
+		assert.NotNil(mt, emailIndex, "index on email not found")
+		checkIndexDocContains(mt, emailIndex, bson.E{"unique", true})
+		verifyIndexExists(mt, iv, index{
+			Key:  bson.D{{"lastName", int32(1)}, {"firstName", int32(-1)}},
+			Name: "lastName_1_firstName_-1",
+		})
+		ttlIndex := getIndexDoc(mt, iv, bson.D{{"createdAt", int32(1)}})
+		assert.NotNil(mt, ttlIndex, "index on createdAt not found")
+		checkIndexDocContains(mt, ttlIndex, bson.E{"expireAfterSeconds", int32(3600)})
+
+		names, err = iv.EnsureFromStruct(context.Background(), &user{})
+		assert.Nil(mt, err, "EnsureFromStruct error: %v", err)
+		assert.Equal(mt, 0, len(names), "expected no indexes to be created, got %v", names)
+	})
+	mt.Run("covers query", func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"x", int32(1)}, {"y", "a"}, {"z", true}},
+			bson.D{{"x", int32(2)}, {"y", "b"}, {"z", false}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+		iv := mt.Coll.Indexes()
+		_, err = iv.CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"x", 1}, {"y", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		testCases := []struct {
+			name       string
+			filter     bson.D
+			sort       bson.D
+			projection bson.D
+			wantIndex  string
+			covered    bool
+		}{
+			{
+				"covered",
+				bson.D{{"x", bson.D{{"$gt", 0}}}},
+				bson.D{{"x", 1}},
+				bson.D{{"_id", 0}, {"x", 1}, {"y", 1}},
+				"x_1_y_1",
+				true,
+			},
+			{"projection needs document", bson.D{{"x", 1}}, nil, bson.D{{"_id", 0}, {"z", 1}}, "x_1_y_1", false},
+			{"no projection", bson.D{{"x", 1}}, nil, nil, "x_1_y_1", false},
+			{"no index", bson.D{{"z", true}}, nil, bson.D{{"_id", 0}, {"z", 1}}, "", false},
+		}
+		for _, tc := range testCases {
+			name, covered, err := iv.CoversQuery(context.Background(), tc.filter, tc.sort, tc.projection)
+			assert.Nil(mt, err, "CoversQuery error for %s: %v", tc.name, err)
+			assert.Equal(mt, tc.wantIndex, name, "expected index %q for %s, got %q", tc.wantIndex, tc.name, name)
+			assert.Equal(mt, tc.covered, covered, "expected covered to be %v for %s, got %v", tc.covered, tc.name,
+				covered)
+		}
+	})
 }
 
 func getIndexDoc(mt *mtest.T, iv mongo.IndexView, expectedKeyDoc bson.D) bson.D {
@@ -0,0 +1,225 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// defaultLoaderWait is the time a Loader waits for more keys before it runs a batch if LoaderOptions.Wait is
+	// not set.
+	defaultLoaderWait = time.Millisecond
+
+	// defaultLoaderMaxBatchSize is the maximum number of keys in a batch if LoaderOptions.MaxBatchSize is not set.
+	defaultLoaderMaxBatchSize = 1000
+)
+
+// LoaderOptions represents options that can be used to configure a Loader.
+type LoaderOptions struct {
+	// Wait is the time the Loader waits after the first key of a batch is requested for more keys to be requested
+	// before it runs the batch. The default value is 1ms.
+	Wait time.Duration
+
+	// MaxBatchSize is the maximum number of distinct keys looked up by a single find command. A batch is run as
+	// soon as it reaches this size. The default value is 1000.
+	MaxBatchSize int
+
+	// KeyField is the field of the documents that holds the key. It can be a dotted path to an embedded field. The
+	// default value is "_id".
+	KeyField string
+}
+
+// Loader batches the lookups of documents by key made within a short time window, possibly from many goroutines,
+// into a single find command with an $in filter, and distributes the matching documents to the callers. This
+// reduces the number of round trips for workloads that look up many documents by _id one at a time, such as
+// resolvers in a GraphQL server.
+//
+// A Loader is safe for concurrent use. It does not cache results, so a key requested in two different batches is
+// looked up twice.
+type Loader[K comparable, V any] struct {
+	coll  *Collection
+	opts  LoaderOptions
+	path  []string
+	fetch func(ctx context.Context, keys []K) (map[K]bson.Raw, error)
+
+	mu    sync.Mutex
+	batch *loaderBatch[K]
+}
+
+// loaderBatch is a set of keys that are looked up by one find command.
+type loaderBatch[K comparable] struct {
+	keys  []K
+	seen  map[K]struct{}
+	timer *time.Timer
+	done  chan struct{}
+
+	// docs and err are set before done is closed.
+	docs map[K]bson.Raw
+	err  error
+}
+
+// NewLoader creates a Loader that looks up documents in coll and decodes them into values of type V. Keys are
+// compared with the value of the key field of each document decoded into a K, so K must be a type the key values
+// decode into, such as primitive.ObjectID or string.
+//
+// Example usage:
+//
+//	loader := mongo.NewLoader[primitive.ObjectID, User](coll, mongo.LoaderOptions{})
+//	user, err := loader.Load(ctx, userID)
+func NewLoader[K comparable, V any](coll *Collection, opts LoaderOptions) *Loader[K, V] {
+	if opts.Wait <= 0 {
+		opts.Wait = defaultLoaderWait
+	}
+	if opts.MaxBatchSize <= 0 {
+		opts.MaxBatchSize = defaultLoaderMaxBatchSize
+	}
+	if opts.KeyField == "" {
+		opts.KeyField = "_id"
+	}
+
+	l := &Loader[K, V]{
+		coll: coll,
+		opts: opts,
+		path: strings.Split(opts.KeyField, "."),
+	}
+	l.fetch = l.find
+	return l
+}
+
+// Load returns the document with the given key, decoded into a V. The lookup is added to the current batch, which
+// is run when the Loader's Wait time has passed since the batch was started or when it reaches MaxBatchSize keys.
+// If no document has the key, Load returns ErrNoDocuments. If the find command fails, every Load call in the batch
+// returns the error. If ctx is done before the batch completes, Load returns ctx.Err() without affecting the other
+// callers.
+//
+// The find command is run with context.Background() because it is shared by all callers in the batch, so it is only
+// bounded by the Timeout of the Client.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	var val V
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	b := l.add(key)
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return val, ctx.Err()
+	}
+
+	if b.err != nil {
+		return val, b.err
+	}
+	raw, ok := b.docs[key]
+	if !ok {
+		return val, ErrNoDocuments
+	}
+	dec, err := getDecoder(raw, l.coll.bsonOpts, l.coll.registry)
+	if err != nil {
+		return val, err
+	}
+	err = dec.Decode(&val)
+	return val, err
+}
+
+// LoadMany returns the documents with the given keys. The returned values and errors have the same length and order
+// as keys, and the error for a key is ErrNoDocuments if no document has that key. All keys are added to the same
+// batch unless it reaches MaxBatchSize.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, []error) {
+	vals := make([]V, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	wg.Add(len(keys))
+	for i, key := range keys {
+		go func(i int, key K) {
+			defer wg.Done()
+			vals[i], errs[i] = l.Load(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+	return vals, errs
+}
+
+// add adds key to the current batch, starting a new batch if there is none, and returns the batch.
+func (l *Loader[K, V]) add(key K) *loaderBatch[K] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[K]{
+			seen: make(map[K]struct{}),
+			done: make(chan struct{}),
+		}
+		l.batch = b
+		b.timer = time.AfterFunc(l.opts.Wait, func() { l.dispatch(b) })
+	}
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+	if len(b.keys) >= l.opts.MaxBatchSize {
+		// Later keys go into a new batch. If the timer already fired, dispatch is about to run the batch.
+		l.batch = nil
+		if b.timer.Stop() {
+			go l.dispatch(b)
+		}
+	}
+	return b
+}
+
+// dispatch runs the find command for b and wakes up the callers waiting on it.
+func (l *Loader[K, V]) dispatch(b *loaderBatch[K]) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	b.docs, b.err = l.fetch(context.Background(), b.keys)
+	close(b.done)
+}
+
+// find looks up the documents with the given keys and returns them by key. Documents whose key field is missing or
+// does not decode into a K are ignored, and only the first document is returned for a duplicated key.
+func (l *Loader[K, V]) find(ctx context.Context, keys []K) (map[K]bson.Raw, error) {
+	cursor, err := l.coll.Find(ctx, bson.D{{l.opts.KeyField, bson.D{{"$in", keys}}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	docs := make(map[K]bson.Raw, len(keys))
+	for cursor.Next(ctx) {
+		keyVal, err := cursor.Current.LookupErr(l.path...)
+		if err != nil {
+			continue
+		}
+		var key K
+		if err := keyVal.UnmarshalWithRegistry(l.coll.registry, &key); err != nil {
+			continue
+		}
+		if _, ok := docs[key]; ok {
+			continue
+		}
+		// The cursor reuses its buffer for later batches, so each document is copied.
+		doc := make(bson.Raw, len(cursor.Current))
+		copy(doc, cursor.Current)
+		docs[key] = doc
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestLoader(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID   int32  `bson:"_id"`
+		Name string `bson:"name"`
+	}
+
+	// newLoader returns a Loader whose find commands are replaced by fetch, which returns a document for every even
+	// key, and a pointer to the list of batches it was called with.
+	newLoader := func(opts LoaderOptions, fetchErr error) (*Loader[int32, item], *[][]int32) {
+		var mu sync.Mutex
+		var batches [][]int32
+
+		l := NewLoader[int32, item](&Collection{registry: bson.DefaultRegistry}, opts)
+		l.fetch = func(_ context.Context, keys []int32) (map[int32]bson.Raw, error) {
+			mu.Lock()
+			batches = append(batches, append([]int32(nil), keys...))
+			mu.Unlock()
+
+			if fetchErr != nil {
+				return nil, fetchErr
+			}
+			docs := make(map[int32]bson.Raw)
+			for _, key := range keys {
+				if key%2 == 0 {
+					doc, err := bson.Marshal(item{ID: key, Name: "item"})
+					if err != nil {
+						return nil, err
+					}
+					docs[key] = doc
+				}
+			}
+			return docs, nil
+		}
+		return l, &batches
+	}
+
+	t.Run("concurrent loads are batched", func(t *testing.T) {
+		t.Parallel()
+
+		l, batches := newLoader(LoaderOptions{Wait: 50 * time.Millisecond}, nil)
+
+		const n = 20
+		vals := make([]item, n)
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// Every key is requested twice.
+				vals[i], errs[i] = l.Load(context.Background(), int32(i/2))
+			}(i)
+		}
+		wg.Wait()
+
+		require.Len(t, *batches, 1, "expected one batch, got %v", *batches)
+		keys := (*batches)[0]
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		want := []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		assert.Equal(t, want, keys, "expected batch keys %v, got %v", want, keys)
+
+		for i := 0; i < n; i++ {
+			key := int32(i / 2)
+			if key%2 != 0 {
+				assert.ErrorIs(t, errs[i], ErrNoDocuments, "expected ErrNoDocuments for key %v", key)
+				continue
+			}
+			assert.NoError(t, errs[i], "Load error for key %v", key)
+			assert.Equal(t, item{ID: key, Name: "item"}, vals[i], "unexpected value for key %v", key)
+		}
+	})
+
+	t.Run("LoadMany", func(t *testing.T) {
+		t.Parallel()
+
+		l, batches := newLoader(LoaderOptions{Wait: 10 * time.Millisecond}, nil)
+
+		vals, errs := l.LoadMany(context.Background(), []int32{4, 3, 2})
+		require.Len(t, *batches, 1, "expected one batch, got %v", *batches)
+		assert.Equal(t, []item{{ID: 4, Name: "item"}, {}, {ID: 2, Name: "item"}}, vals, "unexpected values")
+		assert.NoError(t, errs[0], "LoadMany error for key 4")
+		assert.ErrorIs(t, errs[1], ErrNoDocuments, "expected ErrNoDocuments for key 3")
+		assert.NoError(t, errs[2], "LoadMany error for key 2")
+	})
+
+	t.Run("max batch size", func(t *testing.T) {
+		t.Parallel()
+
+		l, batches := newLoader(LoaderOptions{Wait: time.Hour, MaxBatchSize: 4}, nil)
+
+		keys := []int32{0, 1, 2, 3, 4, 5, 6, 7}
+		_, errs := l.LoadMany(context.Background(), keys)
+		for i, err := range errs {
+			if keys[i]%2 == 0 {
+				assert.NoError(t, err, "LoadMany error for key %v", keys[i])
+			}
+		}
+		assert.Len(t, *batches, 2, "expected two batches, got %v", *batches)
+		for _, batch := range *batches {
+			assert.Len(t, batch, 4, "expected batches of 4 keys, got %v", *batches)
+		}
+	})
+
+	t.Run("error is returned for every key", func(t *testing.T) {
+		t.Parallel()
+
+		fetchErr := errors.New("find failed")
+		l, _ := newLoader(LoaderOptions{Wait: 10 * time.Millisecond}, fetchErr)
+
+		_, errs := l.LoadMany(context.Background(), []int32{1, 2, 3})
+		for _, err := range errs {
+			assert.ErrorIs(t, err, fetchErr, "expected find error")
+		}
+	})
+
+	t.Run("context done before batch runs", func(t *testing.T) {
+		t.Parallel()
+
+		l, batches := newLoader(LoaderOptions{Wait: 100 * time.Millisecond}, nil)
+
+		var cancelled int32
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := l.Load(ctx, 2); errors.Is(err, context.Canceled) {
+				atomic.StoreInt32(&cancelled, 1)
+			}
+		}()
+		val, err := l.Load(context.Background(), 4)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled), "expected cancelled Load to return context.Canceled")
+		assert.NoError(t, err, "Load error")
+		assert.Equal(t, item{ID: 4, Name: "item"}, val, "unexpected value")
+		assert.Len(t, *batches, 1, "expected one batch, got %v", *batches)
+	})
+}
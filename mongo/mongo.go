@@ -20,6 +20,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/codecutil"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -118,6 +119,9 @@ func getEncoder(
 		if opts.StringifyMapKeysWithFmt {
 			enc.StringifyMapKeysWithFmt()
 		}
+		if opts.SortMapKeys {
+			enc.SortMapKeys()
+		}
 		if opts.UseJSONStructTags {
 			enc.UseJSONStructTags()
 		}
@@ -235,6 +239,42 @@ func ensureID(
 	return doc, oid, nil
 }
 
+// ensureGeneratedID is like ensureID, but inserts the value returned by idGen as the "_id" element of documents that
+// do not have one. If idGen is nil, a new ObjectID is generated.
+func ensureGeneratedID(
+	doc bsoncore.Document,
+	idGen func() interface{},
+	bsonOpts *options.BSONOptions,
+	reg *bsoncodec.Registry,
+) (bsoncore.Document, interface{}, error) {
+	if idGen == nil {
+		return ensureID(doc, primitive.NilObjectID, bsonOpts, reg)
+	}
+	if _, err := doc.LookupErr("_id"); err == nil {
+		return ensureID(doc, primitive.NilObjectID, bsonOpts, reg)
+	}
+
+	id := idGen()
+	idVal, err := marshalValue(id, bsonOpts, reg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling generated _id: %w", err)
+	}
+
+	olddoc := doc
+
+	// Reserve extra space for the "_id" element: type (1) + "_id" (3) + terminator (1) + value.
+	doc = make(bsoncore.Document, 0, len(olddoc)+5+len(idVal.Data))
+	_, doc = bsoncore.ReserveLength(doc)
+	doc = bsoncore.AppendValueElement(doc, "_id", idVal)
+
+	// Remove and re-write the BSON document length header.
+	const int32Len = 4
+	doc = append(doc, olddoc[int32Len:]...)
+	doc = bsoncore.UpdateLength(doc, 0, int32(len(doc)))
+
+	return doc, id, nil
+}
+
 func ensureDollarKey(doc bsoncore.Document) error {
 	firstElem, err := doc.IndexErr(0)
 	if err != nil {
@@ -439,6 +479,31 @@ func marshalValue(
 	return codecutil.MarshalValue(val, newEncoderFn(bsonOpts, registry))
 }
 
+// serverAPIWithOverrides returns the server API options to send with an operation. If strict or deprecationErrors is
+// non-nil, it replaces the corresponding Client-wide setting in a copy of base. Overrides are only valid if the Client
+// was configured with a server API version.
+func serverAPIWithOverrides(
+	base *driver.ServerAPIOptions,
+	strict *bool,
+	deprecationErrors *bool,
+) (*driver.ServerAPIOptions, error) {
+	if strict == nil && deprecationErrors == nil {
+		return base, nil
+	}
+	if base == nil {
+		return nil, ErrServerAPIOverrideWithoutVersion
+	}
+
+	sa := *base
+	if strict != nil {
+		sa.Strict = strict
+	}
+	if deprecationErrors != nil {
+		sa.DeprecationErrors = deprecationErrors
+	}
+	return &sa, nil
+}
+
 // Build the aggregation pipeline for the CountDocument command.
 func countDocumentsAggregatePipeline(
 	filter interface{},
@@ -20,6 +20,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 )
 
 func TestEnsureID(t *testing.T) {
@@ -157,6 +158,67 @@ func TestEnsureID_NilObjectID(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestEnsureGeneratedID(t *testing.T) {
+	t.Parallel()
+
+	doc := bsoncore.NewDocumentBuilder().
+		AppendString("foo", "bar").
+		Build()
+
+	t.Run("generated", func(t *testing.T) {
+		t.Parallel()
+
+		var next int32
+		idGen := func() interface{} {
+			next++
+			return fmt.Sprintf("id-%d", next)
+		}
+		for _, wantID := range []string{"id-1", "id-2"} {
+			got, gotID, err := ensureGeneratedID(doc, idGen, nil, nil)
+			require.NoError(t, err, "ensureGeneratedID error")
+
+			want := bsoncore.NewDocumentBuilder().
+				AppendString("_id", wantID).
+				AppendString("foo", "bar").
+				Build()
+			assert.Equal(t, want, got, "expected and actual documents are different")
+			assert.Equal(t, wantID, gotID, "expected and actual IDs are different")
+		}
+	})
+	t.Run("existing _id", func(t *testing.T) {
+		t.Parallel()
+
+		existing := bsoncore.NewDocumentBuilder().
+			AppendInt32("_id", 1).
+			Build()
+		idGen := func() interface{} {
+			t.Error("expected the ID generator not to be called")
+			return nil
+		}
+		got, gotID, err := ensureGeneratedID(existing, idGen, nil, nil)
+		require.NoError(t, err, "ensureGeneratedID error")
+
+		assert.Equal(t, existing, got, "expected the document not to be modified")
+		assert.Equal(t, int32(1), gotID, "expected and actual IDs are different")
+	})
+	t.Run("nil generator", func(t *testing.T) {
+		t.Parallel()
+
+		_, gotID, err := ensureGeneratedID(doc, nil, nil, nil)
+		require.NoError(t, err, "ensureGeneratedID error")
+
+		_, ok := gotID.(primitive.ObjectID)
+		assert.True(t, ok, "expected an ObjectID, got %T", gotID)
+	})
+	t.Run("marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		idGen := func() interface{} { return make(chan int) }
+		_, _, err := ensureGeneratedID(doc, idGen, nil, nil)
+		assert.Error(t, err, "expected ensureGeneratedID error")
+	})
+}
+
 func TestMarshalAggregatePipeline(t *testing.T) {
 	// []byte of [{{"$limit", 12345}}]
 	index, arr := bsoncore.AppendArrayStart(nil)
@@ -597,6 +659,21 @@ func TestMarshalValue(t *testing.T) {
 					Build(),
 			},
 		},
+		{
+			name:  "sort map keys",
+			value: map[string]int32{"c": 3, "a": 1, "b": 2},
+			bsonOpts: &options.BSONOptions{
+				SortMapKeys: true,
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendInt32("a", 1).
+					AppendInt32("b", 2).
+					AppendInt32("c", 3).
+					Build(),
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc // Capture range variable.
@@ -611,6 +688,73 @@ func TestMarshalValue(t *testing.T) {
 	}
 }
 
+func TestServerAPIWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	trueVal, falseVal := true, false
+
+	testCases := []struct {
+		name              string
+		base              *driver.ServerAPIOptions
+		strict            *bool
+		deprecationErrors *bool
+		want              *driver.ServerAPIOptions
+		wantErr           error
+	}{
+		{
+			name: "no server API and no overrides",
+		},
+		{
+			name: "no overrides uses client settings",
+			base: driver.NewServerAPIOptions("1").SetStrict(true),
+			want: driver.NewServerAPIOptions("1").SetStrict(true),
+		},
+		{
+			name:   "strict override takes precedence over client setting",
+			base:   driver.NewServerAPIOptions("1").SetStrict(true).SetDeprecationErrors(true),
+			strict: &falseVal,
+			want:   driver.NewServerAPIOptions("1").SetStrict(false).SetDeprecationErrors(true),
+		},
+		{
+			name:              "deprecation errors override takes precedence over client setting",
+			base:              driver.NewServerAPIOptions("1").SetStrict(true).SetDeprecationErrors(false),
+			deprecationErrors: &trueVal,
+			want:              driver.NewServerAPIOptions("1").SetStrict(true).SetDeprecationErrors(true),
+		},
+		{
+			name:              "overrides set fields unset on the client",
+			base:              driver.NewServerAPIOptions("1"),
+			strict:            &trueVal,
+			deprecationErrors: &falseVal,
+			want:              driver.NewServerAPIOptions("1").SetStrict(true).SetDeprecationErrors(false),
+		},
+		{
+			name:    "overrides without server API version",
+			strict:  &trueVal,
+			wantErr: ErrServerAPIOverrideWithoutVersion,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var before driver.ServerAPIOptions
+			if tc.base != nil {
+				before = *tc.base
+			}
+
+			got, err := serverAPIWithOverrides(tc.base, tc.strict, tc.deprecationErrors)
+			assert.Equal(t, tc.wantErr, err, "expected and actual error do not match")
+			assert.Equal(t, tc.want, got, "expected and actual server API options do not match")
+			if tc.base != nil {
+				assert.Equal(t, before, *tc.base, "expected client server API options to be unmodified")
+			}
+		})
+	}
+}
+
 var _ bsoncodec.ValueMarshaler = bvMarsh{}
 
 type bvMarsh struct {
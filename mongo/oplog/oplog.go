@@ -0,0 +1,277 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package oplog provides typed access to the replica set oplog in the local.oplog.rs collection.
+//
+// Reading the oplog directly is lower-level than using change streams: entries use an internal format that can
+// change between server versions, the oplog is not available on sharded cluster routers, and reading it requires
+// privileges on the local database. Prefer change streams unless the raw oplog is specifically needed.
+//
+// TailOplog opens a tailable cursor that waits for new entries and resumes after the timestamp of the last entry
+// returned, so the loop below processes every new entry exactly once until ctx is canceled:
+//
+//	cursor, err := oplog.TailOplog(ctx, client, options.TailOplog().SetFilter(bson.D{{"ns", "db.coll"}}))
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close(context.Background())
+//
+//	for cursor.Next(ctx) {
+//		fmt.Println(cursor.Current.Op, cursor.Current.Namespace, cursor.Current.Object)
+//	}
+//	return cursor.Err()
+package oplog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// Database is the name of the database that contains the oplog.
+	Database = "local"
+	// Collection is the name of the replica set oplog collection.
+	Collection = "oplog.rs"
+)
+
+// Values of the Op field of an OplogEntry.
+const (
+	OpInsert  = "i"
+	OpUpdate  = "u"
+	OpDelete  = "d"
+	OpCommand = "c"
+	OpNoop    = "n"
+)
+
+// defaultRetryInterval is how long Next waits before reopening the oplog cursor if the server closed it without
+// returning any entries and no MaxAwaitTime is set.
+const defaultRetryInterval = time.Second
+
+// OplogEntry is a single entry in the oplog.
+type OplogEntry struct {
+	// Op is the type of operation, such as OpInsert or OpUpdate.
+	Op string `bson:"op"`
+
+	// Namespace is the "<database>.<collection>" namespace that the operation applies to.
+	Namespace string `bson:"ns"`
+
+	// Object is the operation document: the inserted document, the update description, the _id of the deleted
+	// document, or the command.
+	Object bson.Raw `bson:"o"`
+
+	// Object2 identifies the document that an update applies to. It is nil for other operations.
+	Object2 bson.Raw `bson:"o2,omitempty"`
+
+	// Timestamp is the cluster time at which the operation was applied. Oplog entries are ordered by Timestamp.
+	Timestamp primitive.Timestamp `bson:"ts"`
+
+	// Wall is the wall clock time at which the operation was applied.
+	Wall time.Time `bson:"wall"`
+}
+
+// OplogCursor is a cursor over oplog entries. An OplogCursor is not goroutine safe.
+type OplogCursor struct {
+	// Current contains the entry returned by the last successful call to Next.
+	Current OplogEntry
+
+	coll          *mongo.Collection
+	opts          *options.TailOplogOptions
+	cursor        *mongo.Cursor
+	lastTimestamp primitive.Timestamp
+	err           error
+}
+
+// TailOplog opens a tailable cursor over the oplog of the replica set member selected by the client's read
+// preference. The cursor returns the entries that are newer than opts.StartAfter, or newer than the last entry in
+// the oplog if StartAfter is not set, and waits for new entries to be written.
+func TailOplog(ctx context.Context, client *mongo.Client, opts ...*options.TailOplogOptions) (*OplogCursor, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	to := mergeTailOplogOptions(opts)
+	oc := &OplogCursor{
+		coll: client.Database(Database).Collection(Collection),
+		opts: to,
+	}
+
+	if to.StartAfter != nil {
+		oc.lastTimestamp = *to.StartAfter
+	} else {
+		ts, err := latestTimestamp(ctx, oc.coll)
+		if err != nil {
+			return nil, err
+		}
+		oc.lastTimestamp = ts
+	}
+
+	if err := oc.open(ctx); err != nil {
+		return nil, err
+	}
+	return oc, nil
+}
+
+// latestTimestamp returns the timestamp of the newest entry in the oplog, or the zero timestamp if it is empty.
+func latestTimestamp(ctx context.Context, coll *mongo.Collection) (primitive.Timestamp, error) {
+	findOpts := options.FindOne().
+		SetSort(bson.D{{"$natural", -1}}).
+		SetProjection(bson.D{{"ts", 1}})
+
+	var entry struct {
+		Timestamp primitive.Timestamp `bson:"ts"`
+	}
+	err := coll.FindOne(ctx, bson.D{}, findOpts).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return primitive.Timestamp{}, nil
+	}
+	return entry.Timestamp, err
+}
+
+// open creates a tailable cursor that returns the entries after the last timestamp.
+func (oc *OplogCursor) open(ctx context.Context) error {
+	findOpts := options.Find().SetCursorType(options.TailableAwait)
+	if oc.opts.BatchSize != nil {
+		findOpts.SetBatchSize(*oc.opts.BatchSize)
+	}
+	if oc.opts.MaxAwaitTime != nil {
+		findOpts.SetMaxAwaitTime(*oc.opts.MaxAwaitTime)
+	}
+
+	cursor, err := oc.coll.Find(ctx, buildFilter(oc.lastTimestamp, oc.opts.Filter), findOpts)
+	if err != nil {
+		return err
+	}
+	oc.cursor = cursor
+	return nil
+}
+
+// buildFilter returns the filter for oplog entries that are newer than ts and match filter, if it is not nil.
+func buildFilter(ts primitive.Timestamp, filter interface{}) bson.D {
+	tsFilter := bson.D{{"ts", bson.D{{"$gt", ts}}}}
+	if filter == nil {
+		return tsFilter
+	}
+	return bson.D{{"$and", bson.A{tsFilter, filter}}}
+}
+
+// Next blocks until the next oplog entry is available and stores it in Current. It returns false if ctx expires,
+// the cursor is closed, or an error occurs, in which case the error is reported by Err.
+//
+// If the server closes the tailable cursor, for example because no entries matched when it was opened, Next
+// reopens it after the timestamp of the last entry returned, so no entries are skipped or returned twice.
+func (oc *OplogCursor) Next(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for oc.err == nil {
+		if oc.cursor == nil {
+			return false
+		}
+
+		if oc.cursor.Next(ctx) {
+			var entry OplogEntry
+			if oc.err = oc.cursor.Decode(&entry); oc.err != nil {
+				return false
+			}
+			if !entry.Timestamp.After(oc.lastTimestamp) {
+				// The cursor was reopened and returned an entry that has already been seen.
+				continue
+			}
+			oc.Current = entry
+			oc.lastTimestamp = entry.Timestamp
+			return true
+		}
+
+		if oc.err = oc.cursor.Err(); oc.err != nil {
+			return false
+		}
+		if oc.err = ctx.Err(); oc.err != nil {
+			return false
+		}
+
+		// The server closed the tailable cursor. Wait before reopening it to avoid busy looping on an oplog with
+		// no matching entries.
+		_ = oc.cursor.Close(ctx)
+		oc.cursor = nil
+		if oc.err = oc.waitToReopen(ctx); oc.err != nil {
+			return false
+		}
+		oc.err = oc.open(ctx)
+	}
+	return false
+}
+
+func (oc *OplogCursor) waitToReopen(ctx context.Context) error {
+	interval := defaultRetryInterval
+	if oc.opts.MaxAwaitTime != nil {
+		interval = *oc.opts.MaxAwaitTime
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ResumeTimestamp returns the timestamp of the last entry returned by Next, or the starting timestamp if Next has
+// not returned any entries. It can be passed to options.TailOplogOptions.SetStartAfter to resume tailing with a new
+// OplogCursor.
+func (oc *OplogCursor) ResumeTimestamp() primitive.Timestamp {
+	return oc.lastTimestamp
+}
+
+// Err returns the last error seen by the OplogCursor, or nil if no error has occurred.
+func (oc *OplogCursor) Err() error {
+	return oc.err
+}
+
+// Close closes the OplogCursor. Next returns false after Close is called.
+func (oc *OplogCursor) Close(ctx context.Context) error {
+	if oc.cursor == nil {
+		return nil
+	}
+	err := oc.cursor.Close(ctx)
+	oc.cursor = nil
+	return err
+}
+
+// mergeTailOplogOptions combines the given TailOplogOptions into a single TailOplogOptions in a last-one-wins fashion.
+func mergeTailOplogOptions(opts []*options.TailOplogOptions) *options.TailOplogOptions {
+	to := options.TailOplog()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.BatchSize != nil {
+			to.BatchSize = opt.BatchSize
+		}
+		if opt.Filter != nil {
+			to.Filter = opt.Filter
+		}
+		if opt.MaxAwaitTime != nil {
+			to.MaxAwaitTime = opt.MaxAwaitTime
+		}
+		if opt.StartAfter != nil {
+			to.StartAfter = opt.StartAfter
+		}
+	}
+	return to
+}
@@ -0,0 +1,120 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package oplog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/integtest"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestBuildFilter(t *testing.T) {
+	ts := primitive.Timestamp{T: 10, I: 2}
+	tsFilter := bson.D{{"ts", bson.D{{"$gt", ts}}}}
+
+	testCases := []struct {
+		name   string
+		filter interface{}
+		want   bson.D
+	}{
+		{"no filter", nil, tsFilter},
+		{"filter", bson.D{{"ns", "db.coll"}}, bson.D{{"$and", bson.A{tsFilter, bson.D{{"ns", "db.coll"}}}}}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := buildFilter(ts, tc.filter)
+			assert.Equal(t, tc.want, got, "expected filter %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestOplogEntryDecode(t *testing.T) {
+	wall := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	raw, err := bson.Marshal(bson.D{
+		{"op", "u"},
+		{"ns", "db.coll"},
+		{"o", bson.D{{"$v", 2}, {"diff", bson.D{{"u", bson.D{{"x", 1}}}}}}},
+		{"o2", bson.D{{"_id", 1}}},
+		{"ts", primitive.Timestamp{T: 1704164645, I: 1}},
+		{"wall", wall},
+		{"v", int64(2)},
+	})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	var entry OplogEntry
+	err = bson.Unmarshal(raw, &entry)
+	assert.Nil(t, err, "Unmarshal error: %v", err)
+
+	assert.Equal(t, OpUpdate, entry.Op, "expected op %q, got %q", OpUpdate, entry.Op)
+	assert.Equal(t, "db.coll", entry.Namespace, "expected ns %q, got %q", "db.coll", entry.Namespace)
+	assert.Equal(t, int32(1), entry.Object2.Lookup("_id").Int32(), "expected o2 _id 1, got %v", entry.Object2)
+	assert.Equal(t, primitive.Timestamp{T: 1704164645, I: 1}, entry.Timestamp, "unexpected ts %v", entry.Timestamp)
+	assert.True(t, wall.Equal(entry.Wall), "expected wall %v, got %v", wall, entry.Wall)
+}
+
+func TestTailOplog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	cs := integtest.ConnString(t)
+	if cs.ReplicaSet == "" {
+		t.Skip("skipping test that requires a replica set")
+	}
+
+	clientOpts := options.Client().ApplyURI(cs.Original)
+	integtest.AddTestServerAPIVersion(clientOpts)
+	client, err := mongo.Connect(context.Background(), clientOpts)
+	assert.Nil(t, err, "Connect error: %v", err)
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	db := client.Database(integtest.DBName(t))
+	coll := db.Collection(integtest.ColName(t))
+	defer func() {
+		_ = coll.Drop(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := TailOplog(ctx, client, options.TailOplog().
+		SetFilter(bson.D{{"ns", db.Name() + "." + coll.Name()}}).
+		SetMaxAwaitTime(100*time.Millisecond))
+	assert.Nil(t, err, "TailOplog error: %v", err)
+	defer cursor.Close(context.Background())
+	start := cursor.ResumeTimestamp()
+
+	for i := 0; i < 2; i++ {
+		_, err = coll.InsertOne(ctx, bson.D{{"_id", int32(i)}})
+		assert.Nil(t, err, "InsertOne error: %v", err)
+	}
+
+	prev := start
+	for i := 0; i < 2; i++ {
+		assert.True(t, cursor.Next(ctx), "expected entry %v, got error %v", i, cursor.Err())
+		entry := cursor.Current
+		assert.Equal(t, OpInsert, entry.Op, "expected op %q, got %q", OpInsert, entry.Op)
+		assert.Equal(t, int32(i), entry.Object.Lookup("_id").Int32(), "expected _id %v, got %v", i, entry.Object)
+		assert.True(t, entry.Timestamp.After(prev), "expected ts %v to be after %v", entry.Timestamp, prev)
+		prev = entry.Timestamp
+	}
+	assert.Equal(t, prev, cursor.ResumeTimestamp(), "expected resume timestamp %v, got %v", prev,
+		cursor.ResumeTimestamp())
+}
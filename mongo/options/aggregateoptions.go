@@ -63,6 +63,23 @@ type AggregateOptions struct {
 	// option names and values. Values must be Marshalable. Custom options may conflict with non-custom options, and custom
 	// options bypass client-side validation. Prefer using non-custom options where possible.
 	Custom bson.M
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If non-nil, overrides the "apiStrict" setting from the Client's ServerAPIOptions for this operation. The Client
+	// must be configured with a server API version. The default value is nil, which means the Client-wide setting is
+	// used.
+	ServerAPIStrict *bool
+
+	// If non-nil, overrides the "apiDeprecationErrors" setting from the Client's ServerAPIOptions for this operation.
+	// The Client must be configured with a server API version. The default value is nil, which means the Client-wide
+	// setting is used.
+	ServerAPIDeprecationErrors *bool
 }
 
 // Aggregate creates a new AggregateOptions instance.
@@ -137,6 +154,24 @@ func (ao *AggregateOptions) SetCustom(c bson.M) *AggregateOptions {
 	return ao
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (ao *AggregateOptions) SetBypassAutoEncryption(b bool) *AggregateOptions {
+	ao.BypassAutoEncryption = &b
+	return ao
+}
+
+// SetServerAPIStrict sets the value for the ServerAPIStrict field.
+func (ao *AggregateOptions) SetServerAPIStrict(b bool) *AggregateOptions {
+	ao.ServerAPIStrict = &b
+	return ao
+}
+
+// SetServerAPIDeprecationErrors sets the value for the ServerAPIDeprecationErrors field.
+func (ao *AggregateOptions) SetServerAPIDeprecationErrors(b bool) *AggregateOptions {
+	ao.ServerAPIDeprecationErrors = &b
+	return ao
+}
+
 // MergeAggregateOptions combines the given AggregateOptions instances into a single AggregateOptions in a last-one-wins
 // fashion.
 //
@@ -178,6 +213,15 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.Custom != nil {
 			aggOpts.Custom = ao.Custom
 		}
+		if ao.BypassAutoEncryption != nil {
+			aggOpts.BypassAutoEncryption = ao.BypassAutoEncryption
+		}
+		if ao.ServerAPIStrict != nil {
+			aggOpts.ServerAPIStrict = ao.ServerAPIStrict
+		}
+		if ao.ServerAPIDeprecationErrors != nil {
+			aggOpts.ServerAPIDeprecationErrors = ao.ServerAPIDeprecationErrors
+		}
 	}
 
 	return aggOpts
@@ -35,6 +35,20 @@ type BulkWriteOptions struct {
 	// Deprecated: This option is for internal use only and should not be set. It may be changed or removed in any
 	// release.
 	BypassEmptyTsReplacement *bool
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If set, the write models are split into segments at each mongo.SegmentBoundaryModel and up to SegmentConcurrency
+	// segments are executed concurrently as separate bulk writes. The Ordered option applies within each segment.
+	// Segments are not ordered relative to each other and an error in one segment does not stop the others. This
+	// option cannot be used with an explicit session. The value must be at least 1. The default value is nil, which
+	// means the write models are executed as a single bulk write.
+	SegmentConcurrency *int
 }
 
 // BulkWrite creates a new *BulkWriteOptions instance.
@@ -71,6 +85,18 @@ func (b *BulkWriteOptions) SetLet(let interface{}) *BulkWriteOptions {
 	return b
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (b *BulkWriteOptions) SetBypassAutoEncryption(bypass bool) *BulkWriteOptions {
+	b.BypassAutoEncryption = &bypass
+	return b
+}
+
+// SetSegmentConcurrency sets the value for the SegmentConcurrency field.
+func (b *BulkWriteOptions) SetSegmentConcurrency(concurrency int) *BulkWriteOptions {
+	b.SegmentConcurrency = &concurrency
+	return b
+}
+
 // MergeBulkWriteOptions combines the given BulkWriteOptions instances into a single BulkWriteOptions in a last-one-wins
 // fashion.
 //
@@ -97,6 +123,12 @@ func MergeBulkWriteOptions(opts ...*BulkWriteOptions) *BulkWriteOptions {
 		if opt.BypassEmptyTsReplacement != nil {
 			b.BypassEmptyTsReplacement = opt.BypassEmptyTsReplacement
 		}
+		if opt.BypassAutoEncryption != nil {
+			b.BypassAutoEncryption = opt.BypassAutoEncryption
+		}
+		if opt.SegmentConcurrency != nil {
+			b.SegmentConcurrency = opt.SegmentConcurrency
+		}
 	}
 
 	return b
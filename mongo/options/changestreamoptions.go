@@ -32,10 +32,17 @@ type ChangeStreamOptions struct {
 	FullDocument *FullDocument
 
 	// Specifies how the pre-update document should be returned in change notifications for update operations. The default
-	// is options.Off, which means that the pre-update document will not be included in the change notification.
+	// is options.Off, which means that the pre-update document will not be included in the change notification. Valid
+	// values are FullDocumentBeforeChangeOff, FullDocumentBeforeChangeWhenAvailable, and
+	// FullDocumentBeforeChangeRequired; Watch returns an error for any other value. When enabled, the pre-image is
+	// returned in the "fullDocumentBeforeChange" field of each change event. This option is only valid for MongoDB
+	// versions >= 6.0.
 	FullDocumentBeforeChange *FullDocument
 
 	// The maximum amount of time that the server should wait for new documents to satisfy a tailable cursor query.
+	// This is sent as the maxTimeMS option of each getMore command. Shorter values let the change stream respond to
+	// Context cancellation sooner, while longer values reduce the number of getMore commands. This must not be
+	// negative. A value of 0 is the same as not setting the option, in which case the server's default is used.
 	MaxAwaitTime *time.Duration
 
 	// A document specifying the logical starting point for the change stream. Only changes corresponding to an oplog
@@ -101,7 +108,8 @@ func (cso *ChangeStreamOptions) SetFullDocument(fd FullDocument) *ChangeStreamOp
 	return cso
 }
 
-// SetFullDocumentBeforeChange sets the value for the FullDocumentBeforeChange field.
+// SetFullDocumentBeforeChange sets the value for the FullDocumentBeforeChange field. Use one of
+// FullDocumentBeforeChangeOff, FullDocumentBeforeChangeWhenAvailable, or FullDocumentBeforeChangeRequired.
 func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fdbc FullDocument) *ChangeStreamOptions {
 	cso.FullDocumentBeforeChange = &fdbc
 	return cso
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/youmark/pkcs8"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/httputil"
@@ -63,6 +64,21 @@ type ContextDialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
+// HeartbeatBackoffOptions configures how long the background server monitor waits between checks of a server whose
+// checks are failing. See ClientOptions.SetHeartbeatBackoff for more information.
+type HeartbeatBackoffOptions struct {
+	Min    time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// ServerSelectionRetryOptions configures how many times server selection is attempted before an operation fails. See
+// ClientOptions.SetServerSelectionRetry for more information.
+type ServerSelectionRetryOptions struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
 // Credential can be used to provide authentication options when configuring a Client.
 //
 // AuthMechanism: the mechanism to use for authentication. Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1",
@@ -105,6 +121,11 @@ type ContextDialer interface {
 // PasswordSet: For GSSAPI, this must be true if a password is specified, even if the password is the empty string, and
 // false if no password is specified, indicating that the password should be taken from the context of the running
 // process. For other mechanisms, this field is ignored.
+//
+// ForceMechanism: If true, the driver only authenticates with AuthMechanism if the server advertises it in the
+// saslSupportedMechs field of its handshake response, and returns an error otherwise instead of attempting it. This
+// prevents a silent downgrade in deployments that must use a specific mechanism. It requires AuthMechanism to be
+// "SCRAM-SHA-256" or "SCRAM-SHA-1". The default is false.
 type Credential struct {
 	AuthMechanism           string
 	AuthMechanismProperties map[string]string
@@ -114,6 +135,14 @@ type Credential struct {
 	PasswordSet             bool
 	OIDCMachineCallback     OIDCCallback
 	OIDCHumanCallback       OIDCCallback
+	ForceMechanism          bool
+}
+
+// SetForceMechanism specifies whether authentication must fail if the server does not advertise AuthMechanism. See the
+// Credential documentation for more information.
+func (c *Credential) SetForceMechanism(force bool) *Credential {
+	c.ForceMechanism = force
+	return c
 }
 
 // OIDCCallback is the type for both Human and Machine Callback flows.
@@ -189,6 +218,12 @@ type BSONOptions struct {
 	// string conversion logic.
 	StringifyMapKeysWithFmt bool
 
+	// SortMapKeys causes the driver to marshal the elements of Go maps in
+	// lexicographic order of their BSON document field names, which makes
+	// the marshaled bytes deterministic. It changes the byte output but not
+	// the meaning of the marshaled documents.
+	SortMapKeys bool
+
 	// AllowTruncatingDoubles causes the driver to truncate the fractional part
 	// of BSON "double" values when attempting to unmarshal them into a Go
 	// integer (int, int8, int16, int32, or int64) struct field. The truncation
@@ -210,6 +245,21 @@ type BSONOptions struct {
 	// "interface{}" or "map[string]interface{}".
 	DefaultDocumentM bool
 
+	// IntegralNumbersAsInt64 causes the driver to unmarshal BSON int32 and
+	// int64 values, and BSON double values that have no fractional part and
+	// fit in an int64, into a Go int64. Other BSON double values are
+	// unmarshaled into a Go float64. This behavior is restricted to data typed
+	// as "interface{}", including the values of primitive.M, primitive.D, and
+	// "map[string]interface{}" documents.
+	IntegralNumbersAsInt64 bool
+
+	// NumbersAsFloat64 causes the driver to unmarshal BSON int32, int64, and
+	// double values into a Go float64. This behavior is restricted to data
+	// typed as "interface{}", including the values of primitive.M, primitive.D,
+	// and "map[string]interface{}" documents. NumbersAsFloat64 takes
+	// precedence over IntegralNumbersAsInt64.
+	NumbersAsFloat64 bool
+
 	// UseLocalTimeZone causes the driver to unmarshal time.Time values in the
 	// local timezone instead of the UTC timezone.
 	UseLocalTimeZone bool
@@ -227,44 +277,61 @@ type BSONOptions struct {
 // ClientOptions contains options to configure a Client instance. Each option can be set through setter functions. See
 // documentation for each setter function for an explanation of the option.
 type ClientOptions struct {
-	AppName                  *string
-	Auth                     *Credential
-	AutoEncryptionOptions    *AutoEncryptionOptions
-	ConnectTimeout           *time.Duration
-	Compressors              []string
-	Dialer                   ContextDialer
-	Direct                   *bool
-	DisableOCSPEndpointCheck *bool
-	HeartbeatInterval        *time.Duration
-	Hosts                    []string
-	HTTPClient               *http.Client
-	LoadBalanced             *bool
-	LocalThreshold           *time.Duration
-	LoggerOptions            *LoggerOptions
-	MaxConnIdleTime          *time.Duration
-	MaxPoolSize              *uint64
-	MinPoolSize              *uint64
-	MaxConnecting            *uint64
-	PoolMonitor              *event.PoolMonitor
-	Monitor                  *event.CommandMonitor
-	ServerMonitor            *event.ServerMonitor
-	ReadConcern              *readconcern.ReadConcern
-	ReadPreference           *readpref.ReadPref
-	BSONOptions              *BSONOptions
-	Registry                 *bsoncodec.Registry
-	ReplicaSet               *string
-	RetryReads               *bool
-	RetryWrites              *bool
-	ServerAPIOptions         *ServerAPIOptions
-	ServerMonitoringMode     *string
-	ServerSelectionTimeout   *time.Duration
-	SRVMaxHosts              *int
-	SRVServiceName           *string
-	Timeout                  *time.Duration
-	TLSConfig                *tls.Config
-	WriteConcern             *writeconcern.WriteConcern
-	ZlibLevel                *int
-	ZstdLevel                *int
+	AppName                    *string
+	Auth                       *Credential
+	AutoEncryptionOptions      *AutoEncryptionOptions
+	ConnectTimeout             *time.Duration
+	CompressionMinSize         *int
+	Compressors                []string
+	ContextTagExtractor        func(context.Context) map[string]string
+	DeriveMaxTimeFromContext   *bool
+	Dialer                     ContextDialer
+	Direct                     *bool
+	DisableOCSPEndpointCheck   *bool
+	GuardEmptyFilter           *bool
+	HeartbeatBackoff           *HeartbeatBackoffOptions
+	HeartbeatInterval          *time.Duration
+	HeartbeatTimeout           *time.Duration
+	Hosts                      []string
+	HTTPClient                 *http.Client
+	IDGenerator                func() interface{}
+	KillOnCancel               *bool
+	LoadBalanced               *bool
+	LocalThreshold             *time.Duration
+	LoggerOptions              *LoggerOptions
+	MaxConnIdleTime            *time.Duration
+	MaxPoolSize                *uint64
+	MinPoolSize                *uint64
+	MaxConnecting              *uint64
+	MaxConcurrentOperations    *int
+	PoolMonitor                *event.PoolMonitor
+	ProactiveIdlePruning       *bool
+	Monitor                    *event.CommandMonitor
+	ServerMonitor              *event.ServerMonitor
+	ReadConcern                *readconcern.ReadConcern
+	ReadPreference             *readpref.ReadPref
+	BSONOptions                *BSONOptions
+	Registry                   *bsoncodec.Registry
+	RejectOverloadedOperations *bool
+	ReplicaSet                 *string
+	RetryPredicate             func(err error, attempt int) bool
+	RetryReads                 *bool
+	RetryWrites                *bool
+	ServerAPIOptions           *ServerAPIOptions
+	ServerMonitoringMode       *string
+	ServerSelectionRetry       *ServerSelectionRetryOptions
+	ServerSelectionTimeout     *time.Duration
+	SlowQueryHandler           func(SlowQueryInfo)
+	SlowQueryThreshold         *time.Duration
+	SRVMaxHosts                *int
+	SRVServiceName             *string
+	TCPKeepAlive               *time.Duration
+	TCPNoDelay                 *bool
+	Timeout                    *time.Duration
+	TLSConfig                  *tls.Config
+	WriteConcern               *writeconcern.WriteConcern
+	ZlibLevel                  *int
+	ZstdLevel                  *int
 
 	err error
 	cs  *connstring.ConnString
@@ -323,6 +390,32 @@ func (c *ClientOptions) validate() error {
 		}
 	}
 
+	if hb := c.HeartbeatBackoff; hb != nil {
+		if hb.Min <= 0 || hb.Max < hb.Min {
+			return fmt.Errorf("heartbeat backoff must satisfy 0 < min <= max, got min=%v max=%v", hb.Min, hb.Max)
+		}
+		if hb.Jitter < 0 || hb.Jitter > 1 {
+			return fmt.Errorf("heartbeat backoff jitter must be between 0 and 1, got %v", hb.Jitter)
+		}
+	}
+
+	if c.HeartbeatTimeout != nil && *c.HeartbeatTimeout <= 0 {
+		return fmt.Errorf("heartbeat timeout must be positive, got %v", *c.HeartbeatTimeout)
+	}
+
+	if ssr := c.ServerSelectionRetry; ssr != nil {
+		if ssr.Attempts < 1 {
+			return fmt.Errorf("server selection retry attempts must be at least 1, got %d", ssr.Attempts)
+		}
+		if ssr.Backoff < 0 {
+			return fmt.Errorf("server selection retry backoff must be non-negative, got %v", ssr.Backoff)
+		}
+	}
+
+	if c.MaxConcurrentOperations != nil && *c.MaxConcurrentOperations < 0 {
+		return fmt.Errorf("max concurrent operations must be non-negative, got %d", *c.MaxConcurrentOperations)
+	}
+
 	if c.MaxPoolSize != nil && c.MinPoolSize != nil && *c.MaxPoolSize != 0 && *c.MinPoolSize > *c.MaxPoolSize {
 		return fmt.Errorf("minPoolSize must be less than or equal to maxPoolSize, got minPoolSize=%d maxPoolSize=%d", *c.MinPoolSize, *c.MaxPoolSize)
 	}
@@ -357,10 +450,24 @@ func (c *ClientOptions) validate() error {
 		}
 	}
 
+	if c.CompressionMinSize != nil && *c.CompressionMinSize < 0 {
+		return fmt.Errorf("compression minimum size must be non-negative, got %d", *c.CompressionMinSize)
+	}
+
+	if c.TCPKeepAlive != nil && *c.TCPKeepAlive < 0 {
+		return fmt.Errorf("TCP keep-alive interval must be non-negative, got %v", *c.TCPKeepAlive)
+	}
+
 	if mode := c.ServerMonitoringMode; mode != nil && !connstring.IsValidServerMonitoringMode(*mode) {
 		return fmt.Errorf("invalid server monitoring mode: %q", *mode)
 	}
 
+	if c.Auth != nil && c.Auth.ForceMechanism &&
+		c.Auth.AuthMechanism != auth.SCRAMSHA256 && c.Auth.AuthMechanism != auth.SCRAMSHA1 {
+		return fmt.Errorf("ForceMechanism requires the %s or %s auth mechanism, got %q",
+			auth.SCRAMSHA256, auth.SCRAMSHA1, c.Auth.AuthMechanism)
+	}
+
 	// OIDC Validation
 	if c.Auth != nil && c.Auth.AuthMechanism == auth.MongoDBOIDC {
 		if c.Auth.Password != "" {
@@ -674,6 +781,18 @@ func (c *ClientOptions) SetCompressors(comps []string) *ClientOptions {
 	return c
 }
 
+// SetCompressionMinSize specifies the size in bytes below which wire messages are sent uncompressed even if a
+// compressor has been negotiated with the server. Compressing small messages costs CPU time and saves little network
+// bandwidth, so setting a threshold can help workloads that send many small commands. The size is compared to the
+// length of the complete uncompressed wire message. This option is ignored if no compressors are specified through
+// ApplyURI or SetCompressors. The value must be non-negative. The default is 0, which means all messages that can be
+// compressed are compressed.
+func (c *ClientOptions) SetCompressionMinSize(size int) *ClientOptions {
+	c.CompressionMinSize = &size
+
+	return c
+}
+
 // SetConnectTimeout specifies a timeout that is used for creating connections to the server. This can be set through
 // ApplyURI with the "connectTimeoutMS" (e.g "connectTimeoutMS=30") option. If set to 0, no timeout will be used. The
 // default is 30 seconds.
@@ -682,6 +801,58 @@ func (c *ClientOptions) SetConnectTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetContextTagExtractor specifies a function that is called with the Context of each operation when publishing
+// command monitoring events. The returned map is copied into the Tags field of the CommandStartedEvent,
+// CommandSucceededEvent, and CommandFailedEvent published for the operation's commands. This can be used to
+// correlate commands with values stored in the Context, such as a request ID. The function is called once for every
+// published event, so it should be fast and must be safe for concurrent use. The extractor has no effect if no
+// command monitor is set through SetMonitor. The default is nil, which means that events do not carry tags.
+func (c *ClientOptions) SetContextTagExtractor(fn func(context.Context) map[string]string) *ClientOptions {
+	c.ContextTagExtractor = fn
+	return c
+}
+
+// SetDeriveMaxTimeFromContext specifies whether the driver should derive a "maxTimeMS" value from the deadline of the
+// operation Context and attach it to find, aggregate, count, and distinct commands. The derived value is the time
+// remaining until the deadline minus the 90th percentile round-trip time to the selected server, which allows the
+// server to stop working on a query that the application has already given up on. If the Context has no deadline,
+// "maxTimeMS" is omitted. A MaxTime value set on the operation options takes precedence over the derived value.
+//
+// The value is not derived for Collection.Find, Collection.Aggregate, and Database.Aggregate, which return a cursor,
+// because "maxTimeMS" limits the lifetime of the cursor on the server, which can be used after the Context of the
+// initial command has expired. It is still derived for single-batch reads such as Collection.FindOne.
+//
+// For tailable cursors with awaitData set and change streams, the "maxTimeMS" value sent with each getMore command is
+// also capped at the time remaining until the deadline of the Context passed to Next or TryNext, so the server stops
+// waiting for new results before the deadline. If MaxAwaitTime is set, the smaller of the two values is used. Other
+// cursors do not send "maxTimeMS" with getMore commands because the server only accepts it for awaitData cursors. The
+// default value is false.
+func (c *ClientOptions) SetDeriveMaxTimeFromContext(derive bool) *ClientOptions {
+	c.DeriveMaxTimeFromContext = &derive
+	return c
+}
+
+// SetTCPKeepAlive specifies the interval between TCP keep-alive probes sent on idle connections to the server.
+// Setting a shorter interval can keep connections open behind firewalls or load balancers that silently drop idle
+// connections. The value must be non-negative, and 0 disables keep-alive probes. The settings are applied to each
+// connection after it is dialed and are ignored for connections that are not TCP connections, such as those returned
+// by some custom dialers. The default is to use the keep-alive settings of the dialer, which are the Go defaults for
+// the default net.Dialer.
+func (c *ClientOptions) SetTCPKeepAlive(interval time.Duration) *ClientOptions {
+	c.TCPKeepAlive = &interval
+
+	return c
+}
+
+// SetTCPNoDelay specifies whether Nagle's algorithm is disabled on connections to the server, so that small writes
+// are sent without waiting to be combined with later writes. Like SetTCPKeepAlive, it is applied to each connection
+// after it is dialed. The default is to use the setting of the dialer, which is true for the default net.Dialer.
+func (c *ClientOptions) SetTCPNoDelay(noDelay bool) *ClientOptions {
+	c.TCPNoDelay = &noDelay
+
+	return c
+}
+
 // SetDialer specifies a custom ContextDialer to be used to create new connections to the server. This method overrides
 // the default net.Dialer, so dialer options such as Timeout, KeepAlive, Resolver, etc can be set.
 // See https://golang.org/pkg/net/#Dialer for more information about the net.Dialer type.
@@ -710,6 +881,26 @@ func (c *ClientOptions) SetDirect(b bool) *ClientOptions {
 	return c
 }
 
+// SetGuardEmptyFilter specifies whether Collection.DeleteMany and Collection.UpdateMany should reject an empty filter
+// (e.g. bson.D{}), which matches every document in the collection. If true, these operations return a
+// mongo.EmptyFilterError for an empty filter unless the AllowEmptyFilter option of the operation is set to true. The
+// default value is false.
+func (c *ClientOptions) SetGuardEmptyFilter(guard bool) *ClientOptions {
+	c.GuardEmptyFilter = &guard
+	return c
+}
+
+// SetHeartbeatBackoff specifies that when a background server check fails, the next check should be delayed by an
+// exponential backoff instead of the heartbeat interval. The first delay is min and doubles after every consecutive
+// failed check up to max. Each delay is reduced by a random fraction of up to jitter of its value, which must be between
+// 0 and 1, so that many clients reconnecting to a restarted cluster spread out their attempts. While backing off,
+// checks are not triggered early by application operations. The backoff is reset after a successful check. By
+// default, no backoff is used.
+func (c *ClientOptions) SetHeartbeatBackoff(min, max time.Duration, jitter float64) *ClientOptions {
+	c.HeartbeatBackoff = &HeartbeatBackoffOptions{Min: min, Max: max, Jitter: jitter}
+	return c
+}
+
 // SetHeartbeatInterval specifies the amount of time to wait between periodic background server checks. This can also be
 // set through the "heartbeatIntervalMS" URI option (e.g. "heartbeatIntervalMS=10000"). The default is 10 seconds.
 func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
@@ -717,6 +908,16 @@ func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetHeartbeatTimeout specifies the socket timeout for the "hello" commands sent by the background server monitors,
+// including the connection establishment and handshake of a monitoring connection. It does not affect the timeouts of
+// application operations. A server whose check takes longer than this timeout is marked as unknown, so it should be set
+// above the expected network latency to the servers. The value must be positive. By default, the connect timeout is
+// used if it is set, and 10 seconds otherwise.
+func (c *ClientOptions) SetHeartbeatTimeout(d time.Duration) *ClientOptions {
+	c.HeartbeatTimeout = &d
+	return c
+}
+
 // SetHosts specifies a list of host names or IP addresses for servers in a cluster. Both IPv4 and IPv6 addresses are
 // supported. IPv6 literals must be enclosed in '[]' following RFC-2732 syntax.
 //
@@ -727,6 +928,46 @@ func (c *ClientOptions) SetHosts(s []string) *ClientOptions {
 	return c
 }
 
+// SetIDGenerator specifies a function that generates the "_id" value for documents inserted without one. The
+// function is called once for every such document by InsertOne, InsertMany, and the InsertOneModels of BulkWrite,
+// and the returned value is marshaled as the document's "_id" and reported in the InsertedID and InsertedIDs fields
+// of the results. This can be used to produce predictable IDs in tests or to use other ID schemes, such as UUIDs.
+// The function must be safe for concurrent use. The default is nil, which means a new primitive.ObjectID is
+// generated for each document.
+func (c *ClientOptions) SetIDGenerator(fn func() interface{}) *ClientOptions {
+	c.IDGenerator = fn
+	return c
+}
+
+// SetKillOnCancel specifies whether the driver should try to stop the server-side work of an operation whose context
+// is cancelled or expires while the operation is in progress. If the interrupted operation is a cursor's getMore, the
+// cursor is killed with a killCursors command. For other operations run in a session, the driver looks up the
+// operation with the $currentOp aggregation stage and kills it with a killOp command. This is best-effort: errors are
+// ignored, and operations that are not run in a session or that the user is not authorized to kill are left running.
+//
+// In both cases, the kill commands are sent synchronously before the interrupted operation returns its error, so that
+// the session is not reused while the interrupted command is looked up. This can delay the return of the operation by
+// up to 10 seconds if the server is slow to respond. The default is false.
+func (c *ClientOptions) SetKillOnCancel(b bool) *ClientOptions {
+	c.KillOnCancel = &b
+	return c
+}
+
+// SetRetryPredicate specifies a function that decides whether to retry an operation that failed with an error the
+// driver does not retry by default, such as a write error with an application-specific code. The function is called
+// with the error, converted to the same type the operation would return (e.g. mongo.CommandError or
+// mongo.WriteException), and the 1-based number of the attempt that failed. If it returns true, the operation is
+// retried as if the error were retryable.
+//
+// The predicate does not change the retry budget: it is only called for operations that are retryable under the
+// RetryReads and RetryWrites settings, and an operation is retried at most once, or until its context expires if a
+// Timeout is set. Operations in a transaction other than commitTransaction and abortTransaction are never retried.
+// The function must be safe for concurrent use. The default is nil, which means only the standard rules are used.
+func (c *ClientOptions) SetRetryPredicate(fn func(err error, attempt int) bool) *ClientOptions {
+	c.RetryPredicate = fn
+	return c
+}
+
 // SetLoadBalanced specifies whether or not the MongoDB deployment is hosted behind a load balancer. This can also be
 // set through the "loadBalanced" URI option. The driver will error during Client configuration if this option is set
 // to true and one of the following conditions are met:
@@ -791,6 +1032,19 @@ func (c *ClientOptions) SetMaxConnecting(u uint64) *ClientOptions {
 	return c
 }
 
+// SetMaxConcurrentOperations specifies the maximum number of operations the Client may execute concurrently. Once the
+// limit is reached, new operations wait for an in-flight operation to complete or for their context to expire, unless
+// SetRejectOverloadedOperations is set, in which case they fail immediately with a mongo.OverloadedError. Cursor
+// iteration (getMore) is not counted. Commands that continue an operation that is already in flight are not counted
+// either, such as the later batches of Collection.BulkWrite, the commands run by automatic encryption, change stream
+// resumes, commitTransaction and abortTransaction, and the reads and writes of GridFS streams. The number of operations
+// currently in flight is reported by Client.InFlightOperations. The value must be non-negative. If this is 0, the
+// number of concurrent operations is not bounded. The default is 0.
+func (c *ClientOptions) SetMaxConcurrentOperations(i int) *ClientOptions {
+	c.MaxConcurrentOperations = &i
+	return c
+}
+
 // SetPoolMonitor specifies a PoolMonitor to receive connection pool events. See the event.PoolMonitor documentation
 // for more information about the structure of the monitor and events that can be received.
 func (c *ClientOptions) SetPoolMonitor(m *event.PoolMonitor) *ClientOptions {
@@ -798,6 +1052,16 @@ func (c *ClientOptions) SetPoolMonitor(m *event.PoolMonitor) *ClientOptions {
 	return c
 }
 
+// SetProactiveIdlePruning specifies whether the background connection pool maintenance routine should close
+// connections that have been idle for longer than MaxConnIdleTime on a timer that is scaled to MaxConnIdleTime, rather
+// than relying on connections being pruned when they are checked out. This is useful when the network between the
+// driver and the server (e.g. a load balancer) drops idle TCP connections. This option has no effect if MaxConnIdleTime
+// is not set. The default is false.
+func (c *ClientOptions) SetProactiveIdlePruning(b bool) *ClientOptions {
+	c.ProactiveIdlePruning = &b
+	return c
+}
+
 // SetMonitor specifies a CommandMonitor to receive command events. See the event.CommandMonitor documentation for more
 // information about the structure of the monitor and events that can be received.
 func (c *ClientOptions) SetMonitor(m *event.CommandMonitor) *ClientOptions {
@@ -852,6 +1116,14 @@ func (c *ClientOptions) SetRegistry(registry *bsoncodec.Registry) *ClientOptions
 	return c
 }
 
+// SetRejectOverloadedOperations specifies whether operations that would exceed the limit set with
+// SetMaxConcurrentOperations fail immediately with a mongo.OverloadedError instead of waiting for an in-flight
+// operation to complete. The default is false.
+func (c *ClientOptions) SetRejectOverloadedOperations(b bool) *ClientOptions {
+	c.RejectOverloadedOperations = &b
+	return c
+}
+
 // SetReplicaSet specifies the replica set name for the cluster. If specified, the cluster will be treated as a replica
 // set and the driver will automatically discover all servers in the set, starting with the nodes specified through
 // ApplyURI or SetHosts. All nodes in the replica set must have the same replica set name, or they will not be
@@ -892,6 +1164,17 @@ func (c *ClientOptions) SetRetryReads(b bool) *ClientOptions {
 	return c
 }
 
+// SetServerSelectionRetry specifies that server selection should be attempted up to attempts times before an
+// operation fails, which smooths over brief periods without a suitable server, such as during a rolling restart. Each
+// attempt waits up to the server selection timeout for a suitable server. Between attempts, the driver waits for
+// backoff, which doubles after every failed attempt. Only attempts that fail because the server selection timeout
+// expired are retried; selection is not retried after the operation's context is done. The default is a single
+// attempt.
+func (c *ClientOptions) SetServerSelectionRetry(attempts int, backoff time.Duration) *ClientOptions {
+	c.ServerSelectionRetry = &ServerSelectionRetryOptions{Attempts: attempts, Backoff: backoff}
+	return c
+}
+
 // SetServerSelectionTimeout specifies how long the driver will wait to find an available, suitable server to execute an
 // operation. This can also be set through the "serverSelectionTimeoutMS" URI option (e.g.
 // "serverSelectionTimeoutMS=30000"). The default value is 30 seconds.
@@ -900,6 +1183,41 @@ func (c *ClientOptions) SetServerSelectionTimeout(d time.Duration) *ClientOption
 	return c
 }
 
+// SetSlowQueryThreshold specifies a handler that is called for every command that takes at least threshold to
+// complete, whether it succeeds or fails. The handler receives the command name, namespace, duration, and command
+// document. This is independent of the Monitor and LoggerOptions settings and can be used to send slow queries to a
+// dedicated sink. The handler is called synchronously on the goroutine running the operation, so it should be fast
+// and must be safe for concurrent use. The default is nil, which means that slow queries are not reported.
+func (c *ClientOptions) SetSlowQueryThreshold(threshold time.Duration, handler func(SlowQueryInfo)) *ClientOptions {
+	c.SlowQueryThreshold = &threshold
+	c.SlowQueryHandler = handler
+	return c
+}
+
+// SlowQueryInfo contains information about a command that took at least the threshold configured with
+// ClientOptions.SetSlowQueryThreshold to complete.
+type SlowQueryInfo struct {
+	// CommandName is the name of the command, e.g. "find".
+	CommandName string
+
+	// Namespace is the namespace the command ran against in "database.collection" form, or the database name for
+	// commands that do not target a collection.
+	Namespace string
+
+	// Duration is the time the command took to complete, including the round trip to the server.
+	Duration time.Duration
+
+	// Command is the command document sent to the server. It is empty for security-sensitive commands such as
+	// authentication commands, which are redacted in the same way as in command monitoring events.
+	Command bson.Raw
+
+	// ConnectionID is the ID of the connection the command was sent on.
+	ConnectionID string
+
+	// Failure is the error message if the command failed, or an empty string if it succeeded.
+	Failure string
+}
+
 // SetSocketTimeout specifies how long the driver will wait for a socket read or write to return before returning a
 // network error. This can also be set through the "socketTimeoutMS" URI option (e.g. "socketTimeoutMS=1000"). The
 // default value is 0, meaning no timeout is used and socket operations can block indefinitely.
@@ -1088,21 +1406,54 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Compressors != nil {
 			c.Compressors = opt.Compressors
 		}
+		if opt.CompressionMinSize != nil {
+			c.CompressionMinSize = opt.CompressionMinSize
+		}
 		if opt.ConnectTimeout != nil {
 			c.ConnectTimeout = opt.ConnectTimeout
 		}
+		if opt.TCPKeepAlive != nil {
+			c.TCPKeepAlive = opt.TCPKeepAlive
+		}
+		if opt.TCPNoDelay != nil {
+			c.TCPNoDelay = opt.TCPNoDelay
+		}
+		if opt.ContextTagExtractor != nil {
+			c.ContextTagExtractor = opt.ContextTagExtractor
+		}
 		if opt.Crypt != nil {
 			c.Crypt = opt.Crypt
 		}
+		if opt.DeriveMaxTimeFromContext != nil {
+			c.DeriveMaxTimeFromContext = opt.DeriveMaxTimeFromContext
+		}
+		if opt.GuardEmptyFilter != nil {
+			c.GuardEmptyFilter = opt.GuardEmptyFilter
+		}
+		if opt.HeartbeatBackoff != nil {
+			c.HeartbeatBackoff = opt.HeartbeatBackoff
+		}
 		if opt.HeartbeatInterval != nil {
 			c.HeartbeatInterval = opt.HeartbeatInterval
 		}
+		if opt.HeartbeatTimeout != nil {
+			c.HeartbeatTimeout = opt.HeartbeatTimeout
+		}
 		if len(opt.Hosts) > 0 {
 			c.Hosts = opt.Hosts
 		}
 		if opt.HTTPClient != nil {
 			c.HTTPClient = opt.HTTPClient
 		}
+		if opt.IDGenerator != nil {
+			c.IDGenerator = opt.IDGenerator
+		}
+		if opt.KillOnCancel != nil {
+			c.KillOnCancel = opt.KillOnCancel
+		}
+		if opt.RetryPredicate != nil {
+			c.RetryPredicate = opt.RetryPredicate
+		}
 		if opt.LoadBalanced != nil {
 			c.LoadBalanced = opt.LoadBalanced
 		}
@@ -1121,9 +1472,15 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.MaxConnecting != nil {
 			c.MaxConnecting = opt.MaxConnecting
 		}
+		if opt.MaxConcurrentOperations != nil {
+			c.MaxConcurrentOperations = opt.MaxConcurrentOperations
+		}
 		if opt.PoolMonitor != nil {
 			c.PoolMonitor = opt.PoolMonitor
 		}
+		if opt.ProactiveIdlePruning != nil {
+			c.ProactiveIdlePruning = opt.ProactiveIdlePruning
+		}
 		if opt.Monitor != nil {
 			c.Monitor = opt.Monitor
 		}
@@ -1145,6 +1502,9 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Registry != nil {
 			c.Registry = opt.Registry
 		}
+		if opt.RejectOverloadedOperations != nil {
+			c.RejectOverloadedOperations = opt.RejectOverloadedOperations
+		}
 		if opt.ReplicaSet != nil {
 			c.ReplicaSet = opt.ReplicaSet
 		}
@@ -1154,9 +1514,16 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.RetryReads != nil {
 			c.RetryReads = opt.RetryReads
 		}
+		if opt.ServerSelectionRetry != nil {
+			c.ServerSelectionRetry = opt.ServerSelectionRetry
+		}
 		if opt.ServerSelectionTimeout != nil {
 			c.ServerSelectionTimeout = opt.ServerSelectionTimeout
 		}
+		if opt.SlowQueryThreshold != nil {
+			c.SlowQueryThreshold = opt.SlowQueryThreshold
+			c.SlowQueryHandler = opt.SlowQueryHandler
+		}
 		if opt.Direct != nil {
 			c.Direct = opt.Direct
 		}
@@ -68,20 +68,30 @@ func TestClientOptions(t *testing.T) {
 			{"AppName", (*ClientOptions).SetAppName, "example-application", "AppName", true},
 			{"Auth", (*ClientOptions).SetAuth, Credential{Username: "foo", Password: "bar"}, "Auth", true},
 			{"Compressors", (*ClientOptions).SetCompressors, []string{"zstd", "snappy", "zlib"}, "Compressors", true},
+			{"CompressionMinSize", (*ClientOptions).SetCompressionMinSize, 1024, "CompressionMinSize", true},
 			{"ConnectTimeout", (*ClientOptions).SetConnectTimeout, 5 * time.Second, "ConnectTimeout", true},
+			{"DeriveMaxTimeFromContext", (*ClientOptions).SetDeriveMaxTimeFromContext, true, "DeriveMaxTimeFromContext", true},
 			{"Dialer", (*ClientOptions).SetDialer, testDialer{Num: 12345}, "Dialer", true},
+			{"TCPKeepAlive", (*ClientOptions).SetTCPKeepAlive, 15 * time.Second, "TCPKeepAlive", true},
+			{"TCPNoDelay", (*ClientOptions).SetTCPNoDelay, false, "TCPNoDelay", true},
 			{"HeartbeatInterval", (*ClientOptions).SetHeartbeatInterval, 5 * time.Second, "HeartbeatInterval", true},
+			{"HeartbeatTimeout", (*ClientOptions).SetHeartbeatTimeout, 5 * time.Second, "HeartbeatTimeout", true},
 			{"Hosts", (*ClientOptions).SetHosts, []string{"localhost:27017", "localhost:27018", "localhost:27019"}, "Hosts", true},
+			{"GuardEmptyFilter", (*ClientOptions).SetGuardEmptyFilter, true, "GuardEmptyFilter", true},
+			{"KillOnCancel", (*ClientOptions).SetKillOnCancel, true, "KillOnCancel", true},
 			{"LocalThreshold", (*ClientOptions).SetLocalThreshold, 5 * time.Second, "LocalThreshold", true},
 			{"MaxConnIdleTime", (*ClientOptions).SetMaxConnIdleTime, 5 * time.Second, "MaxConnIdleTime", true},
 			{"MaxPoolSize", (*ClientOptions).SetMaxPoolSize, uint64(250), "MaxPoolSize", true},
 			{"MinPoolSize", (*ClientOptions).SetMinPoolSize, uint64(10), "MinPoolSize", true},
 			{"MaxConnecting", (*ClientOptions).SetMaxConnecting, uint64(10), "MaxConnecting", true},
+			{"MaxConcurrentOperations", (*ClientOptions).SetMaxConcurrentOperations, 100, "MaxConcurrentOperations", true},
 			{"PoolMonitor", (*ClientOptions).SetPoolMonitor, &event.PoolMonitor{}, "PoolMonitor", false},
+			{"ProactiveIdlePruning", (*ClientOptions).SetProactiveIdlePruning, true, "ProactiveIdlePruning", true},
 			{"Monitor", (*ClientOptions).SetMonitor, &event.CommandMonitor{}, "Monitor", false},
 			{"ReadConcern", (*ClientOptions).SetReadConcern, readconcern.Majority(), "ReadConcern", false},
 			{"ReadPreference", (*ClientOptions).SetReadPreference, readpref.SecondaryPreferred(), "ReadPreference", false},
 			{"Registry", (*ClientOptions).SetRegistry, bson.NewRegistryBuilder().Build(), "Registry", false},
+			{"RejectOverloadedOperations", (*ClientOptions).SetRejectOverloadedOperations, true, "RejectOverloadedOperations", true},
 			{"ReplicaSet", (*ClientOptions).SetReplicaSet, "example-replicaset", "ReplicaSet", true},
 			{"RetryWrites", (*ClientOptions).SetRetryWrites, true, "RetryWrites", true},
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
@@ -709,6 +719,190 @@ func TestClientOptions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("heartbeat backoff validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{
+				"valid",
+				Client().SetHeartbeatBackoff(100*time.Millisecond, 10*time.Second, 0.5),
+				nil,
+			},
+			{
+				"min == max without jitter",
+				Client().SetHeartbeatBackoff(time.Second, time.Second, 0),
+				nil,
+			},
+			{
+				"min == 0",
+				Client().SetHeartbeatBackoff(0, time.Second, 0),
+				errors.New("heartbeat backoff must satisfy 0 < min <= max, got min=0s max=1s"),
+			},
+			{
+				"max < min",
+				Client().SetHeartbeatBackoff(time.Second, time.Millisecond, 0),
+				errors.New("heartbeat backoff must satisfy 0 < min <= max, got min=1s max=1ms"),
+			},
+			{
+				"jitter > 1",
+				Client().SetHeartbeatBackoff(time.Millisecond, time.Second, 1.5),
+				errors.New("heartbeat backoff jitter must be between 0 and 1, got 1.5"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("max concurrent operations validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"positive", Client().SetMaxConcurrentOperations(10), nil},
+			{"zero", Client().SetMaxConcurrentOperations(0), nil},
+			{
+				"negative",
+				Client().SetMaxConcurrentOperations(-1),
+				errors.New("max concurrent operations must be non-negative, got -1"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("heartbeat timeout validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"positive", Client().SetHeartbeatTimeout(time.Second), nil},
+			{"zero", Client().SetHeartbeatTimeout(0), errors.New("heartbeat timeout must be positive, got 0s")},
+			{
+				"negative",
+				Client().SetHeartbeatTimeout(-time.Second),
+				errors.New("heartbeat timeout must be positive, got -1s"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("server selection retry validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{
+				"valid",
+				Client().SetServerSelectionRetry(3, 100*time.Millisecond),
+				nil,
+			},
+			{
+				"single attempt without backoff",
+				Client().SetServerSelectionRetry(1, 0),
+				nil,
+			},
+			{
+				"attempts == 0",
+				Client().SetServerSelectionRetry(0, time.Second),
+				errors.New("server selection retry attempts must be at least 1, got 0"),
+			},
+			{
+				"negative backoff",
+				Client().SetServerSelectionRetry(2, -time.Second),
+				errors.New("server selection retry backoff must be non-negative, got -1s"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("TCP keep-alive validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{
+				"valid",
+				Client().SetTCPKeepAlive(30 * time.Second),
+				nil,
+			},
+			{
+				"disabled",
+				Client().SetTCPKeepAlive(0),
+				nil,
+			},
+			{
+				"negative",
+				Client().SetTCPKeepAlive(-time.Second),
+				errors.New("TCP keep-alive interval must be non-negative, got -1s"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("ForceMechanism validation", func(t *testing.T) {
+		forced := func(mechanism string) Credential {
+			cred := Credential{AuthMechanism: mechanism, Username: "user", Password: "pencil"}
+			cred.SetForceMechanism(true)
+			return cred
+		}
+
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{
+				"SCRAM-SHA-256",
+				Client().SetAuth(forced("SCRAM-SHA-256")),
+				nil,
+			},
+			{
+				"SCRAM-SHA-1",
+				Client().SetAuth(forced("SCRAM-SHA-1")),
+				nil,
+			},
+			{
+				"no mechanism",
+				Client().SetAuth(forced("")),
+				errors.New(`ForceMechanism requires the SCRAM-SHA-256 or SCRAM-SHA-1 auth mechanism, got ""`),
+			},
+			{
+				"PLAIN",
+				Client().SetAuth(forced("PLAIN")),
+				errors.New(`ForceMechanism requires the SCRAM-SHA-256 or SCRAM-SHA-1 auth mechanism, got "PLAIN"`),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
 	t.Run("minPoolSize validation", func(t *testing.T) {
 		testCases := []struct {
 			name string
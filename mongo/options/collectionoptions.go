@@ -34,6 +34,14 @@ type CollectionOptions struct {
 	// Registry is the BSON registry to marshal and unmarshal documents for operations executed on the Collection. The default value
 	// is nil, which means that the registry of the Database used to configure the Collection will be used.
 	Registry *bsoncodec.Registry
+
+	// AutoCreateOptions specifies options to create the collection with before the first write operation executed on
+	// the Collection. If set, the first insert, update, replace, findAndModify, or bulk write explicitly creates the
+	// collection with these options, and a collection that already exists is left unchanged. This can be used to ensure
+	// that collections such as time-series collections are created with the right configuration even if a write
+	// would otherwise implicitly create them. The default value is nil, which means that the collection is not created
+	// explicitly.
+	AutoCreateOptions *CreateCollectionOptions
 }
 
 // Collection creates a new CollectionOptions instance.
@@ -71,6 +79,12 @@ func (c *CollectionOptions) SetRegistry(r *bsoncodec.Registry) *CollectionOption
 	return c
 }
 
+// SetAutoCreateOptions sets the value for the AutoCreateOptions field.
+func (c *CollectionOptions) SetAutoCreateOptions(opts *CreateCollectionOptions) *CollectionOptions {
+	c.AutoCreateOptions = opts
+	return c
+}
+
 // MergeCollectionOptions combines the given CollectionOptions instances into a single *CollectionOptions in a
 // last-one-wins fashion.
 //
@@ -98,6 +112,9 @@ func MergeCollectionOptions(opts ...*CollectionOptions) *CollectionOptions {
 		if opt.BSONOptions != nil {
 			c.BSONOptions = opt.BSONOptions
 		}
+		if opt.AutoCreateOptions != nil {
+			c.AutoCreateOptions = opt.AutoCreateOptions
+		}
 	}
 
 	return c
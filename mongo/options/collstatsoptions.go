@@ -0,0 +1,57 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// CollStatsOptions represents options that can be used to configure a Collection.Stats operation.
+type CollStatsOptions struct {
+	// A string that will be included in server logs, profiling logs, and currentOp queries to help trace the operation.
+	// The default is nil, which means that no comment will be included in the logs.
+	Comment *string
+
+	// The factor that sizes are divided by, e.g. 1024 to report sizes in kilobytes. The server truncates scaled sizes
+	// to whole numbers. The default value is nil, which means sizes are reported in bytes.
+	Scale *int32
+}
+
+// CollStats creates a new CollStatsOptions instance.
+func CollStats() *CollStatsOptions {
+	return &CollStatsOptions{}
+}
+
+// SetComment sets the value for the Comment field.
+func (cso *CollStatsOptions) SetComment(comment string) *CollStatsOptions {
+	cso.Comment = &comment
+	return cso
+}
+
+// SetScale sets the value for the Scale field.
+func (cso *CollStatsOptions) SetScale(scale int32) *CollStatsOptions {
+	cso.Scale = &scale
+	return cso
+}
+
+// MergeCollStatsOptions combines the given CollStatsOptions instances into a single CollStatsOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeCollStatsOptions(opts ...*CollStatsOptions) *CollStatsOptions {
+	c := CollStats()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Comment != nil {
+			c.Comment = opt.Comment
+		}
+		if opt.Scale != nil {
+			c.Scale = opt.Scale
+		}
+	}
+
+	return c
+}
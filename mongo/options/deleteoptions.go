@@ -30,6 +30,18 @@ type DeleteOptions struct {
 	// Values must be constant or closed expressions that do not reference document fields. Parameters can then be
 	// accessed as variables in an aggregate expression context (e.g. "$$var").
 	Let interface{}
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If true, DeleteMany runs with an empty filter even if the client was configured with
+	// ClientOptions.SetGuardEmptyFilter. This option has no effect on other operations. The default value is nil, which
+	// means an empty filter is rejected by a guarded client.
+	AllowEmptyFilter *bool
 }
 
 // Delete creates a new DeleteOptions instance.
@@ -61,6 +73,18 @@ func (do *DeleteOptions) SetLet(let interface{}) *DeleteOptions {
 	return do
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (do *DeleteOptions) SetBypassAutoEncryption(b bool) *DeleteOptions {
+	do.BypassAutoEncryption = &b
+	return do
+}
+
+// SetAllowEmptyFilter sets the value for the AllowEmptyFilter field.
+func (do *DeleteOptions) SetAllowEmptyFilter(b bool) *DeleteOptions {
+	do.AllowEmptyFilter = &b
+	return do
+}
+
 // MergeDeleteOptions combines the given DeleteOptions instances into a single DeleteOptions in a last-one-wins fashion.
 //
 // Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
@@ -83,6 +107,12 @@ func MergeDeleteOptions(opts ...*DeleteOptions) *DeleteOptions {
 		if do.Let != nil {
 			dOpts.Let = do.Let
 		}
+		if do.BypassAutoEncryption != nil {
+			dOpts.BypassAutoEncryption = do.BypassAutoEncryption
+		}
+		if do.AllowEmptyFilter != nil {
+			dOpts.AllowEmptyFilter = do.AllowEmptyFilter
+		}
 	}
 
 	return dOpts
@@ -19,7 +19,8 @@ type FindOptions struct {
 	AllowDiskUse *bool
 
 	// AllowPartial results specifies whether the Find operation on a sharded cluster can return partial results if some
-	// shards are down rather than returning an error. The default value is false.
+	// shards are down rather than returning an error. Whether the results were partial can be checked with the
+	// Cursor.Partial method. The default value is false.
 	AllowPartialResults *bool
 
 	// BatchSize is the maximum number of documents to be included in each batch returned by the server.
@@ -57,6 +58,12 @@ type FindOptions struct {
 	// MongoDB versions >= 3.2. For other cursor types or previous server versions, this option is ignored.
 	MaxAwaitTime *time.Duration
 
+	// MaxResultBytes is the maximum total size in bytes of the BSON documents that Cursor.All decodes from the cursor
+	// returned by the operation. If the limit is exceeded, All closes the cursor and returns ErrMaxResultBytesExceeded
+	// from the mongo package. This option does not affect iterating the cursor with Next or TryNext. The default value
+	// is nil, which means there is no limit.
+	MaxResultBytes *int64
+
 	// MaxTime is the maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	//
@@ -79,10 +86,31 @@ type FindOptions struct {
 	// set.
 	OplogReplay *bool
 
+	// Prefetch specifies whether the cursor returned by the Find operation should issue the getMore for the next batch in
+	// the background as soon as the current batch is returned, overlapping network round trips with the processing of
+	// documents. Prefetching is not done if the Find operation uses an explicit session because sessions cannot be used
+	// concurrently. The default value is false.
+	Prefetch *bool
+
 	// Project is a document describing which fields will be included in the documents returned by the Find operation. The
 	// default value is nil, which means all fields will be included.
 	Projection interface{}
 
+	// ResumableCursor is the name of a field that the cursor returned by the Find operation uses to transparently run
+	// the query again if the server reports that the cursor was not found, e.g. because it timed out on the server
+	// while the application was processing a batch. The new query is the original filter combined with a $gt (or $lt
+	// for a descending sort) condition on the value of the field in the last document returned, so the field must be
+	// the first key of Sort. If Sort is not set, documents are sorted by the field in ascending order. The field must
+	// be included in the returned documents and should be unique, such as "_id", because documents that share the
+	// value of the last document returned are skipped by the new query. The resumed query does not apply Skip again
+	// and its Limit is reduced by the number of documents already returned.
+	//
+	// Resuming provides at-least-once delivery at the boundary between the two queries: a document that was already
+	// returned and has since been updated so that it sorts after the last document returned is returned again.
+	// ResumableCursor cannot be used with tailable cursors. The default value is nil, which means the cursor is not
+	// resumed.
+	ResumableCursor *string
+
 	// ReturnKey specifies whether the documents returned by the Find operation will only contain fields corresponding to the
 	// index used. The default value is false.
 	ReturnKey *bool
@@ -109,6 +137,23 @@ type FindOptions struct {
 	// Values must be constant or closed expressions that do not reference document fields. Parameters can then be
 	// accessed as variables in an aggregate expression context (e.g. "$$var").
 	Let interface{}
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If non-nil, overrides the "apiStrict" setting from the Client's ServerAPIOptions for this operation. The Client
+	// must be configured with a server API version. The default value is nil, which means the Client-wide setting is
+	// used.
+	ServerAPIStrict *bool
+
+	// If non-nil, overrides the "apiDeprecationErrors" setting from the Client's ServerAPIOptions for this operation.
+	// The Client must be configured with a server API version. The default value is nil, which means the Client-wide
+	// setting is used.
+	ServerAPIDeprecationErrors *bool
 }
 
 // Find creates a new FindOptions instance.
@@ -182,6 +227,12 @@ func (f *FindOptions) SetMaxAwaitTime(d time.Duration) *FindOptions {
 	return f
 }
 
+// SetMaxResultBytes sets the value for the MaxResultBytes field.
+func (f *FindOptions) SetMaxResultBytes(i int64) *FindOptions {
+	f.MaxResultBytes = &i
+	return f
+}
+
 // SetMaxTime specifies the max time to allow the query to run.
 //
 // NOTE(benjirewis): MaxTime will be deprecated in a future release. The more general Timeout
@@ -212,12 +263,24 @@ func (f *FindOptions) SetOplogReplay(b bool) *FindOptions {
 	return f
 }
 
+// SetPrefetch sets the value for the Prefetch field.
+func (f *FindOptions) SetPrefetch(b bool) *FindOptions {
+	f.Prefetch = &b
+	return f
+}
+
 // SetProjection sets the value for the Projection field.
 func (f *FindOptions) SetProjection(projection interface{}) *FindOptions {
 	f.Projection = projection
 	return f
 }
 
+// SetResumableCursor sets the value for the ResumableCursor field.
+func (f *FindOptions) SetResumableCursor(sortKeyField string) *FindOptions {
+	f.ResumableCursor = &sortKeyField
+	return f
+}
+
 // SetReturnKey sets the value for the ReturnKey field.
 func (f *FindOptions) SetReturnKey(b bool) *FindOptions {
 	f.ReturnKey = &b
@@ -250,6 +313,24 @@ func (f *FindOptions) SetSort(sort interface{}) *FindOptions {
 	return f
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (f *FindOptions) SetBypassAutoEncryption(b bool) *FindOptions {
+	f.BypassAutoEncryption = &b
+	return f
+}
+
+// SetServerAPIStrict sets the value for the ServerAPIStrict field.
+func (f *FindOptions) SetServerAPIStrict(b bool) *FindOptions {
+	f.ServerAPIStrict = &b
+	return f
+}
+
+// SetServerAPIDeprecationErrors sets the value for the ServerAPIDeprecationErrors field.
+func (f *FindOptions) SetServerAPIDeprecationErrors(b bool) *FindOptions {
+	f.ServerAPIDeprecationErrors = &b
+	return f
+}
+
 // MergeFindOptions combines the given FindOptions instances into a single FindOptions in a last-one-wins fashion.
 //
 // Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
@@ -293,6 +374,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.MaxAwaitTime != nil {
 			fo.MaxAwaitTime = opt.MaxAwaitTime
 		}
+		if opt.MaxResultBytes != nil {
+			fo.MaxResultBytes = opt.MaxResultBytes
+		}
 		if opt.MaxTime != nil {
 			fo.MaxTime = opt.MaxTime
 		}
@@ -305,9 +389,15 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.OplogReplay != nil {
 			fo.OplogReplay = opt.OplogReplay
 		}
+		if opt.Prefetch != nil {
+			fo.Prefetch = opt.Prefetch
+		}
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
+		if opt.ResumableCursor != nil {
+			fo.ResumableCursor = opt.ResumableCursor
+		}
 		if opt.ReturnKey != nil {
 			fo.ReturnKey = opt.ReturnKey
 		}
@@ -323,6 +413,15 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
+		if opt.BypassAutoEncryption != nil {
+			fo.BypassAutoEncryption = opt.BypassAutoEncryption
+		}
+		if opt.ServerAPIStrict != nil {
+			fo.ServerAPIStrict = opt.ServerAPIStrict
+		}
+		if opt.ServerAPIDeprecationErrors != nil {
+			fo.ServerAPIDeprecationErrors = opt.ServerAPIDeprecationErrors
+		}
 	}
 
 	return fo
@@ -359,6 +458,12 @@ type FindOneOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// Let specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older
+	// servers will report an error for using this option. This must be a document mapping parameter names to values.
+	// Values must be constant or closed expressions that do not reference document fields. Parameters can then be
+	// accessed as variables in an aggregate expression context (e.g. "$$var").
+	Let interface{}
+
 	// A document specifying the exclusive upper bound for a specific index. The default value is nil, which means that
 	// there is no maximum value.
 	Max interface{}
@@ -418,6 +523,38 @@ type FindOneOptions struct {
 	// A document specifying the sort order to apply to the query. The first document in the sorted order will be
 	// returned. The driver will return an error if the sort parameter is a multi-key map.
 	Sort interface{}
+
+	// If non-nil, the operation is retried on a secondary whose estimated staleness is at most this duration if no
+	// primary can be selected before server selection times out. This option is only used if the operation would
+	// otherwise be sent to the primary, i.e. the read preference is primary and no transaction is running. The
+	// duration must be at least 90 seconds and at least the sum of the heartbeat interval and the server's idle write
+	// period (10 seconds); see the max staleness documentation of the readpref package. The fallback read shares the
+	// Context of the operation, so no fallback read is done if the Context is canceled or its deadline passes while a
+	// primary is being selected. To leave time for the fallback read, set a server selection timeout shorter than the
+	// Context deadline.
+	//
+	// A document read from a secondary may not reflect the latest writes, including writes made earlier by the same
+	// application, so this option should only be used for data that can tolerate stale reads. The fallback read is not
+	// causally consistent with reads and writes done on the primary unless a causally consistent session is used. The
+	// default value is nil, which means an error is returned if no primary can be selected.
+	StalenessFallback *time.Duration
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If non-nil, overrides the "apiStrict" setting from the Client's ServerAPIOptions for this operation. The Client
+	// must be configured with a server API version. The default value is nil, which means the Client-wide setting is
+	// used.
+	ServerAPIStrict *bool
+
+	// If non-nil, overrides the "apiDeprecationErrors" setting from the Client's ServerAPIOptions for this operation.
+	// The Client must be configured with a server API version. The default value is nil, which means the Client-wide
+	// setting is used.
+	ServerAPIDeprecationErrors *bool
 }
 
 // FindOne creates a new FindOneOptions instance.
@@ -465,6 +602,12 @@ func (f *FindOneOptions) SetHint(hint interface{}) *FindOneOptions {
 	return f
 }
 
+// SetLet sets the value for the Let field.
+func (f *FindOneOptions) SetLet(let interface{}) *FindOneOptions {
+	f.Let = let
+	return f
+}
+
 // SetMax sets the value for the Max field.
 func (f *FindOneOptions) SetMax(max interface{}) *FindOneOptions {
 	f.Max = max
@@ -550,6 +693,30 @@ func (f *FindOneOptions) SetSort(sort interface{}) *FindOneOptions {
 	return f
 }
 
+// SetStalenessFallback sets the value for the StalenessFallback field.
+func (f *FindOneOptions) SetStalenessFallback(maxStaleness time.Duration) *FindOneOptions {
+	f.StalenessFallback = &maxStaleness
+	return f
+}
+
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (f *FindOneOptions) SetBypassAutoEncryption(b bool) *FindOneOptions {
+	f.BypassAutoEncryption = &b
+	return f
+}
+
+// SetServerAPIStrict sets the value for the ServerAPIStrict field.
+func (f *FindOneOptions) SetServerAPIStrict(b bool) *FindOneOptions {
+	f.ServerAPIStrict = &b
+	return f
+}
+
+// SetServerAPIDeprecationErrors sets the value for the ServerAPIDeprecationErrors field.
+func (f *FindOneOptions) SetServerAPIDeprecationErrors(b bool) *FindOneOptions {
+	f.ServerAPIDeprecationErrors = &b
+	return f
+}
+
 // MergeFindOneOptions combines the given FindOneOptions instances into a single FindOneOptions in a last-one-wins
 // fashion.
 //
@@ -579,6 +746,9 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.Max != nil {
 			fo.Max = opt.Max
 		}
@@ -615,6 +785,18 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
+		if opt.StalenessFallback != nil {
+			fo.StalenessFallback = opt.StalenessFallback
+		}
+		if opt.BypassAutoEncryption != nil {
+			fo.BypassAutoEncryption = opt.BypassAutoEncryption
+		}
+		if opt.ServerAPIStrict != nil {
+			fo.ServerAPIStrict = opt.ServerAPIStrict
+		}
+		if opt.ServerAPIDeprecationErrors != nil {
+			fo.ServerAPIDeprecationErrors = opt.ServerAPIDeprecationErrors
+		}
 	}
 
 	return fo
@@ -34,6 +34,11 @@ type CreateIndexesOptions struct {
 	// in its place to control the amount of time that a single operation can run before returning an error. MaxTime
 	// is ignored if Timeout is set on the client.
 	MaxTime *time.Duration
+
+	// If true, IndexView.CreateMany drops the indexes it created if the operation fails, so that either all or none
+	// of the requested indexes are created. Indexes that existed before the operation are not dropped. The default
+	// value is false.
+	RollbackOnError *bool
 }
 
 // CreateIndexes creates a new CreateIndexesOptions instance.
@@ -75,6 +80,12 @@ func (c *CreateIndexesOptions) SetCommitQuorumVotingMembers() *CreateIndexesOpti
 	return c
 }
 
+// SetRollbackOnError sets the value for the RollbackOnError field.
+func (c *CreateIndexesOptions) SetRollbackOnError(b bool) *CreateIndexesOptions {
+	c.RollbackOnError = &b
+	return c
+}
+
 // MergeCreateIndexesOptions combines the given CreateIndexesOptions into a single CreateIndexesOptions in a last one
 // wins fashion.
 //
@@ -92,6 +103,9 @@ func MergeCreateIndexesOptions(opts ...*CreateIndexesOptions) *CreateIndexesOpti
 		if opt.CommitQuorum != nil {
 			c.CommitQuorum = opt.CommitQuorum
 		}
+		if opt.RollbackOnError != nil {
+			c.RollbackOnError = opt.RollbackOnError
+		}
 	}
 
 	return c
@@ -23,6 +23,13 @@ type InsertOneOptions struct {
 	// Deprecated: This option is for internal use only and should not be set. It may be changed or removed in any
 	// release.
 	BypassEmptyTsReplacement *bool
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
 }
 
 // InsertOne creates a new InsertOneOptions instance.
@@ -42,6 +49,12 @@ func (ioo *InsertOneOptions) SetComment(comment interface{}) *InsertOneOptions {
 	return ioo
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (ioo *InsertOneOptions) SetBypassAutoEncryption(b bool) *InsertOneOptions {
+	ioo.BypassAutoEncryption = &b
+	return ioo
+}
+
 // MergeInsertOneOptions combines the given InsertOneOptions instances into a single InsertOneOptions in a last-one-wins
 // fashion.
 //
@@ -62,6 +75,9 @@ func MergeInsertOneOptions(opts ...*InsertOneOptions) *InsertOneOptions {
 		if ioo.BypassEmptyTsReplacement != nil {
 			ioOpts.BypassEmptyTsReplacement = ioo.BypassEmptyTsReplacement
 		}
+		if ioo.BypassAutoEncryption != nil {
+			ioOpts.BypassAutoEncryption = ioo.BypassAutoEncryption
+		}
 	}
 
 	return ioOpts
@@ -87,6 +103,20 @@ type InsertManyOptions struct {
 	// Deprecated: This option is for internal use only and should not be set. It may be changed or removed in any
 	// release.
 	BypassEmptyTsReplacement *bool
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// A function that is called after each batch of documents has been written. The driver splits the documents
+	// into as many insert commands as necessary to respect the server's maximum batch count and message size, so
+	// this can be used to report the progress of large inserts. The function is called with the number of documents
+	// inserted so far and the total number of documents. Progress is only reported for acknowledged writes. The
+	// default value is nil, which means no progress is reported.
+	Progress func(inserted, total int)
 }
 
 // InsertMany creates a new InsertManyOptions instance.
@@ -114,6 +144,18 @@ func (imo *InsertManyOptions) SetOrdered(b bool) *InsertManyOptions {
 	return imo
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (imo *InsertManyOptions) SetBypassAutoEncryption(b bool) *InsertManyOptions {
+	imo.BypassAutoEncryption = &b
+	return imo
+}
+
+// SetProgress sets the value for the Progress field.
+func (imo *InsertManyOptions) SetProgress(fn func(inserted, total int)) *InsertManyOptions {
+	imo.Progress = fn
+	return imo
+}
+
 // MergeInsertManyOptions combines the given InsertManyOptions instances into a single InsertManyOptions in a last one
 // wins fashion.
 //
@@ -137,6 +179,12 @@ func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
 		if imo.BypassEmptyTsReplacement != nil {
 			imOpts.BypassEmptyTsReplacement = imo.BypassEmptyTsReplacement
 		}
+		if imo.BypassAutoEncryption != nil {
+			imOpts.BypassAutoEncryption = imo.BypassAutoEncryption
+		}
+		if imo.Progress != nil {
+			imOpts.Progress = imo.Progress
+		}
 	}
 
 	return imOpts
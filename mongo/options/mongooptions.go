@@ -109,6 +109,19 @@ const (
 	WhenAvailable FullDocument = "whenAvailable"
 )
 
+// The FullDocument values that are valid for the ChangeStreamOptions.FullDocumentBeforeChange option. Pre-images are
+// only available for collections with changeStreamPreAndPostImages enabled, which requires MongoDB 6.0 or later.
+const (
+	// FullDocumentBeforeChangeOff does not include a pre-image of the modified document. This is the default.
+	FullDocumentBeforeChangeOff FullDocument = Off
+	// FullDocumentBeforeChangeWhenAvailable includes a pre-image of the modified document for replace, update, and
+	// delete change events if the pre-image for this event is available.
+	FullDocumentBeforeChangeWhenAvailable FullDocument = WhenAvailable
+	// FullDocumentBeforeChangeRequired is the same as FullDocumentBeforeChangeWhenAvailable but raises a server-side
+	// error if the pre-image is not available.
+	FullDocumentBeforeChangeRequired FullDocument = Required
+)
+
 // TODO(GODRIVER-2617): Once Registry is removed, ArrayFilters doesn't need to
 // TODO be a separate type. Remove the type and update all ArrayFilters fields
 // TODO to be type []interface{}.
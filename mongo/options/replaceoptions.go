@@ -46,6 +46,13 @@ type ReplaceOptions struct {
 	// Deprecated: This option is for internal use only and should not be set. It may be changed or removed in any
 	// release.
 	BypassEmptyTsReplacement *bool
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
 }
 
 // Replace creates a new ReplaceOptions instance.
@@ -89,6 +96,12 @@ func (ro *ReplaceOptions) SetLet(l interface{}) *ReplaceOptions {
 	return ro
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (ro *ReplaceOptions) SetBypassAutoEncryption(b bool) *ReplaceOptions {
+	ro.BypassAutoEncryption = &b
+	return ro
+}
+
 // MergeReplaceOptions combines the given ReplaceOptions instances into a single ReplaceOptions in a last-one-wins
 // fashion.
 //
@@ -121,6 +134,9 @@ func MergeReplaceOptions(opts ...*ReplaceOptions) *ReplaceOptions {
 		if ro.BypassEmptyTsReplacement != nil {
 			rOpts.BypassEmptyTsReplacement = ro.BypassEmptyTsReplacement
 		}
+		if ro.BypassAutoEncryption != nil {
+			rOpts.BypassAutoEncryption = ro.BypassAutoEncryption
+		}
 	}
 
 	return rOpts
@@ -15,6 +15,16 @@ type RunCmdOptions struct {
 	// The read preference to use for the operation. The default value is nil, which means that the primary read
 	// preference will be used.
 	ReadPreference *readpref.ReadPref
+
+	// If non-nil, overrides the "apiStrict" setting from the Client's ServerAPIOptions for this operation. The Client
+	// must be configured with a server API version. The default value is nil, which means the Client-wide setting is
+	// used.
+	ServerAPIStrict *bool
+
+	// If non-nil, overrides the "apiDeprecationErrors" setting from the Client's ServerAPIOptions for this operation.
+	// The Client must be configured with a server API version. The default value is nil, which means the Client-wide
+	// setting is used.
+	ServerAPIDeprecationErrors *bool
 }
 
 // RunCmd creates a new RunCmdOptions instance.
@@ -28,6 +38,18 @@ func (rc *RunCmdOptions) SetReadPreference(rp *readpref.ReadPref) *RunCmdOptions
 	return rc
 }
 
+// SetServerAPIStrict sets the value for the ServerAPIStrict field.
+func (rc *RunCmdOptions) SetServerAPIStrict(b bool) *RunCmdOptions {
+	rc.ServerAPIStrict = &b
+	return rc
+}
+
+// SetServerAPIDeprecationErrors sets the value for the ServerAPIDeprecationErrors field.
+func (rc *RunCmdOptions) SetServerAPIDeprecationErrors(b bool) *RunCmdOptions {
+	rc.ServerAPIDeprecationErrors = &b
+	return rc
+}
+
 // MergeRunCmdOptions combines the given RunCmdOptions instances into one *RunCmdOptions in a last-one-wins fashion.
 //
 // Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
@@ -41,6 +63,12 @@ func MergeRunCmdOptions(opts ...*RunCmdOptions) *RunCmdOptions {
 		if opt.ReadPreference != nil {
 			rc.ReadPreference = opt.ReadPreference
 		}
+		if opt.ServerAPIStrict != nil {
+			rc.ServerAPIStrict = opt.ServerAPIStrict
+		}
+		if opt.ServerAPIDeprecationErrors != nil {
+			rc.ServerAPIDeprecationErrors = opt.ServerAPIDeprecationErrors
+		}
 	}
 
 	return rc
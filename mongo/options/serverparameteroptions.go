@@ -0,0 +1,46 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ServerParameterOptions represents options that can be used to configure a Client.GetServerParameter or
+// Client.GetAllServerParameters operation.
+type ServerParameterOptions struct {
+	// The address, in "host:port" form, of the server to read the parameters from. The server must be one that the
+	// Client is connected to. The default value is nil, which means the parameters are read from the primary of a
+	// replica set, from a mongos of a sharded cluster, or from the server of a standalone deployment.
+	Address *string
+}
+
+// ServerParameter creates a new ServerParameterOptions instance.
+func ServerParameter() *ServerParameterOptions {
+	return &ServerParameterOptions{}
+}
+
+// SetAddress sets the value for the Address field.
+func (spo *ServerParameterOptions) SetAddress(addr string) *ServerParameterOptions {
+	spo.Address = &addr
+	return spo
+}
+
+// MergeServerParameterOptions combines the given ServerParameterOptions instances into a single
+// ServerParameterOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeServerParameterOptions(opts ...*ServerParameterOptions) *ServerParameterOptions {
+	s := ServerParameter()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Address != nil {
+			s.Address = opt.Address
+		}
+	}
+
+	return s
+}
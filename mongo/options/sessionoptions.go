@@ -9,6 +9,7 @@ package options
 import (
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -44,6 +45,14 @@ type SessionOptions struct {
 	// error. DefaultMaxCommitTime is ignored if Timeout is set on the client.
 	DefaultMaxCommitTime *time.Duration
 
+	// A cluster time document to seed the session with, in the same format as returned by Session.ClusterTime
+	// (e.g. {"$clusterTime": {"clusterTime": <timestamp>, "signature": ...}}). This allows a session to be causally
+	// consistent with operations performed by another session or service. If causal consistency is enabled, reads
+	// performed with the session will read data at or after the given cluster time. The session's cluster time
+	// advances from this value as responses are received. The default value is nil, which means that the session
+	// starts without a cluster time.
+	InitialClusterTime bson.Raw
+
 	// If true, all read operations performed with this session will be read from the same snapshot. This option cannot
 	// be set to true if CausalConsistency is set to true. Transactions and write operations are not allowed on
 	// snapshot sessions and will error. The default value is false.
@@ -90,6 +99,12 @@ func (s *SessionOptions) SetDefaultMaxCommitTime(mct *time.Duration) *SessionOpt
 	return s
 }
 
+// SetInitialClusterTime sets the value for the InitialClusterTime field.
+func (s *SessionOptions) SetInitialClusterTime(clusterTime bson.Raw) *SessionOptions {
+	s.InitialClusterTime = clusterTime
+	return s
+}
+
 // SetSnapshot sets the value for the Snapshot field.
 func (s *SessionOptions) SetSnapshot(b bool) *SessionOptions {
 	s.Snapshot = &b
@@ -122,6 +137,9 @@ func MergeSessionOptions(opts ...*SessionOptions) *SessionOptions {
 		if opt.DefaultMaxCommitTime != nil {
 			s.DefaultMaxCommitTime = opt.DefaultMaxCommitTime
 		}
+		if opt.InitialClusterTime != nil {
+			s.InitialClusterTime = opt.InitialClusterTime
+		}
 		if opt.Snapshot != nil {
 			s.Snapshot = opt.Snapshot
 		}
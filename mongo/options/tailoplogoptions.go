@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TailOplogOptions represents options that can be used to configure an oplog.TailOplog operation.
+type TailOplogOptions struct {
+	// The number of documents to be included in each batch returned by the server. The default value is nil, which
+	// means the server's default batch size is used.
+	BatchSize *int32
+
+	// A document specifying additional conditions that oplog entries must match, such as {ns: "db.coll"}. The
+	// condition is combined with the timestamp condition used to resume tailing. The default value is nil, which
+	// means all oplog entries are returned.
+	Filter interface{}
+
+	// The maximum amount of time that the server should wait for new oplog entries before returning an empty batch.
+	// The default value is nil, which means the server's default of 1 second is used.
+	MaxAwaitTime *time.Duration
+
+	// The timestamp of the last oplog entry that has already been processed. Only entries with a later timestamp
+	// are returned. The default value is nil, which means tailing starts after the newest entry in the oplog at the
+	// time TailOplog is called.
+	StartAfter *primitive.Timestamp
+}
+
+// TailOplog creates a new TailOplogOptions instance.
+func TailOplog() *TailOplogOptions {
+	return &TailOplogOptions{}
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (to *TailOplogOptions) SetBatchSize(i int32) *TailOplogOptions {
+	to.BatchSize = &i
+	return to
+}
+
+// SetFilter sets the value for the Filter field.
+func (to *TailOplogOptions) SetFilter(filter interface{}) *TailOplogOptions {
+	to.Filter = filter
+	return to
+}
+
+// SetMaxAwaitTime sets the value for the MaxAwaitTime field.
+func (to *TailOplogOptions) SetMaxAwaitTime(d time.Duration) *TailOplogOptions {
+	to.MaxAwaitTime = &d
+	return to
+}
+
+// SetStartAfter sets the value for the StartAfter field.
+func (to *TailOplogOptions) SetStartAfter(ts primitive.Timestamp) *TailOplogOptions {
+	to.StartAfter = &ts
+	return to
+}
@@ -51,6 +51,18 @@ type UpdateOptions struct {
 	// Deprecated: This option is for internal use only and should not be set. It may be changed or removed in any
 	// release.
 	BypassEmptyTsReplacement *bool
+
+	// If true, the command for the operation will not be automatically encrypted, even if the client was configured with
+	// AutoEncryptionOptions. Setting this to false does not enable automatic encryption for a client whose
+	// AutoEncryptionOptions bypass it. Command results are still automatically decrypted, so encrypted fields that the
+	// client can access the data keys for are returned decrypted. The default value is nil, which means the client-wide
+	// setting is used.
+	BypassAutoEncryption *bool
+
+	// If true, UpdateMany runs with an empty filter even if the client was configured with
+	// ClientOptions.SetGuardEmptyFilter. This option has no effect on other operations. The default value is nil, which
+	// means an empty filter is rejected by a guarded client.
+	AllowEmptyFilter *bool
 }
 
 // Update creates a new UpdateOptions instance.
@@ -100,6 +112,18 @@ func (uo *UpdateOptions) SetLet(l interface{}) *UpdateOptions {
 	return uo
 }
 
+// SetBypassAutoEncryption sets the value for the BypassAutoEncryption field.
+func (uo *UpdateOptions) SetBypassAutoEncryption(b bool) *UpdateOptions {
+	uo.BypassAutoEncryption = &b
+	return uo
+}
+
+// SetAllowEmptyFilter sets the value for the AllowEmptyFilter field.
+func (uo *UpdateOptions) SetAllowEmptyFilter(b bool) *UpdateOptions {
+	uo.AllowEmptyFilter = &b
+	return uo
+}
+
 // MergeUpdateOptions combines the given UpdateOptions instances into a single UpdateOptions in a last-one-wins fashion.
 //
 // Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
@@ -134,6 +158,12 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 		if uo.BypassEmptyTsReplacement != nil {
 			uOpts.BypassEmptyTsReplacement = uo.BypassEmptyTsReplacement
 		}
+		if uo.BypassAutoEncryption != nil {
+			uOpts.BypassAutoEncryption = uo.BypassAutoEncryption
+		}
+		if uo.AllowEmptyFilter != nil {
+			uOpts.AllowEmptyFilter = uo.AllowEmptyFilter
+		}
 	}
 
 	return uOpts
@@ -0,0 +1,260 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// defaultPaginatorPageSize is the number of documents in a page if PaginatorOptions.PageSize is not set.
+const defaultPaginatorPageSize = 100
+
+// ErrInvalidPageToken is returned by Paginator.Resume if a page token is malformed or was produced by a Paginator with
+// a different sort.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PaginatorOptions represents options that can be used to configure a Paginator.
+type PaginatorOptions struct {
+	// Sort is the order of the documents, as a document mapping field names to 1 for ascending or -1 for descending
+	// order. Field names can be dotted paths to embedded fields. If Sort does not include "_id", it is appended in
+	// ascending order so that documents with equal values for all other sort keys are still paged in a stable order.
+	// The default value sorts by "_id" only.
+	Sort bson.D
+
+	// PageSize is the maximum number of documents in a page. The default value is 100.
+	PageSize int64
+}
+
+// Paginator pages through the documents in a collection that match a filter using keyset pagination: each page is
+// fetched with a find command whose filter selects the documents after the last document of the previous page in sort
+// order, so pages stay consistent when documents are inserted or deleted between requests and do not get slower as
+// the offset grows. The sort should be supported by an index that starts with the sort keys.
+//
+// The position of a Paginator is represented by an opaque page token, which is the URL-safe, unpadded base64 encoding
+// of the sort key values of the last document of the previous page. Tokens can be returned to API clients and passed
+// to Resume in a later request. Their contents are not part of the API and may change in future versions.
+//
+// Every document must have a value for every sort key, and the values of a sort key should have the same BSON type
+// in all documents, because a document whose value is of a different type than the last value of the previous page
+// may be skipped. A Paginator is not safe for concurrent use.
+type Paginator struct {
+	coll     *Collection
+	filter   interface{}
+	sort     bson.D
+	pageSize int64
+
+	// after holds the sort key values of the last document of the previous page, or nil for the first page.
+	after []bson.RawValue
+	done  bool
+}
+
+// NewPaginator creates a Paginator that pages through the documents in coll that match filter. If filter is nil, all
+// documents are paged through. NewPaginator returns an error if a sort direction in opts is not 1 or -1.
+//
+// Example usage:
+//
+//	p, err := mongo.NewPaginator(coll, bson.D{{"status", "active"}}, mongo.PaginatorOptions{
+//		Sort:     bson.D{{"createdAt", -1}},
+//		PageSize: 20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if err := p.Resume(r.URL.Query().Get("page")); err != nil {
+//		return err
+//	}
+//	page, next, err := p.Next(ctx)
+func NewPaginator(coll *Collection, filter interface{}, opts PaginatorOptions) (*Paginator, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPaginatorPageSize
+	}
+
+	sort := make(bson.D, 0, len(opts.Sort)+1)
+	var hasID bool
+	for _, e := range opts.Sort {
+		dir, err := paginatorSortDirection(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sort for key %q: %w", e.Key, err)
+		}
+		sort = append(sort, bson.E{Key: e.Key, Value: dir})
+		hasID = hasID || e.Key == "_id"
+	}
+	if !hasID {
+		sort = append(sort, bson.E{Key: "_id", Value: int32(1)})
+	}
+
+	return &Paginator{
+		coll:     coll,
+		filter:   filter,
+		sort:     sort,
+		pageSize: opts.PageSize,
+	}, nil
+}
+
+// paginatorSortDirection converts a sort direction to an int32 and returns an error if it is not 1 or -1.
+func paginatorSortDirection(v interface{}) (int32, error) {
+	var dir float64
+	switch v := v.(type) {
+	case int:
+		dir = float64(v)
+	case int32:
+		dir = float64(v)
+	case int64:
+		dir = float64(v)
+	case float64:
+		dir = v
+	default:
+		return 0, fmt.Errorf("direction must be 1 or -1, got %v of type %T", v, v)
+	}
+	if dir != 1 && dir != -1 {
+		return 0, fmt.Errorf("direction must be 1 or -1, got %v", v)
+	}
+	return int32(dir), nil
+}
+
+// Resume positions the Paginator after the page that returned token, so the next call to Next returns the following
+// page. An empty token positions the Paginator at the first page. Resume returns an error wrapping
+// ErrInvalidPageToken if the token is malformed or its sort keys differ from the sort of the Paginator.
+func (p *Paginator) Resume(token string) error {
+	var after []bson.RawValue
+	if token != "" {
+		var err error
+		if after, err = p.decodeToken(token); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+		}
+	}
+	p.after = after
+	p.done = false
+	return nil
+}
+
+// Next returns the next page of documents and the token for the page after it. The token is empty if the returned
+// page is the last one, in which case subsequent calls return an empty page. The returned documents are owned by the
+// caller.
+func (p *Paginator) Next(ctx context.Context) ([]bson.Raw, string, error) {
+	if p.done {
+		return nil, "", nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Fetch one extra document to find out whether there is another page.
+	opts := options.Find().SetSort(p.sort).SetLimit(p.pageSize + 1)
+	cursor, err := p.coll.Find(ctx, p.pageFilter(), opts)
+	if err != nil {
+		return nil, "", err
+	}
+	var docs []bson.Raw
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(docs)) <= p.pageSize {
+		p.done = true
+		return docs, "", nil
+	}
+
+	page := docs[:p.pageSize]
+	after, err := p.sortValues(page[len(page)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	p.after = after
+	return page, p.encodeToken(after), nil
+}
+
+// pageFilter returns the filter for the next page. For sort keys k1, ..., kn and the values v1, ..., vn of the last
+// document of the previous page, it matches the documents where k1 is after v1, or k1 equals v1 and k2 is after v2,
+// and so on.
+func (p *Paginator) pageFilter() interface{} {
+	filter := p.filter
+	if filter == nil {
+		filter = bson.D{}
+	}
+	if p.after == nil {
+		return filter
+	}
+
+	or := make(bson.A, 0, len(p.sort))
+	for i, e := range p.sort {
+		cond := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			cond = append(cond, bson.E{Key: p.sort[j].Key, Value: bson.D{{"$eq", p.after[j]}}})
+		}
+		op := "$gt"
+		if e.Value.(int32) < 0 {
+			op = "$lt"
+		}
+		cond = append(cond, bson.E{Key: e.Key, Value: bson.D{{op, p.after[i]}}})
+		or = append(or, cond)
+	}
+	keyset := bson.D{{"$or", or}}
+	if p.filter == nil {
+		return keyset
+	}
+	return bson.D{{"$and", bson.A{filter, keyset}}}
+}
+
+// sortValues returns the values of the sort keys in doc.
+func (p *Paginator) sortValues(doc bson.Raw) ([]bson.RawValue, error) {
+	vals := make([]bson.RawValue, 0, len(p.sort))
+	for _, e := range p.sort {
+		val, err := doc.LookupErr(strings.Split(e.Key, ".")...)
+		if err != nil {
+			return nil, fmt.Errorf("document is missing sort key %q: %w", e.Key, err)
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+// encodeToken returns the page token for the sort key values in after.
+func (p *Paginator) encodeToken(after []bson.RawValue) string {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for i, e := range p.sort {
+		doc = bsoncore.AppendValueElement(doc, e.Key, bsoncore.Value{Type: after[i].Type, Data: after[i].Value})
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return base64.RawURLEncoding.EncodeToString(doc)
+}
+
+// decodeToken returns the sort key values in token.
+func (p *Paginator) decodeToken(token string) ([]bson.RawValue, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.Raw(b)
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) != len(p.sort) {
+		return nil, fmt.Errorf("expected %d sort keys, got %d", len(p.sort), len(elems))
+	}
+
+	after := make([]bson.RawValue, 0, len(elems))
+	for i, elem := range elems {
+		if key := elem.Key(); key != p.sort[i].Key {
+			return nil, fmt.Errorf("expected sort key %q, got %q", p.sort[i].Key, key)
+		}
+		after = append(after, elem.Value())
+	}
+	return after, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestNewPaginator(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sort     bson.D
+		wantSort bson.D
+	}{
+		{"default", nil, bson.D{{"_id", int32(1)}}},
+		{"tie breaker appended", bson.D{{"a", 1}, {"b.c", int64(-1)}},
+			bson.D{{"a", int32(1)}, {"b.c", int32(-1)}, {"_id", int32(1)}}},
+		{"explicit _id", bson.D{{"_id", -1.0}, {"a", 1}}, bson.D{{"_id", int32(-1)}, {"a", int32(1)}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewPaginator(nil, nil, PaginatorOptions{Sort: tc.sort})
+			assert.Nil(t, err, "NewPaginator error: %v", err)
+			assert.Equal(t, tc.wantSort, p.sort, "expected sort %v, got %v", tc.wantSort, p.sort)
+			assert.Equal(t, int64(defaultPaginatorPageSize), p.pageSize, "expected default page size %d, got %d",
+				defaultPaginatorPageSize, p.pageSize)
+		})
+	}
+
+	for _, dir := range []interface{}{0, 2, "asc", bson.D{{"$meta", "textScore"}}} {
+		_, err := NewPaginator(nil, nil, PaginatorOptions{Sort: bson.D{{"a", dir}}})
+		assert.NotNil(t, err, "expected an error for sort direction %v, got nil", dir)
+	}
+}
+
+func TestPaginatorToken(t *testing.T) {
+	newPaginator := func(t *testing.T, filter interface{}) *Paginator {
+		t.Helper()
+
+		p, err := NewPaginator(nil, filter, PaginatorOptions{Sort: bson.D{{"score", -1}, {"name", 1}}, PageSize: 2})
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+		return p
+	}
+	lastDoc, err := bson.Marshal(bson.D{{"_id", int32(7)}, {"name", "b"}, {"score", int32(10)}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	t.Run("round trip", func(t *testing.T) {
+		p := newPaginator(t, nil)
+		after, err := p.sortValues(lastDoc)
+		assert.Nil(t, err, "sortValues error: %v", err)
+		token := p.encodeToken(after)
+
+		_, err = base64.RawURLEncoding.DecodeString(token)
+		assert.Nil(t, err, "expected a URL-safe base64 token, got %q: %v", token, err)
+
+		resumed := newPaginator(t, nil)
+		err = resumed.Resume(token)
+		assert.Nil(t, err, "Resume error: %v", err)
+		assert.Equal(t, after, resumed.after, "expected values %v, got %v", after, resumed.after)
+	})
+	t.Run("page filter", func(t *testing.T) {
+		p := newPaginator(t, bson.D{{"active", true}})
+		first, err := bson.Marshal(p.pageFilter())
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, `{"active": true}`, bson.Raw(first).String(), "expected the user filter, got %v", bson.Raw(first))
+
+		p.after, err = p.sortValues(lastDoc)
+		assert.Nil(t, err, "sortValues error: %v", err)
+		got, err := bson.Marshal(p.pageFilter())
+		assert.Nil(t, err, "Marshal error: %v", err)
+		want, err := bson.Marshal(bson.D{{"$and", bson.A{
+			bson.D{{"active", true}},
+			bson.D{{"$or", bson.A{
+				bson.D{{"score", bson.D{{"$lt", int32(10)}}}},
+				bson.D{{"score", bson.D{{"$eq", int32(10)}}}, {"name", bson.D{{"$gt", "b"}}}},
+				bson.D{
+					{"score", bson.D{{"$eq", int32(10)}}},
+					{"name", bson.D{{"$eq", "b"}}},
+					{"_id", bson.D{{"$gt", int32(7)}}},
+				},
+			}}},
+		}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(want).String(), bson.Raw(got).String(), "expected filter %v, got %v", bson.Raw(want),
+			bson.Raw(got))
+	})
+	t.Run("missing sort key", func(t *testing.T) {
+		p := newPaginator(t, nil)
+		doc, err := bson.Marshal(bson.D{{"_id", int32(1)}, {"score", int32(1)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		_, err = p.sortValues(doc)
+		assert.NotNil(t, err, "expected an error for a document without a name, got nil")
+	})
+	t.Run("invalid tokens", func(t *testing.T) {
+		other, err := NewPaginator(nil, nil, PaginatorOptions{Sort: bson.D{{"score", -1}}})
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+		after, err := other.sortValues(lastDoc)
+		assert.Nil(t, err, "sortValues error: %v", err)
+		otherToken := other.encodeToken(after)
+
+		renamed, err := NewPaginator(nil, nil, PaginatorOptions{Sort: bson.D{{"score", -1}, {"title", 1}}})
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+		renamedDoc, err := bson.Marshal(bson.D{{"_id", int32(7)}, {"title", "b"}, {"score", int32(10)}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		after, err = renamed.sortValues(renamedDoc)
+		assert.Nil(t, err, "sortValues error: %v", err)
+		renamedToken := renamed.encodeToken(after)
+
+		for name, token := range map[string]string{
+			"not base64":          "!!!",
+			"not BSON":            base64.RawURLEncoding.EncodeToString([]byte("abc")),
+			"different key count": otherToken,
+			"different keys":      renamedToken,
+		} {
+			p := newPaginator(t, nil)
+			err := p.Resume(token)
+			assert.True(t, errors.Is(err, ErrInvalidPageToken), "expected ErrInvalidPageToken for %s, got %v", name, err)
+			assert.Nil(t, p.after, "expected the position to be unchanged for %s, got %v", name, p.after)
+		}
+	})
+	t.Run("empty token", func(t *testing.T) {
+		p := newPaginator(t, nil)
+		p.after, p.done = []bson.RawValue{{}}, true
+		err := p.Resume("")
+		assert.Nil(t, err, "Resume error: %v", err)
+		assert.Nil(t, p.after, "expected the first page, got %v", p.after)
+		assert.False(t, p.done, "expected the Paginator to not be done")
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// dateUnits are the time units accepted by the $dateTrunc and $dateAdd expressions.
+var dateUnits = map[string]struct{}{
+	"year":        {},
+	"quarter":     {},
+	"month":       {},
+	"week":        {},
+	"day":         {},
+	"hour":        {},
+	"minute":      {},
+	"second":      {},
+	"millisecond": {},
+}
+
+func validateDateUnit(op, unit string) error {
+	if _, ok := dateUnits[unit]; !ok {
+		return fmt.Errorf("%s unit must be one of year, quarter, month, week, day, hour, minute, second, or "+
+			"millisecond, got %q", op, unit)
+	}
+	return nil
+}
+
+// DateTrunc returns a $dateTrunc aggregation expression that truncates the date computed by dateExpr to a multiple of
+// binSize units. If binSize is 0, it is omitted and the server uses 1. If timezone is empty, it is omitted and the
+// date is truncated in UTC. An error is returned if unit is not a valid time unit or binSize is negative. $dateTrunc
+// requires MongoDB 5.0 or later.
+//
+// Example usage:
+//
+//	expr, err := mongo.DateTrunc("$orderDate", "hour", 6, "America/New_York")
+//	stage := mongo.Group(bson.D{{"bucket", expr}}, map[string]bson.D{"total": mongo.Sum("$amount")})
+func DateTrunc(dateExpr interface{}, unit string, binSize int64, timezone string) (bson.D, error) {
+	if err := validateDateUnit("$dateTrunc", unit); err != nil {
+		return nil, err
+	}
+	if binSize < 0 {
+		return nil, fmt.Errorf("$dateTrunc binSize must not be negative, got %d", binSize)
+	}
+
+	args := bson.D{{"date", dateExpr}, {"unit", unit}}
+	if binSize != 0 {
+		args = append(args, bson.E{"binSize", binSize})
+	}
+	if timezone != "" {
+		args = append(args, bson.E{"timezone", timezone})
+	}
+	return bson.D{{"$dateTrunc", args}}, nil
+}
+
+// DateAdd returns a $dateAdd aggregation expression that adds amount units to the date computed by startDateExpr. The
+// amount can be an integer or an expression that resolves to one, and a negative amount subtracts time. If timezone is
+// empty, it is omitted and the date is computed in UTC. An error is returned if unit is not a valid time unit.
+// $dateAdd requires MongoDB 5.0 or later.
+//
+// Example usage:
+//
+//	expr, err := mongo.DateAdd("$purchaseDate", "day", 3, "")
+//	stage := bson.D{{"$set", bson.D{{"expectedDeliveryDate", expr}}}}
+func DateAdd(startDateExpr interface{}, unit string, amount interface{}, timezone string) (bson.D, error) {
+	if err := validateDateUnit("$dateAdd", unit); err != nil {
+		return nil, err
+	}
+
+	args := bson.D{{"startDate", startDateExpr}, {"unit", unit}, {"amount", amount}}
+	if timezone != "" {
+		args = append(args, bson.E{"timezone", timezone})
+	}
+	return bson.D{{"$dateAdd", args}}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+var testDateUnits = []string{"year", "quarter", "month", "week", "day", "hour", "minute", "second", "millisecond"}
+
+func TestDateTrunc(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		unit     string
+		binSize  int64
+		timezone string
+		want     string
+		wantErr  bool
+	}
+	testCases := []testCase{
+		{
+			name:     "bin size and timezone",
+			unit:     "hour",
+			binSize:  6,
+			timezone: "America/New_York",
+			want: `{"$dateTrunc": {"date": "$orderDate","unit": "hour","binSize": {"$numberLong":"6"},` +
+				`"timezone": "America/New_York"}}`,
+		},
+		{name: "invalid unit", unit: "days", wantErr: true},
+		{name: "empty unit", unit: "", wantErr: true},
+		{name: "negative bin size", unit: "day", binSize: -1, wantErr: true},
+	}
+	for _, unit := range testDateUnits {
+		testCases = append(testCases, testCase{
+			name: unit,
+			unit: unit,
+			want: `{"$dateTrunc": {"date": "$orderDate","unit": "` + unit + `"}}`,
+		})
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DateTrunc("$orderDate", tc.unit, tc.binSize, tc.timezone)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected DateTrunc error, got nil")
+				return
+			}
+			assert.Nil(t, err, "DateTrunc error: %v", err)
+
+			raw, err := bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.want, bson.Raw(raw).String(), "expected expression %v, got %v", tc.want, bson.Raw(raw))
+		})
+	}
+}
+
+func TestDateAdd(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		unit     string
+		amount   interface{}
+		timezone string
+		want     string
+		wantErr  bool
+	}
+	testCases := []testCase{
+		{
+			name:     "timezone",
+			unit:     "day",
+			amount:   int32(3),
+			timezone: "Europe/Paris",
+			want: `{"$dateAdd": {"startDate": "$purchaseDate","unit": "day","amount": {"$numberInt":"3"},` +
+				`"timezone": "Europe/Paris"}}`,
+		},
+		{
+			name:   "amount expression",
+			unit:   "month",
+			amount: bson.D{{"$multiply", bson.A{"$years", 12}}},
+			want: `{"$dateAdd": {"startDate": "$purchaseDate","unit": "month",` +
+				`"amount": {"$multiply": ["$years",{"$numberInt":"12"}]}}}`,
+		},
+		{
+			name:   "negative amount",
+			unit:   "week",
+			amount: int64(-2),
+			want:   `{"$dateAdd": {"startDate": "$purchaseDate","unit": "week","amount": {"$numberLong":"-2"}}}`,
+		},
+		{name: "invalid unit", unit: "fortnight", amount: 1, wantErr: true},
+	}
+	for _, unit := range testDateUnits {
+		testCases = append(testCases, testCase{
+			name:   unit,
+			unit:   unit,
+			amount: int32(1),
+			want:   `{"$dateAdd": {"startDate": "$purchaseDate","unit": "` + unit + `","amount": {"$numberInt":"1"}}}`,
+		})
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DateAdd("$purchaseDate", tc.unit, tc.amount, tc.timezone)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected DateAdd error, got nil")
+				return
+			}
+			assert.Nil(t, err, "DateAdd error: %v", err)
+
+			raw, err := bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.want, bson.Raw(raw).String(), "expected expression %v, got %v", tc.want, bson.Raw(raw))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DensifyRange represents the range of a $densify aggregation stage. A numeric range has an empty Unit and a numeric
+// Step, Lower, and Upper. A date range has a time unit, an integer Step, and Lower and Upper values of type time.Time
+// or primitive.DateTime.
+type DensifyRange struct {
+	// The amount to increment the field by for each new document. It must be a positive number of type int, int32,
+	// int64, or float64, and an integer if Unit is set. This field is required.
+	Step interface{}
+
+	// The time unit of Step for a date range. It must be one of "year", "quarter", "month", "week", "day", "hour",
+	// "minute", "second", or "millisecond". The default value is "", which means the range is numeric.
+	Unit string
+
+	// Bounds is "full" to densify over the range from the minimum to the maximum value of the field across all
+	// documents, or "partition" to densify each partition over the range of its own documents. It must be empty if
+	// Lower and Upper are set.
+	Bounds string
+
+	// The inclusive lower and exclusive upper bound of the range of values to densify. Both must be set if Bounds is
+	// empty, and Lower must be less than Upper.
+	Lower interface{}
+	Upper interface{}
+}
+
+// Densify returns a $densify aggregation stage that creates documents for the missing values of field in rng, which
+// is numeric or contains dates. If partitionBy is not empty, the documents are grouped by the given fields and each
+// group is densified separately; the new documents also contain the fields of their group. An error is returned if
+// field is empty or one of the partitionBy fields, or if rng is not a valid numeric or date range. $densify requires
+// MongoDB 5.1 or later.
+//
+// Example usage:
+//
+//	stage, err := mongo.Densify("timestamp", mongo.DensifyRange{
+//		Step:   1,
+//		Unit:   "hour",
+//		Bounds: "partition",
+//	}, []string{"sensorId"})
+//
+// For more information about the $densify stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/densify/.
+func Densify(field string, rng DensifyRange, partitionBy []string) (bson.D, error) {
+	if field == "" || strings.HasPrefix(field, "$") {
+		return nil, fmt.Errorf("$densify field must be a non-empty field path without a leading \"$\", got %q", field)
+	}
+	for _, p := range partitionBy {
+		if p == "" {
+			return nil, errors.New("$densify partitionBy fields must not be empty")
+		}
+		if p == field {
+			return nil, fmt.Errorf("$densify field %q must not be a partitionBy field", field)
+		}
+	}
+
+	rangeDoc, err := densifyRange(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	densify := bson.D{{"field", field}}
+	if len(partitionBy) > 0 {
+		densify = append(densify, bson.E{"partitionByFields", partitionBy})
+	}
+	densify = append(densify, bson.E{"range", rangeDoc})
+	return bson.D{{"$densify", densify}}, nil
+}
+
+func densifyRange(rng DensifyRange) (bson.D, error) {
+	step, ok := numberValue(rng.Step)
+	if !ok {
+		return nil, fmt.Errorf("$densify step must be a number, got %T", rng.Step)
+	}
+	if math.IsNaN(step) || step <= 0 {
+		return nil, fmt.Errorf("$densify step must be positive, got %v", rng.Step)
+	}
+	if rng.Unit != "" {
+		if err := validateDateUnit("$densify", rng.Unit); err != nil {
+			return nil, err
+		}
+		if step != math.Trunc(step) {
+			return nil, fmt.Errorf("$densify step must be an integer for a date range, got %v", rng.Step)
+		}
+	}
+
+	var bounds interface{}
+	switch {
+	case rng.Bounds != "":
+		if rng.Bounds != "full" && rng.Bounds != "partition" {
+			return nil, fmt.Errorf("$densify bounds must be \"full\" or \"partition\", got %q", rng.Bounds)
+		}
+		if rng.Lower != nil || rng.Upper != nil {
+			return nil, errors.New("$densify lower and upper bounds cannot be set with \"full\" or \"partition\" bounds")
+		}
+		bounds = rng.Bounds
+	case rng.Lower == nil || rng.Upper == nil:
+		return nil, errors.New("$densify range must specify bounds or both a lower and an upper bound")
+	case rng.Unit != "":
+		lower, lowerOK := dateValue(rng.Lower)
+		upper, upperOK := dateValue(rng.Upper)
+		if !lowerOK || !upperOK {
+			return nil, fmt.Errorf("$densify bounds of a date range must be dates, got %T and %T", rng.Lower, rng.Upper)
+		}
+		if !lower.Before(upper) {
+			return nil, fmt.Errorf("$densify lower bound %v must be before upper bound %v", lower, upper)
+		}
+		bounds = bson.A{rng.Lower, rng.Upper}
+	default:
+		lower, lowerOK := numberValue(rng.Lower)
+		upper, upperOK := numberValue(rng.Upper)
+		if !lowerOK || !upperOK {
+			return nil, fmt.Errorf("$densify bounds of a numeric range must be numbers, got %T and %T", rng.Lower,
+				rng.Upper)
+		}
+		if !(lower < upper) {
+			return nil, fmt.Errorf("$densify lower bound %v must be less than upper bound %v", rng.Lower, rng.Upper)
+		}
+		bounds = bson.A{rng.Lower, rng.Upper}
+	}
+
+	rangeDoc := bson.D{{"step", rng.Step}}
+	if rng.Unit != "" {
+		rangeDoc = append(rangeDoc, bson.E{"unit", rng.Unit})
+	}
+	return append(rangeDoc, bson.E{"bounds", bounds}), nil
+}
+
+// numberValue returns v as a float64 if it is an int, int32, int64, or float64.
+func numberValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// dateValue returns v as a time.Time if it is a time.Time or a primitive.DateTime.
+func dateValue(v interface{}) (time.Time, bool) {
+	switch d := v.(type) {
+	case time.Time:
+		return d, true
+	case primitive.DateTime:
+		return d.Time(), true
+	}
+	return time.Time{}, false
+}
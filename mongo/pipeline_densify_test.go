@@ -0,0 +1,155 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestDensify(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		field       string
+		rng         DensifyRange
+		partitionBy []string
+		want        bson.D
+		wantErr     bool
+	}{
+		{
+			name:  "numeric with explicit bounds",
+			field: "altitude",
+			rng:   DensifyRange{Step: 200, Lower: 0, Upper: 1000},
+			want: bson.D{{"$densify", bson.D{
+				{"field", "altitude"},
+				{"range", bson.D{{"step", 200}, {"bounds", bson.A{0, 1000}}}},
+			}}},
+		},
+		{
+			name:        "numeric with partition bounds",
+			field:       "altitude",
+			rng:         DensifyRange{Step: 0.5, Bounds: "partition"},
+			partitionBy: []string{"variety"},
+			want: bson.D{{"$densify", bson.D{
+				{"field", "altitude"},
+				{"partitionByFields", []string{"variety"}},
+				{"range", bson.D{{"step", 0.5}, {"bounds", "partition"}}},
+			}}},
+		},
+		{
+			name:  "date with explicit bounds",
+			field: "timestamp",
+			rng:   DensifyRange{Step: int32(1), Unit: "hour", Lower: start, Upper: end},
+			want: bson.D{{"$densify", bson.D{
+				{"field", "timestamp"},
+				{"range", bson.D{{"step", int32(1)}, {"unit", "hour"}, {"bounds", bson.A{start, end}}}},
+			}}},
+		},
+		{
+			name:        "date with full bounds",
+			field:       "timestamp",
+			rng:         DensifyRange{Step: int64(15), Unit: "minute", Bounds: "full"},
+			partitionBy: []string{"sensorId", "site"},
+			want: bson.D{{"$densify", bson.D{
+				{"field", "timestamp"},
+				{"partitionByFields", []string{"sensorId", "site"}},
+				{"range", bson.D{{"step", int64(15)}, {"unit", "minute"}, {"bounds", "full"}}},
+			}}},
+		},
+		{
+			name:  "date with DateTime bounds",
+			field: "timestamp",
+			rng: DensifyRange{
+				Step:  1,
+				Unit:  "day",
+				Lower: primitive.NewDateTimeFromTime(start),
+				Upper: primitive.NewDateTimeFromTime(end),
+			},
+			want: bson.D{{"$densify", bson.D{
+				{"field", "timestamp"},
+				{"range", bson.D{{"step", 1}, {"unit", "day"}, {"bounds", bson.A{
+					primitive.NewDateTimeFromTime(start),
+					primitive.NewDateTimeFromTime(end),
+				}}}},
+			}}},
+		},
+		{name: "empty field", field: "", rng: DensifyRange{Step: 1, Bounds: "full"}, wantErr: true},
+		{name: "field path with $", field: "$altitude", rng: DensifyRange{Step: 1, Bounds: "full"}, wantErr: true},
+		{
+			name:        "field in partitionBy",
+			field:       "altitude",
+			rng:         DensifyRange{Step: 1, Bounds: "full"},
+			partitionBy: []string{"altitude"},
+			wantErr:     true,
+		},
+		{
+			name:        "empty partitionBy field",
+			field:       "altitude",
+			rng:         DensifyRange{Step: 1, Bounds: "full"},
+			partitionBy: []string{""},
+			wantErr:     true,
+		},
+		{name: "missing step", field: "altitude", rng: DensifyRange{Bounds: "full"}, wantErr: true},
+		{name: "string step", field: "altitude", rng: DensifyRange{Step: "1", Bounds: "full"}, wantErr: true},
+		{name: "zero step", field: "altitude", rng: DensifyRange{Step: 0, Bounds: "full"}, wantErr: true},
+		{name: "negative step", field: "altitude", rng: DensifyRange{Step: -1.5, Bounds: "full"}, wantErr: true},
+		{name: "invalid bounds", field: "altitude", rng: DensifyRange{Step: 1, Bounds: "all"}, wantErr: true},
+		{name: "missing bounds", field: "altitude", rng: DensifyRange{Step: 1}, wantErr: true},
+		{name: "missing upper bound", field: "altitude", rng: DensifyRange{Step: 1, Lower: 0}, wantErr: true},
+		{
+			name:    "bounds and explicit bounds",
+			field:   "altitude",
+			rng:     DensifyRange{Step: 1, Bounds: "full", Lower: 0, Upper: 10},
+			wantErr: true,
+		},
+		{name: "numeric lower above upper", field: "altitude", rng: DensifyRange{Step: 1, Lower: 10, Upper: 0}, wantErr: true},
+		{name: "numeric date bounds", field: "altitude", rng: DensifyRange{Step: 1, Lower: start, Upper: end}, wantErr: true},
+		{name: "invalid unit", field: "timestamp", rng: DensifyRange{Step: 1, Unit: "hours", Bounds: "full"}, wantErr: true},
+		{
+			name:    "fractional date step",
+			field:   "timestamp",
+			rng:     DensifyRange{Step: 1.5, Unit: "hour", Bounds: "full"},
+			wantErr: true,
+		},
+		{
+			name:    "date lower after upper",
+			field:   "timestamp",
+			rng:     DensifyRange{Step: 1, Unit: "hour", Lower: end, Upper: start},
+			wantErr: true,
+		},
+		{
+			name:    "date numeric bounds",
+			field:   "timestamp",
+			rng:     DensifyRange{Step: 1, Unit: "hour", Lower: 0, Upper: 10},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Densify(tc.field, tc.rng, tc.partitionBy)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Densify error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Densify error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+		})
+	}
+}
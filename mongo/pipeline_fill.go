@@ -0,0 +1,114 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FillOutput specifies how a $fill aggregation stage fills the missing and null values of a field. Exactly one of
+// Value and Method must be set.
+type FillOutput struct {
+	// An expression whose result is used for the missing values, e.g. 0 or "$defaultPrice".
+	Value interface{}
+
+	// The method used to compute the missing values from the surrounding documents in the sort order: "linear" for
+	// linear interpolation or "locf" to carry the last observed value forward.
+	Method string
+}
+
+// FillOptions represents the fields of a $fill aggregation stage.
+type FillOptions struct {
+	// An expression that groups the documents into partitions that are filled separately. It cannot be set together
+	// with PartitionByFields. The default value is nil, which means all documents are in a single partition.
+	PartitionBy interface{}
+
+	// The fields that group the documents into partitions that are filled separately. It cannot be set together with
+	// PartitionBy. The default value is nil, which means all documents are in a single partition.
+	PartitionByFields []string
+
+	// The sort order of the documents in each partition. It is required if an output field uses a Method.
+	SortBy bson.D
+
+	// The fields to fill, mapped to how their missing values are filled. At least one field is required.
+	Output map[string]FillOutput
+}
+
+// Fill returns a $fill aggregation stage that populates the missing and null values of the fields in opts.Output. The
+// output fields are emitted in sorted order so that the resulting stage is deterministic. An error is returned if
+// opts.Output is empty, if an output does not set exactly one of Value and Method, if a Method is not "linear" or
+// "locf", if a Method is used without SortBy, or if both PartitionBy and PartitionByFields are set. $fill requires
+// MongoDB 5.3 or later.
+//
+// Example usage:
+//
+//	stage, err := mongo.Fill(mongo.FillOptions{
+//		PartitionByFields: []string{"sensorId"},
+//		SortBy:            bson.D{{"timestamp", 1}},
+//		Output: map[string]mongo.FillOutput{
+//			"temperature": {Method: "linear"},
+//			"status":      {Value: "unknown"},
+//		},
+//	})
+//
+// For more information about the $fill stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/fill/.
+func Fill(opts FillOptions) (bson.D, error) {
+	if len(opts.Output) == 0 {
+		return nil, errors.New("$fill stage must specify at least one output field")
+	}
+	if opts.PartitionBy != nil && len(opts.PartitionByFields) > 0 {
+		return nil, errors.New("$fill partitionBy and partitionByFields cannot both be set")
+	}
+
+	fields := make([]string, 0, len(opts.Output))
+	for field := range opts.Output {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	output := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		if field == "" {
+			return nil, errors.New("$fill output field must not be empty")
+		}
+		out := opts.Output[field]
+		switch {
+		case out.Value != nil && out.Method != "":
+			return nil, fmt.Errorf("$fill output field %q cannot set both a value and a method", field)
+		case out.Value != nil:
+			output = append(output, bson.E{field, bson.D{{"value", out.Value}}})
+		case out.Method == "linear" || out.Method == "locf":
+			if len(opts.SortBy) == 0 {
+				return nil, fmt.Errorf("$fill output field %q uses method %q, which requires sortBy", field,
+					out.Method)
+			}
+			output = append(output, bson.E{field, bson.D{{"method", out.Method}}})
+		case out.Method == "":
+			return nil, fmt.Errorf("$fill output field %q must set a value or a method", field)
+		default:
+			return nil, fmt.Errorf("$fill method must be \"linear\" or \"locf\", got %q", out.Method)
+		}
+	}
+
+	var fill bson.D
+	if opts.PartitionBy != nil {
+		fill = append(fill, bson.E{"partitionBy", opts.PartitionBy})
+	}
+	if len(opts.PartitionByFields) > 0 {
+		fill = append(fill, bson.E{"partitionByFields", opts.PartitionByFields})
+	}
+	if len(opts.SortBy) > 0 {
+		fill = append(fill, bson.E{"sortBy", opts.SortBy})
+	}
+	fill = append(fill, bson.E{"output", output})
+	return bson.D{{"$fill", fill}}, nil
+}
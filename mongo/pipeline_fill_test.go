@@ -0,0 +1,119 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestFill(t *testing.T) {
+	t.Parallel()
+
+	sortBy := bson.D{{"timestamp", 1}}
+
+	testCases := []struct {
+		name    string
+		opts    FillOptions
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name: "value",
+			opts: FillOptions{Output: map[string]FillOutput{"status": {Value: "unknown"}}},
+			want: bson.D{{"$fill", bson.D{
+				{"output", bson.D{{"status", bson.D{{"value", "unknown"}}}}},
+			}}},
+		},
+		{
+			name: "methods and values in sorted order",
+			opts: FillOptions{
+				PartitionByFields: []string{"sensorId"},
+				SortBy:            sortBy,
+				Output: map[string]FillOutput{
+					"temperature": {Method: "linear"},
+					"humidity":    {Method: "locf"},
+					"status":      {Value: "$defaultStatus"},
+				},
+			},
+			want: bson.D{{"$fill", bson.D{
+				{"partitionByFields", []string{"sensorId"}},
+				{"sortBy", sortBy},
+				{"output", bson.D{
+					{"humidity", bson.D{{"method", "locf"}}},
+					{"status", bson.D{{"value", "$defaultStatus"}}},
+					{"temperature", bson.D{{"method", "linear"}}},
+				}},
+			}}},
+		},
+		{
+			name: "partitionBy expression",
+			opts: FillOptions{
+				PartitionBy: bson.D{{"sensor", "$sensorId"}},
+				SortBy:      sortBy,
+				Output:      map[string]FillOutput{"temperature": {Method: "locf"}},
+			},
+			want: bson.D{{"$fill", bson.D{
+				{"partitionBy", bson.D{{"sensor", "$sensorId"}}},
+				{"sortBy", sortBy},
+				{"output", bson.D{{"temperature", bson.D{{"method", "locf"}}}}},
+			}}},
+		},
+		{name: "no output", opts: FillOptions{SortBy: sortBy}, wantErr: true},
+		{
+			name: "both partitionBy and partitionByFields",
+			opts: FillOptions{
+				PartitionBy:       "$sensorId",
+				PartitionByFields: []string{"sensorId"},
+				Output:            map[string]FillOutput{"status": {Value: 0}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "value and method",
+			opts:    FillOptions{SortBy: sortBy, Output: map[string]FillOutput{"t": {Value: 0, Method: "locf"}}},
+			wantErr: true,
+		},
+		{
+			name:    "neither value nor method",
+			opts:    FillOptions{SortBy: sortBy, Output: map[string]FillOutput{"t": {}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid method",
+			opts:    FillOptions{SortBy: sortBy, Output: map[string]FillOutput{"t": {Method: "spline"}}},
+			wantErr: true,
+		},
+		{
+			name:    "method without sortBy",
+			opts:    FillOptions{Output: map[string]FillOutput{"t": {Method: "linear"}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty output field",
+			opts:    FillOptions{Output: map[string]FillOutput{"": {Value: 0}}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Fill(tc.opts)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Fill error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Fill error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GeoJSONPoint returns a GeoJSON Point document for the given longitude and latitude, in that order. An error is
+// returned if the longitude is not between -180 and 180 or the latitude is not between -90 and 90.
+//
+// For more information about GeoJSON objects, see https://www.mongodb.com/docs/manual/reference/geojson/.
+func GeoJSONPoint(lng, lat float64) (bson.D, error) {
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("longitude %v must be between -180 and 180", lng)
+	}
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("latitude %v must be between -90 and 90", lat)
+	}
+	return bson.D{{"type", "Point"}, {"coordinates", bson.A{lng, lat}}}, nil
+}
+
+// Near returns a filter that matches documents whose GeoJSON location in the path field is within maxMeters meters
+// of the point at the given longitude and latitude, sorted from nearest to farthest. If maxMeters is 0, the distance
+// is not limited. The path field must have a 2dsphere index. An error is returned if path is empty, if the
+// coordinates are out of range, or if maxMeters is negative.
+//
+// Example usage:
+//
+//	filter, err := mongo.Near("location", -73.9667, 40.78, 1000)
+//
+// For more information about the $near operator, see https://www.mongodb.com/docs/manual/reference/operator/query/near/.
+func Near(path string, lng, lat float64, maxMeters float64) (bson.D, error) {
+	if path == "" {
+		return nil, errors.New("$near filter must specify a field path")
+	}
+	point, err := GeoJSONPoint(lng, lat)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(maxMeters) || maxMeters < 0 {
+		return nil, fmt.Errorf("$near maximum distance %v must not be negative", maxMeters)
+	}
+
+	near := bson.D{{"$geometry", point}}
+	if maxMeters > 0 {
+		near = append(near, bson.E{"$maxDistance", maxMeters})
+	}
+	return bson.D{{path, bson.D{{"$near", near}}}}, nil
+}
+
+// GeoNearOptions represents the fields of a $geoNear aggregation stage that uses a GeoJSON point. Distances are in
+// meters.
+type GeoNearOptions struct {
+	// The longitude of the point for which to find the closest documents. This field is required.
+	Longitude float64
+
+	// The latitude of the point for which to find the closest documents. This field is required.
+	Latitude float64
+
+	// The output field that contains the calculated distance. Use dot notation to specify an embedded document. This
+	// field is required.
+	DistanceField string
+
+	// The geospatial indexed field to use when calculating the distance. This must be set if the collection has more
+	// than one 2dsphere index. The default value is "", which means the only 2dsphere index is used.
+	Key string
+
+	// The maximum distance from the point. The default value is nil, which means the distance is not limited.
+	MaxDistance *float64
+
+	// The minimum distance from the point. The default value is nil, which means there is no minimum distance.
+	MinDistance *float64
+
+	// A filter that the documents must also match. It cannot contain a $near predicate. The default value is nil,
+	// which means no additional filter is applied.
+	Query interface{}
+
+	// The output field that contains the location used to calculate the distance. The default value is "", which
+	// means the location is not included.
+	IncludeLocs string
+
+	// The factor to multiply all distances by, e.g. 0.001 to return distances in kilometers. The default value is
+	// nil, which means distances are not multiplied.
+	DistanceMultiplier *float64
+}
+
+// GeoNearStage returns a $geoNear aggregation stage that outputs documents in order from nearest to farthest from a
+// GeoJSON point. The stage must be the first stage of the pipeline. An error is returned if opts is nil, if
+// DistanceField is empty, if the coordinates are out of range, or if the distances are negative or MinDistance is
+// greater than MaxDistance.
+//
+// Example usage:
+//
+//	maxDistance := 2.0
+//	stage, err := mongo.GeoNearStage(&mongo.GeoNearOptions{
+//		Longitude:     -73.99279,
+//		Latitude:      40.719296,
+//		DistanceField: "dist.calculated",
+//		MaxDistance:   &maxDistance,
+//		Query:         bson.D{{"category", "Parks"}},
+//		IncludeLocs:   "dist.location",
+//	})
+//
+// For more information about the $geoNear stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/geoNear/.
+func GeoNearStage(opts *GeoNearOptions) (bson.D, error) {
+	if opts == nil {
+		return nil, errors.New("$geoNear stage options must not be nil")
+	}
+	if opts.DistanceField == "" {
+		return nil, errors.New("$geoNear stage must specify a distance field")
+	}
+	point, err := GeoJSONPoint(opts.Longitude, opts.Latitude)
+	if err != nil {
+		return nil, err
+	}
+	if d := opts.MaxDistance; d != nil && (math.IsNaN(*d) || *d < 0) {
+		return nil, fmt.Errorf("$geoNear maxDistance %v must not be negative", *d)
+	}
+	if d := opts.MinDistance; d != nil && (math.IsNaN(*d) || *d < 0) {
+		return nil, fmt.Errorf("$geoNear minDistance %v must not be negative", *d)
+	}
+	if opts.MaxDistance != nil && opts.MinDistance != nil && *opts.MinDistance > *opts.MaxDistance {
+		return nil, fmt.Errorf("$geoNear minDistance %v must not be greater than maxDistance %v",
+			*opts.MinDistance, *opts.MaxDistance)
+	}
+
+	geoNear := bson.D{
+		{"near", point},
+		{"distanceField", opts.DistanceField},
+		{"spherical", true},
+	}
+	if opts.Key != "" {
+		geoNear = append(geoNear, bson.E{"key", opts.Key})
+	}
+	if opts.MaxDistance != nil {
+		geoNear = append(geoNear, bson.E{"maxDistance", *opts.MaxDistance})
+	}
+	if opts.MinDistance != nil {
+		geoNear = append(geoNear, bson.E{"minDistance", *opts.MinDistance})
+	}
+	if opts.Query != nil {
+		geoNear = append(geoNear, bson.E{"query", opts.Query})
+	}
+	if opts.IncludeLocs != "" {
+		geoNear = append(geoNear, bson.E{"includeLocs", opts.IncludeLocs})
+	}
+	if opts.DistanceMultiplier != nil {
+		geoNear = append(geoNear, bson.E{"distanceMultiplier", *opts.DistanceMultiplier})
+	}
+	return bson.D{{"$geoNear", geoNear}}, nil
+}
@@ -0,0 +1,254 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestGeoJSONPoint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		lng, lat float64
+		want     bson.D
+		wantErr  bool
+	}{
+		{
+			name: "point",
+			lng:  -73.9667,
+			lat:  40.78,
+			want: bson.D{{"type", "Point"}, {"coordinates", bson.A{-73.9667, 40.78}}},
+		},
+		{
+			name: "bounds",
+			lng:  180,
+			lat:  -90,
+			want: bson.D{{"type", "Point"}, {"coordinates", bson.A{180.0, -90.0}}},
+		},
+		{
+			name:    "longitude too small",
+			lng:     -180.5,
+			lat:     0,
+			wantErr: true,
+		},
+		{
+			name:    "latitude too large",
+			lng:     0,
+			lat:     90.5,
+			wantErr: true,
+		},
+		{
+			// A common mistake is to pass latitude first.
+			name:    "swapped coordinates",
+			lng:     40.78,
+			lat:     -173.9667,
+			wantErr: true,
+		},
+		{
+			name:    "NaN",
+			lng:     math.NaN(),
+			lat:     0,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := GeoJSONPoint(tc.lng, tc.lat)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected GeoJSONPoint error, got nil")
+				return
+			}
+			assert.Nil(t, err, "GeoJSONPoint error: %v", err)
+			assert.Equal(t, tc.want, got, "expected point %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestNear(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		path      string
+		lng, lat  float64
+		maxMeters float64
+		want      bson.D
+		wantErr   bool
+	}{
+		{
+			// Example from https://www.mongodb.com/docs/manual/reference/operator/query/near/.
+			name:      "max distance",
+			path:      "location",
+			lng:       -73.9667,
+			lat:       40.78,
+			maxMeters: 1000,
+			want: bson.D{{"location", bson.D{{"$near", bson.D{
+				{"$geometry", bson.D{{"type", "Point"}, {"coordinates", bson.A{-73.9667, 40.78}}}},
+				{"$maxDistance", 1000.0},
+			}}}}},
+		},
+		{
+			name: "no max distance",
+			path: "address.location",
+			lng:  2.2945,
+			lat:  48.8584,
+			want: bson.D{{"address.location", bson.D{{"$near", bson.D{
+				{"$geometry", bson.D{{"type", "Point"}, {"coordinates", bson.A{2.2945, 48.8584}}}},
+			}}}}},
+		},
+		{
+			name:    "empty path",
+			lng:     0,
+			lat:     0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid coordinates",
+			path:    "location",
+			lng:     200,
+			lat:     0,
+			wantErr: true,
+		},
+		{
+			name:      "negative max distance",
+			path:      "location",
+			maxMeters: -1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Near(tc.path, tc.lng, tc.lat, tc.maxMeters)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Near error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Near error: %v", err)
+
+			assert.Equal(t, tc.want, got, "expected filter %v, got %v", tc.want, got)
+
+			_, err = bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
+
+func TestGeoNearStage(t *testing.T) {
+	t.Parallel()
+
+	float64Ptr := func(f float64) *float64 { return &f }
+	point := bson.D{{"type", "Point"}, {"coordinates", bson.A{-73.99279, 40.719296}}}
+
+	testCases := []struct {
+		name    string
+		opts    *GeoNearOptions
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name: "required fields",
+			opts: &GeoNearOptions{Longitude: -73.99279, Latitude: 40.719296, DistanceField: "dist"},
+			want: bson.D{{"$geoNear", bson.D{
+				{"near", point},
+				{"distanceField", "dist"},
+				{"spherical", true},
+			}}},
+		},
+		{
+			// Example from https://www.mongodb.com/docs/manual/reference/operator/aggregation/geoNear/.
+			name: "all fields",
+			opts: &GeoNearOptions{
+				Longitude:          -73.99279,
+				Latitude:           40.719296,
+				DistanceField:      "dist.calculated",
+				Key:                "location",
+				MaxDistance:        float64Ptr(2),
+				MinDistance:        float64Ptr(1),
+				Query:              bson.D{{"category", "Parks"}},
+				IncludeLocs:        "dist.location",
+				DistanceMultiplier: float64Ptr(0.001),
+			},
+			want: bson.D{{"$geoNear", bson.D{
+				{"near", point},
+				{"distanceField", "dist.calculated"},
+				{"spherical", true},
+				{"key", "location"},
+				{"maxDistance", 2.0},
+				{"minDistance", 1.0},
+				{"query", bson.D{{"category", "Parks"}}},
+				{"includeLocs", "dist.location"},
+				{"distanceMultiplier", 0.001},
+			}}},
+		},
+		{
+			name:    "nil options",
+			wantErr: true,
+		},
+		{
+			name:    "missing distance field",
+			opts:    &GeoNearOptions{Longitude: -73.99279, Latitude: 40.719296},
+			wantErr: true,
+		},
+		{
+			name:    "invalid coordinates",
+			opts:    &GeoNearOptions{Longitude: -73.99279, Latitude: 140.719296, DistanceField: "dist"},
+			wantErr: true,
+		},
+		{
+			name: "negative max distance",
+			opts: &GeoNearOptions{
+				DistanceField: "dist",
+				MaxDistance:   float64Ptr(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "min distance greater than max distance",
+			opts: &GeoNearOptions{
+				DistanceField: "dist",
+				MaxDistance:   float64Ptr(1),
+				MinDistance:   float64Ptr(2),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := GeoNearStage(tc.opts)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected GeoNearStage error, got nil")
+				return
+			}
+			assert.Nil(t, err, "GeoNearStage error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+
+			_, err = bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
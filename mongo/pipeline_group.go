@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Group returns a $group aggregation stage that groups documents by the id expression and computes a field for each
+// entry in accumulators. The accumulators are emitted in sorted order of their field names so the resulting stage is
+// deterministic. An "_id" entry in accumulators is ignored because the group key is always specified by id.
+//
+// Example usage:
+//
+//	stage := mongo.Group("$customer", map[string]bson.D{
+//		"total":   mongo.Sum("$amount"),
+//		"average": mongo.Avg("$amount"),
+//	})
+func Group(id interface{}, accumulators map[string]bson.D) bson.D {
+	fields := make([]string, 0, len(accumulators))
+	for field := range accumulators {
+		if field == "_id" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	group := make(bson.D, 0, len(fields)+1)
+	group = append(group, bson.E{"_id", id})
+	for _, field := range fields {
+		group = append(group, bson.E{field, accumulators[field]})
+	}
+	return bson.D{{"$group", group}}
+}
+
+// Sum returns a $sum accumulator for the given expression.
+func Sum(expr interface{}) bson.D {
+	return bson.D{{"$sum", expr}}
+}
+
+// Avg returns an $avg accumulator for the given expression.
+func Avg(expr interface{}) bson.D {
+	return bson.D{{"$avg", expr}}
+}
+
+// Push returns a $push accumulator for the given expression.
+func Push(expr interface{}) bson.D {
+	return bson.D{{"$push", expr}}
+}
+
+// First returns a $first accumulator for the given expression.
+func First(expr interface{}) bson.D {
+	return bson.D{{"$first", expr}}
+}
@@ -0,0 +1,96 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		id           interface{}
+		accumulators map[string]bson.D
+		want         bson.D
+	}{
+		{
+			name: "no accumulators",
+			id:   "$item",
+			want: bson.D{{"$group", bson.D{{"_id", "$item"}}}},
+		},
+		{
+			name:         "null id",
+			id:           nil,
+			accumulators: map[string]bson.D{"count": Sum(1)},
+			want:         bson.D{{"$group", bson.D{{"_id", nil}, {"count", bson.D{{"$sum", 1}}}}}},
+		},
+		{
+			name: "sum",
+			id:   "$item",
+			accumulators: map[string]bson.D{
+				"totalSaleAmount": Sum(bson.D{{"$multiply", bson.A{"$price", "$quantity"}}}),
+			},
+			want: bson.D{{"$group", bson.D{
+				{"_id", "$item"},
+				{"totalSaleAmount", bson.D{{"$sum", bson.D{{"$multiply", bson.A{"$price", "$quantity"}}}}}},
+			}}},
+		},
+		{
+			name:         "avg",
+			id:           "$item",
+			accumulators: map[string]bson.D{"avgQuantity": Avg("$quantity")},
+			want:         bson.D{{"$group", bson.D{{"_id", "$item"}, {"avgQuantity", bson.D{{"$avg", "$quantity"}}}}}},
+		},
+		{
+			name:         "push",
+			id:           "$author",
+			accumulators: map[string]bson.D{"books": Push("$title")},
+			want:         bson.D{{"$group", bson.D{{"_id", "$author"}, {"books", bson.D{{"$push", "$title"}}}}}},
+		},
+		{
+			name:         "first",
+			id:           "$item",
+			accumulators: map[string]bson.D{"firstSale": First("$date")},
+			want:         bson.D{{"$group", bson.D{{"_id", "$item"}, {"firstSale", bson.D{{"$first", "$date"}}}}}},
+		},
+		{
+			name: "multiple accumulators are sorted",
+			id:   bson.D{{"day", bson.D{{"$dayOfYear", "$date"}}}},
+			accumulators: map[string]bson.D{
+				"total":   Sum("$amount"),
+				"average": Avg("$amount"),
+				"items":   Push("$item"),
+				"_id":     First("$item"),
+			},
+			want: bson.D{{"$group", bson.D{
+				{"_id", bson.D{{"day", bson.D{{"$dayOfYear", "$date"}}}}},
+				{"average", bson.D{{"$avg", "$amount"}}},
+				{"items", bson.D{{"$push", "$item"}}},
+				{"total", bson.D{{"$sum", "$amount"}}},
+			}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Group(tc.id, tc.accumulators)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+
+			_, err := bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// LookupPipeline returns a $lookup aggregation stage that joins documents from the "from" collection using a
+// sub-pipeline and writes the results to the array field "as". The variables in let can be referenced in the
+// sub-pipeline as "$$<name>". The from collection can be empty if the sub-pipeline starts with a $documents stage, and
+// let can be nil if the sub-pipeline does not use any variables. An error is returned if as is empty.
+//
+// Example usage:
+//
+//	stage, err := mongo.LookupPipeline(
+//		"warehouses",
+//		bson.D{{"orderItem", "$item"}, {"orderQty", "$ordered"}},
+//		mongo.Pipeline{
+//			{{"$match", bson.D{{"$expr", bson.D{{"$and", bson.A{
+//				bson.D{{"$eq", bson.A{"$stockItem", "$$orderItem"}}},
+//				bson.D{{"$gte", bson.A{"$instock", "$$orderQty"}}},
+//			}}}}}}},
+//			{{"$project", bson.D{{"stockItem", 0}, {"_id", 0}}}},
+//		},
+//		"stockdata",
+//	)
+func LookupPipeline(from string, let bson.D, pipeline Pipeline, as string) (bson.D, error) {
+	if as == "" {
+		return nil, errors.New("$lookup stage must specify an output array field")
+	}
+	if pipeline == nil {
+		pipeline = Pipeline{}
+	}
+
+	lookup := make(bson.D, 0, 4)
+	if from != "" {
+		lookup = append(lookup, bson.E{"from", from})
+	}
+	if let != nil {
+		lookup = append(lookup, bson.E{"let", let})
+	}
+	lookup = append(lookup, bson.E{"pipeline", pipeline}, bson.E{"as", as})
+	return bson.D{{"$lookup", lookup}}, nil
+}
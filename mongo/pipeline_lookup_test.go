@@ -0,0 +1,100 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestLookupPipeline(t *testing.T) {
+	t.Parallel()
+
+	let := bson.D{{"orderItem", "$item"}, {"orderQty", "$ordered"}}
+	pipeline := Pipeline{
+		{{"$match", bson.D{{"$expr", bson.D{{"$and", bson.A{
+			bson.D{{"$eq", bson.A{"$stockItem", "$$orderItem"}}},
+			bson.D{{"$gte", bson.A{"$instock", "$$orderQty"}}},
+		}}}}}}},
+		{{"$project", bson.D{{"stockItem", 0}, {"_id", 0}}}},
+	}
+	documents := Pipeline{{{"$documents", bson.A{bson.D{{"x", 1}}}}}}
+
+	testCases := []struct {
+		name     string
+		from     string
+		let      bson.D
+		pipeline Pipeline
+		as       string
+		want     bson.D
+		wantErr  bool
+	}{
+		{
+			name:     "let and sub-pipeline",
+			from:     "warehouses",
+			let:      let,
+			pipeline: pipeline,
+			as:       "stockdata",
+			want: bson.D{{"$lookup", bson.D{
+				{"from", "warehouses"},
+				{"let", let},
+				{"pipeline", pipeline},
+				{"as", "stockdata"},
+			}}},
+		},
+		{
+			name:     "no let",
+			from:     "holidays",
+			pipeline: Pipeline{{{"$match", bson.D{{"year", 2018}}}}},
+			as:       "holidays",
+			want: bson.D{{"$lookup", bson.D{
+				{"from", "holidays"},
+				{"pipeline", Pipeline{{{"$match", bson.D{{"year", 2018}}}}}},
+				{"as", "holidays"},
+			}}},
+		},
+		{
+			name:     "no from with $documents",
+			pipeline: documents,
+			as:       "docs",
+			want:     bson.D{{"$lookup", bson.D{{"pipeline", documents}, {"as", "docs"}}}},
+		},
+		{
+			name: "nil pipeline",
+			from: "coll",
+			as:   "out",
+			want: bson.D{{"$lookup", bson.D{{"from", "coll"}, {"pipeline", Pipeline{}}, {"as", "out"}}}},
+		},
+		{
+			name:     "empty as",
+			from:     "warehouses",
+			pipeline: pipeline,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LookupPipeline(tc.from, tc.let, tc.pipeline, tc.as)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected LookupPipeline error, got nil")
+				return
+			}
+			assert.Nil(t, err, "LookupPipeline error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+
+			_, err = bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MergeWhenMatched specifies the behavior of a $merge stage when a result document matches an existing document in
+// the output collection.
+type MergeWhenMatched string
+
+// These constants are the valid non-pipeline values for the whenMatched field of a $merge stage.
+const (
+	// MergeWhenMatchedReplace replaces the existing document with the result document.
+	MergeWhenMatchedReplace MergeWhenMatched = "replace"
+	// MergeWhenMatchedKeepExisting keeps the existing document.
+	MergeWhenMatchedKeepExisting MergeWhenMatched = "keepExisting"
+	// MergeWhenMatchedMerge merges the result document into the existing document.
+	MergeWhenMatchedMerge MergeWhenMatched = "merge"
+	// MergeWhenMatchedFail stops the aggregation operation.
+	MergeWhenMatchedFail MergeWhenMatched = "fail"
+)
+
+// MergeWhenNotMatched specifies the behavior of a $merge stage when a result document does not match an existing
+// document in the output collection.
+type MergeWhenNotMatched string
+
+// These constants are the valid values for the whenNotMatched field of a $merge stage.
+const (
+	// MergeWhenNotMatchedInsert inserts the result document into the output collection.
+	MergeWhenNotMatchedInsert MergeWhenNotMatched = "insert"
+	// MergeWhenNotMatchedDiscard discards the result document.
+	MergeWhenNotMatchedDiscard MergeWhenNotMatched = "discard"
+	// MergeWhenNotMatchedFail stops the aggregation operation.
+	MergeWhenNotMatchedFail MergeWhenNotMatched = "fail"
+)
+
+// MergeStage is a builder for a $merge aggregation stage. The zero value is not usable; use NewMergeStage to create
+// a MergeStage.
+//
+// Example usage:
+//
+//	stage, err := mongo.NewMergeStage("totals").
+//		SetOn("_id").
+//		SetLet(bson.D{{"newTotal", "$total"}}).
+//		SetWhenMatchedPipeline(mongo.Pipeline{
+//			{{"$set", bson.D{{"total", bson.D{{"$add", bson.A{"$total", "$$newTotal"}}}}}}},
+//		}).
+//		SetWhenNotMatched(mongo.MergeWhenNotMatchedInsert).
+//		Build()
+type MergeStage struct {
+	db             string
+	coll           string
+	on             []string
+	let            interface{}
+	whenMatched    *MergeWhenMatched
+	whenMatchedPl  Pipeline
+	whenNotMatched *MergeWhenNotMatched
+}
+
+// NewMergeStage creates a new MergeStage that writes its results to the collection with the given name.
+func NewMergeStage(coll string) *MergeStage {
+	return &MergeStage{coll: coll}
+}
+
+// SetDatabase sets the database of the output collection. If not set, the database of the aggregation is used.
+func (ms *MergeStage) SetDatabase(db string) *MergeStage {
+	ms.db = db
+	return ms
+}
+
+// SetOn sets the field or fields that act as the unique identifier for a document in the output collection. If not
+// set, the _id field is used.
+func (ms *MergeStage) SetOn(fields ...string) *MergeStage {
+	ms.on = fields
+	return ms
+}
+
+// SetLet sets the variables that are accessible in the whenMatched pipeline. This option can only be used if
+// SetWhenMatchedPipeline is used.
+func (ms *MergeStage) SetLet(let interface{}) *MergeStage {
+	ms.let = let
+	return ms
+}
+
+// SetWhenMatched sets the action to take when a result document matches an existing document. This overrides any
+// previous call to SetWhenMatchedPipeline.
+func (ms *MergeStage) SetWhenMatched(action MergeWhenMatched) *MergeStage {
+	ms.whenMatched = &action
+	ms.whenMatchedPl = nil
+	return ms
+}
+
+// SetWhenMatchedPipeline sets an aggregation pipeline that is used to update the existing document when a result
+// document matches it. The pipeline can only consist of $addFields, $set, $project, $unset, $replaceRoot, and
+// $replaceWith stages. This overrides any previous call to SetWhenMatched.
+func (ms *MergeStage) SetWhenMatchedPipeline(pipeline Pipeline) *MergeStage {
+	ms.whenMatchedPl = pipeline
+	ms.whenMatched = nil
+	return ms
+}
+
+// SetWhenNotMatched sets the action to take when a result document does not match an existing document.
+func (ms *MergeStage) SetWhenNotMatched(action MergeWhenNotMatched) *MergeStage {
+	ms.whenNotMatched = &action
+	return ms
+}
+
+// Build validates the configured options and returns the $merge stage as a document that can be appended to a
+// Pipeline.
+func (ms *MergeStage) Build() (bson.D, error) {
+	if ms.coll == "" {
+		return nil, errors.New("$merge stage must specify an output collection")
+	}
+
+	var into interface{} = ms.coll
+	if ms.db != "" {
+		into = bson.D{{"db", ms.db}, {"coll", ms.coll}}
+	}
+	merge := bson.D{{"into", into}}
+
+	switch len(ms.on) {
+	case 0:
+	case 1:
+		merge = append(merge, bson.E{"on", ms.on[0]})
+	default:
+		merge = append(merge, bson.E{"on", ms.on})
+	}
+
+	if ms.let != nil {
+		if ms.whenMatchedPl == nil {
+			return nil, errors.New("$merge stage let variables can only be used with a whenMatched pipeline")
+		}
+		merge = append(merge, bson.E{"let", ms.let})
+	}
+
+	switch {
+	case ms.whenMatched != nil:
+		switch *ms.whenMatched {
+		case MergeWhenMatchedReplace, MergeWhenMatchedKeepExisting, MergeWhenMatchedMerge, MergeWhenMatchedFail:
+		default:
+			return nil, fmt.Errorf("invalid $merge whenMatched value %q", *ms.whenMatched)
+		}
+		merge = append(merge, bson.E{"whenMatched", string(*ms.whenMatched)})
+	case ms.whenMatchedPl != nil:
+		if len(ms.whenMatchedPl) == 0 {
+			return nil, errors.New("$merge whenMatched pipeline must contain at least one stage")
+		}
+		merge = append(merge, bson.E{"whenMatched", ms.whenMatchedPl})
+	}
+
+	if ms.whenNotMatched != nil {
+		switch *ms.whenNotMatched {
+		case MergeWhenNotMatchedInsert, MergeWhenNotMatchedDiscard, MergeWhenNotMatchedFail:
+		default:
+			return nil, fmt.Errorf("invalid $merge whenNotMatched value %q", *ms.whenNotMatched)
+		}
+		merge = append(merge, bson.E{"whenNotMatched", string(*ms.whenNotMatched)})
+	}
+
+	return bson.D{{"$merge", merge}}, nil
+}
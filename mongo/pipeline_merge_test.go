@@ -0,0 +1,121 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestMergeStage(t *testing.T) {
+	t.Parallel()
+
+	setPipeline := Pipeline{
+		{{"$set", bson.D{{"total", bson.D{{"$add", bson.A{"$total", "$$newTotal"}}}}}}},
+	}
+
+	testCases := []struct {
+		name    string
+		stage   *MergeStage
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name:  "collection only",
+			stage: NewMergeStage("out"),
+			want:  bson.D{{"$merge", bson.D{{"into", "out"}}}},
+		},
+		{
+			name:  "database and collection",
+			stage: NewMergeStage("out").SetDatabase("db"),
+			want:  bson.D{{"$merge", bson.D{{"into", bson.D{{"db", "db"}, {"coll", "out"}}}}}},
+		},
+		{
+			name: "enum form",
+			stage: NewMergeStage("out").
+				SetOn("a").
+				SetWhenMatched(MergeWhenMatchedKeepExisting).
+				SetWhenNotMatched(MergeWhenNotMatchedDiscard),
+			want: bson.D{{"$merge", bson.D{
+				{"into", "out"},
+				{"on", "a"},
+				{"whenMatched", "keepExisting"},
+				{"whenNotMatched", "discard"},
+			}}},
+		},
+		{
+			name: "pipeline form",
+			stage: NewMergeStage("out").
+				SetOn("a", "b").
+				SetLet(bson.D{{"newTotal", "$total"}}).
+				SetWhenMatchedPipeline(setPipeline).
+				SetWhenNotMatched(MergeWhenNotMatchedInsert),
+			want: bson.D{{"$merge", bson.D{
+				{"into", "out"},
+				{"on", []string{"a", "b"}},
+				{"let", bson.D{{"newTotal", "$total"}}},
+				{"whenMatched", setPipeline},
+				{"whenNotMatched", "insert"},
+			}}},
+		},
+		{
+			name: "pipeline overrides enum",
+			stage: NewMergeStage("out").
+				SetWhenMatched(MergeWhenMatchedFail).
+				SetWhenMatchedPipeline(setPipeline),
+			want: bson.D{{"$merge", bson.D{{"into", "out"}, {"whenMatched", setPipeline}}}},
+		},
+		{
+			name:    "missing collection",
+			stage:   NewMergeStage(""),
+			wantErr: true,
+		},
+		{
+			name:    "invalid whenMatched",
+			stage:   NewMergeStage("out").SetWhenMatched("upsert"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid whenNotMatched",
+			stage:   NewMergeStage("out").SetWhenNotMatched("replace"),
+			wantErr: true,
+		},
+		{
+			name:    "empty whenMatched pipeline",
+			stage:   NewMergeStage("out").SetWhenMatchedPipeline(Pipeline{}),
+			wantErr: true,
+		},
+		{
+			name: "let without pipeline",
+			stage: NewMergeStage("out").
+				SetLet(bson.D{{"x", 1}}).
+				SetWhenMatched(MergeWhenMatchedMerge),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.stage.Build()
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Build error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Build error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+
+			_, err = bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ReplaceRoot returns a $replaceRoot aggregation stage that replaces each input document with the document that
+// newRoot evaluates to. newRoot is usually a field path such as "$address" or an expression such as $mergeObjects. An
+// error is returned if newRoot is nil.
+//
+// Example usage:
+//
+//	stage, err := mongo.ReplaceRoot(bson.D{{"$mergeObjects", bson.A{
+//		bson.D{{"_id", "$_id"}, {"first", ""}, {"last", ""}},
+//		"$name",
+//	}}})
+func ReplaceRoot(newRoot interface{}) (bson.D, error) {
+	if newRoot == nil {
+		return nil, errors.New("$replaceRoot stage must specify a newRoot")
+	}
+	return bson.D{{"$replaceRoot", bson.D{{"newRoot", newRoot}}}}, nil
+}
+
+// ReplaceWith returns a $replaceWith aggregation stage, which is an alias for $replaceRoot that takes the replacement
+// expression directly instead of in a newRoot field. The $replaceWith stage requires MongoDB 4.2 or later. An error is
+// returned if expr is nil.
+//
+// Example usage:
+//
+//	stage, err := mongo.ReplaceWith("$address")
+func ReplaceWith(expr interface{}) (bson.D, error) {
+	if expr == nil {
+		return nil, errors.New("$replaceWith stage must specify a replacement document")
+	}
+	return bson.D{{"$replaceWith", expr}}, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestReplaceRoot(t *testing.T) {
+	t.Parallel()
+
+	mergeObjects := bson.D{{"$mergeObjects", bson.A{
+		bson.D{{"dogs", 0}, {"cats", 0}, {"birds", 0}, {"fish", 0}},
+		"$pets",
+	}}}
+
+	testCases := []struct {
+		name    string
+		newRoot interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "field path",
+			newRoot: "$name",
+			want:    `{"$replaceRoot": {"newRoot": "$name"}}`,
+		},
+		{
+			name:    "mergeObjects",
+			newRoot: mergeObjects,
+			want: `{"$replaceRoot": {"newRoot": {"$mergeObjects": [{"dogs": {"$numberInt":"0"},` +
+				`"cats": {"$numberInt":"0"},"birds": {"$numberInt":"0"},"fish": {"$numberInt":"0"}},"$pets"]}}}`,
+		},
+		{
+			name:    "nil newRoot",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReplaceRoot(tc.newRoot)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected ReplaceRoot error, got nil")
+				return
+			}
+			assert.Nil(t, err, "ReplaceRoot error: %v", err)
+
+			raw, err := bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.want, bson.Raw(raw).String(), "expected stage %v, got %v", tc.want, bson.Raw(raw))
+		})
+	}
+}
+
+func TestReplaceWith(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		expr    interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "field path",
+			expr: "$name",
+			want: `{"$replaceWith": "$name"}`,
+		},
+		{
+			name: "mergeObjects",
+			expr: bson.D{{"$mergeObjects", bson.A{bson.D{{"_id", "$name"}}, "$$ROOT"}}},
+			want: `{"$replaceWith": {"$mergeObjects": [{"_id": "$name"},"$$ROOT"]}}`,
+		},
+		{
+			name:    "nil expression",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReplaceWith(tc.expr)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected ReplaceWith error, got nil")
+				return
+			}
+			assert.Nil(t, err, "ReplaceWith error: %v", err)
+
+			raw, err := bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.want, bson.Raw(raw).String(), "expected stage %v, got %v", tc.want, bson.Raw(raw))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Sample returns a $sample aggregation stage that randomly selects size documents from its input. An error is
+// returned if size is not positive.
+//
+// Example usage:
+//
+//	stage, err := mongo.Sample(3)
+//
+// For more information about the $sample stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/sample/.
+func Sample(size int64) (bson.D, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("$sample size must be positive, got %d", size)
+	}
+	return bson.D{{"$sample", bson.D{{"size", size}}}}, nil
+}
+
+// FindRandom returns a cursor over up to n documents chosen at random from the documents in the collection that
+// match filter. It runs an aggregation with the pipeline [{$match: filter}, {$sample: {size: n}}], so the filter is
+// applied before sampling and fewer than n documents are returned if fewer than n documents match. The same document
+// may be returned more than once in some cases, as described in the $sample documentation. An error is returned if n
+// is not positive or filter is nil.
+//
+// The opts parameter can be used to specify options for the aggregation (see the options.AggregateOptions
+// documentation).
+func (coll *Collection) FindRandom(ctx context.Context, filter interface{}, n int64,
+	opts ...*options.AggregateOptions) (*Cursor, error) {
+
+	if filter == nil {
+		return nil, ErrNilDocument
+	}
+	sample, err := Sample(n)
+	if err != nil {
+		return nil, err
+	}
+	return coll.Aggregate(ctx, Pipeline{{{"$match", filter}}, sample}, opts...)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		size    int64
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name: "positive size",
+			size: 3,
+			want: bson.D{{"$sample", bson.D{{"size", int64(3)}}}},
+		},
+		{
+			name:    "zero size",
+			size:    0,
+			wantErr: true,
+		},
+		{
+			name:    "negative size",
+			size:    -1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Sample(tc.size)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected Sample error, got nil")
+				return
+			}
+			assert.Nil(t, err, "Sample error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestFindRandomValidation(t *testing.T) {
+	t.Parallel()
+
+	coll := &Collection{}
+
+	_, err := coll.FindRandom(context.Background(), nil, 1)
+	assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+	_, err = coll.FindRandom(context.Background(), bson.D{}, 0)
+	assert.NotNil(t, err, "expected FindRandom error for zero size, got nil")
+}
@@ -0,0 +1,33 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// UnionWith returns a $unionWith aggregation stage that combines the results of the pipeline with the documents in
+// the coll collection. If pipeline is nil, all documents in coll are included using the {$unionWith: <coll>} form.
+// Otherwise, the documents in coll are first processed by pipeline using the {$unionWith: {coll, pipeline}} form. An
+// error is returned if coll is empty.
+//
+// Example usage:
+//
+//	stage, err := mongo.UnionWith("sales_2019", mongo.Pipeline{
+//		{{"$set", bson.D{{"_id", "2019"}}}},
+//	})
+func UnionWith(coll string, pipeline Pipeline) (bson.D, error) {
+	if coll == "" {
+		return nil, errors.New("$unionWith stage must specify a collection")
+	}
+	if pipeline == nil {
+		return bson.D{{"$unionWith", coll}}, nil
+	}
+	return bson.D{{"$unionWith", bson.D{{"coll", coll}, {"pipeline", pipeline}}}}, nil
+}
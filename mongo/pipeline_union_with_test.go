@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestUnionWith(t *testing.T) {
+	t.Parallel()
+
+	pipeline := Pipeline{
+		{{"$set", bson.D{{"_id", "2019"}}}},
+		{{"$project", bson.D{{"store", 1}, {"item", 1}}}},
+	}
+
+	testCases := []struct {
+		name     string
+		coll     string
+		pipeline Pipeline
+		want     bson.D
+		wantErr  bool
+	}{
+		{
+			name: "collection only",
+			coll: "warehouses",
+			want: bson.D{{"$unionWith", "warehouses"}},
+		},
+		{
+			name:     "collection and pipeline",
+			coll:     "sales_2019",
+			pipeline: pipeline,
+			want:     bson.D{{"$unionWith", bson.D{{"coll", "sales_2019"}, {"pipeline", pipeline}}}},
+		},
+		{
+			name:     "empty pipeline",
+			coll:     "sales_2019",
+			pipeline: Pipeline{},
+			want:     bson.D{{"$unionWith", bson.D{{"coll", "sales_2019"}, {"pipeline", Pipeline{}}}}},
+		},
+		{
+			name:     "empty collection",
+			pipeline: pipeline,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := UnionWith(tc.coll, tc.pipeline)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected UnionWith error, got nil")
+				return
+			}
+			assert.Nil(t, err, "UnionWith error: %v", err)
+			assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+
+			_, err = bson.Marshal(got)
+			assert.Nil(t, err, "Marshal error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// prefetchBatchCursor is a batchCursor that wraps another batchCursor and requests the next batch in a background
+// goroutine as soon as the current batch has been returned by Next. At most one request is in flight at a time and
+// the wrapped batchCursor is never accessed concurrently: every method that touches it first waits for the in-flight
+// request to finish.
+//
+// The background request of the following batch carries the values of the context passed to the Next call that
+// returns a batch, but not its deadline or cancellation, so that a context that is cancelled as soon as Next returns
+// does not fail the prefetch. The request is only canceled by Close or if the context passed to a Next call that is
+// waiting for the prefetched batch expires, in which case the context error is reported by Err.
+type prefetchBatchCursor struct {
+	bc batchCursor
+
+	// State of the wrapped cursor captured after the last completed call to its Next method.
+	batch   *bsoncore.DocumentSequence
+	id      int64
+	err     error
+	partial bool
+
+	// done is closed when the in-flight prefetch completes and is nil if no prefetch has been started. The result
+	// of the prefetch is stored in prefetched and is only safe to read after done is closed.
+	done       chan struct{}
+	cancel     context.CancelFunc
+	prefetched bool
+}
+
+var _ batchCursor = (*prefetchBatchCursor)(nil)
+
+func newPrefetchBatchCursor(bc batchCursor) *prefetchBatchCursor {
+	pc := &prefetchBatchCursor{bc: bc}
+	pc.capture()
+	return pc
+}
+
+// capture copies the state of the wrapped cursor. The batch is copied so that the DocumentSequence handed to the
+// caller is not modified by the next prefetch. The underlying data is freshly allocated for every server response,
+// so it does not need to be copied.
+func (pc *prefetchBatchCursor) capture() {
+	pc.batch = &bsoncore.DocumentSequence{}
+	if batch := pc.bc.Batch(); batch != nil {
+		pc.batch.Style = batch.Style
+		pc.batch.Data = batch.Data
+	}
+	pc.id = pc.bc.ID()
+	pc.err = pc.bc.Err()
+	if prc, ok := pc.bc.(partialResultsCursor); ok {
+		pc.partial = prc.PartialResultsReturned()
+	}
+}
+
+// unwrap returns the wrapped cursor.
+func (pc *prefetchBatchCursor) unwrap() batchCursor {
+	return pc.bc
+}
+
+// ID returns the ID of the cursor as of the last batch returned by Next.
+func (pc *prefetchBatchCursor) ID() int64 {
+	return pc.id
+}
+
+// Next returns true if there is a batch available. If a prefetch is in flight, Next waits for it to complete or for
+// ctx to expire, whichever happens first.
+func (pc *prefetchBatchCursor) Next(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var ok, interrupted bool
+	if pc.done == nil {
+		ok = pc.bc.Next(ctx)
+	} else {
+		select {
+		case <-pc.done:
+		case <-ctx.Done():
+			interrupted = true
+			pc.cancel()
+			<-pc.done
+		}
+		pc.cancel()
+		pc.done = nil
+		ok = pc.prefetched
+	}
+
+	pc.capture()
+	if interrupted && pc.err != nil {
+		// Report the caller's context error rather than the cancellation of the background request.
+		pc.err = ctx.Err()
+	}
+
+	if ok && pc.err == nil && pc.id != 0 {
+		pc.startPrefetch(ctx)
+	}
+	return ok
+}
+
+func (pc *prefetchBatchCursor) startPrefetch(ctx context.Context) {
+	prefetchCtx, cancel := context.WithCancel(detachedContext{ctx})
+	done := make(chan struct{})
+	pc.cancel = cancel
+	pc.done = done
+
+	go func() {
+		defer close(done)
+		pc.prefetched = pc.bc.Next(prefetchCtx)
+	}()
+}
+
+// wait blocks until the in-flight prefetch, if any, completes. The result is kept for the next call to Next.
+func (pc *prefetchBatchCursor) wait() {
+	if pc.done != nil {
+		<-pc.done
+	}
+}
+
+// Batch returns the batch returned by the last call to Next.
+func (pc *prefetchBatchCursor) Batch() *bsoncore.DocumentSequence {
+	return pc.batch
+}
+
+// PartialResultsReturned returns whether the wrapped cursor had returned partial results as of the last batch
+// returned by Next.
+func (pc *prefetchBatchCursor) PartialResultsReturned() bool {
+	return pc.partial
+}
+
+// Server returns the server of the wrapped cursor.
+func (pc *prefetchBatchCursor) Server() driver.Server {
+	return pc.bc.Server()
+}
+
+// Err returns the error encountered by the last batch returned by Next.
+func (pc *prefetchBatchCursor) Err() error {
+	return pc.err
+}
+
+// Close cancels any in-flight prefetch, waits for it to return, and closes the wrapped cursor.
+func (pc *prefetchBatchCursor) Close(ctx context.Context) error {
+	if pc.done != nil {
+		pc.cancel()
+		<-pc.done
+		pc.done = nil
+	}
+	pc.batch = &bsoncore.DocumentSequence{}
+	return pc.bc.Close(ctx)
+}
+
+// SetBatchSize sets the batch size of the wrapped cursor. The new value applies to the first batch requested after
+// any in-flight prefetch.
+func (pc *prefetchBatchCursor) SetBatchSize(size int32) {
+	pc.wait()
+	pc.bc.SetBatchSize(size)
+}
+
+// SetMaxTime sets the maxTimeMS of the wrapped cursor. The new value applies to the first batch requested after any
+// in-flight prefetch.
+func (pc *prefetchBatchCursor) SetMaxTime(dur time.Duration) {
+	pc.wait()
+	pc.bc.SetMaxTime(dur)
+}
+
+// SetComment sets the comment of the wrapped cursor. The new value applies to the first batch requested after any
+// in-flight prefetch.
+func (pc *prefetchBatchCursor) SetComment(comment interface{}) {
+	pc.wait()
+	pc.bc.SetComment(comment)
+}
+
+// detachedContext is a context that carries the values of the wrapped context but ignores its deadline and
+// cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+// latencyBatchCursor is a testBatchCursor that waits for delay before returning each batch after the first,
+// simulating the round trip of a getMore.
+type latencyBatchCursor struct {
+	*testBatchCursor
+	delay time.Duration
+	calls int32
+	err   error
+}
+
+func newLatencyBatchCursor(numBatches, batchSize int, delay time.Duration) *latencyBatchCursor {
+	return &latencyBatchCursor{
+		testBatchCursor: newTestBatchCursor(numBatches, batchSize),
+		delay:           delay,
+	}
+}
+
+func (lbc *latencyBatchCursor) Next(ctx context.Context) bool {
+	if atomic.AddInt32(&lbc.calls, 1) > 1 {
+		select {
+		case <-time.After(lbc.delay):
+		case <-ctx.Done():
+			lbc.err = ctx.Err()
+			return false
+		}
+	}
+	return lbc.testBatchCursor.Next(ctx)
+}
+
+func (lbc *latencyBatchCursor) Err() error {
+	return lbc.err
+}
+
+func TestPrefetchBatchCursor(t *testing.T) {
+	t.Run("returns all documents", func(t *testing.T) {
+		cursor, err := newCursor(newPrefetchBatchCursor(newLatencyBatchCursor(4, 3, time.Millisecond)), nil, nil)
+		require.NoError(t, err, "newCursor error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(context.Background(), &docs)
+		require.NoError(t, err, "All error: %v", err)
+		assert.Len(t, docs, 12, "expected 12 docs, got %v", len(docs))
+
+		for index, doc := range docs {
+			expected := bson.D{{"foo", int32(index)}}
+			assert.Equal(t, expected, doc, "expected doc %v, got %v", expected, doc)
+		}
+	})
+	t.Run("requests next batch in the background", func(t *testing.T) {
+		lbc := newLatencyBatchCursor(3, 2, 0)
+		pc := newPrefetchBatchCursor(lbc)
+		defer pc.Close(context.Background())
+
+		assert.True(t, pc.Next(context.Background()), "expected first batch")
+		first := pc.Batch()
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&lbc.calls) == 2
+		}, time.Second, time.Millisecond, "expected the second batch to be requested")
+
+		// The batch that was handed out must not change when the prefetch completes.
+		pc.wait()
+		doc, err := first.Next()
+		require.NoError(t, err, "Next error: %v", err)
+		assert.Equal(t, int32(0), doc.Lookup("foo").Int32(), "expected first document of the first batch")
+
+		assert.True(t, pc.Next(context.Background()), "expected second batch")
+		doc, err = pc.Batch().Next()
+		require.NoError(t, err, "Next error: %v", err)
+		assert.Equal(t, int32(2), doc.Lookup("foo").Int32(), "expected first document of the second batch")
+	})
+	t.Run("prefetch outlives the context of Next", func(t *testing.T) {
+		pc := newPrefetchBatchCursor(newLatencyBatchCursor(3, 1, 10*time.Millisecond))
+		defer pc.Close(context.Background())
+
+		// Cancel the context of each call as soon as Next returns, while the prefetch is still in flight.
+		var batches int
+		for {
+			ctx, cancel := context.WithCancel(context.Background())
+			ok := pc.Next(ctx)
+			cancel()
+			if !ok {
+				break
+			}
+			batches++
+		}
+		assert.Nil(t, pc.Err(), "expected no error, got %v", pc.Err())
+		assert.Equal(t, 3, batches, "expected 3 batches, got %v", batches)
+	})
+	t.Run("context expires while waiting for prefetch", func(t *testing.T) {
+		pc := newPrefetchBatchCursor(newLatencyBatchCursor(2, 1, time.Minute))
+		defer pc.Close(context.Background())
+
+		assert.True(t, pc.Next(context.Background()), "expected first batch")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.False(t, pc.Next(ctx), "expected Next to return false")
+		assert.ErrorIs(t, pc.Err(), context.DeadlineExceeded, "expected error %v, got %v",
+			context.DeadlineExceeded, pc.Err())
+	})
+	t.Run("Close cancels in-flight prefetch", func(t *testing.T) {
+		lbc := newLatencyBatchCursor(2, 1, time.Minute)
+		pc := newPrefetchBatchCursor(lbc)
+
+		assert.True(t, pc.Next(context.Background()), "expected first batch")
+
+		start := time.Now()
+		err := pc.Close(context.Background())
+		require.NoError(t, err, "Close error: %v", err)
+		assert.True(t, time.Since(start) < time.Minute/2, "expected Close to cancel the in-flight prefetch")
+		assert.True(t, lbc.closed, "expected wrapped cursor to be closed")
+		assert.ErrorIs(t, lbc.err, context.Canceled, "expected prefetch error %v, got %v",
+			context.Canceled, lbc.err)
+	})
+}
+
+func BenchmarkPrefetchBatchCursor(b *testing.B) {
+	const (
+		numBatches = 10
+		latency    = 2 * time.Millisecond
+		processing = 2 * time.Millisecond
+	)
+
+	benchmarks := []struct {
+		name     string
+		prefetch bool
+	}{
+		{"no prefetch", false},
+		{"prefetch", true},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var bc batchCursor = newLatencyBatchCursor(numBatches, 10, latency)
+				if bm.prefetch {
+					bc = newPrefetchBatchCursor(bc)
+				}
+				cursor, err := newCursor(bc, nil, nil)
+				if err != nil {
+					b.Fatalf("newCursor error: %v", err)
+				}
+
+				ctx := context.Background()
+				for cursor.Next(ctx) {
+					// Simulate the application spending time on each batch.
+					if cursor.RemainingBatchLength() == 0 {
+						time.Sleep(processing)
+					}
+				}
+				if err := cursor.Err(); err != nil {
+					b.Fatalf("cursor error: %v", err)
+				}
+				_ = cursor.Close(ctx)
+			}
+		})
+	}
+}
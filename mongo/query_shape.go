@@ -0,0 +1,190 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// queryShapePlaceholder replaces every value in a query shape.
+const queryShapePlaceholder = "?"
+
+// QueryShape returns a fingerprint of the shape of filter: the field names and query operators it uses, with every
+// value replaced by a "?" placeholder. Filters that only differ in their values, in the order of their fields and
+// operators, or in the order of the clauses of $and, $or, and $nor have the same fingerprint, so it can be used to
+// group queries for analysis or to look up policies for them. For example, the filters
+//
+//	bson.D{{"age", bson.D{{"$gte", 21}, {"$lt", 65}}}, {"status", "active"}}
+//	bson.D{{"status", "inactive"}, {"age", bson.D{{"$lt", 30}, {"$gte", 18}}}}
+//
+// both have the fingerprint
+//
+//	{"age": {"$gte": ?, "$lt": ?}, "status": ?}
+//
+// A document value whose first key does not start with "$", such as the value of {"a": {"b": 1}}, is an equality
+// match on the whole document and is replaced by a single placeholder, as are arrays. The filters of $elemMatch are
+// fingerprinted like top-level filters. Within $expr, field paths such as "$qty" are kept, because they are part of
+// the shape of the expression, and all other constants are replaced.
+//
+// Values that cannot be marshaled to BSON are replaced by a placeholder like any other value.
+func QueryShape(filter bson.D) string {
+	var b strings.Builder
+	writeShapeFields(&b, len(filter), func(i int) (string, func(*strings.Builder)) {
+		key, val := filter[i].Key, filter[i].Value
+		return key, func(b *strings.Builder) {
+			rv, err := marshalShapeValue(val)
+			if err != nil {
+				b.WriteString(queryShapePlaceholder)
+				return
+			}
+			writeFilterValueShape(b, key, rv)
+		}
+	})
+	return b.String()
+}
+
+func marshalShapeValue(val interface{}) (bson.RawValue, error) {
+	t, data, err := bson.MarshalValue(val)
+	if err != nil {
+		return bson.RawValue{}, err
+	}
+	return bson.RawValue{Type: t, Value: data}, nil
+}
+
+// writeShapeFields writes a document with n fields to b. field returns the key of the i-th field and a function that
+// writes its shape. The fields are sorted by key and, for equal keys, by shape so that the output does not depend on
+// the order of the fields.
+func writeShapeFields(b *strings.Builder, n int, field func(i int) (string, func(*strings.Builder))) {
+	fields := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		key, writeValue := field(i)
+		var fb strings.Builder
+		fb.WriteString(strconv.Quote(key))
+		fb.WriteString(": ")
+		writeValue(&fb)
+		fields = append(fields, fb.String())
+	}
+	sort.Strings(fields)
+
+	b.WriteString("{")
+	b.WriteString(strings.Join(fields, ", "))
+	b.WriteString("}")
+}
+
+// writeFilterShape writes the shape of a filter document, such as the top-level filter, a clause of $and, or the
+// filter of $elemMatch.
+func writeFilterShape(b *strings.Builder, doc bson.Raw) {
+	elems, err := doc.Elements()
+	if err != nil {
+		b.WriteString(queryShapePlaceholder)
+		return
+	}
+	writeShapeFields(b, len(elems), func(i int) (string, func(*strings.Builder)) {
+		key, val := elems[i].Key(), elems[i].Value()
+		return key, func(b *strings.Builder) { writeFilterValueShape(b, key, val) }
+	})
+}
+
+// writeFilterValueShape writes the shape of the value of key in a filter or in an operator document.
+func writeFilterValueShape(b *strings.Builder, key string, val bson.RawValue) {
+	switch key {
+	case "$and", "$or", "$nor":
+		arr, ok := val.ArrayOK()
+		if !ok {
+			break
+		}
+		values, err := arr.Values()
+		if err != nil {
+			break
+		}
+		clauses := make([]string, 0, len(values))
+		for _, v := range values {
+			var cb strings.Builder
+			if doc, ok := v.DocumentOK(); ok {
+				writeFilterShape(&cb, doc)
+			} else {
+				cb.WriteString(queryShapePlaceholder)
+			}
+			clauses = append(clauses, cb.String())
+		}
+		// The clauses are sorted because their order does not change the result of the query.
+		sort.Strings(clauses)
+		b.WriteString("[")
+		b.WriteString(strings.Join(clauses, ", "))
+		b.WriteString("]")
+		return
+	case "$elemMatch":
+		if doc, ok := val.DocumentOK(); ok {
+			writeFilterShape(b, doc)
+			return
+		}
+	case "$expr":
+		writeExprShape(b, val)
+		return
+	}
+
+	if doc, ok := val.DocumentOK(); ok && isOperatorDocument(doc) {
+		writeFilterShape(b, doc)
+		return
+	}
+	b.WriteString(queryShapePlaceholder)
+}
+
+// isOperatorDocument returns true if the first key of doc starts with "$", which means that doc contains query
+// operators rather than being a document to match exactly.
+func isOperatorDocument(doc bson.Raw) bool {
+	elem, err := doc.IndexErr(0)
+	return err == nil && strings.HasPrefix(elem.Key(), "$")
+}
+
+// writeExprShape writes the shape of an aggregation expression. Field paths and variables are kept, other constants
+// are replaced by placeholders, and the order of array elements is kept because it is significant for the arguments
+// of most aggregation operators.
+func writeExprShape(b *strings.Builder, val bson.RawValue) {
+	switch val.Type {
+	case bson.TypeString:
+		if s := val.StringValue(); strings.HasPrefix(s, "$") {
+			b.WriteString(strconv.Quote(s))
+			return
+		}
+	case bson.TypeEmbeddedDocument:
+		elems, err := val.Document().Elements()
+		if err != nil {
+			break
+		}
+		writeShapeFields(b, len(elems), func(i int) (string, func(*strings.Builder)) {
+			key, val := elems[i].Key(), elems[i].Value()
+			return key, func(b *strings.Builder) {
+				if key == "$literal" {
+					b.WriteString(queryShapePlaceholder)
+					return
+				}
+				writeExprShape(b, val)
+			}
+		})
+		return
+	case bson.TypeArray:
+		values, err := val.Array().Values()
+		if err != nil {
+			break
+		}
+		b.WriteString("[")
+		for i, v := range values {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeExprShape(b, v)
+		}
+		b.WriteString("]")
+		return
+	}
+	b.WriteString(queryShapePlaceholder)
+}
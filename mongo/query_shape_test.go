@@ -0,0 +1,124 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestQueryShape(t *testing.T) {
+	t.Run("fingerprint", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			filter bson.D
+			want   string
+		}{
+			{"empty", bson.D{}, "{}"},
+			{"equality", bson.D{{"name", "Ada"}}, `{"name": ?}`},
+			{
+				"operators",
+				bson.D{{"age", bson.D{{"$lt", 65}, {"$gte", 21}}}, {"status", "active"}},
+				`{"age": {"$gte": ?, "$lt": ?}, "status": ?}`,
+			},
+			{"embedded document", bson.D{{"address", bson.D{{"city", "Paris"}}}}, `{"address": ?}`},
+			{"array", bson.D{{"tags", bson.A{"a", "b"}}}, `{"tags": ?}`},
+			{"in", bson.D{{"x", bson.D{{"$in", bson.A{1, 2, 3}}}}}, `{"x": {"$in": ?}}`},
+			{"not", bson.D{{"x", bson.D{{"$not", bson.D{{"$gt", 5}}}}}}, `{"x": {"$not": {"$gt": ?}}}`},
+			{
+				"and or",
+				bson.D{{"$or", bson.A{
+					bson.D{{"b", 1}},
+					bson.D{{"$and", bson.A{bson.D{{"c", bson.D{{"$exists", true}}}}, bson.D{{"a", 2}}}}},
+				}}},
+				`{"$or": [{"$and": [{"a": ?}, {"c": {"$exists": ?}}]}, {"b": ?}]}`,
+			},
+			{
+				"elemMatch",
+				bson.D{{"items", bson.D{{"$elemMatch", bson.D{{"sku", "x"}, {"qty", bson.D{{"$gt", 1}}}}}}}},
+				`{"items": {"$elemMatch": {"qty": {"$gt": ?}, "sku": ?}}}`,
+			},
+			{
+				"expr",
+				bson.D{{"$expr", bson.D{{"$gt", bson.A{"$spent", bson.D{{"$multiply", bson.A{"$budget", 1.5}}}}}}}},
+				`{"$expr": {"$gt": ["$spent", {"$multiply": ["$budget", ?]}]}}`,
+			},
+			{"map value", bson.D{{"x", bson.M{"$lt": 1}}}, `{"x": {"$lt": ?}}`},
+			{"unmarshalable value", bson.D{{"x", make(chan int)}}, `{"x": ?}`},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := QueryShape(tc.filter)
+				assert.Equal(t, tc.want, got, "expected shape %s, got %s", tc.want, got)
+			})
+		}
+	})
+	t.Run("same shape", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			a, b bson.D
+		}{
+			{
+				"different values",
+				bson.D{{"age", bson.D{{"$gte", 21}}}, {"name", "Ada"}},
+				bson.D{{"age", bson.D{{"$gte", int64(65)}}}, {"name", primitive.Regex{Pattern: "^B"}}},
+			},
+			{
+				"different field order",
+				bson.D{{"a", 1}, {"b", 2}},
+				bson.D{{"b", "x"}, {"a", time.Now()}},
+			},
+			{
+				"different operator order",
+				bson.D{{"x", bson.D{{"$gt", 1}, {"$lt", 10}}}},
+				bson.D{{"x", bson.D{{"$lt", 100}, {"$gt", 50}}}},
+			},
+			{
+				"different clause order",
+				bson.D{{"$or", bson.A{bson.D{{"a", 1}}, bson.D{{"b", bson.D{{"$in", bson.A{1, 2}}}}}}}},
+				bson.D{{"$or", bson.A{bson.D{{"b", bson.D{{"$in", bson.A{3}}}}}, bson.D{{"a", "z"}}}}},
+			},
+			{
+				"different nested values",
+				bson.D{{"$and", bson.A{bson.D{{"tags", bson.D{{"$all", bson.A{"a"}}}}}, bson.D{{"n", nil}}}}},
+				bson.D{{"$and", bson.A{bson.D{{"n", 3}}, bson.D{{"tags", bson.D{{"$all", bson.A{"b", "c"}}}}}}}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				a, b := QueryShape(tc.a), QueryShape(tc.b)
+				assert.Equal(t, a, b, "expected the same shape for %v and %v, got %s and %s", tc.a, tc.b, a, b)
+			})
+		}
+	})
+	t.Run("different shape", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			a, b bson.D
+		}{
+			{"different fields", bson.D{{"a", 1}}, bson.D{{"b", 1}}},
+			{"different operators", bson.D{{"a", bson.D{{"$gt", 1}}}}, bson.D{{"a", bson.D{{"$gte", 1}}}}},
+			{"operator and equality", bson.D{{"a", bson.D{{"$eq", 1}}}}, bson.D{{"a", 1}}},
+			{"and and or", bson.D{{"$and", bson.A{bson.D{{"a", 1}}}}}, bson.D{{"$or", bson.A{bson.D{{"a", 1}}}}}},
+			{
+				"expr field paths",
+				bson.D{{"$expr", bson.D{{"$eq", bson.A{"$a", "$b"}}}}},
+				bson.D{{"$expr", bson.D{{"$eq", bson.A{"$a", "$c"}}}}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				a, b := QueryShape(tc.a), QueryShape(tc.b)
+				assert.NotEqual(t, a, b, "expected different shapes for %v and %v, got %s", tc.a, tc.b, a)
+			})
+		}
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TextSearchOptions represents the optional fields of a $text query.
+type TextSearchOptions struct {
+	// The language that determines the stop words and the rules for the stemmer and tokenizer. The value "none" uses
+	// simple tokenization with no stop words and no stemming. The default value is "", which means the default
+	// language of the text index is used.
+	Language string
+
+	// Whether the search is case sensitive. The default value is nil, which means the search is case insensitive.
+	CaseSensitive *bool
+
+	// Whether the search is diacritic sensitive. The default value is nil, which means the search is diacritic
+	// insensitive.
+	DiacriticSensitive *bool
+}
+
+// TextSearch returns a $text filter that performs a text search for the given query on the content of the fields
+// indexed with a text index. The collection must have a text index.
+//
+// Example usage:
+//
+//	filter := mongo.TextSearch("coffee shop", mongo.TextSearchOptions{Language: "english"})
+//	opts := options.Find().
+//		SetProjection(bson.D{{"score", mongo.TextScoreProjection()}}).
+//		SetSort(bson.D{{"score", mongo.TextScoreProjection()}})
+//	cursor, err := coll.Find(ctx, filter, opts)
+//
+// For more information about the $text operator, see https://www.mongodb.com/docs/manual/reference/operator/query/text/.
+func TextSearch(query string, opts TextSearchOptions) bson.D {
+	text := bson.D{{"$search", query}}
+	if opts.Language != "" {
+		text = append(text, bson.E{"$language", opts.Language})
+	}
+	if opts.CaseSensitive != nil {
+		text = append(text, bson.E{"$caseSensitive", *opts.CaseSensitive})
+	}
+	if opts.DiacriticSensitive != nil {
+		text = append(text, bson.E{"$diacriticSensitive", *opts.DiacriticSensitive})
+	}
+	return bson.D{{"$text", text}}
+}
+
+// TextScoreProjection returns the {$meta: "textScore"} expression, which evaluates to the relevance score that a
+// $text query assigned to each matching document. It can be used as a field value in a projection to include the
+// score in the results, or in a sort to sort the results by relevance.
+func TextScoreProjection() bson.D {
+	return bson.D{{"$meta", "textScore"}}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestTextSearch(t *testing.T) {
+	t.Parallel()
+
+	boolPtr := func(b bool) *bool { return &b }
+
+	testCases := []struct {
+		name  string
+		query string
+		opts  TextSearchOptions
+		want  string
+	}{
+		{
+			name:  "search only",
+			query: "coffee",
+			want:  `{"$text": {"$search": "coffee"}}`,
+		},
+		{
+			name:  "phrase and negation",
+			query: `"coffee shop" -cake`,
+			want:  `{"$text": {"$search": "\"coffee shop\" -cake"}}`,
+		},
+		{
+			name:  "language",
+			query: "leche",
+			opts:  TextSearchOptions{Language: "es"},
+			want:  `{"$text": {"$search": "leche","$language": "es"}}`,
+		},
+		{
+			name:  "all options",
+			query: "Café",
+			opts: TextSearchOptions{
+				Language:           "none",
+				CaseSensitive:      boolPtr(true),
+				DiacriticSensitive: boolPtr(false),
+			},
+			want: `{"$text": {"$search": "Café","$language": "none","$caseSensitive": true,"$diacriticSensitive": false}}`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := bson.Marshal(TextSearch(tc.query, tc.opts))
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.want, bson.Raw(raw).String(), "expected %v, got %v", tc.want, bson.Raw(raw))
+		})
+	}
+}
+
+func TestTextScoreProjection(t *testing.T) {
+	t.Parallel()
+
+	raw, err := bson.Marshal(bson.D{{"score", TextScoreProjection()}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	want := `{"score": {"$meta": "textScore"}}`
+	assert.Equal(t, want, bson.Raw(raw).String(), "expected %v, got %v", want, bson.Raw(raw))
+}
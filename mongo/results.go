@@ -8,9 +8,11 @@ package mongo
 
 import (
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -33,25 +35,52 @@ type BulkWriteResult struct {
 
 	// A map of operation index to the _id of each upserted document.
 	UpsertedIDs map[int64]interface{}
+
+	// The operationTime reported by the server for the last batch of the operation, or nil if the server did not
+	// report one. See InsertOneResult.OperationTime for more information.
+	OperationTime *primitive.Timestamp
+
+	// The $clusterTime reported by the server for the last batch of the operation, or nil if the server did not
+	// report one. See InsertOneResult.ClusterTime for more information.
+	ClusterTime bson.Raw
 }
 
 // InsertOneResult is the result type returned by an InsertOne operation.
 type InsertOneResult struct {
 	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
 	InsertedID interface{}
+
+	// The operationTime reported by the server for the write, or nil if the server did not report one, e.g. because
+	// it is a standalone server or the write was unacknowledged. It can be passed to Session.AdvanceOperationTime on
+	// a session in another process to make its causally consistent reads observe the write.
+	OperationTime *primitive.Timestamp
+
+	// The $clusterTime reported by the server for the write, or nil if the server did not report one. It can be
+	// passed to Session.AdvanceClusterTime along with OperationTime.
+	ClusterTime bson.Raw
 }
 
 // InsertManyResult is a result type returned by an InsertMany operation.
 type InsertManyResult struct {
 	// The _id values of the inserted documents. Values generated by the driver will be of type primitive.ObjectID.
 	InsertedIDs []interface{}
+
+	// The operationTime reported by the server for the last batch of the operation, or nil if the server did not
+	// report one. See InsertOneResult.OperationTime for more information.
+	OperationTime *primitive.Timestamp
+
+	// The $clusterTime reported by the server for the last batch of the operation, or nil if the server did not
+	// report one. See InsertOneResult.ClusterTime for more information.
+	ClusterTime bson.Raw
 }
 
 // TODO(GODRIVER-2367): Remove the BSON struct tags on DeleteResult.
 
 // DeleteResult is the result type returned by DeleteOne and DeleteMany operations.
 type DeleteResult struct {
-	DeletedCount int64 `bson:"n"` // The number of documents deleted.
+	DeletedCount  int64                `bson:"n"` // The number of documents deleted.
+	OperationTime *primitive.Timestamp `bson:"-"` // The operationTime reported by the server (see InsertOneResult).
+	ClusterTime   bson.Raw             `bson:"-"` // The $clusterTime reported by the server (see InsertOneResult).
 }
 
 // RewrapManyDataKeyResult is the result of the bulk write operation used to update the key vault collection with
@@ -96,6 +125,9 @@ type UpdateResult struct {
 	ModifiedCount int64       // The number of documents modified by the operation.
 	UpsertedCount int64       // The number of documents upserted by the operation.
 	UpsertedID    interface{} // The _id field of the upserted document, or nil if no upsert was done.
+
+	OperationTime *primitive.Timestamp // The operationTime reported by the server (see InsertOneResult).
+	ClusterTime   bson.Raw             // The $clusterTime reported by the server (see InsertOneResult).
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -284,3 +316,74 @@ func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 	cs.IDIndex = temp.IDIndex
 	return nil
 }
+
+// CollectionSpec represents a collection in a database with its creation options decoded. This type is returned by
+// the Database.ListCollectionSpecs function.
+type CollectionSpec struct {
+	// The collection name.
+	Name string
+
+	// The type of the collection. This will be "collection", "view", or "timeseries".
+	Type string
+
+	// Whether or not the collection is readOnly. This will be false for MongoDB versions < 3.4.
+	ReadOnly bool
+
+	// The collection UUID. This field will be nil for views and for MongoDB versions < 3.6. For versions 3.6 and
+	// higher, this will be a primitive.Binary with Subtype 4.
+	UUID *primitive.Binary
+
+	// The validator of the collection. This will be nil if the collection does not have a validator.
+	Validator bson.Raw
+
+	// The validation level and action of the collection. These will be empty if they were not set when the
+	// collection was created.
+	ValidationLevel  string
+	ValidationAction string
+
+	// The default collation of the collection. This will be nil if the collection uses simple binary comparison.
+	Collation *options.Collation
+
+	// Whether or not the collection is capped. If it is, SizeInBytes is the maximum size of the collection and
+	// MaxDocuments is the maximum number of documents, or 0 if there is no document limit.
+	Capped       bool
+	SizeInBytes  int64
+	MaxDocuments int64
+
+	// The time-series options of the collection. This will be nil if the collection is not a time-series collection.
+	TimeSeries *TimeSeriesInfo
+
+	// The name of the collection or view a view is defined on and the aggregation pipeline that defines it. These will
+	// be empty if the collection is not a view.
+	ViewOn   string
+	Pipeline []bson.Raw
+
+	// A document containing all of the options used to construct the collection, including ones that are not
+	// decoded into the other fields.
+	Options bson.Raw
+
+	// An IndexSpecification instance with details about the collection's _id index. This will be nil for views, if the
+	// NameOnly option is used, and for MongoDB versions < 3.4.
+	IDIndex *IndexSpecification
+}
+
+// TimeSeriesInfo represents the options of a time-series collection. This type is returned by the
+// Collection.IsTimeSeries function.
+type TimeSeriesInfo struct {
+	// The name of the field that contains the date in each time-series document.
+	TimeField string
+
+	// The name of the field that contains metadata in each time-series document. This will be empty if the collection
+	// does not have a meta field.
+	MetaField string
+
+	// The granularity of the time-series data. This will be empty if the collection uses custom bucketing parameters.
+	Granularity string
+
+	// The maximum range of time values for a bucket. This will be 0 if the server did not report it.
+	BucketMaxSpan time.Duration
+
+	// The interval that the first timestamp of a bucket is rounded down to. This will be 0 if the server did not
+	// report it.
+	BucketRounding time.Duration
+}
@@ -0,0 +1,227 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// resumeFindFunc runs a new Find operation that returns the documents sorting after last, which is the sort key value
+// of the last document returned so far, and returns its batch cursor. If no document has been returned yet, last is
+// the zero Value and the original query is run again. returned is the number of documents returned so far and is used
+// to reduce the limit of the new query.
+type resumeFindFunc func(ctx context.Context, last bsoncore.Value, returned int64) (batchCursor, error)
+
+// resumableFindSort validates the sort document of a Find operation whose cursor is resumed on key. key must be the
+// first field of sort, sorted in ascending or descending order. If sort is nil, the documents are sorted by key in
+// ascending order. It returns the sort document to use and the query operator that selects the documents sorting after
+// a value of key.
+func resumableFindSort(key string, sort bsoncore.Document) (bsoncore.Document, string, error) {
+	if key == "" {
+		return nil, "", errors.New("the sort key field of a resumable cursor must not be empty")
+	}
+	if sort == nil {
+		return bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, key, 1)), "$gt", nil
+	}
+
+	elems, err := sort.Elements()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(elems) == 0 || elems[0].Key() != key {
+		return nil, "", fmt.Errorf("the sort key field %q of a resumable cursor must be the first field of the sort", key)
+	}
+	switch dir, _ := elems[0].Value().AsInt64OK(); dir {
+	case 1:
+		return sort, "$gt", nil
+	case -1:
+		return sort, "$lt", nil
+	default:
+		return nil, "", fmt.Errorf("the sort key field %q of a resumable cursor must be sorted with 1 or -1, got %v", key,
+			elems[0].Value())
+	}
+}
+
+// resumeFindFilter returns the filter of a resumed Find operation, which matches the documents that match filter and
+// whose value of key compares to last with op. If last is the zero Value, filter is returned unchanged.
+func resumeFindFilter(filter bsoncore.Document, key, op string, last bsoncore.Value) bsoncore.Document {
+	if last.Type == 0 {
+		return filter
+	}
+	cond := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, key, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendValueElement(nil, op, last))))
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendArrayElement(nil, "$and", bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: filter},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: cond})))
+}
+
+// resumableBatchCursor is a batchCursor that wraps the batchCursor of a Find operation and transparently re-runs the
+// query if requesting the next batch fails with a CursorNotFound error. The Cursor built on top of a batchCursor only
+// requests the next batch after all documents of the current one were returned, so the last document of each batch is
+// the last document returned to the application when the next batch is requested.
+type resumableBatchCursor struct {
+	bc     batchCursor
+	key    []string
+	resume resumeFindFunc
+
+	// last is the sort key value of the last document returned so far and canResume reports whether it is known.
+	last      bsoncore.Value
+	canResume bool
+	returned  int64
+	err       error
+
+	// Modifications of the cursor that are applied again to the cursor of a resumed query.
+	batchSize *int32
+	maxTime   *time.Duration
+	comment   interface{}
+}
+
+var _ batchCursor = (*resumableBatchCursor)(nil)
+
+// newResumableBatchCursor returns a resumableBatchCursor that wraps bc and resumes it with the resume function. key is
+// the sort key field, which may be a dotted path into embedded documents.
+func newResumableBatchCursor(bc batchCursor, key string, resume resumeFindFunc) *resumableBatchCursor {
+	return &resumableBatchCursor{
+		bc:        bc,
+		key:       strings.Split(key, "."),
+		resume:    resume,
+		canResume: true,
+	}
+}
+
+// unwrap returns the current cursor.
+func (rc *resumableBatchCursor) unwrap() batchCursor {
+	return rc.bc
+}
+
+// ID returns the ID of the current cursor.
+func (rc *resumableBatchCursor) ID() int64 {
+	return rc.bc.ID()
+}
+
+// Next returns true if there is a batch available. If the wrapped cursor fails with a CursorNotFound error, Next runs
+// a new query for the documents after the last document returned and returns its first batch. The query is resumed at
+// most once per call.
+func (rc *resumableBatchCursor) Next(ctx context.Context) bool {
+	if rc.bc.Next(ctx) {
+		rc.record()
+		return true
+	}
+	if !rc.canResume || !isCursorNotFoundError(rc.bc.Err()) {
+		return false
+	}
+
+	bc, err := rc.resume(ctx, rc.last, rc.returned)
+	if err != nil {
+		rc.err = fmt.Errorf("error resuming cursor after it was not found: %w", err)
+		return false
+	}
+	// The server-side cursor no longer exists, so closing the old cursor only releases its client-side resources.
+	_ = rc.bc.Close(ctx)
+	rc.bc = bc
+	if rc.batchSize != nil {
+		rc.bc.SetBatchSize(*rc.batchSize)
+	}
+	if rc.maxTime != nil {
+		rc.bc.SetMaxTime(*rc.maxTime)
+	}
+	if rc.comment != nil {
+		rc.bc.SetComment(rc.comment)
+	}
+
+	if rc.bc.Next(ctx) {
+		rc.record()
+		return true
+	}
+	return false
+}
+
+// record updates the number of documents returned and the sort key value of the last document from the current batch.
+// If the last document does not contain the sort key, the cursor cannot be resumed anymore.
+func (rc *resumableBatchCursor) record() {
+	docs, err := rc.bc.Batch().Documents()
+	if err != nil || len(docs) == 0 {
+		return
+	}
+	rc.returned += int64(len(docs))
+
+	last, err := docs[len(docs)-1].LookupErr(rc.key...)
+	if err != nil {
+		rc.canResume = false
+		return
+	}
+	rc.last = last
+}
+
+// isCursorNotFoundError returns true if err reports that the server-side cursor does not exist anymore.
+func isCursorNotFoundError(err error) bool {
+	var de driver.Error
+	if errors.As(err, &de) {
+		return de.Code == errorCursorNotFound
+	}
+	return errors.Is(err, driver.ErrCursorNotFound)
+}
+
+// Batch returns the current batch of the current cursor.
+func (rc *resumableBatchCursor) Batch() *bsoncore.DocumentSequence {
+	return rc.bc.Batch()
+}
+
+// PartialResultsReturned returns whether the current cursor returned partial results.
+func (rc *resumableBatchCursor) PartialResultsReturned() bool {
+	if prc, ok := rc.bc.(partialResultsCursor); ok {
+		return prc.PartialResultsReturned()
+	}
+	return false
+}
+
+// Server returns the server of the current cursor.
+func (rc *resumableBatchCursor) Server() driver.Server {
+	return rc.bc.Server()
+}
+
+// Err returns the error encountered while resuming the cursor or, if it was not resumed, the last error of the
+// current cursor.
+func (rc *resumableBatchCursor) Err() error {
+	if rc.err != nil {
+		return rc.err
+	}
+	return rc.bc.Err()
+}
+
+// Close closes the current cursor.
+func (rc *resumableBatchCursor) Close(ctx context.Context) error {
+	return rc.bc.Close(ctx)
+}
+
+// SetBatchSize sets the batch size of the current cursor and of any cursor it is resumed with.
+func (rc *resumableBatchCursor) SetBatchSize(size int32) {
+	rc.batchSize = &size
+	rc.bc.SetBatchSize(size)
+}
+
+// SetMaxTime sets the maxTimeMS of the current cursor and of any cursor it is resumed with.
+func (rc *resumableBatchCursor) SetMaxTime(dur time.Duration) {
+	rc.maxTime = &dur
+	rc.bc.SetMaxTime(dur)
+}
+
+// SetComment sets the comment of the current cursor and of any cursor it is resumed with.
+func (rc *resumableBatchCursor) SetComment(comment interface{}) {
+	rc.comment = comment
+	rc.bc.SetComment(comment)
+}
@@ -0,0 +1,193 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// lostBatchCursor is a testBatchCursor that fails with err instead of returning the batch after the first lostAfter
+// batches, simulating a server-side cursor that is killed in the middle of the iteration.
+type lostBatchCursor struct {
+	*testBatchCursor
+	lostAfter int
+	err       error
+	calls     int
+	lost      bool
+}
+
+func (lbc *lostBatchCursor) Next(ctx context.Context) bool {
+	lbc.calls++
+	if lbc.calls > lbc.lostAfter {
+		lbc.lost = true
+		return false
+	}
+	return lbc.testBatchCursor.Next(ctx)
+}
+
+func (lbc *lostBatchCursor) Err() error {
+	if lbc.lost {
+		return lbc.err
+	}
+	return nil
+}
+
+func TestResumableBatchCursor(t *testing.T) {
+	const numBatches, batchSize = 4, 3
+	cursorNotFound := driver.Error{Code: errorCursorNotFound, Message: "cursor id 10 not found"}
+
+	// newLostCursor returns a cursor over numBatches batches whose server-side cursor is lost after lostAfter
+	// batches, and a resume function that returns a cursor over the remaining batches.
+	newLostCursor := func(key string, lostAfter int, err error) (*resumableBatchCursor, *[]bsoncore.Value, *[]int64) {
+		var lasts []bsoncore.Value
+		var returned []int64
+		lbc := &lostBatchCursor{
+			testBatchCursor: newTestBatchCursor(numBatches, batchSize),
+			lostAfter:       lostAfter,
+			err:             err,
+		}
+		rc := newResumableBatchCursor(lbc, key, func(_ context.Context, last bsoncore.Value, n int64) (batchCursor, error) {
+			lasts = append(lasts, last)
+			returned = append(returned, n)
+
+			resumed := newTestBatchCursor(numBatches, batchSize)
+			resumed.batches = resumed.batches[n/batchSize:]
+			return resumed, nil
+		})
+		return rc, &lasts, &returned
+	}
+
+	t.Run("resumes after the cursor is lost", func(t *testing.T) {
+		rc, lasts, returned := newLostCursor("foo", 2, cursorNotFound)
+		cursor, err := newCursor(rc, nil, nil)
+		require.NoError(t, err, "newCursor error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(context.Background(), &docs)
+		require.NoError(t, err, "All error: %v", err)
+		assert.Len(t, docs, numBatches*batchSize, "expected %d docs, got %v", numBatches*batchSize, len(docs))
+		for index, doc := range docs {
+			expected := bson.D{{"foo", int32(index)}}
+			assert.Equal(t, expected, doc, "expected doc %v, got %v", expected, doc)
+		}
+
+		require.Equal(t, 1, len(*lasts), "expected the cursor to be resumed once, got %d", len(*lasts))
+		assert.Equal(t, int32(5), (*lasts)[0].Int32(), "expected to resume after foo=5, got %v", (*lasts)[0])
+		assert.Equal(t, int64(6), (*returned)[0], "expected 6 returned documents, got %v", (*returned)[0])
+	})
+	t.Run("resumes before the first getMore", func(t *testing.T) {
+		rc, lasts, _ := newLostCursor("foo", 0, cursorNotFound)
+		cursor, err := newCursor(rc, nil, nil)
+		require.NoError(t, err, "newCursor error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(context.Background(), &docs)
+		require.NoError(t, err, "All error: %v", err)
+		assert.Len(t, docs, numBatches*batchSize, "expected %d docs, got %v", numBatches*batchSize, len(docs))
+		require.Equal(t, 1, len(*lasts), "expected the cursor to be resumed once, got %d", len(*lasts))
+		assert.Equal(t, bsoncore.Value{}, (*lasts)[0], "expected no last value, got %v", (*lasts)[0])
+	})
+	t.Run("does not resume other errors", func(t *testing.T) {
+		otherErr := driver.Error{Code: 50, Message: "operation exceeded time limit"}
+		rc, lasts, _ := newLostCursor("foo", 2, otherErr)
+		cursor, err := newCursor(rc, nil, nil)
+		require.NoError(t, err, "newCursor error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(context.Background(), &docs)
+		var cmdErr CommandError
+		assert.True(t, errors.As(err, &cmdErr), "expected a CommandError, got %v", err)
+		assert.Equal(t, int32(50), cmdErr.Code, "expected error code 50, got %v", cmdErr.Code)
+		assert.Len(t, *lasts, 0, "expected the cursor not to be resumed")
+	})
+	t.Run("does not resume without a sort key value", func(t *testing.T) {
+		rc, lasts, _ := newLostCursor("bar", 2, cursorNotFound)
+		cursor, err := newCursor(rc, nil, nil)
+		require.NoError(t, err, "newCursor error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(context.Background(), &docs)
+		assert.NotNil(t, err, "expected a CursorNotFound error, got nil")
+		assert.Len(t, *lasts, 0, "expected the cursor not to be resumed")
+	})
+	t.Run("resume error", func(t *testing.T) {
+		lbc := &lostBatchCursor{
+			testBatchCursor: newTestBatchCursor(numBatches, batchSize),
+			lostAfter:       1,
+			err:             cursorNotFound,
+		}
+		resumeErr := errors.New("no server available")
+		rc := newResumableBatchCursor(lbc, "foo", func(context.Context, bsoncore.Value, int64) (batchCursor, error) {
+			return nil, resumeErr
+		})
+
+		assert.True(t, rc.Next(context.Background()), "expected the first batch")
+		assert.False(t, rc.Next(context.Background()), "expected no batch after the resume error")
+		assert.ErrorIs(t, rc.Err(), resumeErr, "expected the resume error, got %v", rc.Err())
+	})
+}
+
+func TestResumableFindSort(t *testing.T) {
+	doc := func(d bson.D) bsoncore.Document {
+		b, err := bson.Marshal(d)
+		require.NoError(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	testCases := []struct {
+		name     string
+		key      string
+		sort     bsoncore.Document
+		wantSort bsoncore.Document
+		wantOp   string
+		wantErr  bool
+	}{
+		{"no sort", "_id", nil, doc(bson.D{{"_id", int32(1)}}), "$gt", false},
+		{"ascending", "x", doc(bson.D{{"x", 1}, {"_id", 1}}), doc(bson.D{{"x", 1}, {"_id", 1}}), "$gt", false},
+		{"descending", "x", doc(bson.D{{"x", -1}}), doc(bson.D{{"x", -1}}), "$lt", false},
+		{"not the first key", "x", doc(bson.D{{"y", 1}, {"x", 1}}), nil, "", true},
+		{"text score", "x", doc(bson.D{{"x", bson.D{{"$meta", "textScore"}}}}), nil, "", true},
+		{"empty key", "", nil, nil, "", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sort, op, err := resumableFindSort(tc.key, tc.sort)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected an error, got nil")
+				return
+			}
+			require.NoError(t, err, "resumableFindSort error: %v", err)
+			assert.Equal(t, tc.wantSort, sort, "expected sort %v, got %v", tc.wantSort, sort)
+			assert.Equal(t, tc.wantOp, op, "expected operator %q, got %q", tc.wantOp, op)
+		})
+	}
+}
+
+func TestResumeFindFilter(t *testing.T) {
+	filter, err := bson.Marshal(bson.D{{"status", "active"}})
+	require.NoError(t, err, "Marshal error: %v", err)
+
+	got := resumeFindFilter(filter, "x", "$gt", bsoncore.Value{})
+	assert.Equal(t, bsoncore.Document(filter), got, "expected the original filter without a last value")
+
+	last := bsoncore.Value{Type: bson.TypeInt32, Data: bsoncore.AppendInt32(nil, 7)}
+	got = resumeFindFilter(filter, "x", "$gt", last)
+	want, err := bson.Marshal(bson.D{{"$and", bson.A{
+		bson.D{{"status", "active"}},
+		bson.D{{"x", bson.D{{"$gt", int32(7)}}}},
+	}}})
+	require.NoError(t, err, "Marshal error: %v", err)
+	assert.Equal(t, bson.Raw(want).String(), bson.Raw(got).String(), "expected filter %v, got %v", bson.Raw(want), bson.Raw(got))
+}
@@ -0,0 +1,105 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// GetServerParameter returns the value of the server parameter with the given name by running the getParameter
+// command against the admin database. The parameter is read from the primary by default. The server can be chosen
+// with the Address option. An error is returned if the server does not report a value for the parameter.
+func (c *Client) GetServerParameter(
+	ctx context.Context,
+	name string,
+	opts ...*options.ServerParameterOptions,
+) (bson.RawValue, error) {
+	if name == "" {
+		return bson.RawValue{}, errors.New("server parameter name must not be empty")
+	}
+
+	cmd := bsoncore.NewDocumentBuilder().AppendInt32("getParameter", 1).AppendInt32(name, 1).Build()
+	res, err := c.getParameter(ctx, cmd, opts...)
+	if err != nil {
+		return bson.RawValue{}, err
+	}
+
+	val, err := res.LookupErr(name)
+	if err != nil {
+		return bson.RawValue{}, fmt.Errorf("server did not report a value for parameter %q", name)
+	}
+	return val, nil
+}
+
+// GetAllServerParameters returns all server parameters by running the getParameter command with the "*" argument
+// against the admin database. The parameters are read from the primary by default. The server can be chosen with the
+// Address option. The returned document contains one element per parameter. The "ok" and "operationTime" fields of
+// the command response and fields whose names start with "$", such as "$clusterTime", are removed.
+func (c *Client) GetAllServerParameters(ctx context.Context, opts ...*options.ServerParameterOptions) (bson.Raw, error) {
+	cmd := bsoncore.NewDocumentBuilder().AppendString("getParameter", "*").Build()
+	res, err := c.getParameter(ctx, cmd, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return stripCommandResponseFields(res)
+}
+
+// getParameter runs the getParameter command cmd against the server selected by the options.
+func (c *Client) getParameter(
+	ctx context.Context,
+	cmd bsoncore.Document,
+	opts ...*options.ServerParameterOptions,
+) (bson.Raw, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	selector := description.ReadPrefSelector(readpref.Primary())
+	spo := options.MergeServerParameterOptions(opts...)
+	if spo.Address != nil {
+		selector = addressSelector(address.Address(*spo.Address).Canonicalize())
+	}
+
+	op := c.newAdminCommand(cmd, selector)
+	if err := op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+	return bson.Raw(op.Result()), nil
+}
+
+// stripCommandResponseFields returns a copy of the command response res without its "ok" and "operationTime" fields
+// and fields whose names start with "$".
+func stripCommandResponseFields(res bson.Raw) (bson.Raw, error) {
+	elems, err := res.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		key := elem.Key()
+		if key == "ok" || key == "operationTime" || strings.HasPrefix(key, "$") {
+			continue
+		}
+		doc = append(doc, elem...)
+	}
+	doc, err = bsoncore.AppendDocumentEnd(doc, idx)
+	if err != nil {
+		return nil, err
+	}
+	return bson.Raw(doc), nil
+}
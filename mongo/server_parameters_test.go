@@ -0,0 +1,42 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestStripCommandResponseFields(t *testing.T) {
+	t.Parallel()
+
+	res, err := bson.Marshal(bson.D{
+		{"authenticationMechanisms", bson.A{"SCRAM-SHA-256"}},
+		{"featureCompatibilityVersion", bson.D{{"version", "7.0"}}},
+		{"ok", 1.0},
+		{"$clusterTime", bson.D{{"clusterTime", 1}}},
+		{"operationTime", 1},
+	})
+	require.NoError(t, err, "Marshal error")
+
+	got, err := stripCommandResponseFields(res)
+	require.NoError(t, err, "stripCommandResponseFields error")
+
+	want := `{"authenticationMechanisms": ["SCRAM-SHA-256"],"featureCompatibilityVersion": {"version": "7.0"}}`
+	assert.Equal(t, want, got.String(), "expected parameters %v, got %v", want, got)
+}
+
+func TestGetServerParameterEmptyName(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Client{}).GetServerParameter(context.Background(), "")
+	assert.NotNil(t, err, "expected error for empty parameter name, got nil")
+}
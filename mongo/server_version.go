@@ -0,0 +1,146 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ServerVersion is the version of a MongoDB server. ServerVersion values can be compared with ==, and two versions
+// that differ only in their pre-release or build suffix (e.g. "7.0.0-rc1") are equal.
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// String returns the version in the form "major.minor.patch".
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1 if v is older than other, 0 if they are the same version, and 1 if v is newer than other.
+func (v ServerVersion) Compare(other ServerVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		switch {
+		case diff < 0:
+			return -1
+		case diff > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if v is the version major.minor.patch or newer. For example, the version 4.4.10 is at least
+// 4.4.0 and 4.4.10, but not 4.4.11 or 5.0.0.
+func (v ServerVersion) AtLeast(major, minor, patch int) bool {
+	return v.Compare(ServerVersion{Major: major, Minor: minor, Patch: patch}) >= 0
+}
+
+// parseServerVersion parses a version string reported by the buildInfo command, such as "4.4", "4.4.10", or
+// "7.0.0-rc1". Missing minor and patch versions are 0 and anything after the patch version is ignored.
+func parseServerVersion(s string) (ServerVersion, error) {
+	version := s
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.SplitN(version, ".", 4)
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return ServerVersion{}, fmt.Errorf("invalid server version %q", s)
+		}
+		nums[i] = n
+	}
+	return ServerVersion{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// serverVersionKey identifies a server process. A server that restarts, such as during an upgrade, reports a new
+// process ID, so a cached version is not reused after the server is upgraded.
+type serverVersionKey struct {
+	addr       address.Address
+	processID  primitive.ObjectID
+	maxWireVer int32
+}
+
+// ServerVersion returns the version of a server the Client is connected to. The server is selected with the primary
+// preferred read preference, so the primary's version is returned for a replica set if there is one. For a sharded
+// cluster, the version of a mongos is returned, which may differ from the version of the shards during an upgrade.
+//
+// The version is found by running the buildInfo command the first time ServerVersion selects a server and is cached
+// for that server afterwards. The cache is keyed on the server's address and the process ID it reports in its
+// handshake, so the version is fetched again after the server restarts, such as when it is upgraded. Servers older
+// than 4.4 do not report a process ID, so for them the version is only fetched again if their maximum wire version
+// changes.
+func (c *Client) ServerVersion(ctx context.Context) (ServerVersion, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var selected description.Server
+	rpSelector := description.ReadPrefSelector(readpref.PrimaryPreferred())
+	_, err := c.deployment.SelectServer(ctx, description.ServerSelectorFunc(func(
+		t description.Topology,
+		candidates []description.Server,
+	) ([]description.Server, error) {
+		servers, err := rpSelector.SelectServer(t, candidates)
+		if err != nil || len(servers) == 0 {
+			return servers, err
+		}
+		selected = servers[0]
+		return servers[:1], nil
+	}))
+	if err != nil {
+		return ServerVersion{}, replaceErrors(err)
+	}
+
+	key := serverVersionKey{addr: selected.Addr}
+	if selected.TopologyVersion != nil {
+		key.processID = selected.TopologyVersion.ProcessID
+	}
+	if selected.WireVersion != nil {
+		key.maxWireVer = selected.WireVersion.Max
+	}
+	if v, ok := c.serverVersions.Load(key); ok {
+		return v.(ServerVersion), nil
+	}
+
+	cmd := bsoncore.NewDocumentBuilder().AppendInt32("buildInfo", 1).Build()
+	op := c.newAdminCommand(cmd, addressSelector(selected.Addr))
+	if err := op.Execute(ctx); err != nil {
+		return ServerVersion{}, replaceErrors(err)
+	}
+
+	var info struct {
+		Version string `bson:"version"`
+	}
+	if err := bson.Unmarshal(op.Result(), &info); err != nil {
+		return ServerVersion{}, err
+	}
+	v, err := parseServerVersion(info.Version)
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	c.serverVersions.Store(key, v)
+	return v, nil
+}
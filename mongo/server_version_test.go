@@ -0,0 +1,93 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version string
+		want    ServerVersion
+	}{
+		{"4.4", ServerVersion{4, 4, 0}},
+		{"4.4.10", ServerVersion{4, 4, 10}},
+		{"7.0.0-rc1", ServerVersion{7, 0, 0}},
+		{"6.0.5-ent", ServerVersion{6, 0, 5}},
+		{"8.1.0-alpha0-123-gabcdef", ServerVersion{8, 1, 0}},
+		{"3.6.23.1", ServerVersion{3, 6, 23}},
+		{"5", ServerVersion{5, 0, 0}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.version, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseServerVersion(tc.version)
+			require.NoError(t, err, "parseServerVersion error")
+			assert.Equal(t, tc.want, got, "expected version %v, got %v", tc.want, got)
+		})
+	}
+
+	for _, version := range []string{"", "four.four", "4..4", "4.-1"} {
+		version := version
+		t.Run("invalid "+version, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseServerVersion(version)
+			assert.NotNil(t, err, "expected error parsing %q, got nil", version)
+		})
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AtLeast", func(t *testing.T) {
+		t.Parallel()
+
+		v := ServerVersion{Major: 4, Minor: 4, Patch: 10}
+		testCases := []struct {
+			major, minor, patch int
+			want                bool
+		}{
+			{4, 4, 0, true},
+			{4, 4, 9, true},
+			{4, 4, 10, true},
+			{4, 4, 11, false},
+			{4, 2, 20, true},
+			{3, 6, 99, true},
+			{4, 5, 0, false},
+			{5, 0, 0, false},
+		}
+		for _, tc := range testCases {
+			got := v.AtLeast(tc.major, tc.minor, tc.patch)
+			assert.Equal(t, tc.want, got, "expected %v.AtLeast(%d, %d, %d) to be %v, got %v", v, tc.major,
+				tc.minor, tc.patch, tc.want, got)
+		}
+	})
+	t.Run("Compare", func(t *testing.T) {
+		t.Parallel()
+
+		v44, err := parseServerVersion("4.4")
+		require.NoError(t, err, "parseServerVersion error")
+		v4410, err := parseServerVersion("4.4.10")
+		require.NoError(t, err, "parseServerVersion error")
+
+		assert.Equal(t, -1, v44.Compare(v4410), "expected 4.4 to be older than 4.4.10")
+		assert.Equal(t, 1, v4410.Compare(v44), "expected 4.4.10 to be newer than 4.4")
+		assert.Equal(t, 0, v44.Compare(ServerVersion{4, 4, 0}), "expected 4.4 to equal 4.4.0")
+		assert.True(t, v44 == ServerVersion{4, 4, 0}, "expected 4.4 to equal 4.4.0")
+		assert.Equal(t, "4.4.10", v4410.String(), "expected string %q, got %q", "4.4.10", v4410.String())
+	})
+}
@@ -86,7 +86,7 @@ func SessionFromContext(ctx context.Context) Session {
 type Session interface {
 	// StartTransaction starts a new transaction, configured with the given options, on this
 	// session. This method returns an error if there is already a transaction in-progress for this
-	// session.
+	// session. Options that are not set are resolved as described in EffectiveTransactionOptions.
 	StartTransaction(...*options.TransactionOptions) error
 
 	// AbortTransaction aborts the active transaction for this session. This method returns an error
@@ -125,6 +125,23 @@ type Session interface {
 	// Client the Client associated with the session.
 	Client() *Client
 
+	// EffectiveTransactionOptions returns the options that a call to StartTransaction without
+	// arguments would use. Each transaction option is resolved from the first of the following
+	// that sets it:
+	//
+	// 1. The options passed to StartTransaction or WithTransaction.
+	//
+	// 2. The DefaultReadConcern, DefaultWriteConcern, DefaultReadPreference, and
+	// DefaultMaxCommitTime options of the SessionOptions used to start the session.
+	//
+	// 3. The read concern, write concern, and read preference of the Client when the session was
+	// started. The Client has no maximum commit time.
+	//
+	// The read concern, write concern, and read preference of a Database or Collection are never
+	// used for transactions. A nil field in the returned options means that the option is not set
+	// at any layer, so the server default is used.
+	EffectiveTransactionOptions() *options.TransactionOptions
+
 	// ID returns the current ID document associated with the session. The ID document is in the
 	// form {"id": <BSON binary value>}.
 	ID() bson.Raw
@@ -297,7 +314,7 @@ func (s *sessionImpl) AbortTransaction(ctx context.Context) error {
 		Deployment(s.deployment).WriteConcern(s.clientSession.CurrentWc).ServerSelector(selector).
 		Retry(driver.RetryOncePerCommand).CommandMonitor(s.client.monitor).
 		RecoveryToken(bsoncore.Document(s.clientSession.RecoveryToken)).ServerAPI(s.client.serverAPI).
-		Authenticator(s.client.authenticator).Execute(ctx)
+		Authenticator(s.client.authenticator).Execute(driver.WithinOperation(ctx))
 
 	s.clientSession.Aborting = false
 	_ = s.clientSession.AbortTransaction()
@@ -331,7 +348,9 @@ func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
 		CommandMonitor(s.client.monitor).RecoveryToken(bsoncore.Document(s.clientSession.RecoveryToken)).
 		ServerAPI(s.client.serverAPI).MaxTime(s.clientSession.CurrentMct).Authenticator(s.client.authenticator)
 
-	err = op.Execute(ctx)
+	// Ending a transaction completes the operations that were run in it, so it
+	// is not limited or rejected while the Client is draining.
+	err = op.Execute(driver.WithinOperation(ctx))
 	// Return error without updating transaction state if it is a timeout, as the transaction has not
 	// actually been committed.
 	if IsTimeout(err) {
@@ -374,6 +393,17 @@ func (s *sessionImpl) Client() *Client {
 	return s.client
 }
 
+// EffectiveTransactionOptions implements the Session interface.
+func (s *sessionImpl) EffectiveTransactionOptions() *options.TransactionOptions {
+	defaults := s.clientSession.DefaultTransactionOptions()
+	return &options.TransactionOptions{
+		ReadConcern:    defaults.ReadConcern,
+		ReadPreference: defaults.ReadPreference,
+		WriteConcern:   defaults.WriteConcern,
+		MaxCommitTime:  defaults.MaxCommitTime,
+	}
+}
+
 // session implements the Session interface.
 func (*sessionImpl) session() {
 }
@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// StreamToSSE writes the documents of cursor to w as a stream of Server-Sent Events. Each document is written as a
+// single "data:" line containing the document in relaxed Extended JSON followed by a blank line, and w is flushed
+// after each event so the client receives documents as soon as the cursor returns them. The Content-Type of the
+// response is set to "text/event-stream" unless it has already been set.
+//
+// StreamToSSE returns when the cursor is exhausted, when an error occurs, or when ctx is done. In an HTTP handler, ctx
+// should be the request's Context, which is cancelled when the client disconnects. The returned error is nil if the
+// cursor was exhausted. The cursor is always closed before StreamToSSE returns. w must implement http.Flusher.
+//
+// StreamToSSE can be used with a tailable cursor to stream documents as they are inserted into a capped collection.
+//
+// Example usage:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		cursor, err := coll.Find(r.Context(), bson.D{})
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusInternalServerError)
+//			return
+//		}
+//		if err := mongo.StreamToSSE(r.Context(), cursor, w); err != nil {
+//			log.Printf("error streaming results: %v", err)
+//		}
+//	}
+func StreamToSSE(ctx context.Context, cursor *Cursor, w http.ResponseWriter) error {
+	if cursor == nil {
+		return errors.New("cursor must not be nil")
+	}
+	defer cursor.Close(context.Background())
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("http.ResponseWriter does not implement http.Flusher")
+	}
+
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/event-stream")
+	}
+	header.Set("Cache-Control", "no-cache")
+	flusher.Flush()
+
+	var buf []byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !cursor.Next(ctx) {
+			break
+		}
+
+		data, err := bson.MarshalExtJSON(cursor.Current, false, false)
+		if err != nil {
+			return err
+		}
+		buf = append(buf[:0], "data: "...)
+		buf = append(buf, data...)
+		buf = append(buf, "\n\n"...)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		flusher.Flush()
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,92 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+// nonFlushingWriter is an http.ResponseWriter that does not implement http.Flusher.
+type nonFlushingWriter struct {
+	http.ResponseWriter
+}
+
+func TestStreamToSSE(t *testing.T) {
+	t.Parallel()
+
+	newCursor := func(t *testing.T) *Cursor {
+		t.Helper()
+
+		cursor, err := NewCursorFromDocuments([]interface{}{
+			bson.D{{"_id", 1}, {"msg", "hello\nworld"}},
+			bson.D{{"_id", 2}, {"n", 1.5}},
+		}, nil, nil)
+		require.NoError(t, err, "NewCursorFromDocuments error")
+		return cursor
+	}
+
+	t.Run("event framing", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		err := StreamToSSE(context.Background(), newCursor(t), rec)
+		require.NoError(t, err, "StreamToSSE error")
+
+		want := "data: {\"_id\":1,\"msg\":\"hello\\nworld\"}\n\n" +
+			"data: {\"_id\":2,\"n\":1.5}\n\n"
+		assert.Equal(t, want, rec.Body.String(), "expected body %q, got %q", want, rec.Body.String())
+		assert.True(t, rec.Flushed, "expected response to be flushed")
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"), "unexpected Content-Type")
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"), "unexpected Cache-Control")
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		rec := httptest.NewRecorder()
+		err := StreamToSSE(ctx, newCursor(t), rec)
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+		assert.Equal(t, "", rec.Body.String(), "expected no events, got %q", rec.Body.String())
+	})
+
+	t.Run("writer without Flush", func(t *testing.T) {
+		t.Parallel()
+
+		err := StreamToSSE(context.Background(), newCursor(t), nonFlushingWriter{httptest.NewRecorder()})
+		assert.NotNil(t, err, "expected StreamToSSE error, got nil")
+	})
+
+	t.Run("HTTP server", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = StreamToSSE(r.Context(), newCursor(t), w)
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err, "Get error")
+		defer resp.Body.Close()
+
+		body := new(bytes.Buffer)
+		_, err = body.ReadFrom(resp.Body)
+		require.NoError(t, err, "ReadFrom error")
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"), "unexpected Content-Type")
+		assert.Equal(t, 2, bytes.Count(body.Bytes(), []byte("data: ")), "expected 2 events, got %q", body.String())
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PushModifiers represents the modifiers of a $push update. If any modifier is set, the values are pushed with the
+// $each modifier.
+type PushModifiers struct {
+	// Each specifies that the value passed to PushUpdate is a slice or array of values that are each appended to the
+	// array, rather than a single value. A value that is not a slice or array is pushed as the only element of $each,
+	// and a nil slice pushes no values.
+	Each bool
+
+	// Slice limits the number of elements in the array after the push. A positive value keeps the first Slice
+	// elements, a negative value keeps the last -Slice elements, and 0 empties the array. The default value is nil,
+	// which means the array is not limited.
+	Slice *int
+
+	// Sort orders the elements of the array after the push. It is either 1 or -1 to sort elements by value, or a
+	// document such as bson.D{{"score", -1}} to sort embedded documents by their fields. The default value is nil,
+	// which means the array is not sorted.
+	Sort interface{}
+
+	// Position is the index in the array at which the values are inserted. A negative value counts from the end of
+	// the array. The default value is nil, which means the values are appended to the end of the array.
+	Position *int
+}
+
+func (pm PushModifiers) isSet() bool {
+	return pm.Each || pm.Slice != nil || pm.Sort != nil || pm.Position != nil
+}
+
+// PushUpdate returns a $push update operator that appends value to the array in field. If any modifier is set in
+// mods, the update uses the {$push: {<field>: {$each: [...], <modifiers>}}} form. The modifiers are given as a
+// PushModifiers struct, like the options structs used by the rest of the driver, rather than as option functions.
+//
+// Example usage:
+//
+//	// Append two scores, keep the array sorted in descending order, and keep only the top 5.
+//	slice := 5
+//	update := mongo.PushUpdate("scores", []int{89, 72}, mongo.PushModifiers{
+//		Each:  true,
+//		Sort:  -1,
+//		Slice: &slice,
+//	})
+//
+// For more information about the $push operator, see https://www.mongodb.com/docs/manual/reference/operator/update/push/.
+func PushUpdate(field string, value interface{}, mods PushModifiers) bson.D {
+	if !mods.isSet() {
+		return bson.D{{"$push", bson.D{{field, value}}}}
+	}
+
+	push := bson.D{{"$each", eachValues(value, mods.Each)}}
+	if mods.Slice != nil {
+		push = append(push, bson.E{"$slice", *mods.Slice})
+	}
+	if mods.Sort != nil {
+		push = append(push, bson.E{"$sort", mods.Sort})
+	}
+	if mods.Position != nil {
+		push = append(push, bson.E{"$position", *mods.Position})
+	}
+	return bson.D{{"$push", bson.D{{field, push}}}}
+}
+
+// eachValues returns the $each array for value. If each is true and value is a slice or array other than a byte
+// slice, it is returned as is, or as an empty bson.A if it is a nil slice, which would otherwise be encoded as null.
+// Otherwise, value is the only element.
+func eachValues(value interface{}, each bool) interface{} {
+	if each {
+		if _, ok := value.([]byte); !ok {
+			switch v := reflect.ValueOf(value); v.Kind() {
+			case reflect.Slice:
+				if v.IsNil() {
+					return bson.A{}
+				}
+				return value
+			case reflect.Array:
+				return value
+			}
+		}
+	}
+	return bson.A{value}
+}
+
+// AddToSetUpdate returns an $addToSet update operator that adds values to the array in field unless they are already
+// present. A single value uses the {$addToSet: {<field>: <value>}} form, and any other number of values uses the
+// $each modifier.
+//
+// Example usage:
+//
+//	update := mongo.AddToSetUpdate("tags", "camera", "electronics")
+//
+// For more information about the $addToSet operator, see
+// https://www.mongodb.com/docs/manual/reference/operator/update/addToSet/.
+func AddToSetUpdate(field string, values ...interface{}) bson.D {
+	if len(values) == 1 {
+		return bson.D{{"$addToSet", bson.D{{field, values[0]}}}}
+	}
+	each := bson.A(values)
+	if each == nil {
+		each = bson.A{}
+	}
+	return bson.D{{"$addToSet", bson.D{{field, bson.D{{"$each", each}}}}}}
+}
+
+// PullUpdate returns a $pull update operator that removes the elements of the array in field that match condition.
+// condition is either a value to remove or a query such as bson.D{{"$gte", 6}}. For arrays of embedded documents, a
+// condition document such as bson.D{{"score", 8}} matches documents as if each were a separate collection document.
+//
+// Example usage:
+//
+//	update := mongo.PullUpdate("votes", bson.D{{"$gte", 6}})
+//
+// For more information about the $pull operator, see https://www.mongodb.com/docs/manual/reference/operator/update/pull/.
+func PullUpdate(field string, condition interface{}) bson.D {
+	return bson.D{{"$pull", bson.D{{field, condition}}}}
+}
+
+// MergeUpdates combines update documents such as those returned by PushUpdate, AddToSetUpdate, and PullUpdate into a
+// single update document. The fields of operators that appear in more than one update are combined into one operator
+// document, so MergeUpdates(PushUpdate("a", 1, PushModifiers{}), PushUpdate("b", 2, PushModifiers{})) returns
+// {$push: {a: 1, b: 2}}. Operators whose values are not bson.D documents are not combined.
+//
+// Example usage:
+//
+//	update := mongo.MergeUpdates(
+//		bson.D{{"$set", bson.D{{"updatedAt", time.Now()}}}},
+//		mongo.AddToSetUpdate("tags", "sale"),
+//		mongo.PullUpdate("tags", "new"),
+//	)
+func MergeUpdates(updates ...bson.D) bson.D {
+	var merged bson.D
+	for _, update := range updates {
+		for _, elem := range update {
+			fields, ok := elem.Value.(bson.D)
+			i := indexOfKey(merged, elem.Key)
+			if !ok || i < 0 {
+				merged = append(merged, elem)
+				continue
+			}
+			existing, ok := merged[i].Value.(bson.D)
+			if !ok {
+				merged = append(merged, elem)
+				continue
+			}
+			combined := make(bson.D, 0, len(existing)+len(fields))
+			combined = append(combined, existing...)
+			merged[i].Value = append(combined, fields...)
+		}
+	}
+	return merged
+}
+
+// indexOfKey returns the index of the first element of d with the given key, or -1 if there is none.
+func indexOfKey(d bson.D, key string) int {
+	for i, elem := range d {
+		if elem.Key == key {
+			return i
+		}
+	}
+	return -1
+}
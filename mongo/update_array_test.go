@@ -0,0 +1,134 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestArrayUpdates(t *testing.T) {
+	t.Parallel()
+
+	five := 5
+	minusThree := -3
+	zero := 0
+
+	testCases := []struct {
+		name   string
+		update bson.D
+		want   string
+	}{
+		{
+			name:   "push",
+			update: PushUpdate("scores", 89, PushModifiers{}),
+			want:   `{"$push": {"scores": {"$numberInt":"89"}}}`,
+		},
+		{
+			name:   "push array as single element",
+			update: PushUpdate("pairs", bson.A{1, 2}, PushModifiers{}),
+			want:   `{"$push": {"pairs": [{"$numberInt":"1"},{"$numberInt":"2"}]}}`,
+		},
+		{
+			name:   "push each",
+			update: PushUpdate("scores", []int32{90, 92, 85}, PushModifiers{Each: true}),
+			want:   `{"$push": {"scores": {"$each": [{"$numberInt":"90"},{"$numberInt":"92"},{"$numberInt":"85"}]}}}`,
+		},
+		{
+			name:   "push each with nil slice",
+			update: PushUpdate("scores", []int32(nil), PushModifiers{Each: true}),
+			want:   `{"$push": {"scores": {"$each": []}}}`,
+		},
+		{
+			name:   "push each with non-array value",
+			update: PushUpdate("scores", int32(90), PushModifiers{Each: true}),
+			want:   `{"$push": {"scores": {"$each": [{"$numberInt":"90"}]}}}`,
+		},
+		{
+			name: "push with slice and sort",
+			update: PushUpdate("quizzes", bson.A{bson.D{{"wk", 5}, {"score", 8}}}, PushModifiers{
+				Each:  true,
+				Sort:  bson.D{{"score", -1}},
+				Slice: &minusThree,
+			}),
+			want: `{"$push": {"quizzes": {"$each": [{"wk": {"$numberInt":"5"},"score": {"$numberInt":"8"}}],` +
+				`"$slice": {"$numberInt":"-3"},"$sort": {"score": {"$numberInt":"-1"}}}}}`,
+		},
+		{
+			name:   "push at position",
+			update: PushUpdate("scores", []int32{50, 60}, PushModifiers{Each: true, Position: &zero}),
+			want: `{"$push": {"scores": {"$each": [{"$numberInt":"50"},{"$numberInt":"60"}],` +
+				`"$position": {"$numberInt":"0"}}}}`,
+		},
+		{
+			name:   "push modifier without each",
+			update: PushUpdate("scores", int32(100), PushModifiers{Slice: &five, Sort: -1}),
+			want: `{"$push": {"scores": {"$each": [{"$numberInt":"100"}],"$slice": {"$numberInt":"5"},` +
+				`"$sort": {"$numberInt":"-1"}}}}`,
+		},
+		{
+			name:   "add to set",
+			update: AddToSetUpdate("tags", "accessories"),
+			want:   `{"$addToSet": {"tags": "accessories"}}`,
+		},
+		{
+			name:   "add to set each",
+			update: AddToSetUpdate("tags", "camera", "electronics", "accessories"),
+			want:   `{"$addToSet": {"tags": {"$each": ["camera","electronics","accessories"]}}}`,
+		},
+		{
+			name:   "add to set no values",
+			update: AddToSetUpdate("tags"),
+			want:   `{"$addToSet": {"tags": {"$each": []}}}`,
+		},
+		{
+			name:   "pull value",
+			update: PullUpdate("vegetables", "carrots"),
+			want:   `{"$pull": {"vegetables": "carrots"}}`,
+		},
+		{
+			name:   "pull condition",
+			update: PullUpdate("votes", bson.D{{"$gte", 6}}),
+			want:   `{"$pull": {"votes": {"$gte": {"$numberInt":"6"}}}}`,
+		},
+		{
+			name: "merged updates",
+			update: MergeUpdates(
+				bson.D{{"$set", bson.D{{"status", "active"}}}},
+				PushUpdate("scores", int32(1), PushModifiers{}),
+				PullUpdate("tags", "old"),
+				PushUpdate("history", "created", PushModifiers{}),
+			),
+			want: `{"$set": {"status": "active"},"$push": {"scores": {"$numberInt":"1"},"history": "created"},` +
+				`"$pull": {"tags": "old"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := bson.Marshal(tc.update)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, tc.want, bson.Raw(got).String(), "expected update %v, got %v", tc.want, bson.Raw(got))
+		})
+	}
+
+	t.Run("merge does not modify inputs", func(t *testing.T) {
+		t.Parallel()
+
+		first := PushUpdate("a", 1, PushModifiers{})
+		MergeUpdates(first, PushUpdate("b", 2, PushModifiers{}))
+		want := PushUpdate("a", 1, PushModifiers{})
+		assert.Equal(t, want, first, "expected %v, got %v", want, first)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Upsert returns an update document for an upsert that writes the fields of doc. The fields named in immutableFields
+// are put under $setOnInsert, so they are only written when the upsert inserts a new document, and all other fields
+// are put under $set, so they are written on both insert and update. The _id field is always put under $setOnInsert
+// because it cannot be changed by an update.
+//
+// The doc parameter is marshaled with the default registry, so struct fields are named by their bson tags and fields
+// omitted with "omitempty" are not written. Names in immutableFields that are not fields of the marshaled document are
+// ignored. Only top-level fields are split; embedded documents are written as a whole.
+//
+// Example usage:
+//
+//	type Account struct {
+//		ID        string    `bson:"_id"`
+//		Email     string    `bson:"email"`
+//		CreatedAt time.Time `bson:"createdAt"`
+//	}
+//
+//	update, err := mongo.Upsert(account, []string{"createdAt"})
+//	if err != nil {
+//		return err
+//	}
+//	_, err = coll.UpdateOne(ctx, bson.D{{"_id", account.ID}}, update, options.Update().SetUpsert(true))
+func Upsert(doc interface{}, immutableFields []string) (bson.D, error) {
+	if doc == nil {
+		return nil, ErrNilDocument
+	}
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	elems, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	immutable := make(map[string]bool, len(immutableFields)+1)
+	immutable["_id"] = true
+	for _, field := range immutableFields {
+		immutable[field] = true
+	}
+
+	var set, setOnInsert bson.D
+	for _, elem := range elems {
+		e := bson.E{Key: elem.Key(), Value: elem.Value()}
+		if immutable[e.Key] {
+			setOnInsert = append(setOnInsert, e)
+		} else {
+			set = append(set, e)
+		}
+	}
+
+	// Empty $set and $setOnInsert documents are rejected by servers before 5.0, so they are omitted.
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+	if len(setOnInsert) > 0 {
+		update = append(update, bson.E{Key: "$setOnInsert", Value: setOnInsert})
+	}
+	return update, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestUpsert(t *testing.T) {
+	t.Parallel()
+
+	type account struct {
+		ID        string `bson:"_id"`
+		Email     string `bson:"email"`
+		CreatedBy string `bson:"createdBy"`
+		Nickname  string `bson:"nick,omitempty"`
+		Visits    int    `bson:"visits"`
+	}
+
+	testCases := []struct {
+		name      string
+		doc       interface{}
+		immutable []string
+		want      string
+	}{
+		{
+			name:      "struct",
+			doc:       account{ID: "a1", Email: "a@example.com", CreatedBy: "sync", Visits: 2},
+			immutable: []string{"createdBy"},
+			want: `{"$set": {"email": "a@example.com","visits": {"$numberInt":"2"}},` +
+				`"$setOnInsert": {"_id": "a1","createdBy": "sync"}}`,
+		},
+		{
+			name:      "omitted and unknown immutable fields",
+			doc:       account{ID: "a1", Email: "a@example.com"},
+			immutable: []string{"nick", "missing"},
+			want: `{"$set": {"email": "a@example.com","createdBy": "","visits": {"$numberInt":"0"}},` +
+				`"$setOnInsert": {"_id": "a1"}}`,
+		},
+		{
+			name:      "document without immutable fields",
+			doc:       bson.D{{"x", int32(1)}, {"y", bson.D{{"z", "a"}}}},
+			immutable: nil,
+			want:      `{"$set": {"x": {"$numberInt":"1"},"y": {"z": "a"}}}`,
+		},
+		{
+			name:      "only immutable fields",
+			doc:       bson.D{{"_id", int32(1)}, {"x", int32(1)}},
+			immutable: []string{"x"},
+			want:      `{"$setOnInsert": {"_id": {"$numberInt":"1"},"x": {"$numberInt":"1"}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			update, err := Upsert(tc.doc, tc.immutable)
+			require.NoError(t, err, "Upsert error")
+			got, err := bson.Marshal(update)
+			require.NoError(t, err, "Marshal error")
+			assert.Equal(t, tc.want, bson.Raw(got).String(), "expected update %v, got %v", tc.want, bson.Raw(got))
+		})
+	}
+
+	t.Run("nil document", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Upsert(nil, nil)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+	})
+}
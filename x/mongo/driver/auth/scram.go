@@ -14,6 +14,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/xdg-go/scram"
@@ -52,6 +53,7 @@ func newScramSHA1Authenticator(cred *Cred, _ *http.Client) (Authenticator, error
 		mechanism: SCRAMSHA1,
 		source:    source,
 		client:    client,
+		force:     cred.ForceMechanism,
 	}, nil
 }
 
@@ -73,6 +75,7 @@ func newScramSHA256Authenticator(cred *Cred, _ *http.Client) (Authenticator, err
 		mechanism: SCRAMSHA256,
 		source:    source,
 		client:    client,
+		force:     cred.ForceMechanism,
 	}, nil
 }
 
@@ -81,12 +84,20 @@ type ScramAuthenticator struct {
 	mechanism string
 	source    string
 	client    *scram.Client
+
+	// force requires the server to advertise mechanism in saslSupportedMechs before a conversation is attempted.
+	force bool
 }
 
 var _ SpeculativeAuthenticator = (*ScramAuthenticator)(nil)
 
 // Auth authenticates the provided connection by conducting a full SASL conversation.
 func (a *ScramAuthenticator) Auth(ctx context.Context, cfg *Config) error {
+	if a.force && !mechanismAdvertised(cfg, a.mechanism) {
+		return newAuthError(fmt.Sprintf("server does not support the forced auth mechanism %q, supported mechanisms: %v",
+			a.mechanism, cfg.HandshakeInfo.SaslSupportedMechs), nil)
+	}
+
 	err := ConductSaslConversation(ctx, cfg, a.source, a.createSaslClient())
 	if err != nil {
 		return newAuthError("sasl conversation error", err)
@@ -104,6 +115,16 @@ func (a *ScramAuthenticator) CreateSpeculativeConversation() (SpeculativeConvers
 	return newSaslConversation(a.createSaslClient(), a.source, true), nil
 }
 
+// mechanismAdvertised returns true if the server listed mech in the saslSupportedMechs field of its handshake response.
+func mechanismAdvertised(cfg *Config, mech string) bool {
+	for _, m := range cfg.HandshakeInfo.SaslSupportedMechs {
+		if m == mech {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *ScramAuthenticator) createSaslClient() SaslClient {
 	return &scramSaslAdapter{
 		conversation: a.client.NewConversation(),
@@ -9,11 +9,13 @@ package auth
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
 )
 
@@ -95,6 +97,64 @@ func TestSCRAM(t *testing.T) {
 			})
 		}
 	})
+	t.Run("forced mechanism", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			supported []string
+			wantErr   bool
+		}{
+			{"advertised", []string{SCRAMSHA1, SCRAMSHA256}, false},
+			{"not advertised", []string{SCRAMSHA1}, true},
+			{"no mechanisms advertised", nil, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				authenticator, err := newScramSHA256Authenticator(
+					&Cred{
+						Username:       "user",
+						Password:       "pencil",
+						Source:         "admin",
+						ForceMechanism: true,
+					},
+					&http.Client{})
+				assert.Nil(t, err, "error creating authenticator: %v", err)
+				sa, _ := authenticator.(*ScramAuthenticator)
+				sa.client = sa.client.WithNonceGenerator(func() string {
+					return scramSha256Nonce
+				})
+
+				responses := make(chan []byte, len(scramSha256ShortPayloads))
+				writeReplies(responses, createSCRAMConversation(scramSha256ShortPayloads)...)
+
+				desc := description.Server{
+					WireVersion: &description.VersionRange{
+						Max: 21,
+					},
+				}
+				conn := &drivertest.ChannelConn{
+					Written:  make(chan []byte, len(scramSha256ShortPayloads)),
+					ReadResp: responses,
+					Desc:     desc,
+				}
+
+				cfg := &Config{
+					Description:   desc,
+					Connection:    conn,
+					HandshakeInfo: driver.HandshakeInformation{SaslSupportedMechs: tc.supported},
+				}
+				err = authenticator.Auth(context.Background(), cfg)
+				if !tc.wantErr {
+					assert.Nil(t, err, "Auth error: %v", err)
+					return
+				}
+
+				assert.NotNil(t, err, "expected an error when %s is not advertised, got nil", SCRAMSHA256)
+				assert.True(t, strings.Contains(err.Error(), "forced auth mechanism"),
+					"expected forced auth mechanism error, got %v", err)
+				assert.Equal(t, 0, len(conn.Written), "expected no messages to be written, got %d", len(conn.Written))
+			})
+		}
+	})
 }
 
 func createSCRAMConversation(payloads [][]byte) []bsoncore.Document {
@@ -19,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/codecutil"
 	"go.mongodb.org/mongo-driver/internal/csot"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
@@ -52,6 +53,19 @@ type BatchCursor struct {
 	crypt                Crypt
 	serverAPI            *ServerAPIOptions
 
+	// partialResultsReturned is true if any response for the cursor reported that some shards were unavailable.
+	partialResultsReturned bool
+
+	// killOnCancel is true if the cursor should be killed when a getMore is interrupted by Context cancellation.
+	killOnCancel bool
+
+	// awaitData is true if the cursor is a tailable cursor with awaitData set, such as a change stream cursor.
+	awaitData bool
+
+	// deriveMaxTime is true if getMore commands for awaitData cursors should bound maxTimeMS by the time remaining
+	// until the Context deadline.
+	deriveMaxTime bool
+
 	// legacy server (< 3.2) fields
 	limit       int32
 	numReturned int32 // number of docs returned by server
@@ -69,6 +83,8 @@ type CursorResponse struct {
 	Collection           string
 	ID                   int64
 	postBatchResumeToken bsoncore.Document
+
+	partialResultsReturned bool
 }
 
 // NewCursorResponse constructs a cursor response from the given response and
@@ -124,6 +140,11 @@ func NewCursorResponse(info ResponseInfo) (CursorResponse, error) {
 			if !ok {
 				return CursorResponse{}, fmt.Errorf("post batch resume token should be a document but it is a BSON %s", elem.Value().Type)
 			}
+		case "partialResultsReturned":
+			curresp.partialResultsReturned, ok = elem.Value().BooleanOK()
+			if !ok {
+				return CursorResponse{}, fmt.Errorf("partialResultsReturned should be a boolean but it is a BSON %s", elem.Value().Type)
+			}
 		}
 	}
 
@@ -160,6 +181,21 @@ type CursorOptions struct {
 	Crypt                 Crypt
 	ServerAPI             *ServerAPIOptions
 	MarshalValueEncoderFn func(io.Writer) (*bson.Encoder, error)
+
+	// KillOnCancel causes the cursor to be killed with a killCursors command if a getMore is interrupted because its
+	// Context is cancelled.
+	KillOnCancel bool
+
+	// AwaitData indicates that the cursor was created with awaitData set, so the server accepts a "maxTimeMS" value
+	// on getMore commands, which bounds how long each getMore waits for new results.
+	AwaitData bool
+
+	// DeriveMaxTimeFromContext causes getMore commands for AwaitData cursors to send a "maxTimeMS" value no greater
+	// than the time remaining until the Context deadline minus the 90th percentile RTT. If MaxTimeMS is set, the
+	// smaller of the two values is sent. The server rejects "maxTimeMS" on getMore commands for cursors without
+	// awaitData because their time limit is set by the command that created them, so this option has no effect on
+	// those cursors.
+	DeriveMaxTimeFromContext bool
 }
 
 // NewBatchCursor creates a new BatchCursor from the provided parameters.
@@ -184,6 +220,11 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		serverAPI:            opts.ServerAPI,
 		serverDescription:    cr.Desc,
 		encoderFn:            opts.MarshalValueEncoderFn,
+
+		partialResultsReturned: cr.partialResultsReturned,
+		killOnCancel:           opts.KillOnCancel,
+		awaitData:              opts.AwaitData,
+		deriveMaxTime:          opts.DeriveMaxTimeFromContext,
 	}
 
 	if ds != nil {
@@ -234,6 +275,16 @@ func (bc *BatchCursor) ID() int64 {
 	return bc.id
 }
 
+// Namespace returns the namespace of the cursor in the form "database.collection".
+func (bc *BatchCursor) Namespace() string {
+	return bc.database + "." + bc.collection
+}
+
+// ServerAddress returns the address of the server the cursor was created on.
+func (bc *BatchCursor) ServerAddress() address.Address {
+	return bc.serverDescription.Addr
+}
+
 // Next indicates if there is another batch available. Returning false does not necessarily indicate
 // that the cursor is closed. This method will return false when an empty batch is returned.
 //
@@ -368,6 +419,12 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		return
 	}
 
+	maxTimeMS, err := bc.getMoreMaxTimeMS(ctx)
+	if err != nil {
+		bc.err = err
+		return
+	}
+
 	bc.err = Operation{
 		CommandFn: func(dst []byte, _ description.SelectedServer) ([]byte, error) {
 			dst = bsoncore.AppendInt64Element(dst, "getMore", bc.id)
@@ -375,8 +432,8 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 			if numToReturn > 0 {
 				dst = bsoncore.AppendInt32Element(dst, "batchSize", numToReturn)
 			}
-			if bc.maxTimeMS > 0 {
-				dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", bc.maxTimeMS)
+			if maxTimeMS > 0 {
+				dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", maxTimeMS)
 			}
 
 			comment, err := codecutil.MarshalValue(bc.comment, bc.encoderFn)
@@ -410,6 +467,12 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 			bc.currentBatch.ResetIterator()
 			bc.numReturned += int32(bc.currentBatch.DocumentCount()) // Required for legacy operations which don't support limit.
 
+			// mongos only reports partialResultsReturned on the responses for which a shard was unavailable, so the
+			// flag is never reset once it has been seen.
+			if partial, ok := response.Lookup("cursor", "partialResultsReturned").BooleanOK(); ok && partial {
+				bc.partialResultsReturned = true
+			}
+
 			pbrt, err := response.LookupErr("cursor", "postBatchResumeToken")
 			if err != nil {
 				// I don't really understand why we don't set bc.err here
@@ -455,6 +518,15 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		bc.id = 0
 	}
 
+	// The server keeps the cursor open until it times out if the getMore was interrupted because ctx was cancelled,
+	// so kill it with a new Context. Errors are ignored because the cursor is already in an error state.
+	if bc.err != nil && bc.killOnCancel && ctx.Err() != nil {
+		killCtx, cancel := context.WithTimeout(context.Background(), killOnCancelTimeout)
+		_ = bc.KillCursor(killCtx)
+		cancel()
+		bc.id = 0
+	}
+
 	// Required for legacy operations which don't support limit.
 	if bc.limit != 0 && bc.numReturned >= bc.limit {
 		// call KillCursor instead of Close because Close will clear out the data for the current batch.
@@ -470,6 +542,13 @@ func (bc *BatchCursor) PostBatchResumeToken() bsoncore.Document {
 	return bc.postBatchResumeToken
 }
 
+// PartialResultsReturned returns true if the server reported that the results of any batch seen so far are
+// incomplete because some shards were unavailable. The server only reports this for queries run with the
+// allowPartialResults option against a sharded cluster.
+func (bc *BatchCursor) PartialResultsReturned() bool {
+	return bc.partialResultsReturned
+}
+
 // SetBatchSize sets the batchSize for future getMore operations.
 func (bc *BatchCursor) SetBatchSize(size int32) {
 	bc.batchSize = size
@@ -485,6 +564,43 @@ func (bc *BatchCursor) SetMaxTime(dur time.Duration) {
 	bc.maxTimeMS = int64(dur / time.Millisecond)
 }
 
+// defaultAwaitTimeMS is the time the server waits for new results on a getMore for an awaitData cursor if the getMore
+// has no "maxTimeMS" value.
+const defaultAwaitTimeMS = 1000
+
+// getMoreMaxTimeMS returns the "maxTimeMS" value to send with a getMore command, or 0 if it should be omitted. If
+// deriveMaxTime is set, the cursor has awaitData set, and ctx has a deadline, the configured await time (or the
+// server's default await time if none is configured) is capped at the time remaining until the deadline so that the
+// server returns before the deadline passes. The value is not derived for CSOT contexts because the CSOT-calculated
+// "maxTimeMS" is already appended to the command.
+func (bc *BatchCursor) getMoreMaxTimeMS(ctx context.Context) (int64, error) {
+	if !bc.deriveMaxTime || !bc.awaitData || csot.IsTimeoutContext(ctx) {
+		return bc.maxTimeMS, nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return bc.maxTimeMS, nil
+	}
+
+	var mon RTTMonitor = &csot.ZeroRTTMonitor{}
+	if bc.server != nil {
+		mon = bc.server.RTTMonitor()
+	}
+	remaining, err := maxTimeMSFromDeadline(deadline, mon)
+	if err != nil {
+		return 0, err
+	}
+
+	awaitTimeMS := bc.maxTimeMS
+	if awaitTimeMS <= 0 {
+		awaitTimeMS = defaultAwaitTimeMS
+	}
+	if remaining > 0 && int64(remaining) < awaitTimeMS {
+		return int64(remaining), nil
+	}
+	return bc.maxTimeMS, nil
+}
+
 // SetComment sets the comment for future getMore operations.
 func (bc *BatchCursor) SetComment(comment interface{}) {
 	bc.comment = comment
@@ -7,10 +7,15 @@
 package driver
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestBatchCursor(t *testing.T) {
@@ -131,3 +136,180 @@ func TestBatchCursorSetMaxTime(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchCursorGetMoreMaxTimeMS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		awaitData bool
+		derive    bool
+		maxTimeMS int64
+		timeout   time.Duration
+		wantMin   int64
+		wantMax   int64 // 0 means maxTimeMS should be omitted
+	}{
+		{
+			name:      "derived from deadline for awaitData cursor",
+			awaitData: true,
+			derive:    true,
+			timeout:   500 * time.Millisecond,
+			wantMin:   1,
+			wantMax:   500,
+		},
+		{
+			name:      "MaxAwaitTime smaller than remaining time",
+			awaitData: true,
+			derive:    true,
+			maxTimeMS: 100,
+			timeout:   10 * time.Second,
+			wantMin:   100,
+			wantMax:   100,
+		},
+		{
+			name:      "remaining time smaller than MaxAwaitTime",
+			awaitData: true,
+			derive:    true,
+			maxTimeMS: 5000,
+			timeout:   500 * time.Millisecond,
+			wantMin:   1,
+			wantMax:   500,
+		},
+		{
+			name:      "remaining time larger than default await time",
+			awaitData: true,
+			derive:    true,
+			timeout:   10 * time.Second,
+		},
+		{
+			name:    "not derived for cursor without awaitData",
+			derive:  true,
+			timeout: 500 * time.Millisecond,
+		},
+		{
+			name:      "not derived if disabled",
+			awaitData: true,
+			timeout:   500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := bsoncore.NewDocumentBuilder().
+				AppendInt32("ok", 1).
+				AppendDocument("cursor", bsoncore.NewDocumentBuilder().
+					AppendInt64("id", 0).
+					AppendArray("nextBatch", bsoncore.NewArrayBuilder().Build()).
+					Build()).
+				Build()
+			conn := &mockConnection{
+				rDesc:   description.Server{WireVersion: &description.VersionRange{Max: 21}},
+				rReadWM: createExhaustServerResponse(response, false),
+			}
+
+			var started bson.Raw
+			monitor := &event.CommandMonitor{
+				Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+					started = evt.Command
+				},
+			}
+			bc, err := NewBatchCursor(
+				CursorResponse{
+					ID:         1,
+					Server:     SingleConnectionDeployment{conn},
+					Database:   "db",
+					Collection: "coll",
+					FirstBatch: new(bsoncore.DocumentSequence),
+				},
+				nil,
+				nil,
+				CursorOptions{
+					MaxTimeMS:                test.maxTimeMS,
+					CommandMonitor:           monitor,
+					AwaitData:                test.awaitData,
+					DeriveMaxTimeFromContext: test.derive,
+				})
+			assert.Nil(t, err, "NewBatchCursor error: %v", err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			bc.getMore(ctx)
+			assert.Nil(t, bc.Err(), "getMore error: %v", bc.Err())
+			assert.NotNil(t, started, "expected a getMore command to be started")
+
+			val, err := started.LookupErr("maxTimeMS")
+			if test.wantMax == 0 {
+				assert.NotNil(t, err, "expected maxTimeMS to be omitted, got %v", val)
+				return
+			}
+			assert.Nil(t, err, "expected getMore to include maxTimeMS")
+			got := val.Int64()
+			assert.True(t, got >= test.wantMin && got <= test.wantMax,
+				"expected maxTimeMS between %d and %d, got %d", test.wantMin, test.wantMax, got)
+		})
+	}
+}
+
+func TestNewCursorResponsePartialResultsReturned(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cursor  bsoncore.Document
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "not reported",
+			cursor: bsoncore.NewDocumentBuilder().
+				AppendInt64("id", 0).
+				AppendString("ns", "db.coll").
+				Build(),
+			want: false,
+		},
+		{
+			name: "reported",
+			cursor: bsoncore.NewDocumentBuilder().
+				AppendInt64("id", 0).
+				AppendString("ns", "db.coll").
+				AppendBoolean("partialResultsReturned", true).
+				Build(),
+			want: true,
+		},
+		{
+			name: "wrong type",
+			cursor: bsoncore.NewDocumentBuilder().
+				AppendInt64("id", 0).
+				AppendString("ns", "db.coll").
+				AppendInt32("partialResultsReturned", 1).
+				Build(),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := bsoncore.NewDocumentBuilder().AppendDocument("cursor", test.cursor).Build()
+			cr, err := NewCursorResponse(ResponseInfo{ServerResponse: response})
+			if test.wantErr {
+				assert.NotNil(t, err, "expected NewCursorResponse error, got nil")
+				return
+			}
+			assert.Nil(t, err, "NewCursorResponse error: %v", err)
+
+			bc, err := NewBatchCursor(cr, nil, nil, CursorOptions{})
+			assert.Nil(t, err, "NewBatchCursor error: %v", err)
+
+			got := bc.PartialResultsReturned()
+			assert.Equal(t, test.want, got, "expected PartialResultsReturned %v, got %v", test.want, got)
+		})
+	}
+}
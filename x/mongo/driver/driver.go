@@ -79,6 +79,7 @@ type Cred struct {
 	Props               map[string]string
 	OIDCMachineCallback OIDCCallback
 	OIDCHumanCallback   OIDCCallback
+	ForceMechanism      bool
 }
 
 // Deployment is implemented by types that can select a server from a deployment.
@@ -87,6 +88,45 @@ type Deployment interface {
 	Kind() description.TopologyKind
 }
 
+// OperationLimiter is implemented by Deployments that bound the number of operations executing concurrently.
+// Operation.Execute calls AcquireOperation before selecting a server and calls the returned release function when the
+// operation completes, unless its Context was returned by WithinOperation. AcquireOperation returns an error if the
+// operation cannot be started.
+type OperationLimiter interface {
+	AcquireOperation(context.Context) (release func(), err error)
+}
+
+type withinOperationKey struct{}
+
+// WithinOperation returns a copy of ctx that marks the operations executed with it as part of a logical operation
+// that an OperationLimiter has already admitted, such as the later batches of a bulk write or the commands run by
+// automatic encryption for an operation. Operation.Execute does not call AcquireOperation for these operations, so
+// they are neither limited nor rejected while the Deployment is draining.
+func WithinOperation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withinOperationKey{}, true)
+}
+
+// IsWithinOperation reports whether ctx was returned by WithinOperation.
+func IsWithinOperation(ctx context.Context) bool {
+	within, _ := ctx.Value(withinOperationKey{}).(bool)
+	return within
+}
+
+// OperationKiller is implemented by Deployments that can be configured to kill the server-side work of operations
+// whose Context is cancelled while they are in progress. If KillOnCancel returns true, Operation.Execute sends a
+// best-effort killOp command for interrupted operations that run in a session before it returns.
+type OperationKiller interface {
+	KillOnCancel() bool
+}
+
+// RetryDecider is implemented by Deployments that are configured with an additional rule for retrying operations.
+// Operation.Execute calls ShouldRetry with errors that the standard retryable reads and writes rules do not retry and
+// the 1-based number of the attempt that returned them. The operation is retried if ShouldRetry returns true, retries
+// are supported for the operation, and the operation has retries remaining.
+type RetryDecider interface {
+	ShouldRetry(err error, attempt int) bool
+}
+
 // Connector represents a type that can connect to a server.
 type Connector interface {
 	Connect() error
@@ -26,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/driverutil"
 	"go.mongodb.org/mongo-driver/internal/handshake"
 	"go.mongodb.org/mongo-driver/internal/logger"
+	"go.mongodb.org/mongo-driver/internal/serverpin"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -290,6 +291,10 @@ type Operation struct {
 	// Crypt specifies a Crypt object to use for automatic client side encryption and decryption.
 	Crypt Crypt
 
+	// BypassAutoEncryption specifies that the command should not be automatically encrypted, even if Crypt is set
+	// and does not bypass auto-encryption. Results are still automatically decrypted.
+	BypassAutoEncryption bool
+
 	// ServerAPI specifies options used to configure the API version sent to the server.
 	ServerAPI *ServerAPIOptions
 
@@ -315,6 +320,12 @@ type Operation struct {
 	// [Operation.MaxTime].
 	OmitCSOTMaxTimeMS bool
 
+	// DeriveMaxTimeFromContext calculates "maxTimeMS" from the context
+	// deadline for Read operations when neither CSOT nor [Operation.MaxTime]
+	// supplies a value. If the context has no deadline, "maxTimeMS" is
+	// omitted.
+	DeriveMaxTimeFromContext bool
+
 	// Authenticator is the authenticator to use for this operation when a reauthentication is
 	// required.
 	Authenticator Authenticator
@@ -328,7 +339,7 @@ type Operation struct {
 
 // shouldEncrypt returns true if this operation should automatically be encrypted.
 func (op Operation) shouldEncrypt() bool {
-	return op.Crypt != nil && !op.Crypt.BypassAutoEncryption()
+	return op.Crypt != nil && !op.BypassAutoEncryption && !op.Crypt.BypassAutoEncryption()
 }
 
 // filterDeprioritizedServers will filter out the server candidates that have
@@ -413,6 +424,9 @@ func (op Operation) selectServer(
 			description.LatencySelector(defaultLocalThreshold),
 		})
 	}
+	if pin := serverpin.FromContext(ctx); pin != nil {
+		selector = pin.Selector(selector)
+	}
 
 	oss := &opServerSelector{
 		selector:             selector,
@@ -456,6 +470,11 @@ func (op Operation) getServerAndConnection(
 		return nil, nil, err
 	}
 
+	// If the Context pins operations to a server, pin the server this operation was sent to if none is pinned yet.
+	if pin := serverpin.FromContext(ctx); pin != nil {
+		pin.Set(conn.Description().Addr)
+	}
+
 	// If we're in load balanced mode and this is the first operation in a transaction, pin the session to a connection.
 	if conn.Description().LoadBalanced() && op.Client != nil && op.Client.TransactionStarting() {
 		pinnedConn, ok := conn.(PinnedConnection)
@@ -518,12 +537,33 @@ func (op Operation) Execute(ctx context.Context) error {
 		defer cancelFunc()
 	}
 
+	if limiter, ok := op.Deployment.(OperationLimiter); ok && !IsWithinOperation(ctx) {
+		release, err := limiter.AcquireOperation(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		// Commands started on behalf of this operation, such as the key vault queries of automatic encryption, must
+		// not wait for a slot that this operation may be holding.
+		ctx = WithinOperation(ctx)
+	}
+
 	if op.Client != nil {
 		if err := op.Client.StartCommand(); err != nil {
 			return err
 		}
 	}
 
+	// killInterrupted is set if a command is interrupted by Context cancellation. It runs before Execute returns, and
+	// therefore before the session can be used for another command, but after the connection is released.
+	var killInterrupted func()
+	defer func() {
+		if killInterrupted != nil {
+			killInterrupted()
+		}
+	}()
+
 	var retries int
 	if op.RetryMode != nil {
 		switch op.Type {
@@ -562,6 +602,8 @@ func (op Operation) Execute(ctx context.Context) error {
 	batching := op.Batches.Valid()
 	retrySupported := false
 	first := true
+	// attempt is the 1-based number of the current attempt to execute the current batch.
+	attempt := 1
 	currIndex := 0
 
 	// deprioritizedServers are a running list of servers that should be
@@ -573,6 +615,7 @@ func (op Operation) Execute(ctx context.Context) error {
 	// retry loop variables to request a new server and a new connection for the next attempt.
 	resetForRetry := func(err error) {
 		retries--
+		attempt++
 		prevErr = err
 
 		// Set the previous indefinite error to be returned in any case where a retryable write error does not have a
@@ -812,6 +855,9 @@ func (op Operation) Execute(ctx context.Context) error {
 			if ep, ok := srvr.(ErrorProcessor); ok {
 				_ = ep.ProcessError(err, conn)
 			}
+			if err != nil && ctx.Err() != nil {
+				killInterrupted = op.interruptedKiller(srvr, startedInfo.cmdName, startedInfo.cmd)
+			}
 		}
 
 		finishedInfo.response = res
@@ -846,9 +892,10 @@ func (op Operation) Execute(ctx context.Context) error {
 			}
 
 			// If retries are supported for the current operation on the first server description,
-			// the error is considered retryable, and there are retries remaining (negative retries
-			// means retry indefinitely), then retry the operation.
-			if retrySupported && retryableErr && retries != 0 {
+			// the error is considered retryable by the standard rules or the Deployment's RetryDecider,
+			// and there are retries remaining (negative retries means retry indefinitely), then retry
+			// the operation.
+			if retrySupported && retries != 0 && (retryableErr || op.customRetryable(tt, attempt)) {
 				if op.Client != nil && op.Client.Committing {
 					// Apply majority write concern for retries
 					op.Client.UpdateCommitTransactionWriteConcern()
@@ -965,9 +1012,10 @@ func (op Operation) Execute(ctx context.Context) error {
 			}
 
 			// If retries are supported for the current operation on the first server description,
-			// the error is considered retryable, and there are retries remaining (negative retries
-			// means retry indefinitely), then retry the operation.
-			if retrySupported && retryableErr && retries != 0 {
+			// the error is considered retryable by the standard rules or the Deployment's RetryDecider,
+			// and there are retries remaining (negative retries means retry indefinitely), then retry
+			// the operation.
+			if retrySupported && retries != 0 && (retryableErr || op.customRetryable(tt, attempt)) {
 				if op.Client != nil && op.Client.Committing {
 					// Apply majority write concern for retries
 					op.Client.UpdateCommitTransactionWriteConcern()
@@ -1053,6 +1101,7 @@ func (op Operation) Execute(ctx context.Context) error {
 					retries = 1
 				}
 			}
+			attempt = 1
 			currIndex += len(op.Batches.Current)
 			op.Batches.ClearBatch()
 			continue
@@ -1089,6 +1138,13 @@ func (op Operation) retryable(desc description.Server) bool {
 	return false
 }
 
+// customRetryable reports whether the RetryDecider of the Deployment, if it implements one, chooses to retry err,
+// which was returned by the given 1-based attempt.
+func (op Operation) customRetryable(err error, attempt int) bool {
+	decider, ok := op.Deployment.(RetryDecider)
+	return ok && decider.ShouldRetry(err, attempt)
+}
+
 // roundTrip writes a wiremessage to the connection and then reads a wiremessage. The wm parameter
 // is reused when reading the wiremessage.
 func (op Operation) roundTrip(ctx context.Context, conn Connection, wm []byte) ([]byte, error) {
@@ -1590,8 +1646,9 @@ func (op Operation) addClusterTime(dst []byte, desc description.SelectedServer)
 // calculateMaxTimeMS calculates the value of the 'maxTimeMS' field to potentially append
 // to the wire message based on the current context's deadline and the 90th percentile RTT
 // if the ctx is a Timeout context. If the context is not a Timeout context, it uses the
-// operation's MaxTimeMS if set. If no MaxTimeMS is set on the operation, and context is
-// not a Timeout context, calculateMaxTimeMS returns 0.
+// operation's MaxTimeMS if set. If no MaxTimeMS is set on the operation, the operation is a
+// Read and DeriveMaxTimeFromContext is set, the context's deadline is used as if ctx were a
+// Timeout context. Otherwise, calculateMaxTimeMS returns 0.
 func (op Operation) calculateMaxTimeMS(ctx context.Context, mon RTTMonitor) (uint64, error) {
 	// If CSOT is enabled and we're not omitting the CSOT-calculated maxTimeMS
 	// value, then calculate maxTimeMS.
@@ -1605,31 +1662,7 @@ func (op Operation) calculateMaxTimeMS(ctx context.Context, mon RTTMonitor) (uin
 	// CSOT-calculated maxTimeMS values if that's the behavior they want.
 	if csot.IsTimeoutContext(ctx) && !op.OmitCSOTMaxTimeMS {
 		if deadline, ok := ctx.Deadline(); ok {
-			remainingTimeout := time.Until(deadline)
-			rtt90 := mon.P90()
-			maxTime := remainingTimeout - rtt90
-
-			// Always round up to the next millisecond value so we never truncate the calculated
-			// maxTimeMS value (e.g. 400 microseconds evaluates to 1ms, not 0ms).
-			maxTimeMS := int64((maxTime + (time.Millisecond - 1)) / time.Millisecond)
-			if maxTimeMS <= 0 {
-				return 0, fmt.Errorf(
-					"negative maxTimeMS: remaining time %v until context deadline is less than 90th percentile network round-trip time (%v): %w",
-					remainingTimeout,
-					mon.Stats(),
-					ErrDeadlineWouldBeExceeded)
-			}
-
-			// The server will return a "BadValue" error if maxTimeMS is greater
-			// than the maximum positive int32 value (about 24.9 days). If the
-			// user specified a timeout value greater than that,  omit maxTimeMS
-			// and let the client-side timeout handle cancelling the op if the
-			// timeout is ever reached.
-			if maxTimeMS > math.MaxInt32 {
-				return 0, nil
-			}
-
-			return uint64(maxTimeMS), nil
+			return maxTimeMSFromDeadline(deadline, mon)
 		}
 	} else if op.MaxTime != nil {
 		// Users are not allowed to pass a negative value as MaxTime. A value of 0 would indicate
@@ -1640,10 +1673,46 @@ func (op Operation) calculateMaxTimeMS(ctx context.Context, mon RTTMonitor) (uin
 		// Always round up to the next millisecond value so we never truncate the requested
 		// MaxTime value (e.g. 400 microseconds evaluates to 1ms, not 0ms).
 		return uint64((*op.MaxTime + (time.Millisecond - 1)) / time.Millisecond), nil
+	} else if op.DeriveMaxTimeFromContext && op.Type == Read && !op.OmitCSOTMaxTimeMS {
+		// Operations that omit the CSOT maxTimeMS return a cursor that can outlive ctx, so a maxTimeMS derived from
+		// ctx would cap the lifetime of the cursor on the server.
+		if deadline, ok := ctx.Deadline(); ok {
+			return maxTimeMSFromDeadline(deadline, mon)
+		}
 	}
 	return 0, nil
 }
 
+// maxTimeMSFromDeadline calculates a 'maxTimeMS' value from the time remaining until deadline
+// minus the 90th percentile RTT reported by mon.
+func maxTimeMSFromDeadline(deadline time.Time, mon RTTMonitor) (uint64, error) {
+	remainingTimeout := time.Until(deadline)
+	rtt90 := mon.P90()
+	maxTime := remainingTimeout - rtt90
+
+	// Always round up to the next millisecond value so we never truncate the calculated
+	// maxTimeMS value (e.g. 400 microseconds evaluates to 1ms, not 0ms).
+	maxTimeMS := int64((maxTime + (time.Millisecond - 1)) / time.Millisecond)
+	if maxTimeMS <= 0 {
+		return 0, fmt.Errorf(
+			"negative maxTimeMS: remaining time %v until context deadline is less than 90th percentile network round-trip time (%v): %w",
+			remainingTimeout,
+			mon.Stats(),
+			ErrDeadlineWouldBeExceeded)
+	}
+
+	// The server will return a "BadValue" error if maxTimeMS is greater
+	// than the maximum positive int32 value (about 24.9 days). If the
+	// user specified a timeout value greater than that,  omit maxTimeMS
+	// and let the client-side timeout handle cancelling the op if the
+	// timeout is ever reached.
+	if maxTimeMS > math.MaxInt32 {
+		return 0, nil
+	}
+
+	return uint64(maxTimeMS), nil
+}
+
 // updateClusterTimes updates the cluster times for the session and cluster clock attached to this
 // operation. While the session's AdvanceClusterTime may return an error, this method does not
 // because an error being returned from this method will not be returned further up.
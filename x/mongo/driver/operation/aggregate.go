@@ -53,7 +53,9 @@ type Aggregate struct {
 	timeout                  *time.Duration
 	omitCSOTMaxTimeMS        bool
 
-	result driver.CursorResponse
+	result                   driver.CursorResponse
+	deriveMaxTimeFromContext bool
+	bypassAutoEncryption     bool
 }
 
 // NewAggregate constructs and returns a new Aggregate.
@@ -117,6 +119,8 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Name:                           driverutil.AggregateOp,
 		OmitCSOTMaxTimeMS:              a.omitCSOTMaxTimeMS,
 		Authenticator:                  a.authenticator,
+		DeriveMaxTimeFromContext:       a.deriveMaxTimeFromContext,
+		BypassAutoEncryption:           a.bypassAutoEncryption,
 	}.Execute(ctx)
 
 }
@@ -445,3 +449,25 @@ func (a *Aggregate) Authenticator(authenticator driver.Authenticator) *Aggregate
 	a.authenticator = authenticator
 	return a
 }
+
+// DeriveMaxTimeFromContext calculates "maxTimeMS" from the context deadline
+// when no other "maxTimeMS" value applies.
+func (a *Aggregate) DeriveMaxTimeFromContext(derive bool) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.deriveMaxTimeFromContext = derive
+	return a
+}
+
+// BypassAutoEncryption specifies that the command should not be automatically encrypted, overriding
+// the client-wide auto-encryption setting. Results are still automatically decrypted.
+func (a *Aggregate) BypassAutoEncryption(bypassAutoEncryption bool) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.bypassAutoEncryption = bypassAutoEncryption
+	return a
+}
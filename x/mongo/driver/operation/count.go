@@ -25,24 +25,25 @@ import (
 
 // Count represents a count operation.
 type Count struct {
-	authenticator  driver.Authenticator
-	maxTime        *time.Duration
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	comment        bsoncore.Value
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         CountResult
-	serverAPI      *driver.ServerAPIOptions
-	timeout        *time.Duration
+	authenticator            driver.Authenticator
+	maxTime                  *time.Duration
+	query                    bsoncore.Document
+	session                  *session.Client
+	clock                    *session.ClusterClock
+	collection               string
+	comment                  bsoncore.Value
+	monitor                  *event.CommandMonitor
+	crypt                    driver.Crypt
+	database                 string
+	deployment               driver.Deployment
+	readConcern              *readconcern.ReadConcern
+	readPreference           *readpref.ReadPref
+	selector                 description.ServerSelector
+	retry                    *driver.RetryMode
+	result                   CountResult
+	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
+	deriveMaxTimeFromContext bool
 }
 
 // CountResult represents a count result returned by the server.
@@ -112,24 +113,25 @@ func (c *Count) Execute(ctx context.Context) error {
 	}
 
 	err := driver.Operation{
-		CommandFn:         c.command,
-		ProcessResponseFn: c.processResponse,
-		RetryMode:         c.retry,
-		Type:              driver.Read,
-		Client:            c.session,
-		Clock:             c.clock,
-		CommandMonitor:    c.monitor,
-		Crypt:             c.crypt,
-		Database:          c.database,
-		Deployment:        c.deployment,
-		MaxTime:           c.maxTime,
-		ReadConcern:       c.readConcern,
-		ReadPreference:    c.readPreference,
-		Selector:          c.selector,
-		ServerAPI:         c.serverAPI,
-		Timeout:           c.timeout,
-		Name:              driverutil.CountOp,
-		Authenticator:     c.authenticator,
+		CommandFn:                c.command,
+		ProcessResponseFn:        c.processResponse,
+		RetryMode:                c.retry,
+		Type:                     driver.Read,
+		Client:                   c.session,
+		Clock:                    c.clock,
+		CommandMonitor:           c.monitor,
+		Crypt:                    c.crypt,
+		Database:                 c.database,
+		Deployment:               c.deployment,
+		MaxTime:                  c.maxTime,
+		ReadConcern:              c.readConcern,
+		ReadPreference:           c.readPreference,
+		Selector:                 c.selector,
+		ServerAPI:                c.serverAPI,
+		Timeout:                  c.timeout,
+		Name:                     driverutil.CountOp,
+		Authenticator:            c.authenticator,
+		DeriveMaxTimeFromContext: c.deriveMaxTimeFromContext,
 	}.Execute(ctx)
 
 	// Swallow error if NamespaceNotFound(26) is returned from aggregate on non-existent namespace
@@ -323,3 +325,14 @@ func (c *Count) Authenticator(authenticator driver.Authenticator) *Count {
 	c.authenticator = authenticator
 	return c
 }
+
+// DeriveMaxTimeFromContext calculates "maxTimeMS" from the context deadline
+// when no other "maxTimeMS" value applies.
+func (c *Count) DeriveMaxTimeFromContext(derive bool) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.deriveMaxTimeFromContext = derive
+	return c
+}
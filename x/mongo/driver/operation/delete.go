@@ -25,32 +25,35 @@ import (
 
 // Delete performs a delete operation
 type Delete struct {
-	authenticator driver.Authenticator
-	comment       bsoncore.Value
-	deletes       []bsoncore.Document
-	ordered       *bool
-	session       *session.Client
-	clock         *session.ClusterClock
-	collection    string
-	monitor       *event.CommandMonitor
-	crypt         driver.Crypt
-	database      string
-	deployment    driver.Deployment
-	selector      description.ServerSelector
-	writeConcern  *writeconcern.WriteConcern
-	retry         *driver.RetryMode
-	hint          *bool
-	result        DeleteResult
-	serverAPI     *driver.ServerAPIOptions
-	let           bsoncore.Document
-	timeout       *time.Duration
-	logger        *logger.Logger
+	authenticator        driver.Authenticator
+	comment              bsoncore.Value
+	deletes              []bsoncore.Document
+	ordered              *bool
+	session              *session.Client
+	clock                *session.ClusterClock
+	collection           string
+	monitor              *event.CommandMonitor
+	crypt                driver.Crypt
+	database             string
+	deployment           driver.Deployment
+	selector             description.ServerSelector
+	writeConcern         *writeconcern.WriteConcern
+	retry                *driver.RetryMode
+	hint                 *bool
+	result               DeleteResult
+	serverAPI            *driver.ServerAPIOptions
+	let                  bsoncore.Document
+	timeout              *time.Duration
+	logger               *logger.Logger
+	bypassAutoEncryption bool
 }
 
 // DeleteResult represents a delete result returned by the server.
 type DeleteResult struct {
 	// Number of documents successfully deleted.
 	N int64
+
+	WriteTimes
 }
 
 func buildDeleteResult(response bsoncore.Document) (DeleteResult, error) {
@@ -84,6 +87,7 @@ func (d *Delete) Result() DeleteResult { return d.result }
 func (d *Delete) processResponse(info driver.ResponseInfo) error {
 	dr, err := buildDeleteResult(info.ServerResponse)
 	d.result.N += dr.N
+	d.result.update(info.ServerResponse)
 	return err
 }
 
@@ -99,24 +103,25 @@ func (d *Delete) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         d.command,
-		ProcessResponseFn: d.processResponse,
-		Batches:           batches,
-		RetryMode:         d.retry,
-		Type:              driver.Write,
-		Client:            d.session,
-		Clock:             d.clock,
-		CommandMonitor:    d.monitor,
-		Crypt:             d.crypt,
-		Database:          d.database,
-		Deployment:        d.deployment,
-		Selector:          d.selector,
-		WriteConcern:      d.writeConcern,
-		ServerAPI:         d.serverAPI,
-		Timeout:           d.timeout,
-		Logger:            d.logger,
-		Name:              driverutil.DeleteOp,
-		Authenticator:     d.authenticator,
+		CommandFn:            d.command,
+		ProcessResponseFn:    d.processResponse,
+		Batches:              batches,
+		RetryMode:            d.retry,
+		Type:                 driver.Write,
+		Client:               d.session,
+		Clock:                d.clock,
+		CommandMonitor:       d.monitor,
+		Crypt:                d.crypt,
+		Database:             d.database,
+		Deployment:           d.deployment,
+		Selector:             d.selector,
+		WriteConcern:         d.writeConcern,
+		ServerAPI:            d.serverAPI,
+		Timeout:              d.timeout,
+		Logger:               d.logger,
+		Name:                 driverutil.DeleteOp,
+		Authenticator:        d.authenticator,
+		BypassAutoEncryption: d.bypassAutoEncryption,
 	}.Execute(ctx)
 
 }
@@ -339,3 +344,14 @@ func (d *Delete) Authenticator(authenticator driver.Authenticator) *Delete {
 	d.authenticator = authenticator
 	return d
 }
+
+// BypassAutoEncryption specifies that the command should not be automatically encrypted, overriding
+// the client-wide auto-encryption setting. Results are still automatically decrypted.
+func (d *Delete) BypassAutoEncryption(bypassAutoEncryption bool) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.bypassAutoEncryption = bypassAutoEncryption
+	return d
+}
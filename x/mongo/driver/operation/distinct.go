@@ -24,26 +24,27 @@ import (
 
 // Distinct performs a distinct operation.
 type Distinct struct {
-	authenticator  driver.Authenticator
-	collation      bsoncore.Document
-	key            *string
-	maxTime        *time.Duration
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	comment        bsoncore.Value
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         DistinctResult
-	serverAPI      *driver.ServerAPIOptions
-	timeout        *time.Duration
+	authenticator            driver.Authenticator
+	collation                bsoncore.Document
+	key                      *string
+	maxTime                  *time.Duration
+	query                    bsoncore.Document
+	session                  *session.Client
+	clock                    *session.ClusterClock
+	collection               string
+	comment                  bsoncore.Value
+	monitor                  *event.CommandMonitor
+	crypt                    driver.Crypt
+	database                 string
+	deployment               driver.Deployment
+	readConcern              *readconcern.ReadConcern
+	readPreference           *readpref.ReadPref
+	selector                 description.ServerSelector
+	retry                    *driver.RetryMode
+	result                   DistinctResult
+	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
+	deriveMaxTimeFromContext bool
 }
 
 // DistinctResult represents a distinct result returned by the server.
@@ -90,24 +91,25 @@ func (d *Distinct) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         d.command,
-		ProcessResponseFn: d.processResponse,
-		RetryMode:         d.retry,
-		Type:              driver.Read,
-		Client:            d.session,
-		Clock:             d.clock,
-		CommandMonitor:    d.monitor,
-		Crypt:             d.crypt,
-		Database:          d.database,
-		Deployment:        d.deployment,
-		MaxTime:           d.maxTime,
-		ReadConcern:       d.readConcern,
-		ReadPreference:    d.readPreference,
-		Selector:          d.selector,
-		ServerAPI:         d.serverAPI,
-		Timeout:           d.timeout,
-		Name:              driverutil.DistinctOp,
-		Authenticator:     d.authenticator,
+		CommandFn:                d.command,
+		ProcessResponseFn:        d.processResponse,
+		RetryMode:                d.retry,
+		Type:                     driver.Read,
+		Client:                   d.session,
+		Clock:                    d.clock,
+		CommandMonitor:           d.monitor,
+		Crypt:                    d.crypt,
+		Database:                 d.database,
+		Deployment:               d.deployment,
+		MaxTime:                  d.maxTime,
+		ReadConcern:              d.readConcern,
+		ReadPreference:           d.readPreference,
+		Selector:                 d.selector,
+		ServerAPI:                d.serverAPI,
+		Timeout:                  d.timeout,
+		Name:                     driverutil.DistinctOp,
+		Authenticator:            d.authenticator,
+		DeriveMaxTimeFromContext: d.deriveMaxTimeFromContext,
 	}.Execute(ctx)
 
 }
@@ -322,3 +324,14 @@ func (d *Distinct) Authenticator(authenticator driver.Authenticator) *Distinct {
 	d.authenticator = authenticator
 	return d
 }
+
+// DeriveMaxTimeFromContext calculates "maxTimeMS" from the context deadline
+// when no other "maxTimeMS" value applies.
+func (d *Distinct) DeriveMaxTimeFromContext(derive bool) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.deriveMaxTimeFromContext = derive
+	return d
+}
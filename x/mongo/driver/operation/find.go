@@ -25,46 +25,48 @@ import (
 
 // Find performs a find operation.
 type Find struct {
-	authenticator       driver.Authenticator
-	allowDiskUse        *bool
-	allowPartialResults *bool
-	awaitData           *bool
-	batchSize           *int32
-	collation           bsoncore.Document
-	comment             *string
-	filter              bsoncore.Document
-	hint                bsoncore.Value
-	let                 bsoncore.Document
-	limit               *int64
-	max                 bsoncore.Document
-	maxTime             *time.Duration
-	min                 bsoncore.Document
-	noCursorTimeout     *bool
-	oplogReplay         *bool
-	projection          bsoncore.Document
-	returnKey           *bool
-	showRecordID        *bool
-	singleBatch         *bool
-	skip                *int64
-	snapshot            *bool
-	sort                bsoncore.Document
-	tailable            *bool
-	session             *session.Client
-	clock               *session.ClusterClock
-	collection          string
-	monitor             *event.CommandMonitor
-	crypt               driver.Crypt
-	database            string
-	deployment          driver.Deployment
-	readConcern         *readconcern.ReadConcern
-	readPreference      *readpref.ReadPref
-	selector            description.ServerSelector
-	retry               *driver.RetryMode
-	result              driver.CursorResponse
-	serverAPI           *driver.ServerAPIOptions
-	timeout             *time.Duration
-	omitCSOTMaxTimeMS   bool
-	logger              *logger.Logger
+	authenticator            driver.Authenticator
+	allowDiskUse             *bool
+	allowPartialResults      *bool
+	awaitData                *bool
+	batchSize                *int32
+	collation                bsoncore.Document
+	comment                  *string
+	filter                   bsoncore.Document
+	hint                     bsoncore.Value
+	let                      bsoncore.Document
+	limit                    *int64
+	max                      bsoncore.Document
+	maxTime                  *time.Duration
+	min                      bsoncore.Document
+	noCursorTimeout          *bool
+	oplogReplay              *bool
+	projection               bsoncore.Document
+	returnKey                *bool
+	showRecordID             *bool
+	singleBatch              *bool
+	skip                     *int64
+	snapshot                 *bool
+	sort                     bsoncore.Document
+	tailable                 *bool
+	session                  *session.Client
+	clock                    *session.ClusterClock
+	collection               string
+	monitor                  *event.CommandMonitor
+	crypt                    driver.Crypt
+	database                 string
+	deployment               driver.Deployment
+	readConcern              *readconcern.ReadConcern
+	readPreference           *readpref.ReadPref
+	selector                 description.ServerSelector
+	retry                    *driver.RetryMode
+	result                   driver.CursorResponse
+	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
+	omitCSOTMaxTimeMS        bool
+	logger                   *logger.Logger
+	deriveMaxTimeFromContext bool
+	bypassAutoEncryption     bool
 }
 
 // NewFind constructs and returns a new Find.
@@ -93,27 +95,29 @@ func (f *Find) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         f.command,
-		ProcessResponseFn: f.processResponse,
-		RetryMode:         f.retry,
-		Type:              driver.Read,
-		Client:            f.session,
-		Clock:             f.clock,
-		CommandMonitor:    f.monitor,
-		Crypt:             f.crypt,
-		Database:          f.database,
-		Deployment:        f.deployment,
-		MaxTime:           f.maxTime,
-		ReadConcern:       f.readConcern,
-		ReadPreference:    f.readPreference,
-		Selector:          f.selector,
-		Legacy:            driver.LegacyFind,
-		ServerAPI:         f.serverAPI,
-		Timeout:           f.timeout,
-		Logger:            f.logger,
-		Name:              driverutil.FindOp,
-		OmitCSOTMaxTimeMS: f.omitCSOTMaxTimeMS,
-		Authenticator:     f.authenticator,
+		CommandFn:                f.command,
+		ProcessResponseFn:        f.processResponse,
+		RetryMode:                f.retry,
+		Type:                     driver.Read,
+		Client:                   f.session,
+		Clock:                    f.clock,
+		CommandMonitor:           f.monitor,
+		Crypt:                    f.crypt,
+		Database:                 f.database,
+		Deployment:               f.deployment,
+		MaxTime:                  f.maxTime,
+		ReadConcern:              f.readConcern,
+		ReadPreference:           f.readPreference,
+		Selector:                 f.selector,
+		Legacy:                   driver.LegacyFind,
+		ServerAPI:                f.serverAPI,
+		Timeout:                  f.timeout,
+		Logger:                   f.logger,
+		Name:                     driverutil.FindOp,
+		OmitCSOTMaxTimeMS:        f.omitCSOTMaxTimeMS,
+		Authenticator:            f.authenticator,
+		DeriveMaxTimeFromContext: f.deriveMaxTimeFromContext,
+		BypassAutoEncryption:     f.bypassAutoEncryption,
 	}.Execute(ctx)
 
 }
@@ -587,3 +591,25 @@ func (f *Find) Authenticator(authenticator driver.Authenticator) *Find {
 	f.authenticator = authenticator
 	return f
 }
+
+// DeriveMaxTimeFromContext calculates "maxTimeMS" from the context deadline
+// when no other "maxTimeMS" value applies.
+func (f *Find) DeriveMaxTimeFromContext(derive bool) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.deriveMaxTimeFromContext = derive
+	return f
+}
+
+// BypassAutoEncryption specifies that the command should not be automatically encrypted, overriding
+// the client-wide auto-encryption setting. Results are still automatically decrypted.
+func (f *Find) BypassAutoEncryption(bypassAutoEncryption bool) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.bypassAutoEncryption = bypassAutoEncryption
+	return f
+}
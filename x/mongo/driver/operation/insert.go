@@ -45,12 +45,16 @@ type Insert struct {
 	timeout                  *time.Duration
 	bypassEmptyTsReplacement *bool
 	logger                   *logger.Logger
+	bypassAutoEncryption     bool
+	progress                 func(inserted, total int)
 }
 
 // InsertResult represents an insert result returned by the server.
 type InsertResult struct {
 	// Number of documents successfully inserted.
 	N int64
+
+	WriteTimes
 }
 
 func buildInsertResult(response bsoncore.Document) (InsertResult, error) {
@@ -84,6 +88,10 @@ func (i *Insert) Result() InsertResult { return i.result }
 func (i *Insert) processResponse(info driver.ResponseInfo) error {
 	ir, err := buildInsertResult(info.ServerResponse)
 	i.result.N += ir.N
+	i.result.update(info.ServerResponse)
+	if i.progress != nil {
+		i.progress(int(i.result.N), len(i.documents))
+	}
 	return err
 }
 
@@ -99,24 +107,25 @@ func (i *Insert) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         i.command,
-		ProcessResponseFn: i.processResponse,
-		Batches:           batches,
-		RetryMode:         i.retry,
-		Type:              driver.Write,
-		Client:            i.session,
-		Clock:             i.clock,
-		CommandMonitor:    i.monitor,
-		Crypt:             i.crypt,
-		Database:          i.database,
-		Deployment:        i.deployment,
-		Selector:          i.selector,
-		WriteConcern:      i.writeConcern,
-		ServerAPI:         i.serverAPI,
-		Timeout:           i.timeout,
-		Logger:            i.logger,
-		Name:              driverutil.InsertOp,
-		Authenticator:     i.authenticator,
+		CommandFn:            i.command,
+		ProcessResponseFn:    i.processResponse,
+		Batches:              batches,
+		RetryMode:            i.retry,
+		Type:                 driver.Write,
+		Client:               i.session,
+		Clock:                i.clock,
+		CommandMonitor:       i.monitor,
+		Crypt:                i.crypt,
+		Database:             i.database,
+		Deployment:           i.deployment,
+		Selector:             i.selector,
+		WriteConcern:         i.writeConcern,
+		ServerAPI:            i.serverAPI,
+		Timeout:              i.timeout,
+		Logger:               i.logger,
+		Name:                 driverutil.InsertOp,
+		Authenticator:        i.authenticator,
+		BypassAutoEncryption: i.bypassAutoEncryption,
 	}.Execute(ctx)
 
 }
@@ -331,3 +340,25 @@ func (i *Insert) BypassEmptyTsReplacement(bypassEmptyTsReplacement bool) *Insert
 	i.bypassEmptyTsReplacement = &bypassEmptyTsReplacement
 	return i
 }
+
+// BypassAutoEncryption specifies that the command should not be automatically encrypted, overriding
+// the client-wide auto-encryption setting. Results are still automatically decrypted.
+func (i *Insert) BypassAutoEncryption(bypassAutoEncryption bool) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.bypassAutoEncryption = bypassAutoEncryption
+	return i
+}
+
+// Progress sets a function that is called after the response to each batch of documents is processed with the
+// number of documents inserted so far and the total number of documents.
+func (i *Insert) Progress(fn func(inserted, total int)) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.progress = fn
+	return i
+}
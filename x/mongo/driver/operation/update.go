@@ -49,6 +49,7 @@ type Update struct {
 	timeout                  *time.Duration
 	bypassEmptyTsReplacement *bool
 	logger                   *logger.Logger
+	bypassAutoEncryption     bool
 }
 
 // Upsert contains the information for an upsert in an Update operation.
@@ -65,6 +66,8 @@ type UpdateResult struct {
 	NModified int64
 	// Information about upserted documents.
 	Upserted []Upsert
+
+	WriteTimes
 }
 
 func buildUpdateResult(response bsoncore.Document) (UpdateResult, error) {
@@ -136,6 +139,7 @@ func (u *Update) processResponse(info driver.ResponseInfo) error {
 		}
 	}
 	u.result.Upserted = append(u.result.Upserted, ur.Upserted...)
+	u.result.update(info.ServerResponse)
 	return err
 
 }
@@ -152,24 +156,25 @@ func (u *Update) Execute(ctx context.Context) error {
 	}
 
 	return driver.Operation{
-		CommandFn:         u.command,
-		ProcessResponseFn: u.processResponse,
-		Batches:           batches,
-		RetryMode:         u.retry,
-		Type:              driver.Write,
-		Client:            u.session,
-		Clock:             u.clock,
-		CommandMonitor:    u.monitor,
-		Database:          u.database,
-		Deployment:        u.deployment,
-		Selector:          u.selector,
-		WriteConcern:      u.writeConcern,
-		Crypt:             u.crypt,
-		ServerAPI:         u.serverAPI,
-		Timeout:           u.timeout,
-		Logger:            u.logger,
-		Name:              driverutil.UpdateOp,
-		Authenticator:     u.authenticator,
+		CommandFn:            u.command,
+		ProcessResponseFn:    u.processResponse,
+		Batches:              batches,
+		RetryMode:            u.retry,
+		Type:                 driver.Write,
+		Client:               u.session,
+		Clock:                u.clock,
+		CommandMonitor:       u.monitor,
+		Database:             u.database,
+		Deployment:           u.deployment,
+		Selector:             u.selector,
+		WriteConcern:         u.writeConcern,
+		Crypt:                u.crypt,
+		ServerAPI:            u.serverAPI,
+		Timeout:              u.timeout,
+		Logger:               u.logger,
+		Name:                 driverutil.UpdateOp,
+		Authenticator:        u.authenticator,
+		BypassAutoEncryption: u.bypassAutoEncryption,
 	}.Execute(ctx)
 
 }
@@ -440,3 +445,14 @@ func (u *Update) BypassEmptyTsReplacement(bypassEmptyTsReplacement bool) *Update
 	u.bypassEmptyTsReplacement = &bypassEmptyTsReplacement
 	return u
 }
+
+// BypassAutoEncryption specifies that the command should not be automatically encrypted, overriding
+// the client-wide auto-encryption setting. Results are still automatically decrypted.
+func (u *Update) BypassAutoEncryption(bypassAutoEncryption bool) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.bypassAutoEncryption = bypassAutoEncryption
+	return u
+}
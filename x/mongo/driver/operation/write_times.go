@@ -0,0 +1,33 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// WriteTimes contains the logical times reported by the server in its response to a write command. If a write is
+// split into multiple batches, they are the times reported for the last batch that was acknowledged.
+type WriteTimes struct {
+	// OperationTime is the operationTime of the write, or nil if the server did not report one.
+	OperationTime *primitive.Timestamp
+
+	// ClusterTime is the $clusterTime document, or nil if the server did not report one.
+	ClusterTime bsoncore.Document
+}
+
+// update sets the times reported in response, leaving times that response does not contain unchanged.
+func (wt *WriteTimes) update(response bsoncore.Document) {
+	if t, i, ok := response.Lookup("operationTime").TimestampOK(); ok {
+		wt.OperationTime = &primitive.Timestamp{T: t, I: i}
+	}
+	if doc, ok := response.Lookup("$clusterTime").DocumentOK(); ok {
+		// The response may be backed by a buffer that is reused, so the document is copied.
+		wt.ClusterTime = bsoncore.Document(append([]byte(nil), doc...))
+	}
+}
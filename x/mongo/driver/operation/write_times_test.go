@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestWriteTimes(t *testing.T) {
+	clusterTime := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendTimestampElement(nil, "clusterTime", 10, 2),
+	))
+	response := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "n", 1),
+		bsoncore.AppendDocumentElement(nil, "$clusterTime", clusterTime),
+		bsoncore.AppendTimestampElement(nil, "operationTime", 10, 1),
+		bsoncore.AppendDoubleElement(nil, "ok", 1),
+	)
+
+	t.Run("response with times", func(t *testing.T) {
+		var wt WriteTimes
+		wt.update(response)
+
+		want := &primitive.Timestamp{T: 10, I: 1}
+		assert.Equal(t, want, wt.OperationTime, "expected operation time %v, got %v", want, wt.OperationTime)
+		assert.Equal(t, clusterTime, wt.ClusterTime, "expected cluster time %v, got %v", clusterTime, wt.ClusterTime)
+
+		// The cluster time must not share memory with the response.
+		copy(response, make([]byte, len(response)))
+		assert.Equal(t, clusterTime, wt.ClusterTime, "expected cluster time to be copied")
+	})
+	t.Run("response without times", func(t *testing.T) {
+		wt := WriteTimes{
+			OperationTime: &primitive.Timestamp{T: 5, I: 1},
+			ClusterTime:   clusterTime,
+		}
+		wt.update(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDoubleElement(nil, "ok", 1)))
+
+		want := &primitive.Timestamp{T: 5, I: 1}
+		assert.Equal(t, want, wt.OperationTime, "expected operation time %v, got %v", want, wt.OperationTime)
+		assert.Equal(t, clusterTime, wt.ClusterTime, "expected cluster time %v, got %v", clusterTime, wt.ClusterTime)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// killOnCancelTimeout bounds the time spent killing an operation after its Context was cancelled.
+const killOnCancelTimeout = 10 * time.Second
+
+// killOnCancel reports whether the Deployment is configured to kill operations whose Context is cancelled.
+func killOnCancel(d Deployment) bool {
+	killer, ok := d.(OperationKiller)
+	return ok && killer.KillOnCancel()
+}
+
+// interruptedKiller returns a function that makes a best-effort attempt to kill the server-side work of the command
+// cmd, which was sent to srvr but interrupted because the operation's Context was cancelled, or nil if the command
+// should not be killed. The operation is looked up by the session ID in the command, so commands that were not sent
+// with a session are not killed. Cursors are killed by BatchCursor instead, so getMore and killCursors commands are
+// ignored.
+//
+// The returned function blocks for up to killOnCancelTimeout. It must be run before the session is used for another
+// command so that only the interrupted command can match the lookup.
+func (op Operation) interruptedKiller(srvr Server, cmdName string, cmd bsoncore.Document) func() {
+	if !killOnCancel(op.Deployment) || cmdName == "getMore" || cmdName == "killCursors" {
+		return nil
+	}
+	lsid, err := cmd.LookupErr("lsid", "id")
+	if err != nil {
+		return nil
+	}
+	// Copy the session ID because cmd is backed by a buffer that is reused once the operation completes.
+	lsid.Data = append([]byte(nil), lsid.Data...)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), killOnCancelTimeout)
+		defer cancel()
+
+		opids, err := op.findSessionOperations(ctx, srvr, lsid, cmdName)
+		if err != nil {
+			return
+		}
+		for _, opid := range opids {
+			_ = op.runKillCommand(ctx, srvr, func(dst []byte) []byte {
+				dst = bsoncore.AppendInt32Element(dst, "killOp", 1)
+				return bsoncore.AppendValueElement(dst, "op", opid)
+			}, nil)
+		}
+	}
+}
+
+// findSessionOperations returns the opids of the in-progress cmdName commands on srvr that run in the session with
+// the given ID.
+func (op Operation) findSessionOperations(
+	ctx context.Context,
+	srvr Server,
+	lsid bsoncore.Value,
+	cmdName string,
+) ([]bsoncore.Value, error) {
+	var opids []bsoncore.Value
+	err := op.runKillCommand(ctx, srvr, func(dst []byte) []byte {
+		dst = bsoncore.AppendInt32Element(dst, "aggregate", 1)
+		dst = bsoncore.BuildArrayElement(dst, "pipeline",
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.NewDocumentBuilder().
+				AppendDocument("$currentOp", bsoncore.NewDocumentBuilder().Build()).
+				Build()},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.NewDocumentBuilder().
+				AppendDocument("$match", bsoncore.NewDocumentBuilder().
+					AppendValue("lsid.id", lsid).
+					AppendDocument("command."+cmdName, bsoncore.NewDocumentBuilder().
+						AppendBoolean("$exists", true).
+						Build()).
+					Build()).
+				Build()},
+		)
+		return bsoncore.AppendDocumentElement(dst, "cursor", bsoncore.NewDocumentBuilder().Build())
+	}, func(response bsoncore.Document) {
+		batch, ok := response.Lookup("cursor", "firstBatch").ArrayOK()
+		if !ok {
+			return
+		}
+		vals, _ := batch.Values()
+		for _, val := range vals {
+			doc, ok := val.DocumentOK()
+			if !ok {
+				continue
+			}
+			if opid, err := doc.LookupErr("opid"); err == nil {
+				opid.Data = append([]byte(nil), opid.Data...)
+				opids = append(opids, opid)
+			}
+		}
+	})
+	return opids, err
+}
+
+// runKillCommand runs the command built by cmdFn against the admin database on srvr without a session and passes
+// the response to processFn if it is not nil.
+func (op Operation) runKillCommand(
+	ctx context.Context,
+	srvr Server,
+	cmdFn func([]byte) []byte,
+	processFn func(bsoncore.Document),
+) error {
+	return Operation{
+		CommandFn: func(dst []byte, _ description.SelectedServer) ([]byte, error) {
+			return cmdFn(dst), nil
+		},
+		ProcessResponseFn: func(info ResponseInfo) error {
+			if processFn != nil {
+				processFn(info.ServerResponse)
+			}
+			return nil
+		},
+		Database:       "admin",
+		Deployment:     SingleServerDeployment{srvr},
+		Clock:          op.Clock,
+		CommandMonitor: op.CommandMonitor,
+		ServerAPI:      op.ServerAPI,
+		Logger:         op.Logger,
+		Authenticator:  op.Authenticator,
+	}.Execute(ctx)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/csot"
 	"go.mongodb.org/mongo-driver/internal/handshake"
@@ -280,14 +281,17 @@ func TestOperation(t *testing.T) {
 		longRTT := 10 * time.Second
 		timeoutCtx, cancel := csot.MakeTimeoutContext(context.Background(), timeout)
 		defer cancel()
+		deadlineCtx, deadlineCancel := context.WithTimeout(context.Background(), timeout)
+		defer deadlineCancel()
 
 		testCases := []struct {
-			name  string
-			op    Operation
-			ctx   context.Context
-			rtt90 time.Duration
-			want  uint64
-			err   error
+			name    string
+			op      Operation
+			ctx     context.Context
+			rtt90   time.Duration
+			want    uint64
+			err     error
+			derived bool // the value is derived from the context deadline and must not be 0
 		}{
 			{
 				name:  "uses context deadline and rtt90 with timeout",
@@ -321,6 +325,55 @@ func TestOperation(t *testing.T) {
 				want:  0,
 				err:   ErrNegativeMaxTime,
 			},
+			{
+				name:    "derives from context deadline minus rtt90 for reads",
+				op:      Operation{DeriveMaxTimeFromContext: true, Type: Read},
+				ctx:     deadlineCtx,
+				rtt90:   shortRTT,
+				want:    4950,
+				err:     nil,
+				derived: true,
+			},
+			{
+				name:  "does not derive when omitting maxTimeMS for cursors",
+				op:    Operation{DeriveMaxTimeFromContext: true, Type: Read, OmitCSOTMaxTimeMS: true},
+				ctx:   deadlineCtx,
+				rtt90: shortRTT,
+				want:  0,
+				err:   nil,
+			},
+			{
+				name:  "does not derive without context deadline",
+				op:    Operation{DeriveMaxTimeFromContext: true, Type: Read},
+				ctx:   context.Background(),
+				rtt90: shortRTT,
+				want:  0,
+				err:   nil,
+			},
+			{
+				name:  "does not derive for writes",
+				op:    Operation{DeriveMaxTimeFromContext: true, Type: Write},
+				ctx:   deadlineCtx,
+				rtt90: shortRTT,
+				want:  0,
+				err:   nil,
+			},
+			{
+				name:  "prefers MaxTime over derived value",
+				op:    Operation{DeriveMaxTimeFromContext: true, Type: Read, MaxTime: &maxTime},
+				ctx:   deadlineCtx,
+				rtt90: shortRTT,
+				want:  2000,
+				err:   nil,
+			},
+			{
+				name:  "derived value errors when remaining time is less than rtt90",
+				op:    Operation{DeriveMaxTimeFromContext: true, Type: Read},
+				ctx:   deadlineCtx,
+				rtt90: longRTT,
+				want:  0,
+				err:   ErrDeadlineWouldBeExceeded,
+			},
 		}
 		for _, tc := range testCases {
 			// Capture test-case for parallel sub-test.
@@ -336,6 +389,9 @@ func TestOperation(t *testing.T) {
 				if got > tc.want {
 					t.Errorf("maxTimeMS value higher than expected. got %v; wanted at most %v", got, tc.want)
 				}
+				if tc.derived && got == 0 {
+					t.Errorf("expected maxTimeMS to be derived from the context deadline, got 0")
+				}
 				if !errors.Is(err, tc.err) {
 					t.Errorf("error values do not match. got %v; want %v", err, tc.err)
 				}
@@ -612,6 +668,46 @@ func TestOperation(t *testing.T) {
 		assert.Nil(t, err, "ExecuteExhaust error: %v", err)
 		assert.True(t, conn.CurrentlyStreaming(), "expected CurrentlyStreaming to be true")
 	})
+	t.Run("command monitoring events include server connection ID", func(t *testing.T) {
+		serverConnID := int64(42)
+		conn := &mockConnection{
+			rDesc: description.Server{
+				WireVersion: &description.VersionRange{Max: 6},
+			},
+			rReadWM: createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "ok", 1),
+			), false),
+			rID:           "localhost:27017[-1]",
+			rServerConnID: &serverConnID,
+		}
+
+		var started *event.CommandStartedEvent
+		var succeeded *event.CommandSucceededEvent
+		op := Operation{
+			CommandFn: func(dst []byte, _ description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+			},
+			Database:   "admin",
+			Deployment: SingleConnectionDeployment{conn},
+			CommandMonitor: &event.CommandMonitor{
+				Started:   func(_ context.Context, evt *event.CommandStartedEvent) { started = evt },
+				Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) { succeeded = evt },
+			},
+		}
+		err := op.Execute(context.Background())
+		require.NoError(t, err, "Execute error")
+
+		require.NotNil(t, started, "expected a CommandStartedEvent")
+		require.NotNil(t, started.ServerConnectionID64, "expected started event to have a server connection ID")
+		assert.Equal(t, serverConnID, *started.ServerConnectionID64, "expected server connection ID %v, got %v",
+			serverConnID, *started.ServerConnectionID64)
+		assert.Equal(t, conn.rID, started.ConnectionID, "expected connection ID %q, got %q", conn.rID,
+			started.ConnectionID)
+		require.NotNil(t, succeeded, "expected a CommandSucceededEvent")
+		require.NotNil(t, succeeded.ServerConnectionID64, "expected succeeded event to have a server connection ID")
+		assert.Equal(t, serverConnID, *succeeded.ServerConnectionID64, "expected server connection ID %v, got %v",
+			serverConnID, *succeeded.ServerConnectionID64)
+	})
 	t.Run("context deadline exceeded not marked as TransientTransactionError", func(t *testing.T) {
 		conn := new(mockConnection)
 		// Create a context that's already timed out.
@@ -866,6 +962,122 @@ func TestRetry(t *testing.T) {
 			time.Now().After(deadline),
 			"expected operation to complete only after the context deadline is exceeded")
 	})
+	t.Run("RetryDecider", func(t *testing.T) {
+		const customCode = 12345
+		errorResponse := createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", customCode),
+			bsoncore.AppendStringElement(nil, "errmsg", "migration in progress"),
+		), false)
+		okResponse := createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		), false)
+
+		testCases := []struct {
+			name         string
+			retryMode    RetryMode
+			retry        bool
+			responses    [][]byte
+			wantErr      bool
+			wantAttempts []int
+		}{
+			{
+				name:         "forced retry succeeds",
+				retryMode:    RetryOnce,
+				retry:        true,
+				responses:    [][]byte{errorResponse, okResponse},
+				wantAttempts: []int{1},
+			},
+			{
+				name:         "not retried when predicate returns false",
+				retryMode:    RetryOnce,
+				responses:    [][]byte{errorResponse, okResponse},
+				wantErr:      true,
+				wantAttempts: []int{1},
+			},
+			{
+				name:         "retry budget is respected",
+				retryMode:    RetryOnce,
+				retry:        true,
+				responses:    [][]byte{errorResponse, errorResponse, okResponse},
+				wantErr:      true,
+				wantAttempts: []int{1},
+			},
+			{
+				name:      "not called when retries are disabled",
+				retryMode: RetryNone,
+				retry:     true,
+				responses: [][]byte{errorResponse, okResponse},
+				wantErr:   true,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				conn := &scriptedConnection{
+					mockConnection: &mockConnection{
+						rDesc: description.Server{WireVersion: &description.VersionRange{Max: 21}},
+					},
+					responses: tc.responses,
+				}
+				var attempts []int
+				d := retryDeciderDeployment{
+					SingleConnectionDeployment: SingleConnectionDeployment{conn},
+					shouldRetry: func(err error, attempt int) bool {
+						var derr Error
+						assert.True(t, errors.As(err, &derr) && derr.Code == customCode,
+							"expected error with code %v, got %v", customCode, err)
+						attempts = append(attempts, attempt)
+						return tc.retry
+					},
+				}
+
+				retryMode := tc.retryMode
+				err := Operation{
+					CommandFn: func(dst []byte, _ description.SelectedServer) ([]byte, error) {
+						return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+					},
+					Database:   "admin",
+					Deployment: d,
+					RetryMode:  &retryMode,
+					Type:       Read,
+				}.Execute(context.Background())
+				if tc.wantErr {
+					assert.NotNil(t, err, "expected an error from Execute()")
+				} else {
+					assert.Nil(t, err, "Execute error: %v", err)
+				}
+				assert.Equal(t, tc.wantAttempts, attempts, "expected ShouldRetry attempts %v, got %v",
+					tc.wantAttempts, attempts)
+			})
+		}
+	})
+}
+
+// scriptedConnection is a mockConnection that returns the wire messages in responses in order.
+type scriptedConnection struct {
+	*mockConnection
+	responses [][]byte
+}
+
+func (c *scriptedConnection) ReadWireMessage(context.Context) ([]byte, error) {
+	if len(c.responses) == 0 {
+		return nil, errors.New("no more responses")
+	}
+	wm := c.responses[0]
+	c.responses = c.responses[1:]
+	return wm, nil
+}
+
+// retryDeciderDeployment is a SingleConnectionDeployment that implements RetryDecider with shouldRetry.
+type retryDeciderDeployment struct {
+	SingleConnectionDeployment
+	shouldRetry func(err error, attempt int) bool
+}
+
+func (d retryDeciderDeployment) ShouldRetry(err error, attempt int) bool {
+	return d.shouldRetry(err, attempt)
 }
 
 func TestConvertI64PtrToI32Ptr(t *testing.T) {
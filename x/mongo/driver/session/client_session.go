@@ -9,6 +9,7 @@ package session // import "go.mongodb.org/mongo-driver/x/mongo/driver/session"
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -155,6 +156,34 @@ func getClusterTime(clusterTime bson.Raw) (uint32, uint32) {
 	return timestampVal.Timestamp()
 }
 
+// validateClusterTime checks that clusterTime is a document of the form
+// {"$clusterTime": {"clusterTime": <timestamp>, ...}} and returns the timestamp.
+func validateClusterTime(clusterTime bson.Raw) (primitive.Timestamp, error) {
+	if err := clusterTime.Validate(); err != nil {
+		return primitive.Timestamp{}, err
+	}
+
+	clusterTimeVal, err := clusterTime.LookupErr("$clusterTime")
+	if err != nil {
+		return primitive.Timestamp{}, errors.New(`document must contain a "$clusterTime" field`)
+	}
+	clusterTimeDoc, ok := clusterTimeVal.DocumentOK()
+	if !ok {
+		return primitive.Timestamp{}, fmt.Errorf(`"$clusterTime" field must be a document, got %v`, clusterTimeVal.Type)
+	}
+
+	timestampVal, err := clusterTimeDoc.LookupErr("clusterTime")
+	if err != nil {
+		return primitive.Timestamp{}, errors.New(`"$clusterTime" document must contain a "clusterTime" field`)
+	}
+	t, i, ok := timestampVal.TimestampOK()
+	if !ok {
+		return primitive.Timestamp{}, fmt.Errorf(`"clusterTime" field must be a timestamp, got %v`, timestampVal.Type)
+	}
+
+	return primitive.Timestamp{T: t, I: i}, nil
+}
+
 // MaxClusterTime compares 2 clusterTime documents and returns the document representing the highest cluster time.
 func MaxClusterTime(ct1, ct2 bson.Raw) bson.Raw {
 	epoch1, ord1 := getClusterTime(ct1)
@@ -223,6 +252,20 @@ func NewClientSession(pool *Pool, clientID uuid.UUID, opts ...*ClientOptions) (*
 		return nil, errors.New("causal consistency and snapshot cannot both be set for a session")
 	}
 
+	if mergedOpts.InitialClusterTime != nil {
+		opTime, err := validateClusterTime(mergedOpts.InitialClusterTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial cluster time: %w", err)
+		}
+
+		c.ClusterTime = mergedOpts.InitialClusterTime
+		if c.Consistent {
+			// Reads in a causally consistent session request data at or after the session's operation
+			// time, so seed it to make the first read observe the initial cluster time.
+			c.OperationTime = &opTime
+		}
+	}
+
 	if err := c.SetServer(); err != nil {
 		return nil, err
 	}
@@ -399,6 +442,17 @@ func (c *Client) CheckStartTransaction() error {
 	return nil
 }
 
+// DefaultTransactionOptions returns the options StartTransaction uses for any option that is not
+// set in the options passed to it.
+func (c *Client) DefaultTransactionOptions() TransactionOptions {
+	return TransactionOptions{
+		ReadConcern:    c.transactionRc,
+		WriteConcern:   c.transactionWc,
+		ReadPreference: c.transactionRp,
+		MaxCommitTime:  c.transactionMaxCommitTime,
+	}
+}
+
 // StartTransaction initializes the transaction options and advances the state machine.
 // It does not contact the server to start the transaction.
 func (c *Client) StartTransaction(opts *TransactionOptions) error {
@@ -73,6 +73,72 @@ func TestClientSession(t *testing.T) {
 		sess.EndSession()
 	})
 
+	t.Run("InitialClusterTime", func(t *testing.T) {
+		t.Run("seeds cluster and operation time", func(t *testing.T) {
+			id, _ := uuid.New()
+			sess, err := NewClientSession(&Pool{}, id, &ClientOptions{InitialClusterTime: clusterTime2})
+			require.Nil(t, err, "Unexpected error")
+			defer sess.EndSession()
+
+			if !bytes.Equal(sess.ClusterTime, clusterTime2) {
+				t.Errorf("Session cluster time incorrect, expected %v, received %v", clusterTime2, sess.ClusterTime)
+			}
+			require.NotNil(t, sess.OperationTime, "expected operation time to be set")
+			compareOperationTimes(t, &primitive.Timestamp{T: 5, I: 5}, sess.OperationTime)
+
+			// Gossip should only advance from the initial cluster time.
+			err = sess.AdvanceClusterTime(clusterTime3)
+			require.Nil(t, err, "Unexpected error")
+			if !bytes.Equal(sess.ClusterTime, clusterTime2) {
+				t.Errorf("Session cluster time incorrect, expected %v, received %v", clusterTime2, sess.ClusterTime)
+			}
+			err = sess.AdvanceClusterTime(clusterTime1)
+			require.Nil(t, err, "Unexpected error")
+			if !bytes.Equal(sess.ClusterTime, clusterTime1) {
+				t.Errorf("Session cluster time incorrect, expected %v, received %v", clusterTime1, sess.ClusterTime)
+			}
+		})
+		t.Run("does not seed operation time without causal consistency", func(t *testing.T) {
+			consistent := false
+			id, _ := uuid.New()
+			sess, err := NewClientSession(&Pool{}, id, &ClientOptions{
+				CausalConsistency:  &consistent,
+				InitialClusterTime: clusterTime2,
+			})
+			require.Nil(t, err, "Unexpected error")
+			defer sess.EndSession()
+
+			assert.Equal(t, []byte(clusterTime2), []byte(sess.ClusterTime), "expected cluster time to be set")
+			assert.Nil(t, sess.OperationTime, "expected operation time to be nil, got %v", sess.OperationTime)
+		})
+
+		invalid := []struct {
+			description string
+			clusterTime []byte
+		}{
+			{"malformed document", bsoncore.Document{0x01, 0x02}},
+			{"missing $clusterTime", bsoncore.BuildDocument(nil, bsoncore.AppendTimestampElement(nil, "clusterTime", 10, 5))},
+			{"$clusterTime not a document", bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "$clusterTime", 1))},
+			{
+				"missing clusterTime",
+				bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "$clusterTime",
+					bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "signature", 1)))),
+			},
+			{
+				"clusterTime not a timestamp",
+				bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "$clusterTime",
+					bsoncore.BuildDocument(nil, bsoncore.AppendInt64Element(nil, "clusterTime", 1)))),
+			},
+		}
+		for _, tc := range invalid {
+			t.Run(tc.description, func(t *testing.T) {
+				id, _ := uuid.New()
+				_, err := NewClientSession(&Pool{}, id, &ClientOptions{InitialClusterTime: tc.clusterTime})
+				assert.NotNil(t, err, "expected error for invalid initial cluster time")
+			})
+		}
+	})
+
 	t.Run("TestEndSession", func(t *testing.T) {
 		id, _ := uuid.New()
 		sess, err := NewClientSession(&Pool{}, id, sessionOpts)
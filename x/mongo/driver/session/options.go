@@ -9,6 +9,7 @@ package session
 import (
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -22,6 +23,7 @@ type ClientOptions struct {
 	DefaultReadPreference *readpref.ReadPref
 	DefaultMaxCommitTime  *time.Duration
 	Snapshot              *bool
+	InitialClusterTime    bson.Raw
 }
 
 // TransactionOptions represents all possible options for starting a transaction in a session.
@@ -56,6 +58,9 @@ func mergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Snapshot != nil {
 			c.Snapshot = opt.Snapshot
 		}
+		if opt.InitialClusterTime != nil {
+			c.InitialClusterTime = opt.InitialClusterTime
+		}
 	}
 
 	return c
@@ -171,6 +171,43 @@ func configureTLS(ctx context.Context,
 	return client, nil
 }
 
+// tcpConn is implemented by *net.TCPConn and by other connections that support TCP socket options.
+type tcpConn interface {
+	SetKeepAlive(keepalive bool) error
+	SetKeepAlivePeriod(d time.Duration) error
+	SetNoDelay(noDelay bool) error
+}
+
+// configureTCP applies the TCP keep-alive and no-delay settings of config to nc. Connections returned by a custom
+// Dialer that do not implement tcpConn, such as Unix domain socket connections, are left unchanged.
+func configureTCP(nc net.Conn, config *connectionConfig) error {
+	if config.tcpKeepAlive == nil && config.tcpNoDelay == nil {
+		return nil
+	}
+	tc, ok := nc.(tcpConn)
+	if !ok {
+		return nil
+	}
+
+	if config.tcpKeepAlive != nil {
+		period := *config.tcpKeepAlive
+		if err := tc.SetKeepAlive(period > 0); err != nil {
+			return err
+		}
+		if period > 0 {
+			if err := tc.SetKeepAlivePeriod(period); err != nil {
+				return err
+			}
+		}
+	}
+	if config.tcpNoDelay != nil {
+		if err := tc.SetNoDelay(*config.tcpNoDelay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // connect handles the I/O for a connection. It will dial, configure TLS, and perform initialization
 // handshakes. All errors returned by connect are considered "before the handshake completes" and
 // must be handled by calling the appropriate SDAM handshake error handler.
@@ -235,6 +272,10 @@ func (c *connection) connect(ctx context.Context) (err error) {
 	if err != nil {
 		return ConnectionError{Wrapped: err, init: true}
 	}
+	if err := configureTCP(tempNc, c.config); err != nil {
+		_ = tempNc.Close()
+		return ConnectionError{Wrapped: err, init: true}
+	}
 	c.nc = tempNc
 
 	if c.config.tlsConfig != nil {
@@ -747,14 +788,15 @@ func (c *Connection) ReadWireMessage(ctx context.Context) ([]byte, error) {
 
 // CompressWireMessage handles compressing the provided wire message using the underlying
 // connection's compressor. The dst parameter will be overwritten with the new wire message. If
-// there is no compressor set on the underlying connection, then no compression will be performed.
+// there is no compressor set on the underlying connection or the wire message is smaller than the
+// configured minimum compression size, then no compression will be performed.
 func (c *Connection) CompressWireMessage(src, dst []byte) ([]byte, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	if c.connection == nil {
 		return dst, ErrConnectionClosed
 	}
-	if c.connection.compressor == wiremessage.CompressorNoOp {
+	if c.connection.compressor == wiremessage.CompressorNoOp || len(src) < c.connection.config.compressionMinSize {
 		return append(dst, src...), nil
 	}
 	_, reqid, respto, origcode, rem, ok := wiremessage.ReadHeader(src)
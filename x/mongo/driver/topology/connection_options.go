@@ -60,11 +60,14 @@ type connectionConfig struct {
 	compressors              []string
 	zlibLevel                *int
 	zstdLevel                *int
+	compressionMinSize       int
 	ocspCache                ocsp.Cache
 	disableOCSPEndpointCheck bool
 	tlsConnectionSource      tlsConnectionSource
 	loadBalanced             bool
 	getGenerationFn          generationNumberFn
+	tcpKeepAlive             *time.Duration
+	tcpNoDelay               *bool
 }
 
 func newConnectionConfig(opts ...ConnectionOption) *connectionConfig {
@@ -186,6 +189,29 @@ func WithZstdLevel(fn func(*int) *int) ConnectionOption {
 	}
 }
 
+// WithCompressionMinSize sets the size in bytes below which wire messages are not compressed.
+func WithCompressionMinSize(fn func(int) int) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.compressionMinSize = fn(c.compressionMinSize)
+	}
+}
+
+// WithTCPKeepAlive sets the period between TCP keep-alive probes on new connections. A period of 0 disables
+// keep-alive probes. If it is not set, the keep-alive settings of the Dialer are used.
+func WithTCPKeepAlive(fn func(*time.Duration) *time.Duration) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.tcpKeepAlive = fn(c.tcpKeepAlive)
+	}
+}
+
+// WithTCPNoDelay sets whether Nagle's algorithm is disabled on new connections. If it is not set, the setting of the
+// Dialer is used.
+func WithTCPNoDelay(fn func(*bool) *bool) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.tcpNoDelay = fn(c.tcpNoDelay)
+	}
+}
+
 // WithOCSPCache specifies a cache to use for OCSP verification.
 func WithOCSPCache(fn func(ocsp.Cache) ocsp.Cache) ConnectionOption {
 	return func(c *connectionConfig) {
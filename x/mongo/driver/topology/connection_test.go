@@ -22,6 +22,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
@@ -97,6 +98,60 @@ func TestConnection(t *testing.T) {
 				connState := atomic.LoadInt64(&conn.state)
 				assert.Equal(t, connDisconnected, connState, "expected connection state %v, got %v", connDisconnected, connState)
 			})
+			t.Run("TCP options", func(t *testing.T) {
+				keepAlive := 30 * time.Second
+				disabled := time.Duration(0)
+				noDelay := false
+
+				testCases := []struct {
+					name          string
+					opts          []ConnectionOption
+					wantKeepAlive *bool
+					wantPeriod    time.Duration
+					wantNoDelay   *bool
+				}{
+					{
+						name: "not set",
+					},
+					{
+						name: "keep-alive and no-delay",
+						opts: []ConnectionOption{
+							WithTCPKeepAlive(func(*time.Duration) *time.Duration { return &keepAlive }),
+							WithTCPNoDelay(func(*bool) *bool { return &noDelay }),
+						},
+						wantKeepAlive: func() *bool { b := true; return &b }(),
+						wantPeriod:    keepAlive,
+						wantNoDelay:   &noDelay,
+					},
+					{
+						name: "keep-alive disabled",
+						opts: []ConnectionOption{
+							WithTCPKeepAlive(func(*time.Duration) *time.Duration { return &disabled }),
+						},
+						wantKeepAlive: func() *bool { b := false; return &b }(),
+					},
+				}
+				for _, tc := range testCases {
+					t.Run(tc.name, func(t *testing.T) {
+						nc := &tcpOptionsConn{}
+						opts := append(tc.opts, WithDialer(func(Dialer) Dialer {
+							return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+								return nc, nil
+							})
+						}))
+						conn := newConnection(address.Address(""), opts...)
+						err := conn.connect(context.Background())
+						assert.Nil(t, err, "connect error: %v", err)
+
+						assert.Equal(t, tc.wantKeepAlive, nc.keepAlive, "expected keep-alive %v, got %v",
+							tc.wantKeepAlive, nc.keepAlive)
+						assert.Equal(t, tc.wantPeriod, nc.keepAlivePeriod, "expected keep-alive period %v, got %v",
+							tc.wantPeriod, nc.keepAlivePeriod)
+						assert.Equal(t, tc.wantNoDelay, nc.noDelay, "expected no-delay %v, got %v",
+							tc.wantNoDelay, nc.noDelay)
+					})
+				}
+			})
 			t.Run("context is not pinned by connect", func(t *testing.T) {
 				// connect creates a cancel-able version of the context passed to it and stores the CancelFunc on the
 				// connection. The CancelFunc must be set to nil once the connection has been established so the driver
@@ -941,6 +996,50 @@ func TestConnection(t *testing.T) {
 	})
 }
 
+func TestConnection_CompressWireMessage(t *testing.T) {
+	t.Parallel()
+
+	newWireMessage := func(size int) []byte {
+		idx, wm := wiremessage.AppendHeaderStart(nil, 1, 0, wiremessage.OpMsg)
+		wm = append(wm, make([]byte, size-len(wm))...)
+		return bsoncore.UpdateLength(wm, idx, int32(len(wm[idx:])))
+	}
+
+	testCases := []struct {
+		name           string
+		minSize        int
+		size           int
+		wantCompressed bool
+	}{
+		{"no minimum size", 0, 64, true},
+		{"smaller than minimum size", 1024, 512, false},
+		{"equal to minimum size", 1024, 1024, true},
+		{"larger than minimum size", 1024, 4096, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := Connection{connection: &connection{
+				compressor: wiremessage.CompressorSnappy,
+				config:     newConnectionConfig(WithCompressionMinSize(func(int) int { return tc.minSize })),
+			}}
+			src := newWireMessage(tc.size)
+			dst, err := conn.CompressWireMessage(src, nil)
+			assert.Nil(t, err, "CompressWireMessage error: %v", err)
+
+			_, _, _, opcode, _, ok := wiremessage.ReadHeader(dst)
+			assert.True(t, ok, "expected a valid wire message header")
+			if tc.wantCompressed {
+				assert.Equal(t, wiremessage.OpCompressed, opcode, "expected opcode %v, got %v", wiremessage.OpCompressed, opcode)
+				return
+			}
+			assert.Equal(t, src, dst, "expected wire message to be sent uncompressed")
+		})
+	}
+}
+
 func BenchmarkConnection(b *testing.B) {
 	b.Run("CompressWireMessage CompressorNoOp", func(b *testing.B) {
 		buf := make([]byte, 256)
@@ -1334,3 +1433,26 @@ func TestConnection_IsAlive(t *testing.T) {
 			"expected isAlive for an open connection that reads data to return false")
 	})
 }
+
+// tcpOptionsConn is a net.Conn that records the TCP socket options set on it.
+type tcpOptionsConn struct {
+	net.Conn
+	keepAlive       *bool
+	keepAlivePeriod time.Duration
+	noDelay         *bool
+}
+
+func (c *tcpOptionsConn) SetKeepAlive(keepAlive bool) error {
+	c.keepAlive = &keepAlive
+	return nil
+}
+
+func (c *tcpOptionsConn) SetKeepAlivePeriod(d time.Duration) error {
+	c.keepAlivePeriod = d
+	return nil
+}
+
+func (c *tcpOptionsConn) SetNoDelay(noDelay bool) error {
+	c.noDelay = &noDelay
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import "time"
+
+// heartbeatBackoff computes the delay between checks of a server that keeps failing its checks. Delays start at min
+// and double after every failed check up to max. Each delay is reduced by a random fraction of up to jitter of its
+// value so that monitors across many clients spread their reconnect attempts out.
+type heartbeatBackoff struct {
+	min    time.Duration
+	max    time.Duration
+	jitter float64
+
+	// float64 returns a pseudo-random number in [0.0, 1.0).
+	float64 func() float64
+
+	attempt int
+}
+
+func newHeartbeatBackoff(min, max time.Duration, jitter float64) *heartbeatBackoff {
+	if max < min {
+		max = min
+	}
+	if jitter < 0 {
+		jitter = 0
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return &heartbeatBackoff{
+		min:     min,
+		max:     max,
+		jitter:  jitter,
+		float64: random.Float64,
+	}
+}
+
+// next returns the delay before the next check and advances the backoff.
+func (b *heartbeatBackoff) next() time.Duration {
+	delay := b.max
+	// Stop doubling once the delay would exceed max to avoid overflowing.
+	if b.attempt < 62 && b.min<<b.attempt > 0 && b.min<<b.attempt < b.max {
+		delay = b.min << b.attempt
+	}
+	b.attempt++
+
+	if b.jitter > 0 {
+		delay -= time.Duration(b.jitter * b.float64() * float64(delay))
+	}
+	return delay
+}
+
+// reset restarts the backoff from min after a successful check.
+func (b *heartbeatBackoff) reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,89 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestHeartbeatBackoff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exponential without jitter", func(t *testing.T) {
+		t.Parallel()
+
+		b := newHeartbeatBackoff(100*time.Millisecond, time.Second, 0)
+		want := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+			time.Second,
+		}
+		for i, w := range want {
+			got := b.next()
+			assert.Equal(t, w, got, "expected delay %d to be %v, got %v", i, w, got)
+		}
+
+		b.reset()
+		got := b.next()
+		assert.Equal(t, 100*time.Millisecond, got, "expected delay after reset to be %v, got %v", 100*time.Millisecond, got)
+	})
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		t.Parallel()
+
+		const jitter = 0.25
+		min, max := 50*time.Millisecond, 2*time.Second
+		b := newHeartbeatBackoff(min, max, jitter)
+
+		base := min
+		for i := 0; i < 100; i++ {
+			got := b.next()
+			lower := time.Duration(float64(base) * (1 - jitter))
+			assert.True(t, got >= lower && got <= base,
+				"expected delay %d to be in [%v, %v], got %v", i, lower, base, got)
+
+			if base *= 2; base > max {
+				base = max
+			}
+		}
+	})
+	t.Run("jitter extremes", func(t *testing.T) {
+		t.Parallel()
+
+		b := newHeartbeatBackoff(time.Second, time.Second, 0.5)
+
+		b.float64 = func() float64 { return 0 }
+		got := b.next()
+		assert.Equal(t, time.Second, got, "expected delay %v, got %v", time.Second, got)
+
+		b.float64 = func() float64 { return 0.999999 }
+		got = b.next()
+		assert.True(t, got > 500*time.Millisecond && got < 501*time.Millisecond,
+			"expected delay just above %v, got %v", 500*time.Millisecond, got)
+	})
+	t.Run("does not overflow", func(t *testing.T) {
+		t.Parallel()
+
+		b := newHeartbeatBackoff(time.Millisecond, time.Hour, 0)
+		for i := 0; i < 200; i++ {
+			got := b.next()
+			assert.True(t, got > 0 && got <= time.Hour, "expected delay %d to be in (0, %v], got %v", i, time.Hour, got)
+		}
+	})
+	t.Run("invalid arguments are clamped", func(t *testing.T) {
+		t.Parallel()
+
+		b := newHeartbeatBackoff(time.Second, time.Millisecond, 2)
+		assert.Equal(t, time.Second, b.max, "expected max to be raised to min, got %v", b.max)
+		assert.Equal(t, 1.0, b.jitter, "expected jitter to be clamped to 1, got %v", b.jitter)
+	})
+}
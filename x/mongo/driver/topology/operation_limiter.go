@@ -0,0 +1,164 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrTopologyDraining is returned when an operation is started on a Topology that is draining in-flight operations
+// before it is disconnected.
+var ErrTopologyDraining = errors.New("topology is draining")
+
+// overloadedError is returned when an operation is rejected because the maximum number of concurrent operations is
+// already in flight and the topology is configured to fail fast. The mongo package converts it to
+// mongo.OverloadedError through its OperationLimit method.
+type overloadedError struct {
+	limit int
+}
+
+// Error implements the error interface.
+func (e overloadedError) Error() string {
+	return fmt.Sprintf("operation rejected: %d operations are already in flight", e.limit)
+}
+
+// OperationLimit returns the maximum number of concurrent operations that was reached.
+func (e overloadedError) OperationLimit() int {
+	return e.limit
+}
+
+// operationLimiter tracks the number of in-flight operations and optionally bounds it.
+type operationLimiter struct {
+	inFlight int64
+
+	// slots is nil if the number of operations is not bounded. Otherwise, an operation holds a slot by
+	// sending to it for as long as it is in flight.
+	slots    chan struct{}
+	failFast bool
+
+	// mu guards draining and idle, and is held when inFlight is changed so that a drain cannot miss the
+	// last operation completing.
+	mu       sync.Mutex
+	draining bool
+	// idle is closed when the last in-flight operation completes while draining.
+	idle chan struct{}
+}
+
+func newOperationLimiter(maxOperations int, failFast bool) *operationLimiter {
+	ol := &operationLimiter{failFast: failFast}
+	if maxOperations > 0 {
+		ol.slots = make(chan struct{}, maxOperations)
+	}
+	return ol
+}
+
+// acquire reserves a slot for an operation. If all slots are in use, it either returns an overloadedError or waits
+// for a slot to be released or for ctx to expire.
+func (ol *operationLimiter) acquire(ctx context.Context) (func(), error) {
+	if ol.slots != nil {
+		select {
+		case ol.slots <- struct{}{}:
+		default:
+			if ol.failFast {
+				return nil, overloadedError{limit: cap(ol.slots)}
+			}
+			select {
+			case ol.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("timed out waiting for an operation slot: %w", ctx.Err())
+			}
+		}
+	}
+
+	ol.mu.Lock()
+	if ol.draining {
+		ol.mu.Unlock()
+		if ol.slots != nil {
+			<-ol.slots
+		}
+		return nil, ErrTopologyDraining
+	}
+	atomic.AddInt64(&ol.inFlight, 1)
+	ol.mu.Unlock()
+
+	var released int32
+	return func() {
+		if !atomic.CompareAndSwapInt32(&released, 0, 1) {
+			return
+		}
+		ol.mu.Lock()
+		if atomic.AddInt64(&ol.inFlight, -1) == 0 && ol.idle != nil {
+			close(ol.idle)
+			ol.idle = nil
+		}
+		ol.mu.Unlock()
+		if ol.slots != nil {
+			<-ol.slots
+		}
+	}, nil
+}
+
+// drain causes subsequent calls to acquire to return ErrTopologyDraining and waits for the in-flight operations to
+// complete or for ctx to expire. It returns the number of operations that were still in flight when ctx expired.
+func (ol *operationLimiter) drain(ctx context.Context) int {
+	ol.mu.Lock()
+	ol.draining = true
+	if atomic.LoadInt64(&ol.inFlight) == 0 {
+		ol.mu.Unlock()
+		return 0
+	}
+	if ol.idle == nil {
+		ol.idle = make(chan struct{})
+	}
+	idle := ol.idle
+	ol.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-ctx.Done():
+		return int(atomic.LoadInt64(&ol.inFlight))
+	}
+}
+
+// stopDraining allows operations to be acquired again after a drain.
+func (ol *operationLimiter) stopDraining() {
+	ol.mu.Lock()
+	defer ol.mu.Unlock()
+
+	ol.draining = false
+	ol.idle = nil
+}
+
+// AcquireOperation implements the driver.OperationLimiter interface. It reserves one of the slots configured with
+// WithMaxConcurrentOperations and returns a function that releases it.
+func (t *Topology) AcquireOperation(ctx context.Context) (func(), error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return t.operations.acquire(ctx)
+}
+
+// DrainOperations stops the Topology from starting new operations and waits for the operations that are already in
+// flight to complete or for ctx to expire. Operations started after DrainOperations is called fail with
+// ErrTopologyDraining. It returns the number of operations that were still in flight when ctx expired, or 0 if all
+// operations completed. DrainOperations does not disconnect the Topology.
+func (t *Topology) DrainOperations(ctx context.Context) int {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return t.operations.drain(ctx)
+}
+
+// InFlightOperations returns the number of operations that are currently executing against the Topology.
+func (t *Topology) InFlightOperations() int {
+	return int(atomic.LoadInt64(&t.operations.inFlight))
+}
@@ -0,0 +1,191 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestOperationLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("caps concurrent operations", func(t *testing.T) {
+		t.Parallel()
+
+		const maxOperations = 3
+		topo, err := New(&Config{MaxConcurrentOperations: maxOperations})
+		require.NoError(t, err, "New error: %v", err)
+
+		var running, peak int64
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				release, err := topo.AcquireOperation(context.Background())
+				if err != nil {
+					t.Errorf("AcquireOperation error: %v", err)
+					return
+				}
+				defer release()
+
+				n := atomic.AddInt64(&running, 1)
+				for {
+					p := atomic.LoadInt64(&peak)
+					if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+						break
+					}
+				}
+				if inFlight := topo.InFlightOperations(); inFlight > maxOperations {
+					t.Errorf("expected at most %d in-flight operations, got %d", maxOperations, inFlight)
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt64(&running, -1)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(maxOperations), atomic.LoadInt64(&peak),
+			"expected peak concurrency %d, got %d", maxOperations, peak)
+		assert.Equal(t, 0, topo.InFlightOperations(), "expected no in-flight operations, got %d",
+			topo.InFlightOperations())
+	})
+	t.Run("fail fast", func(t *testing.T) {
+		t.Parallel()
+
+		opts := options.Client().SetMaxConcurrentOperations(1).SetRejectOverloadedOperations(true)
+		cfg, err := NewConfig(opts, nil)
+		require.NoError(t, err, "NewConfig error: %v", err)
+		topo, err := New(cfg)
+		require.NoError(t, err, "New error: %v", err)
+
+		release, err := topo.AcquireOperation(context.Background())
+		require.NoError(t, err, "AcquireOperation error: %v", err)
+
+		_, err = topo.AcquireOperation(context.Background())
+		want := overloadedError{limit: 1}
+		assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+		release()
+		release()
+		assert.Equal(t, 0, topo.InFlightOperations(), "expected no in-flight operations, got %d",
+			topo.InFlightOperations())
+
+		release, err = topo.AcquireOperation(context.Background())
+		require.NoError(t, err, "AcquireOperation error: %v", err)
+		release()
+	})
+	t.Run("waiting respects context", func(t *testing.T) {
+		t.Parallel()
+
+		ol := newOperationLimiter(1, false)
+		release, err := ol.acquire(context.Background())
+		require.NoError(t, err, "acquire error: %v", err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = ol.acquire(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "expected error %v, got %v", context.DeadlineExceeded, err)
+	})
+	t.Run("unbounded", func(t *testing.T) {
+		t.Parallel()
+
+		ol := newOperationLimiter(0, true)
+		var releases []func()
+		for i := 0; i < 100; i++ {
+			release, err := ol.acquire(context.Background())
+			require.NoError(t, err, "acquire error: %v", err)
+			releases = append(releases, release)
+		}
+		assert.Equal(t, int64(100), atomic.LoadInt64(&ol.inFlight), "expected 100 in-flight operations, got %d",
+			ol.inFlight)
+		for _, release := range releases {
+			release()
+		}
+		assert.Equal(t, int64(0), atomic.LoadInt64(&ol.inFlight), "expected no in-flight operations, got %d",
+			ol.inFlight)
+	})
+	t.Run("drain times out with slow operation", func(t *testing.T) {
+		t.Parallel()
+
+		topo, err := New(&Config{})
+		require.NoError(t, err, "New error: %v", err)
+
+		release, err := topo.AcquireOperation(context.Background())
+		require.NoError(t, err, "AcquireOperation error: %v", err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		inFlight := topo.DrainOperations(ctx)
+		assert.Equal(t, 1, inFlight, "expected 1 in-flight operation, got %d", inFlight)
+
+		_, err = topo.AcquireOperation(context.Background())
+		assert.ErrorIs(t, err, ErrTopologyDraining, "expected error %v, got %v", ErrTopologyDraining, err)
+	})
+	t.Run("drain waits for in-flight operations", func(t *testing.T) {
+		t.Parallel()
+
+		ol := newOperationLimiter(2, false)
+		var releases []func()
+		for i := 0; i < 2; i++ {
+			release, err := ol.acquire(context.Background())
+			require.NoError(t, err, "acquire error: %v", err)
+			releases = append(releases, release)
+		}
+
+		done := make(chan int)
+		go func() {
+			done <- ol.drain(context.Background())
+		}()
+
+		for _, release := range releases {
+			time.Sleep(5 * time.Millisecond)
+			select {
+			case <-done:
+				t.Fatal("expected drain to wait for in-flight operations")
+			default:
+			}
+			release()
+		}
+
+		select {
+		case inFlight := <-done:
+			assert.Equal(t, 0, inFlight, "expected no in-flight operations, got %d", inFlight)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for drain to complete")
+		}
+
+		// A rejected operation must not keep holding a slot.
+		assert.Equal(t, 0, len(ol.slots), "expected no slots in use, got %d", len(ol.slots))
+		_, err := ol.acquire(context.Background())
+		assert.ErrorIs(t, err, ErrTopologyDraining, "expected error %v, got %v", ErrTopologyDraining, err)
+		assert.Equal(t, 0, len(ol.slots), "expected no slots in use, got %d", len(ol.slots))
+
+		ol.stopDraining()
+		release, err := ol.acquire(context.Background())
+		require.NoError(t, err, "acquire error: %v", err)
+		release()
+	})
+	t.Run("drain with no in-flight operations", func(t *testing.T) {
+		t.Parallel()
+
+		ol := newOperationLimiter(0, false)
+		inFlight := ol.drain(context.Background())
+		assert.Equal(t, 0, inFlight, "expected no in-flight operations, got %d", inFlight)
+	})
+}
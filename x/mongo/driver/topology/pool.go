@@ -75,6 +75,7 @@ type poolConfig struct {
 	MaxConnecting    uint64
 	MaxIdleTime      time.Duration
 	MaintainInterval time.Duration
+	ProactivePruning bool
 	LoadBalanced     bool
 	PoolMonitor      *event.PoolMonitor
 	Logger           *logger.Logger
@@ -206,6 +207,17 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 	if config.MaintainInterval != 0 {
 		maintainInterval = config.MaintainInterval
 	}
+	// With proactive pruning, run maintain() at least twice per max idle time so that idle connections are closed
+	// in the background shortly after they expire instead of when they are next checked out.
+	if config.ProactivePruning && config.MaxIdleTime > 0 && maintainInterval > 0 {
+		pruneInterval := config.MaxIdleTime / 2
+		if pruneInterval < time.Millisecond {
+			pruneInterval = time.Millisecond
+		}
+		if pruneInterval < maintainInterval {
+			maintainInterval = pruneInterval
+		}
+	}
 
 	pool := &pool{
 		address:               config.Address,
@@ -1193,6 +1193,45 @@ func TestPool(t *testing.T) {
 			assert.Equalf(t, 3, p.availableConnectionCount(), "should be 3 idle connections in pool")
 			assert.Equalf(t, 3, p.totalConnectionCount(), "should be 3 total connection in pool")
 
+			p.close(context.Background())
+		})
+		t.Run("proactively removes idle connections when ProactivePruning is set", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			// Use the default maintain interval (10s) so that the idle connections are only closed
+			// within the assertion timeout if proactive pruning shortens the interval.
+			d := newdialer(&net.Dialer{})
+			p := newPool(poolConfig{
+				Address:          address.Address(addr.String()),
+				MaxIdleTime:      50 * time.Millisecond,
+				ProactivePruning: true,
+			}, WithDialer(func(Dialer) Dialer { return d }))
+			err := p.ready()
+			require.NoError(t, err)
+			assert.Equalf(t, 25*time.Millisecond, p.maintainInterval, "expected maintain interval to be half of MaxIdleTime")
+
+			conns := make([]*connection, 3)
+			for i := range conns {
+				conns[i], err = p.checkOut(context.Background())
+				require.NoError(t, err)
+			}
+			for _, c := range conns {
+				err = p.checkIn(c)
+				require.NoError(t, err)
+			}
+
+			// Assert that the idle connections are closed in the background without any checkOut.
+			assertConnectionsClosed(t, d, 3)
+			assert.Equalf(t, 0, p.availableConnectionCount(), "should be 0 idle connections in pool")
+			assert.Equalf(t, 0, p.totalConnectionCount(), "should be 0 total connection in pool")
+
 			p.close(context.Background())
 		})
 	})
@@ -186,6 +186,7 @@ func NewServer(addr address.Address, topologyID primitive.ObjectID, opts ...Serv
 		MaxConnecting:    cfg.maxConnecting,
 		MaxIdleTime:      cfg.poolMaxIdleTime,
 		MaintainInterval: cfg.poolMaintainInterval,
+		ProactivePruning: cfg.poolProactivePruning,
 		LoadBalanced:     cfg.loadBalanced,
 		PoolMonitor:      cfg.poolMonitor,
 		Logger:           cfg.logger,
@@ -588,6 +589,23 @@ func (s *Server) update() {
 		}
 	}
 
+	// If configured, wait with an exponential backoff between checks of a server whose checks keep failing instead
+	// of waiting for the next heartbeat.
+	var backoff *heartbeatBackoff
+	if s.cfg.heartbeatBackoffMin > 0 {
+		backoff = newHeartbeatBackoff(s.cfg.heartbeatBackoffMin, s.cfg.heartbeatBackoffMax, s.cfg.heartbeatBackoffJitter)
+	}
+	waitForBackoff := func() {
+		timer := time.NewTimer(backoff.next())
+		defer timer.Stop()
+
+		// Don't wake up for immediate check requests so that application operations can't defeat the backoff.
+		select {
+		case <-timer.C:
+		case <-done:
+		}
+	}
+
 	timeoutCnt := 0
 	for {
 		// Check if the server is disconnecting. Even if waitForNextCheck has already read from the done channel, we
@@ -655,6 +673,10 @@ func (s *Server) update() {
 			continue
 		}
 
+		if backoff != nil && desc.LastError == nil {
+			backoff.reset()
+		}
+
 		// If the server supports streaming or we're already streaming, we want to move to streaming the next response
 		// without waiting. If the server has transitioned to Unknown from a network error, we want to do another
 		// check without waiting in case it was a transient error and the server isn't actually down.
@@ -672,6 +694,10 @@ func (s *Server) update() {
 
 		// The server either does not support the streamable protocol or is not in a healthy state, so we wait until
 		// the next check.
+		if backoff != nil && desc.LastError != nil {
+			waitForBackoff()
+			continue
+		}
 		waitUntilNextCheck()
 	}
 }
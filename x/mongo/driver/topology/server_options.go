@@ -21,18 +21,21 @@ import (
 var defaultRegistry = bson.NewRegistryBuilder().Build()
 
 type serverConfig struct {
-	clock                *session.ClusterClock
-	compressionOpts      []string
-	connectionOpts       []ConnectionOption
-	appname              string
-	heartbeatInterval    time.Duration
-	heartbeatTimeout     time.Duration
-	serverMonitoringMode string
-	serverMonitor        *event.ServerMonitor
-	registry             *bsoncodec.Registry
-	monitoringDisabled   bool
-	serverAPI            *driver.ServerAPIOptions
-	loadBalanced         bool
+	clock                  *session.ClusterClock
+	compressionOpts        []string
+	connectionOpts         []ConnectionOption
+	appname                string
+	heartbeatInterval      time.Duration
+	heartbeatTimeout       time.Duration
+	heartbeatBackoffMin    time.Duration
+	heartbeatBackoffMax    time.Duration
+	heartbeatBackoffJitter float64
+	serverMonitoringMode   string
+	serverMonitor          *event.ServerMonitor
+	registry               *bsoncodec.Registry
+	monitoringDisabled     bool
+	serverAPI              *driver.ServerAPIOptions
+	loadBalanced           bool
 
 	// Connection pool options.
 	maxConns             uint64
@@ -42,6 +45,7 @@ type serverConfig struct {
 	logger               *logger.Logger
 	poolMaxIdleTime      time.Duration
 	poolMaintainInterval time.Duration
+	poolProactivePruning bool
 }
 
 func newServerConfig(opts ...ServerOption) *serverConfig {
@@ -112,6 +116,18 @@ func WithHeartbeatTimeout(fn func(time.Duration) time.Duration) ServerOption {
 	}
 }
 
+// WithHeartbeatBackoff configures the server monitor to wait between checks of a server that failed its last check with
+// an exponential backoff from min to max instead of the heartbeat interval. Each wait is reduced by a random fraction
+// of up to jitter (between 0 and 1) of its value. A min of 0 disables the backoff.
+func WithHeartbeatBackoff(
+	fn func(min, max time.Duration, jitter float64) (time.Duration, time.Duration, float64),
+) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.heartbeatBackoffMin, cfg.heartbeatBackoffMax, cfg.heartbeatBackoffJitter = fn(
+			cfg.heartbeatBackoffMin, cfg.heartbeatBackoffMax, cfg.heartbeatBackoffJitter)
+	}
+}
+
 // WithMaxConnections configures the maximum number of connections to allow for
 // a given server. If max is 0, then maximum connection pool size is not limited.
 func WithMaxConnections(fn func(uint64) uint64) ServerOption {
@@ -155,6 +171,14 @@ func WithConnectionPoolMaintainInterval(fn func(time.Duration) time.Duration) Se
 	}
 }
 
+// WithConnectionPoolProactiveIdlePruning configures whether the background connection pool maintenance goroutine runs
+// often enough to close connections soon after they exceed the maximum idle time.
+func WithConnectionPoolProactiveIdlePruning(fn func(bool) bool) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.poolProactivePruning = fn(cfg.poolProactivePruning)
+	}
+}
+
 // WithConnectionPoolMonitor configures the monitor for all connection pool actions
 func WithConnectionPoolMonitor(fn func(*event.PoolMonitor) *event.PoolMonitor) ServerOption {
 	return func(cfg *serverConfig) {
@@ -13,7 +13,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/binary"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -359,6 +361,93 @@ func TestServerConnectionTimeout(t *testing.T) {
 	}
 }
 
+// TestServerHeartbeatLatency tests that the heartbeat timeout, rather than any other timeout, determines whether a
+// slow server is marked as unknown by the server monitor.
+func TestServerHeartbeatLatency(t *testing.T) {
+	const latency = 100 * time.Millisecond
+
+	// Start a listener that responds to every request with {"ok": 1} after a delay.
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+
+				for {
+					var size [4]byte
+					if _, err := io.ReadFull(conn, size[:]); err != nil {
+						return
+					}
+					rest := make([]byte, binary.LittleEndian.Uint32(size[:])-4)
+					if _, err := io.ReadFull(conn, rest); err != nil {
+						return
+					}
+					time.Sleep(latency)
+					if _, err := conn.Write(makeHelloReply()); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	testCases := []struct {
+		desc             string
+		heartbeatTimeout time.Duration
+		expectUnknown    bool
+	}{
+		{
+			desc:             "heartbeat timeout above the latency should not mark the server unknown",
+			heartbeatTimeout: 5 * time.Second,
+			expectUnknown:    false,
+		},
+		{
+			desc:             "heartbeat timeout below the latency should mark the server unknown",
+			heartbeatTimeout: latency / 5,
+			expectUnknown:    true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := ConnectServer(
+				address.Address(l.Addr().String()),
+				nil,
+				primitive.NewObjectID(),
+				withMonitoringDisabled(func(bool) bool { return true }),
+				WithHeartbeatTimeout(func(time.Duration) time.Duration { return tc.heartbeatTimeout }),
+				WithConnectionOptions(func(opts ...ConnectionOption) []ConnectionOption {
+					// The connect timeout is overridden by the heartbeat timeout for monitoring connections.
+					return append(opts, WithConnectTimeout(func(time.Duration) time.Duration { return latency / 5 }))
+				}),
+			)
+			require.NoError(t, err, "ConnectServer error")
+			defer func() { _ = server.Disconnect(context.Background()) }()
+
+			// The first check creates the monitoring connection and the second one sends a hello on it.
+			for i := 0; i < 2; i++ {
+				desc, err := server.check()
+				require.NoError(t, err, "check error")
+				if tc.expectUnknown {
+					assert.True(t, desc.Kind == description.Unknown, "expected check %d to mark the server unknown", i)
+					assert.NotNil(t, desc.LastError, "expected check %d to return a timeout error, got nil", i)
+					continue
+				}
+				assert.True(t, desc.Kind != description.Unknown, "expected check %d not to mark the server unknown", i)
+				assert.Nil(t, desc.LastError, "expected check %d to succeed, got %v", i, desc.LastError)
+			}
+		})
+	}
+}
+
 func TestServer(t *testing.T) {
 	var serverTestTable = []struct {
 		name            string
@@ -117,12 +117,15 @@ type Topology struct {
 	serversClosed bool
 	servers       map[address.Address]*Server
 
+	operations *operationLimiter
+
 	id primitive.ObjectID
 }
 
 var (
-	_ driver.Deployment = &Topology{}
-	_ driver.Subscriber = &Topology{}
+	_ driver.Deployment       = &Topology{}
+	_ driver.Subscriber       = &Topology{}
+	_ driver.OperationLimiter = &Topology{}
 )
 
 type serverSelectionState struct {
@@ -156,6 +159,7 @@ func New(cfg *Config) (*Topology, error) {
 		subscribers:       make(map[uint64]chan description.Topology),
 		servers:           make(map[address.Address]*Server),
 		dnsResolver:       dns.DefaultResolver,
+		operations:        newOperationLimiter(cfg.MaxConcurrentOperations, cfg.RejectOverloadedOperations),
 		id:                primitive.NewObjectID(),
 	}
 	t.desc.Store(description.Topology{})
@@ -452,6 +456,10 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 
 	t.desc.Store(description.Topology{})
 
+	// Operations started after the Topology is disconnected fail during server selection with ErrTopologyClosed
+	// rather than with ErrTopologyDraining.
+	t.operations.stopDraining()
+
 	atomic.StoreInt64(&t.state, topologyDisconnected)
 	t.publishTopologyClosedEvent()
 	return nil
@@ -469,6 +477,16 @@ func (t *Topology) Description() description.Topology {
 // Kind returns the topology kind of this Topology.
 func (t *Topology) Kind() description.TopologyKind { return t.Description().Kind }
 
+// KillOnCancel implements the driver.OperationKiller interface. It reports whether the Topology was configured to kill
+// operations whose Context is cancelled while they are in progress.
+func (t *Topology) KillOnCancel() bool { return t.cfg.KillOnCancel }
+
+// ShouldRetry implements the driver.RetryDecider interface. It reports whether the RetryPredicate of the Topology
+// chooses to retry an operation that failed with err on the given attempt.
+func (t *Topology) ShouldRetry(err error, attempt int) bool {
+	return t.cfg.RetryPredicate != nil && t.cfg.RetryPredicate(err, attempt)
+}
+
 // Subscribe returns a Subscription on which all updated description.Topologys
 // will be sent. The channel of the subscription will have a buffer size of one,
 // and will be pre-populated with the current description.Topology.
@@ -534,8 +552,33 @@ func (t *Topology) RequestImmediateCheck() {
 
 // SelectServer selects a server with given a selector. SelectServer complies with the
 // server selection spec, and will time out after serverSelectionTimeout or when the
-// parent context is done.
+// parent context is done. If server selection retries are configured, a selection that
+// times out is attempted again after a backoff.
 func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (driver.Server, error) {
+	srv, err := t.selectServer(ctx, ss)
+	backoff := t.cfg.ServerSelectionRetryBackoff
+	for attempt := 1; attempt < t.cfg.ServerSelectionRetryAttempts; attempt++ {
+		if err == nil || !errors.Is(err, ErrServerSelectionTimeout) {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+
+		srv, err = t.selectServer(ctx, ss)
+	}
+	return srv, err
+}
+
+// selectServer makes a single attempt to select a server, which times out after
+// serverSelectionTimeout or when the parent context is done.
+func (t *Topology) selectServer(ctx context.Context, ss description.ServerSelector) (driver.Server, error) {
 	if atomic.LoadInt64(&t.state) != topologyConnected {
 		if mustLogServerSelection(t, logger.LevelDebug) {
 			logServerSelectionFailed(ctx, t, ss, ErrTopologyClosed)
@@ -39,6 +39,30 @@ type Config struct {
 	SRVServiceName         string
 	LoadBalanced           bool
 	logger                 *logger.Logger
+
+	// MaxConcurrentOperations is the maximum number of operations that can execute concurrently. If it is zero,
+	// the number of operations is not bounded.
+	MaxConcurrentOperations int
+
+	// RejectOverloadedOperations causes operations that would exceed MaxConcurrentOperations to fail with an
+	// error instead of waiting for another operation to complete.
+	RejectOverloadedOperations bool
+
+	// KillOnCancel causes operations whose context is cancelled while they are in progress to be killed on the
+	// server.
+	KillOnCancel bool
+
+	// RetryPredicate is called with errors that are not retryable by the standard rules and the 1-based number of
+	// the attempt that returned them. If it returns true, the operation is retried if it has retries remaining.
+	RetryPredicate func(err error, attempt int) bool
+
+	// ServerSelectionRetryAttempts is the maximum number of times SelectServer attempts to select a server when
+	// selection times out. Values less than 2 disable retries.
+	ServerSelectionRetryAttempts int
+
+	// ServerSelectionRetryBackoff is the time SelectServer waits before its first retry. It doubles after every
+	// failed retry.
+	ServerSelectionRetryBackoff time.Duration
 }
 
 // ConvertToDriverAPIOptions converts a options.ServerAPIOptions instance to a driver.ServerAPIOptions.
@@ -115,6 +139,7 @@ func ConvertCreds(cred *options.Credential) *driver.Cred {
 		Props:               cred.AuthMechanismProperties,
 		OIDCMachineCallback: oidcMachineCallback,
 		OIDCHumanCallback:   oidcHumanCallback,
+		ForceMechanism:      cred.ForceMechanism,
 	}
 }
 
@@ -216,6 +241,12 @@ func NewConfigWithAuthenticator(
 			}
 		}
 
+		if co.CompressionMinSize != nil {
+			connOpts = append(connOpts, WithCompressionMinSize(func(int) int {
+				return *co.CompressionMinSize
+			}))
+		}
+
 		serverOpts = append(serverOpts, WithCompressionOptions(
 			func(opts ...string) []string { return append(opts, comps...) },
 		))
@@ -238,7 +269,7 @@ func NewConfigWithAuthenticator(
 			ClusterClock:  clock,
 		}
 
-		if co.Auth.AuthMechanism == "" {
+		if co.Auth.AuthMechanism == "" || co.Auth.ForceMechanism {
 			// Required for SASL mechanism negotiation during handshake
 			handshakeOpts.DBUser = co.Auth.AuthSource + "." + co.Auth.Username
 		}
@@ -273,12 +304,30 @@ func NewConfigWithAuthenticator(
 			func(time.Duration) time.Duration { return *co.ConnectTimeout },
 		))
 	}
+	// HeartbeatTimeout
+	if co.HeartbeatTimeout != nil {
+		serverOpts = append(serverOpts, WithHeartbeatTimeout(
+			func(time.Duration) time.Duration { return *co.HeartbeatTimeout },
+		))
+	}
 	// Dialer
 	if co.Dialer != nil {
 		connOpts = append(connOpts, WithDialer(
 			func(Dialer) Dialer { return co.Dialer },
 		))
 	}
+	// TCPKeepAlive
+	if co.TCPKeepAlive != nil {
+		connOpts = append(connOpts, WithTCPKeepAlive(
+			func(*time.Duration) *time.Duration { return co.TCPKeepAlive },
+		))
+	}
+	// TCPNoDelay
+	if co.TCPNoDelay != nil {
+		connOpts = append(connOpts, WithTCPNoDelay(
+			func(*bool) *bool { return co.TCPNoDelay },
+		))
+	}
 	// Direct
 	if co.Direct != nil && *co.Direct {
 		cfgp.Mode = SingleMode
@@ -290,6 +339,14 @@ func NewConfigWithAuthenticator(
 			func(time.Duration) time.Duration { return *co.HeartbeatInterval },
 		))
 	}
+	// HeartbeatBackoff
+	if hb := co.HeartbeatBackoff; hb != nil {
+		serverOpts = append(serverOpts, WithHeartbeatBackoff(
+			func(time.Duration, time.Duration, float64) (time.Duration, time.Duration, float64) {
+				return hb.Min, hb.Max, hb.Jitter
+			},
+		))
+	}
 	// Hosts
 	cfgp.SeedList = []string{"localhost:27017"} // default host
 	if len(co.Hosts) > 0 {
@@ -302,6 +359,12 @@ func NewConfigWithAuthenticator(
 			func(time.Duration) time.Duration { return *co.MaxConnIdleTime },
 		))
 	}
+	// ProactiveIdlePruning
+	if co.ProactiveIdlePruning != nil {
+		serverOpts = append(serverOpts, WithConnectionPoolProactiveIdlePruning(
+			func(bool) bool { return *co.ProactiveIdlePruning },
+		))
+	}
 	// MaxPoolSize
 	if co.MaxPoolSize != nil {
 		serverOpts = append(
@@ -352,6 +415,11 @@ func NewConfigWithAuthenticator(
 	if co.ServerSelectionTimeout != nil {
 		cfgp.ServerSelectionTimeout = *co.ServerSelectionTimeout
 	}
+	// ServerSelectionRetry
+	if ssr := co.ServerSelectionRetry; ssr != nil {
+		cfgp.ServerSelectionRetryAttempts = ssr.Attempts
+		cfgp.ServerSelectionRetryBackoff = ssr.Backoff
+	}
 	// SocketTimeout
 	if co.SocketTimeout != nil {
 		connOpts = append(
@@ -407,6 +475,19 @@ func NewConfigWithAuthenticator(
 		)
 	}
 
+	// MaxConcurrentOperations
+	if co.MaxConcurrentOperations != nil {
+		cfgp.MaxConcurrentOperations = *co.MaxConcurrentOperations
+	}
+	if co.RejectOverloadedOperations != nil {
+		cfgp.RejectOverloadedOperations = *co.RejectOverloadedOperations
+	}
+
+	// KillOnCancel
+	if co.KillOnCancel != nil {
+		cfgp.KillOnCancel = *co.KillOnCancel
+	}
+
 	lgr, err := newLogger(co.LoggerOptions)
 	if err != nil {
 		return nil, err
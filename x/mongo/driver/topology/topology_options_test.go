@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -106,6 +107,31 @@ func TestTopologyNewConfig(t *testing.T) {
 		assert.Nil(t, err, "error constructing topology config: %v", err)
 		assert.Equal(t, []string{"localhost:27018"}, cfg.SeedList)
 	})
+	t.Run("KillOnCancel", func(t *testing.T) {
+		cfg, err := NewConfig(options.Client(), nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		assert.False(t, cfg.KillOnCancel, "expected KillOnCancel to be false by default")
+
+		cfg, err = NewConfig(options.Client().SetKillOnCancel(true), nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		topo, err := New(cfg)
+		assert.Nil(t, err, "error constructing topology: %v", err)
+		assert.True(t, topo.KillOnCancel(), "expected KillOnCancel to be true")
+	})
+	t.Run("HeartbeatTimeout", func(t *testing.T) {
+		opts := options.Client().SetConnectTimeout(time.Second)
+		cfg, err := NewConfig(opts, nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		srvr := NewServer("", primitive.NewObjectID(), cfg.ServerOpts...)
+		assert.Equal(t, time.Second, srvr.cfg.heartbeatTimeout, "expected the connect timeout to be used by default")
+
+		cfg, err = NewConfig(opts.SetHeartbeatTimeout(5*time.Second), nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		srvr = NewServer("", primitive.NewObjectID(), cfg.ServerOpts...)
+		assert.Equal(t, 5*time.Second, srvr.cfg.heartbeatTimeout, "expected heartbeat timeout 5s, got %v", srvr.cfg.heartbeatTimeout)
+		conn := newConnection("", srvr.cfg.connectionOpts...)
+		assert.Equal(t, time.Second, conn.config.connectTimeout, "expected the connect timeout to be unchanged, got %v", conn.config.connectTimeout)
+	})
 }
 
 // Test that convertOIDCArgs exhaustively copies all fields of a driver.OIDCArgs
@@ -405,6 +405,63 @@ func TestServerSelection(t *testing.T) {
 		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
 		assert.Equal(t, ErrSubscribeAfterClosed, err, "expected error %v, got %v", ErrSubscribeAfterClosed, err)
 	})
+	t.Run("retry after timeout", func(t *testing.T) {
+		const ssTimeout = 50 * time.Millisecond
+
+		newTopology := func(t *testing.T, attempts int) *Topology {
+			t.Helper()
+
+			topo, err := New(nil)
+			require.NoError(t, err)
+			topo.cfg.ServerSelectionTimeout = ssTimeout
+			topo.cfg.ServerSelectionRetryAttempts = attempts
+			topo.cfg.ServerSelectionRetryBackoff = 10 * time.Millisecond
+			atomic.StoreInt64(&topo.state, topologyConnected)
+
+			desc := description.Topology{
+				Servers: []description.Server{{Addr: address.Address("one"), Kind: description.Standalone}},
+			}
+			topo.desc.Store(desc)
+			s, err := ConnectServer(desc.Servers[0].Addr, topo.updateCallback, topo.id)
+			require.NoError(t, err)
+			topo.servers[desc.Servers[0].Addr] = s
+			return topo
+		}
+		// The selector finds no suitable server until the first attempt has timed out, like a topology that
+		// recovers after a brief period without an available server.
+		newSelector := func() description.ServerSelector {
+			start := time.Now()
+			return description.ServerSelectorFunc(func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+				if time.Since(start) < ssTimeout {
+					return nil, nil
+				}
+				return candidates, nil
+			})
+		}
+
+		t.Run("succeeds on second attempt", func(t *testing.T) {
+			topo := newTopology(t, 2)
+			srv, err := topo.SelectServer(context.Background(), newSelector())
+			require.NoError(t, err)
+			addr := srv.(*SelectedServer).address
+			assert.Equal(t, address.Address("one"), addr, "expected address %v, got %v", "one", addr)
+		})
+		t.Run("single attempt by default", func(t *testing.T) {
+			topo := newTopology(t, 0)
+			_, err := topo.SelectServer(context.Background(), newSelector())
+			assert.True(t, errors.Is(err, ErrServerSelectionTimeout), "expected error %v, got %v",
+				ErrServerSelectionTimeout, err)
+		})
+		t.Run("context errors are not retried", func(t *testing.T) {
+			topo := newTopology(t, 2)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := topo.SelectServer(ctx, selectNone)
+			assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected error %v, got %v",
+				context.DeadlineExceeded, err)
+		})
+	})
 }
 
 func TestSessionTimeout(t *testing.T) {